@@ -0,0 +1,52 @@
+package main
+
+import (
+	"backend-form/m/internal/channel"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// notifysink runs local stand-ins for the email, SMS and WhatsApp providers and prints
+// everything they receive, so notifications can be tested without real accounts:
+//
+//	SMTP_HOST=localhost SMTP_PORT=2525 SMTP_FROM=rent@localhost
+//	SMS_PROVIDER=http SMS_API_URL=http://localhost:8025/sms
+//	WHATSAPP_PROVIDER=cloud WHATSAPP_API_URL=http://localhost:8025/whatsapp WHATSAPP_PHONE_NUMBER_ID=1 WHATSAPP_ACCESS_TOKEN=test
+func main() {
+	smtpAddr := flag.String("smtp", "127.0.0.1:2525", "address of the SMTP sink")
+	httpAddr := flag.String("http", "127.0.0.1:8025", "address of the fake SMS/WhatsApp provider")
+	failWith := flag.Int("fail", 0, "HTTP status the fake provider fails every request with (0 = succeed)")
+	flag.Parse()
+
+	sink, err := channel.NewSMTPSink(*smtpAddr)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer sink.Close()
+	sink.OnMessage = func(msg channel.SinkMessage) {
+		fmt.Printf("📧 Email from %s to %v\n%s\n\n", msg.From, msg.To, msg.Data)
+	}
+
+	provider := channel.NewFakeProvider()
+	provider.FailWith = *failWith
+	provider.OnRequest = func(req channel.FakeRequest) {
+		fmt.Printf("📱 POST %s %v\n\n", req.Path, req.Body)
+	}
+	go func() {
+		if err := http.ListenAndServe(*httpAddr, provider); err != nil {
+			log.Fatal(err)
+		}
+	}()
+
+	fmt.Printf("SMTP sink listening on %s\n", sink.Addr())
+	fmt.Printf("Fake SMS/WhatsApp provider listening on http://%s\n", *httpAddr)
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+	<-quit
+}
@@ -1,7 +1,9 @@
 package main
 
 import (
+	"backend-form/m/internal/channel"
 	"backend-form/m/internal/config"
+	"backend-form/m/internal/gateway"
 	"backend-form/m/internal/handlers"
 	httplib "backend-form/m/internal/http"
 	"backend-form/m/internal/http/middleware"
@@ -28,6 +30,7 @@ var templates = template.Must(template.ParseFiles(
 	"templates/unit-detail.html",
 	"templates/login.html",
 	"templates/tenant-dashboard.html",
+	"templates/receipt.html",
 ))
 
 // App holds all application dependencies
@@ -40,38 +43,78 @@ type App struct {
 	Router                *httplib.Router
 	Server                *http.Server
 	NotificationScheduler *service.NotificationScheduler
+	NotificationWorker    *service.NotificationWorker
+	LateFeeScheduler      *service.LateFeeScheduler
 }
 
 // Repositories holds all repository instances
 type Repositories struct {
 	Unit         interfaces.UnitRepository
+	Property     interfaces.PropertyRepository
 	Tenant       interfaces.TenantRepository
 	Payment      interfaces.PaymentRepository
 	User         interfaces.UserRepository
 	Session      interfaces.SessionRepository
 	Notification interfaces.NotificationRepository
+	Deposit      interfaces.DepositRepository
+	LateFee      interfaces.LateFeeRepository
+	Category     interfaces.ChargeCategoryRepository
+	Recurring    interfaces.RecurringChargeRepository
+	Utility      interfaces.UtilityRepository
+	Lease        interfaces.LeaseRepository
+	Proration    interfaces.ProrationRepository
+	Credit       interfaces.CreditRepository
+	Statement    interfaces.BankStatementRepository
+	GatewayOrder interfaces.GatewayOrderRepository
+	Receipt      interfaces.ReceiptRepository
+	Expense      interfaces.ExpenseRepository
+	Installment  interfaces.InstallmentPlanRepository
+	Adjustment   interfaces.PaymentAdjustmentRepository
+	TelegramLink interfaces.TelegramLinkRepository
+	Preference   interfaces.NotificationPreferenceRepository
 }
 
 // Services holds all service instances
 type Services struct {
 	Unit                  *service.UnitService
+	Property              *service.PropertyService
 	Payment               *service.PaymentService
 	PaymentQuery          *service.PaymentQueryService
 	PaymentTransaction    *service.PaymentTransactionService
 	PaymentHistory        *service.PaymentHistoryService
 	Tenant                *service.TenantService
+	Deposit               *service.DepositService
+	LateFee               *service.LateFeeService
+	ChargeCategory        *service.ChargeCategoryService
+	RecurringCharge       *service.RecurringChargeService
+	Utility               *service.UtilityService
+	Lease                 *service.LeaseService
+	Proration             *service.ProrationService
+	Credit                *service.CreditService
+	Reconciliation        *service.ReconciliationService
+	Gateway               *service.GatewayService
+	Receipt               *service.ReceiptService
+	RentStatement         *service.RentStatementService
+	Expense               *service.ExpenseService
+	InstallmentPlan       *service.InstallmentPlanService
+	PaymentAdjustment     *service.PaymentAdjustmentService
 	Auth                  *service.AuthService
 	Dashboard             *service.DashboardService
 	Notification          *service.NotificationService
+	NotificationPref      *service.NotificationPreferenceService
+	TelegramLink          *service.TelegramLinkService
 	NotificationScheduler *service.NotificationScheduler
 }
 
 // Handlers holds all HTTP handler instances
 type Handlers struct {
-	Auth    *handlers.AuthHandler
-	Rental  *handlers.RentalHandler
-	Tenant  *handlers.TenantHandler
-	Metrics *handlers.MetricsHandler
+	Auth         *handlers.AuthHandler
+	Rental       *handlers.RentalHandler
+	Tenant       *handlers.TenantHandler
+	Gateway      *handlers.GatewayHandler
+	Telegram     *handlers.TelegramHandler
+	Notification *handlers.NotificationHandler
+	Metrics      *handlers.MetricsHandler
 }
 
 func main() {
@@ -142,6 +185,8 @@ func setupApplication(cfg *config.Config) *App {
 	router := setupRouter(cfg, handlers, repos, db)
 	server := setupHTTPServer(cfg)
 	notificationScheduler := setupNotificationScheduler(cfg, services.Notification)
+	notificationWorker := setupNotificationWorker(services.Notification)
+	lateFeeScheduler := setupLateFeeScheduler(services.LateFee)
 
 	return &App{
 		Config:                cfg,
@@ -152,6 +197,8 @@ func setupApplication(cfg *config.Config) *App {
 		Router:                router,
 		Server:                server,
 		NotificationScheduler: notificationScheduler,
+		NotificationWorker:    notificationWorker,
+		LateFeeScheduler:      lateFeeScheduler,
 	}
 }
 
@@ -184,11 +231,28 @@ func setupDatabase(cfg *config.Config) *sql.DB {
 func setupRepositories(db *sql.DB) *Repositories {
 	return &Repositories{
 		Unit:         repository.NewPostgresUnitRepository(db),
+		Property:     repository.NewPostgresPropertyRepository(db),
 		Tenant:       repository.NewPostgresTenantRepository(db),
 		Payment:      repository.NewPostgresPaymentRepository(db),
 		User:         repository.NewPostgresUserRepository(db),
 		Session:      repository.NewPostgresSessionRepository(db),
 		Notification: repository.NewPostgresNotificationRepository(db),
+		Deposit:      repository.NewPostgresDepositRepository(db),
+		LateFee:      repository.NewPostgresLateFeeRepository(db),
+		Category:     repository.NewPostgresChargeCategoryRepository(db),
+		Recurring:    repository.NewPostgresRecurringChargeRepository(db),
+		Utility:      repository.NewPostgresUtilityRepository(db),
+		Lease:        repository.NewPostgresLeaseRepository(db),
+		Proration:    repository.NewPostgresProrationRepository(db),
+		Credit:       repository.NewPostgresCreditRepository(db),
+		Statement:    repository.NewPostgresBankStatementRepository(db),
+		GatewayOrder: repository.NewPostgresGatewayOrderRepository(db),
+		Receipt:      repository.NewPostgresReceiptRepository(db),
+		Expense:      repository.NewPostgresExpenseRepository(db),
+		Installment:  repository.NewPostgresInstallmentPlanRepository(db),
+		Adjustment:   repository.NewPostgresPaymentAdjustmentRepository(db),
+		TelegramLink: repository.NewPostgresTelegramLinkRepository(db),
+		Preference:   repository.NewPostgresNotificationPreferenceRepository(db),
 	}
 }
 
@@ -196,34 +260,104 @@ func setupRepositories(db *sql.DB) *Repositories {
 func setupServices(cfg *config.Config, repos *Repositories) *Services {
 	// Note: PaymentService must be created before TenantService since TenantService depends on it
 	unitService := service.NewUnitService(repos.Unit)
-	paymentService := service.NewPaymentService(repos.Payment, repos.Tenant, repos.Unit, cfg.DefaultPaymentMethod, cfg.DefaultUPIID)
-	paymentQueryService := service.NewPaymentQueryService(repos.Payment)
-	paymentTransactionService := service.NewPaymentTransactionService(repos.Payment, paymentService)
-	paymentHistoryService := service.NewPaymentHistoryService(repos.Payment, repos.Tenant, repos.Unit, paymentService)
-	tenantService := service.NewTenantService(repos.Tenant, repos.Unit, paymentService)
-	authService := service.NewAuthService(repos.User, repos.Session, 7*24*60*60*1e9)
-	dashboardService := service.NewDashboardService(unitService, tenantService, paymentQueryService)
-
+	propertyService := service.NewPropertyService(repos.Property, repos.Unit)
+	chargeCategoryService := service.NewChargeCategoryService(repos.Category)
+	recurringChargeService := service.NewRecurringChargeService(repos.Recurring, repos.Tenant, repos.Unit, chargeCategoryService)
+	leaseService := service.NewLeaseService(repos.Lease, repos.Tenant, repos.Unit)
+	// Notifications are created early: payment services notify tenants and the owner as events happen
+	dispatcher, err := channel.New(channel.Config{
+		Order:                 channel.ParseOrder(cfg.NotificationChannels),
+		TelegramBotToken:      cfg.TelegramBotToken,
+		SMTPHost:              cfg.SMTPHost,
+		SMTPPort:              cfg.SMTPPort,
+		SMTPUsername:          cfg.SMTPUsername,
+		SMTPPassword:          cfg.SMTPPassword,
+		SMTPFrom:              cfg.SMTPFrom,
+		SMSProvider:           cfg.SMSProvider,
+		SMSAPIURL:             cfg.SMSAPIURL,
+		SMSAPIKey:             cfg.SMSAPIKey,
+		SMSSender:             cfg.SMSSender,
+		WhatsAppProvider:      cfg.WhatsAppProvider,
+		WhatsAppAPIURL:        cfg.WhatsAppAPIURL,
+		WhatsAppPhoneNumberID: cfg.WhatsAppPhoneNumberID,
+		WhatsAppAccessToken:   cfg.WhatsAppAccessToken,
+		WhatsAppTemplate:      cfg.WhatsAppTemplate,
+		WhatsAppLanguage:      cfg.WhatsAppLanguage,
+	})
+	if err != nil {
+		logger.Fatal("Failed to configure notification channels",
+			zap.Error(err),
+		)
+	}
+	notificationPreferenceService := service.NewNotificationPreferenceService(repos.Preference, dispatcher)
 	notificationService := service.NewNotificationService(
 		repos.Notification,
 		repos.Payment,
 		repos.Tenant,
 		repos.Unit,
-		cfg.TelegramBotToken,
-		cfg.OwnerChatID,
+		repos.TelegramLink,
+		leaseService,
+		notificationPreferenceService,
+		dispatcher,
+		channel.Recipient{TelegramChatID: cfg.OwnerChatID, Email: cfg.OwnerEmail, Phone: cfg.OwnerPhone},
 	)
+	prorationService := service.NewProrationService(repos.Proration, repos.Payment, cfg.RentProrationPolicy)
+	creditService := service.NewCreditService(repos.Credit, repos.Payment)
+	paymentService := service.NewPaymentService(repos.Payment, repos.Tenant, repos.Unit, chargeCategoryService, recurringChargeService, leaseService, prorationService, creditService, notificationService, cfg.DefaultPaymentMethod, cfg.DefaultUPIID, cfg.UPIPayeeName)
+	paymentQueryService := service.NewPaymentQueryService(repos.Payment)
+	receiptService := service.NewReceiptService(repos.Receipt, repos.Payment, repos.Tenant, repos.Unit, repos.Property, cfg.UPIPayeeName, cfg.DefaultUPIID)
+	paymentTransactionService := service.NewPaymentTransactionService(repos.Payment, paymentService, creditService, receiptService, notificationService)
+	reconciliationService := service.NewReconciliationService(repos.Statement, paymentTransactionService)
+	paymentGateway, err := gateway.New(cfg.PaymentGateway, cfg.GatewayKeyID, cfg.GatewayKeySecret, cfg.GatewayWebhookSecret)
+	if err != nil {
+		logger.Fatal("Failed to configure payment gateway",
+			zap.Error(err),
+		)
+	}
+	gatewayService := service.NewGatewayService(paymentGateway, repos.GatewayOrder, repos.Payment, paymentService, paymentTransactionService)
+	installmentPlanService := service.NewInstallmentPlanService(repos.Installment, repos.Payment, repos.Tenant)
+	rentStatementService := service.NewRentStatementService(paymentService, installmentPlanService, repos.Tenant, repos.Unit, repos.Property, cfg.LandlordName, cfg.LandlordPAN, cfg.LandlordAddress)
+	paymentHistoryService := service.NewPaymentHistoryService(repos.Payment, repos.Tenant, repos.Unit, paymentService)
+	depositService := service.NewDepositService(repos.Deposit, repos.Payment)
+	tenantService := service.NewTenantService(repos.Tenant, repos.Unit, paymentService, depositService, leaseService, prorationService)
+	expenseService := service.NewExpenseService(repos.Expense, repos.Adjustment, repos.Unit, repos.Property)
+	paymentAdjustmentService := service.NewPaymentAdjustmentService(repos.Adjustment, repos.Payment, paymentService)
+	lateFeeService := service.NewLateFeeService(repos.LateFee, repos.Payment, repos.Tenant)
+	utilityService := service.NewUtilityService(repos.Utility, repos.Tenant, repos.Unit, chargeCategoryService, paymentService)
+	authService := service.NewAuthService(repos.User, repos.Session, 7*24*60*60*1e9)
+	dashboardService := service.NewDashboardService(unitService, tenantService, paymentQueryService, propertyService)
+
+	telegramLinkService := service.NewTelegramLinkService(repos.TelegramLink, repos.Tenant, notificationService, cfg.TelegramBotUsername, cfg.TelegramWebhookSecret)
 	notificationScheduler := service.NewNotificationScheduler(notificationService)
 
 	return &Services{
 		Unit:                  unitService,
+		Property:              propertyService,
 		Payment:               paymentService,
 		PaymentQuery:          paymentQueryService,
 		PaymentTransaction:    paymentTransactionService,
 		PaymentHistory:        paymentHistoryService,
 		Tenant:                tenantService,
+		Deposit:               depositService,
+		LateFee:               lateFeeService,
+		ChargeCategory:        chargeCategoryService,
+		RecurringCharge:       recurringChargeService,
+		Utility:               utilityService,
+		Lease:                 leaseService,
+		Proration:             prorationService,
+		Credit:                creditService,
+		Reconciliation:        reconciliationService,
+		Gateway:               gatewayService,
+		Receipt:               receiptService,
+		RentStatement:         rentStatementService,
+		Expense:               expenseService,
+		InstallmentPlan:       installmentPlanService,
+		PaymentAdjustment:     paymentAdjustmentService,
 		Auth:                  authService,
 		Dashboard:             dashboardService,
 		Notification:          notificationService,
+		NotificationPref:      notificationPreferenceService,
+		TelegramLink:          telegramLinkService,
 		NotificationScheduler: notificationScheduler,
 	}
 }
@@ -232,7 +366,21 @@ func setupServices(cfg *config.Config, repos *Repositories) *Services {
 func setupHandlers(cfg *config.Config, services *Services, repos *Repositories) *Handlers {
 	rentalHandler := handlers.NewRentalHandler(
 		services.Unit,
+		services.Property,
 		services.Tenant,
+		services.Deposit,
+		services.LateFee,
+		services.ChargeCategory,
+		services.RecurringCharge,
+		services.Utility,
+		services.Lease,
+		services.Credit,
+		services.Reconciliation,
+		services.Receipt,
+		services.RentStatement,
+		services.Expense,
+		services.InstallmentPlan,
+		services.PaymentAdjustment,
 		services.Payment,
 		services.PaymentQuery,
 		services.PaymentTransaction,
@@ -255,6 +403,12 @@ func setupHandlers(cfg *config.Config, services *Services, repos *Repositories)
 		services.Tenant,
 		services.Payment,
 		services.PaymentTransaction,
+		services.LateFee,
+		services.Utility,
+		services.Gateway,
+		services.Receipt,
+		services.RentStatement,
+		services.TelegramLink,
 		repos.User,
 		templates,
 		cfg.CookieName,
@@ -262,10 +416,13 @@ func setupHandlers(cfg *config.Config, services *Services, repos *Repositories)
 	)
 
 	return &Handlers{
-		Auth:    authHandler,
-		Rental:  rentalHandler,
-		Tenant:  tenantHandler,
-		Metrics: handlers.NewMetricsHandler(),
+		Auth:         authHandler,
+		Rental:       rentalHandler,
+		Tenant:       tenantHandler,
+		Gateway:      handlers.NewGatewayHandler(services.Gateway, services.Dashboard),
+		Telegram:     handlers.NewTelegramHandler(services.TelegramLink),
+		Notification: handlers.NewNotificationHandler(services.NotificationPref, services.Notification),
+		Metrics:      handlers.NewMetricsHandler(),
 	}
 }
 
@@ -278,6 +435,9 @@ func setupRouter(cfg *config.Config, handlers *Handlers, repos *Repositories, db
 		handlers.Auth,
 		handlers.Rental,
 		handlers.Tenant,
+		handlers.Gateway,
+		handlers.Telegram,
+		handlers.Notification,
 		repos.User,
 		loginLimiter,
 		dbHealthCheck,
@@ -305,13 +465,34 @@ func setupHTTPServer(cfg *config.Config) *http.Server {
 func setupNotificationScheduler(cfg *config.Config, notificationService *service.NotificationService) *service.NotificationScheduler {
 	scheduler := service.NewNotificationScheduler(notificationService)
 
-	if cfg.TelegramBotToken != "" && cfg.OwnerChatID != "" {
+	if notificationService.IsEnabled() {
 		scheduler.Start()
 		logger.Info("Notification scheduler started")
 	} else {
-		logger.Warn("Telegram bot token or owner chat ID not configured. Notifications disabled.")
+		logger.Warn("No notification channel configured (Telegram, WhatsApp, SMS or email). Notifications disabled.")
+	}
+
+	return scheduler
+}
+
+// setupNotificationWorker starts the worker delivering queued notifications if any channel is configured
+func setupNotificationWorker(notificationService *service.NotificationService) *service.NotificationWorker {
+	worker := service.NewNotificationWorker(notificationService)
+
+	if notificationService.IsEnabled() {
+		worker.Start()
+		logger.Info("Notification worker started")
 	}
 
+	return worker
+}
+
+// setupLateFeeScheduler starts the daily late fee job
+func setupLateFeeScheduler(lateFeeService *service.LateFeeService) *service.LateFeeScheduler {
+	scheduler := service.NewLateFeeScheduler(lateFeeService)
+	scheduler.Start()
+	logger.Info("Late fee scheduler started")
+
 	return scheduler
 }
 
@@ -340,12 +521,14 @@ func waitForShutdown(app *App) {
 	logger.Info("Shutting down server...")
 
 	// Stop notification scheduler first (non-blocking)
-	if app.Config.TelegramBotToken != "" && app.Config.OwnerChatID != "" {
+	if app.Services.Notification.IsEnabled() {
 		app.NotificationScheduler.Stop()
-		// Give it a moment to stop, but don't wait too long
+		app.NotificationWorker.Stop()
+		// Give them a moment to stop, but don't wait too long; undelivered notifications stay queued
 		time.Sleep(100 * time.Millisecond)
-		logger.Info("Notification scheduler stop signal sent")
+		logger.Info("Notification scheduler and worker stop signals sent")
 	}
+	app.LateFeeScheduler.Stop()
 
 	// Graceful shutdown with timeout
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"backend-form/m/internal/channel"
 	"backend-form/m/internal/config"
 	repository "backend-form/m/internal/repository/postgres"
 	"backend-form/m/internal/service"
@@ -35,24 +36,47 @@ func main() {
 	// Create repositories (matching main.go structure)
 	fmt.Println("\n📦 Initializing repositories...")
 	unitRepo := repository.NewPostgresUnitRepository(db)
+	propertyRepo := repository.NewPostgresPropertyRepository(db)
 	tenantRepo := repository.NewPostgresTenantRepository(db)
 	paymentRepo := repository.NewPostgresPaymentRepository(db)
 	userRepo := repository.NewPostgresUserRepository(db)
 	sessionRepo := repository.NewPostgresSessionRepository(db)
+	depositRepo := repository.NewPostgresDepositRepository(db)
+	categoryRepo := repository.NewPostgresChargeCategoryRepository(db)
+	recurringRepo := repository.NewPostgresRecurringChargeRepository(db)
+	leaseRepo := repository.NewPostgresLeaseRepository(db)
+	prorationRepo := repository.NewPostgresProrationRepository(db)
+	creditRepo := repository.NewPostgresCreditRepository(db)
+	receiptRepo := repository.NewPostgresReceiptRepository(db)
+	notificationRepo := repository.NewPostgresNotificationRepository(db)
+	preferenceRepo := repository.NewPostgresNotificationPreferenceRepository(db)
+	telegramLinkRepo := repository.NewPostgresTelegramLinkRepository(db)
 	fmt.Println("✅ All repositories initialized")
 
 	// Create services (matching main.go structure and order)
 	fmt.Println("\n⚙️  Initializing services...")
 	unitService := service.NewUnitService(unitRepo)
+	propertyService := service.NewPropertyService(propertyRepo, unitRepo)
 	// Use default payment config values
-	paymentService := service.NewPaymentService(paymentRepo, tenantRepo, unitRepo, "UPI", "9848790200@ybl")
+	chargeCategoryService := service.NewChargeCategoryService(categoryRepo)
+	recurringChargeService := service.NewRecurringChargeService(recurringRepo, tenantRepo, unitRepo, chargeCategoryService)
+	leaseService := service.NewLeaseService(leaseRepo, tenantRepo, unitRepo)
+	// No notification channels: events are recorded but nothing is sent
+	dispatcher := channel.NewDispatcher()
+	notificationPreferenceService := service.NewNotificationPreferenceService(preferenceRepo, dispatcher)
+	notificationService := service.NewNotificationService(notificationRepo, paymentRepo, tenantRepo, unitRepo, telegramLinkRepo, leaseService, notificationPreferenceService, dispatcher, channel.Recipient{})
+	prorationService := service.NewProrationService(prorationRepo, paymentRepo, "actual_days")
+	creditService := service.NewCreditService(creditRepo, paymentRepo)
+	paymentService := service.NewPaymentService(paymentRepo, tenantRepo, unitRepo, chargeCategoryService, recurringChargeService, leaseService, prorationService, creditService, notificationService, "UPI", "9848790200@ybl", "Rent")
 	paymentQueryService := service.NewPaymentQueryService(paymentRepo)
-	paymentTransactionService := service.NewPaymentTransactionService(paymentRepo, paymentService)
+	receiptService := service.NewReceiptService(receiptRepo, paymentRepo, tenantRepo, unitRepo, propertyRepo, "Rent", "9848790200@ybl")
+	paymentTransactionService := service.NewPaymentTransactionService(paymentRepo, paymentService, creditService, receiptService, notificationService)
 	paymentHistoryService := service.NewPaymentHistoryService(paymentRepo, tenantRepo, unitRepo, paymentService)
 	_ = paymentHistoryService // Keep for completeness (matches main.go structure)
-	tenantService := service.NewTenantService(tenantRepo, unitRepo, paymentService)
+	depositService := service.NewDepositService(depositRepo, paymentRepo)
+	tenantService := service.NewTenantService(tenantRepo, unitRepo, paymentService, depositService, leaseService, prorationService)
 	authService := service.NewAuthService(userRepo, sessionRepo, 7*24*60*60*1e9)
-	dashboardService := service.NewDashboardService(unitService, tenantService, paymentQueryService)
+	dashboardService := service.NewDashboardService(unitService, tenantService, paymentQueryService, propertyService)
 	fmt.Println("✅ All services initialized")
 
 	fmt.Println("\n🧪 Running Tests...")
@@ -110,7 +134,7 @@ func testUnitService(unitService *service.UnitService) {
 
 	// Test 2: Get rental summary
 	fmt.Println("\n  Test 1.2: Getting rental summary...")
-	summary, err := unitService.GetRentalSummary()
+	summary, err := unitService.GetRentalSummary(0)
 	if err != nil {
 		log.Printf("    ❌ Failed: %v", err)
 	} else {
@@ -164,7 +188,7 @@ func testUnitService(unitService *service.UnitService) {
 
 		// Test 7: Get unit by code
 		fmt.Println("\n  Test 1.7: Getting unit by code...")
-		unitByCode, err := unitService.GetUnitByCode(units[0].UnitCode)
+		unitByCode, err := unitService.GetUnitByCode(units[0].PropertyID, units[0].UnitCode)
 		if err != nil {
 			log.Printf("    ❌ Failed: %v", err)
 		} else {
@@ -266,7 +290,7 @@ func testPaymentQueryService(paymentQueryService *service.PaymentQueryService) {
 
 	// Test 2: Get payment summary
 	fmt.Println("\n  Test 3.2: Getting payment summary...")
-	paymentSummary, err := paymentQueryService.GetPaymentSummary()
+	paymentSummary, err := paymentQueryService.GetPaymentSummary(0)
 	if err != nil {
 		log.Printf("    ❌ Failed: %v", err)
 	} else {
@@ -393,7 +417,7 @@ func testDashboardService(dashboardService *service.DashboardService) {
 
 	// Test 1: Get dashboard data
 	fmt.Println("\n  Test 6.1: Getting dashboard data...")
-	dashboardData, err := dashboardService.GetDashboardData()
+	dashboardData, err := dashboardService.GetDashboardData(0)
 	if err != nil {
 		log.Printf("    ❌ Failed: %v", err)
 	} else {
@@ -415,7 +439,7 @@ func testDashboardService(dashboardService *service.DashboardService) {
 
 	// Test 2: Get dashboard summary
 	fmt.Println("\n  Test 6.2: Getting dashboard summary...")
-	dashboardSummary, err := dashboardService.GetDashboardSummary()
+	dashboardSummary, err := dashboardService.GetDashboardSummary(0)
 	if err != nil {
 		log.Printf("    ❌ Failed: %v", err)
 	} else {
@@ -4,7 +4,11 @@ go 1.24.0
 
 toolchain go1.24.2
 
-require github.com/lib/pq v1.10.9
+require (
+	github.com/jung-kurt/gofpdf v1.16.2
+	github.com/lib/pq v1.10.9
+	github.com/skip2/go-qrcode v0.0.0-20200617195104-da1b6568686e
+)
 
 require (
 	github.com/go-telegram-bot-api/telegram-bot-api v4.6.4+incompatible // indirect
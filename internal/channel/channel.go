@@ -0,0 +1,263 @@
+package channel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Channel names
+const (
+	ChannelTelegram = "telegram"
+	ChannelWhatsApp = "whatsapp"
+	ChannelSMS      = "sms"
+	ChannelEmail    = "email"
+)
+
+// DefaultOrder is the order channels are tried in when none is configured
+var DefaultOrder = []string{ChannelTelegram, ChannelWhatsApp, ChannelSMS, ChannelEmail}
+
+// ErrNoChannel is returned when none of the configured channels can reach a recipient
+var ErrNoChannel = errors.New("no notification channel can reach the recipient")
+
+// Channel delivers messages over one medium (Telegram, email, SMS, WhatsApp)
+type Channel interface {
+	// Name returns the channel name stored with each notification (sent_via)
+	Name() string
+	// Address returns where the recipient is reached on this channel ("" if they cannot be)
+	Address(to Recipient) string
+	// Send delivers a message to an address returned by Address
+	Send(ctx context.Context, address string, msg Message) error
+}
+
+// Message is the content of a notification
+type Message struct {
+	Subject string // Email subject / Telegram title
+	Body    string
+}
+
+// Recipient holds the contact details of the person being notified
+// Empty details mean the recipient cannot be reached on that channel
+type Recipient struct {
+	TelegramChatID string
+	Email          string
+	Phone          string   // Used for SMS and WhatsApp
+	Channels       []string // Channels to try, in order; nil means the dispatcher's order
+}
+
+// Attempt is one try to deliver a message over a channel
+type Attempt struct {
+	Channel string
+	Address string
+	Err     error
+}
+
+// Delivery is the outcome of sending a message
+type Delivery struct {
+	Channel  string // Channel that delivered the message ("" if none did)
+	Address  string
+	Attempts []Attempt
+}
+
+// Dispatcher sends messages over the first channel that can reach a recipient,
+// falling back to the next one when a channel fails
+type Dispatcher struct {
+	channels []Channel // In priority order
+}
+
+// NewDispatcher creates a Dispatcher trying channels in the given order (nil channels are skipped)
+func NewDispatcher(channels ...Channel) *Dispatcher {
+	d := &Dispatcher{}
+	for _, ch := range channels {
+		if ch != nil {
+			d.channels = append(d.channels, ch)
+		}
+	}
+	return d
+}
+
+// Channel returns the configured channel with the given name (nil if it is not configured)
+func (d *Dispatcher) Channel(name string) Channel {
+	for _, ch := range d.channels {
+		if ch.Name() == name {
+			return ch
+		}
+	}
+	return nil
+}
+
+// Names returns the configured channel names in priority order
+func (d *Dispatcher) Names() []string {
+	names := make([]string, 0, len(d.channels))
+	for _, ch := range d.channels {
+		names = append(names, ch.Name())
+	}
+	return names
+}
+
+// Send delivers msg to the recipient, trying each channel that can reach them until one succeeds
+// The returned Delivery lists every attempt, also when an error is returned
+func (d *Dispatcher) Send(ctx context.Context, to Recipient, msg Message) (*Delivery, error) {
+	delivery := &Delivery{}
+
+	for _, ch := range d.channelsFor(to) {
+		address := ch.Address(to)
+		if address == "" {
+			continue
+		}
+		err := ch.Send(ctx, address, msg)
+		delivery.Attempts = append(delivery.Attempts, Attempt{Channel: ch.Name(), Address: address, Err: err})
+		if err == nil {
+			delivery.Channel = ch.Name()
+			delivery.Address = address
+			return delivery, nil
+		}
+	}
+
+	if len(delivery.Attempts) == 0 {
+		return delivery, ErrNoChannel
+	}
+	var failures []string
+	for _, attempt := range delivery.Attempts {
+		failures = append(failures, fmt.Sprintf("%s: %v", attempt.Channel, attempt.Err))
+	}
+	return delivery, fmt.Errorf("all channels failed: %s", strings.Join(failures, "; "))
+}
+
+// CanReach reports whether any channel the recipient accepts has an address for them
+func (d *Dispatcher) CanReach(to Recipient) bool {
+	for _, ch := range d.channelsFor(to) {
+		if ch.Address(to) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// channelsFor returns the channels to try for a recipient, in order
+func (d *Dispatcher) channelsFor(to Recipient) []Channel {
+	if to.Channels == nil {
+		return d.channels
+	}
+	var channels []Channel
+	for _, name := range to.Channels {
+		if ch := d.Channel(name); ch != nil {
+			channels = append(channels, ch)
+		}
+	}
+	return channels
+}
+
+// NormalizePhone returns a phone number as digits with country code (e.g. 919876543210)
+// 10-digit numbers are taken to be Indian; "" is returned for numbers that cannot be used
+func NormalizePhone(phone string) string {
+	var digits strings.Builder
+	for _, r := range phone {
+		if unicode.IsDigit(r) {
+			digits.WriteRune(r)
+		}
+	}
+	number := strings.TrimLeft(digits.String(), "0")
+	if len(number) == 10 {
+		number = "91" + number
+	}
+	if len(number) < 11 || len(number) > 15 {
+		return ""
+	}
+	return number
+}
+
+// Config selects and configures the notification channels
+type Config struct {
+	Order []string // Channels in the order they are tried; nil means DefaultOrder
+
+	TelegramBotToken string
+
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+	SMTPFrom     string
+
+	SMSProvider string // "" (disabled), http
+	SMSAPIURL   string
+	SMSAPIKey   string
+	SMSSender   string
+
+	WhatsAppProvider      string // "" (disabled), cloud
+	WhatsAppAPIURL        string // Defaults to the Graph API
+	WhatsAppPhoneNumberID string
+	WhatsAppAccessToken   string
+	WhatsAppTemplate      string
+	WhatsAppLanguage      string
+}
+
+// New builds a Dispatcher with every channel that is configured, in cfg.Order
+func New(cfg Config) (*Dispatcher, error) {
+	available := make(map[string]Channel)
+	if cfg.TelegramBotToken != "" {
+		available[ChannelTelegram] = NewTelegramChannel(cfg.TelegramBotToken)
+	}
+	if cfg.SMTPHost != "" {
+		if cfg.SMTPFrom == "" {
+			return nil, fmt.Errorf("email requires a from address")
+		}
+		available[ChannelEmail] = NewEmailChannel(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.SMTPFrom)
+	}
+	switch cfg.SMSProvider {
+	case "":
+	case SMSProviderHTTP:
+		if cfg.SMSAPIURL == "" {
+			return nil, fmt.Errorf("sms provider http requires an API URL")
+		}
+		available[ChannelSMS] = NewSMSChannel(NewHTTPSMSProvider(cfg.SMSAPIURL, cfg.SMSAPIKey, cfg.SMSSender))
+	default:
+		return nil, fmt.Errorf("unknown sms provider: %s. Must be one of: http", cfg.SMSProvider)
+	}
+	switch cfg.WhatsAppProvider {
+	case "":
+	case WhatsAppProviderCloud:
+		if cfg.WhatsAppPhoneNumberID == "" || cfg.WhatsAppAccessToken == "" {
+			return nil, fmt.Errorf("whatsapp cloud requires a phone number ID and access token")
+		}
+		available[ChannelWhatsApp] = NewWhatsAppChannel(NewCloudAPIProvider(cfg.WhatsAppAPIURL, cfg.WhatsAppPhoneNumberID, cfg.WhatsAppAccessToken, cfg.WhatsAppTemplate, cfg.WhatsAppLanguage))
+	default:
+		return nil, fmt.Errorf("unknown whatsapp provider: %s. Must be one of: cloud", cfg.WhatsAppProvider)
+	}
+
+	order := cfg.Order
+	if order == nil {
+		order = DefaultOrder
+	}
+	var channels []Channel
+	for _, name := range order {
+		if !IsValidName(name) {
+			return nil, fmt.Errorf("unknown notification channel: %s. Must be one of: %s", name, strings.Join(DefaultOrder, ", "))
+		}
+		channels = append(channels, available[name])
+	}
+	return NewDispatcher(channels...), nil
+}
+
+// IsValidName reports whether name is a known channel
+func IsValidName(name string) bool {
+	for _, known := range DefaultOrder {
+		if name == known {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseOrder parses a comma-separated channel list such as "telegram,sms" (empty means nil)
+func ParseOrder(list string) []string {
+	var order []string
+	for _, name := range strings.Split(list, ",") {
+		if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+			order = append(order, name)
+		}
+	}
+	return order
+}
@@ -0,0 +1,133 @@
+package channel
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDispatcherFallsBackToNextChannel(t *testing.T) {
+	whatsApp := NewFakeProvider()
+	whatsApp.FailWith = http.StatusBadRequest
+	whatsAppServer := httptest.NewServer(whatsApp)
+	defer whatsAppServer.Close()
+
+	sms := NewFakeProvider()
+	smsServer := httptest.NewServer(sms)
+	defer smsServer.Close()
+
+	d := NewDispatcher(
+		NewWhatsAppChannel(NewCloudAPIProvider(whatsAppServer.URL, "123", "token", "", "")),
+		NewSMSChannel(NewHTTPSMSProvider(smsServer.URL+"/sms", "key", "RENTAL")),
+	)
+
+	delivery, err := d.Send(context.Background(), Recipient{Phone: "98765 43210"}, Message{Subject: "Rent Reminder", Body: "Rent due"})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if delivery.Channel != ChannelSMS || delivery.Address != "919876543210" || len(delivery.Attempts) != 2 {
+		t.Fatalf("unexpected delivery: %+v", delivery)
+	}
+	if delivery.Attempts[0].Channel != ChannelWhatsApp || delivery.Attempts[0].Err == nil {
+		t.Fatalf("expected a failed whatsapp attempt first, got %+v", delivery.Attempts[0])
+	}
+
+	requests := sms.Requests()
+	if len(requests) != 1 || requests[0].Body["to"] != "919876543210" || requests[0].Body["message"] != "Rent due" || requests[0].Authorization != "Bearer key" {
+		t.Fatalf("unexpected sms requests: %+v", requests)
+	}
+	if got := whatsApp.Requests(); len(got) != 1 || got[0].Path != "/123/messages" {
+		t.Fatalf("unexpected whatsapp requests: %+v", got)
+	}
+}
+
+func TestDispatcherRecipientChannels(t *testing.T) {
+	sms := NewFakeProvider()
+	smsServer := httptest.NewServer(sms)
+	defer smsServer.Close()
+
+	d := NewDispatcher(NewSMSChannel(NewHTTPSMSProvider(smsServer.URL, "", "")))
+
+	if d.CanReach(Recipient{Email: "a@example.com"}) || !d.CanReach(Recipient{Phone: "9876543210"}) {
+		t.Fatalf("CanReach should only report recipients with an SMS number")
+	}
+
+	// Recipient has no address on the configured channel
+	if _, err := d.Send(context.Background(), Recipient{Email: "a@example.com"}, Message{Body: "x"}); !errors.Is(err, ErrNoChannel) {
+		t.Fatalf("expected ErrNoChannel, got %v", err)
+	}
+	// Recipient only wants channels that are not configured
+	if _, err := d.Send(context.Background(), Recipient{Phone: "9876543210", Channels: []string{ChannelEmail}}, Message{Body: "x"}); !errors.Is(err, ErrNoChannel) {
+		t.Fatalf("expected ErrNoChannel, got %v", err)
+	}
+
+	sms.FailWith = http.StatusInternalServerError
+	delivery, err := d.Send(context.Background(), Recipient{Phone: "9876543210"}, Message{Body: "x"})
+	if err == nil || errors.Is(err, ErrNoChannel) || delivery.Channel != "" || len(delivery.Attempts) != 1 {
+		t.Fatalf("expected a failed delivery, got %+v, %v", delivery, err)
+	}
+}
+
+func TestEmailChannelWithSMTPSink(t *testing.T) {
+	sink, err := NewSMTPSink("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("NewSMTPSink: %v", err)
+	}
+	defer sink.Close()
+
+	host, port, _ := net.SplitHostPort(sink.Addr())
+	email := NewEmailChannel(host, port, "", "", "rent@example.com")
+
+	if err := email.Send(context.Background(), "tenant@example.com", Message{Subject: "📅 Rent Reminder", Body: "Rent due\non Aug 5"}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	messages := sink.Messages()
+	if len(messages) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(messages))
+	}
+	msg := messages[0]
+	if msg.From != "rent@example.com" || len(msg.To) != 1 || msg.To[0] != "tenant@example.com" {
+		t.Fatalf("unexpected envelope: %+v", msg)
+	}
+	if !strings.Contains(msg.Data, "Subject: =?utf-8?q?") || !strings.Contains(msg.Data, "Rent due\non Aug 5") {
+		t.Fatalf("unexpected data: %q", msg.Data)
+	}
+}
+
+func TestNew(t *testing.T) {
+	d, err := New(Config{Order: []string{ChannelEmail, ChannelSMS}, SMTPHost: "localhost", SMTPPort: "2525", SMTPFrom: "rent@example.com"})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if names := d.Names(); len(names) != 1 || names[0] != ChannelEmail {
+		t.Fatalf("expected only email to be configured, got %v", names)
+	}
+
+	if _, err := New(Config{Order: []string{"pigeon"}}); err == nil {
+		t.Fatalf("expected error for unknown channel")
+	}
+	if _, err := New(Config{WhatsAppProvider: WhatsAppProviderCloud}); err == nil {
+		t.Fatalf("expected error for whatsapp without credentials")
+	}
+}
+
+func TestNormalizePhone(t *testing.T) {
+	tests := map[string]string{
+		"9876543210":       "919876543210",
+		"+91 98765 43210":  "919876543210",
+		"09876543210":      "919876543210",
+		"+1 (415) 5550100": "14155550100",
+		"12345":            "",
+		"":                 "",
+	}
+	for in, want := range tests {
+		if got := NormalizePhone(in); got != want {
+			t.Errorf("NormalizePhone(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package channel
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// EmailChannel sends plain-text emails through an SMTP server
+type EmailChannel struct {
+	host     string
+	port     string
+	username string
+	password string
+	from     string
+}
+
+// NewEmailChannel creates a new EmailChannel
+// Username may be empty for servers that accept mail without authentication (e.g. a local sink)
+func NewEmailChannel(host, port, username, password, from string) *EmailChannel {
+	return &EmailChannel{
+		host:     host,
+		port:     port,
+		username: username,
+		password: password,
+		from:     from,
+	}
+}
+
+// Name returns the channel name
+func (c *EmailChannel) Name() string {
+	return ChannelEmail
+}
+
+// Address returns the recipient's email address
+func (c *EmailChannel) Address(to Recipient) string {
+	return strings.TrimSpace(to.Email)
+}
+
+// Send sends an email
+func (c *EmailChannel) Send(ctx context.Context, address string, msg Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if c.username != "" {
+		auth = smtp.PlainAuth("", c.username, c.password, c.host)
+	}
+
+	if err := smtp.SendMail(net.JoinHostPort(c.host, c.port), auth, c.from, []string{address}, c.buildMessage(address, msg)); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return nil
+}
+
+// buildMessage renders the email headers and body
+func (c *EmailChannel) buildMessage(address string, msg Message) []byte {
+	var b strings.Builder
+	b.WriteString("From: " + c.from + "\r\n")
+	b.WriteString("To: " + address + "\r\n")
+	b.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", msg.Subject) + "\r\n")
+	b.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+	b.WriteString("\r\n")
+	return []byte(b.String())
+}
@@ -0,0 +1,185 @@
+package channel
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/textproto"
+	"strings"
+	"sync"
+)
+
+// SinkMessage is an email received by SMTPSink
+type SinkMessage struct {
+	From string
+	To   []string
+	Data string // Headers and body as sent
+}
+
+// SMTPSink is a local SMTP server that accepts every email and keeps it in memory
+// Use it for development and tests instead of a real mail server; it supports no TLS or AUTH
+type SMTPSink struct {
+	listener  net.Listener
+	OnMessage func(SinkMessage) // Called for every received email (optional)
+
+	mu       sync.Mutex
+	messages []SinkMessage
+}
+
+// NewSMTPSink starts an SMTPSink listening on addr (e.g. "127.0.0.1:0" for a random port)
+func NewSMTPSink(addr string) (*SMTPSink, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start smtp sink: %w", err)
+	}
+	sink := &SMTPSink{listener: listener}
+	go sink.serve()
+	return sink, nil
+}
+
+// Addr returns the host:port the sink listens on
+func (s *SMTPSink) Addr() string {
+	return s.listener.Addr().String()
+}
+
+// Messages returns the emails received so far
+func (s *SMTPSink) Messages() []SinkMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]SinkMessage(nil), s.messages...)
+}
+
+// Close stops the sink
+func (s *SMTPSink) Close() error {
+	return s.listener.Close()
+}
+
+// serve accepts connections until the sink is closed
+func (s *SMTPSink) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(textproto.NewConn(conn))
+	}
+}
+
+// handle speaks just enough SMTP for net/smtp.SendMail
+func (s *SMTPSink) handle(conn *textproto.Conn) {
+	defer conn.Close()
+
+	var current SinkMessage
+	conn.PrintfLine("220 localhost SMTP sink")
+	for {
+		line, err := conn.ReadLine()
+		if err != nil {
+			return
+		}
+		verb := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+		switch verb {
+		case "EHLO", "HELO":
+			conn.PrintfLine("250 localhost")
+		case "MAIL":
+			current = SinkMessage{From: smtpPathArg(line)}
+			conn.PrintfLine("250 OK")
+		case "RCPT":
+			current.To = append(current.To, smtpPathArg(line))
+			conn.PrintfLine("250 OK")
+		case "DATA":
+			conn.PrintfLine("354 End data with <CR><LF>.<CR><LF>")
+			data, err := conn.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			current.Data = string(data)
+			s.mu.Lock()
+			s.messages = append(s.messages, current)
+			s.mu.Unlock()
+			if s.OnMessage != nil {
+				s.OnMessage(current)
+			}
+			conn.PrintfLine("250 OK: queued")
+		case "RSET":
+			current = SinkMessage{}
+			conn.PrintfLine("250 OK")
+		case "NOOP":
+			conn.PrintfLine("250 OK")
+		case "QUIT":
+			conn.PrintfLine("221 Bye")
+			return
+		default:
+			conn.PrintfLine("502 Command not implemented")
+		}
+	}
+}
+
+// smtpPathArg extracts the address from "MAIL FROM:<a@b>" or "RCPT TO:<a@b>"
+func smtpPathArg(line string) string {
+	start, end := strings.Index(line, "<"), strings.Index(line, ">")
+	if start < 0 || end < start {
+		return ""
+	}
+	return line[start+1 : end]
+}
+
+// FakeRequest is a request received by FakeProvider
+type FakeRequest struct {
+	Path          string
+	Authorization string
+	Body          map[string]interface{}
+}
+
+// FakeProvider is a local stand-in for the SMS and WhatsApp HTTP APIs
+// It accepts any JSON POST and records it; set FailWith to make every request fail with that status
+type FakeProvider struct {
+	FailWith  int               // HTTP status to fail with (0 = succeed)
+	OnRequest func(FakeRequest) // Called for every received request (optional)
+
+	mu       sync.Mutex
+	requests []FakeRequest
+}
+
+// NewFakeProvider creates a new FakeProvider
+func NewFakeProvider() *FakeProvider {
+	return &FakeProvider{}
+}
+
+// ServeHTTP records the request and answers like a provider would
+func (p *FakeProvider) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	request := FakeRequest{Path: r.URL.Path, Authorization: r.Header.Get("Authorization")}
+	if err := json.NewDecoder(r.Body).Decode(&request.Body); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	p.mu.Lock()
+	p.requests = append(p.requests, request)
+	id := len(p.requests)
+	failWith := p.FailWith
+	p.mu.Unlock()
+	if p.OnRequest != nil {
+		p.OnRequest(request)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if failWith != 0 {
+		w.WriteHeader(failWith)
+		json.NewEncoder(w).Encode(map[string]interface{}{"error": "fake provider failure"})
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{"success": true, "id": fmt.Sprintf("fake_%d", id)})
+}
+
+// Requests returns the requests received so far
+func (p *FakeProvider) Requests() []FakeRequest {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]FakeRequest(nil), p.requests...)
+}
@@ -0,0 +1,107 @@
+package channel
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// SMS provider names
+const (
+	SMSProviderHTTP = "http"
+)
+
+// SMSProvider sends text messages through an SMS gateway
+type SMSProvider interface {
+	// SendSMS sends text to a phone number given as digits with country code
+	SendSMS(ctx context.Context, phone, text string) error
+}
+
+// SMSChannel sends notifications as text messages
+type SMSChannel struct {
+	provider SMSProvider
+}
+
+// NewSMSChannel creates a new SMSChannel
+func NewSMSChannel(provider SMSProvider) *SMSChannel {
+	return &SMSChannel{provider: provider}
+}
+
+// Name returns the channel name
+func (c *SMSChannel) Name() string {
+	return ChannelSMS
+}
+
+// Address returns the recipient's normalized phone number
+func (c *SMSChannel) Address(to Recipient) string {
+	return NormalizePhone(to.Phone)
+}
+
+// Send sends the message body as an SMS (the subject is not sent)
+func (c *SMSChannel) Send(ctx context.Context, address string, msg Message) error {
+	if err := c.provider.SendSMS(ctx, address, msg.Body); err != nil {
+		return fmt.Errorf("failed to send sms: %w", err)
+	}
+	return nil
+}
+
+// HTTPSMSProvider sends SMS through a JSON HTTP API:
+// POST {url} with {"to": "919876543210", "sender": "RENTAL", "message": "..."} and a bearer API key
+// Most Indian SMS gateways offer such an endpoint; point the URL at FakeProvider for offline testing
+type HTTPSMSProvider struct {
+	url    string
+	apiKey string
+	sender string
+	client *http.Client
+}
+
+// NewHTTPSMSProvider creates a new HTTPSMSProvider
+func NewHTTPSMSProvider(url, apiKey, sender string) *HTTPSMSProvider {
+	return &HTTPSMSProvider{
+		url:    url,
+		apiKey: apiKey,
+		sender: sender,
+		client: &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+// SendSMS posts the message to the provider
+func (p *HTTPSMSProvider) SendSMS(ctx context.Context, phone, text string) error {
+	body, err := json.Marshal(map[string]string{
+		"to":      phone,
+		"sender":  p.sender,
+		"message": text,
+	})
+	if err != nil {
+		return err
+	}
+	return postJSON(ctx, p.client, p.url, p.apiKey, body)
+}
+
+// postJSON posts a JSON body with a bearer token and fails on non-2xx responses
+func postJSON(ctx context.Context, client *http.Client, url, token string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("provider returned %d: %s", resp.StatusCode, bytes.TrimSpace(respBody))
+	}
+	return nil
+}
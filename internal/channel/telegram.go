@@ -0,0 +1,52 @@
+package channel
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/nikoksr/notify"
+	"github.com/nikoksr/notify/service/telegram"
+)
+
+// TelegramChannel sends messages through a Telegram bot
+type TelegramChannel struct {
+	botToken string
+}
+
+// NewTelegramChannel creates a new TelegramChannel
+func NewTelegramChannel(botToken string) *TelegramChannel {
+	return &TelegramChannel{botToken: botToken}
+}
+
+// Name returns the channel name
+func (c *TelegramChannel) Name() string {
+	return ChannelTelegram
+}
+
+// Address returns the recipient's chat ID
+func (c *TelegramChannel) Address(to Recipient) string {
+	return to.TelegramChatID
+}
+
+// Send sends a message to a chat using the notify library
+func (c *TelegramChannel) Send(ctx context.Context, address string, msg Message) error {
+	chatID, err := strconv.ParseInt(address, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid chat ID format: %w", err)
+	}
+
+	telegramService, err := telegram.New(c.botToken)
+	if err != nil {
+		return fmt.Errorf("failed to create telegram service: %w", err)
+	}
+	telegramService.AddReceivers(chatID)
+
+	notifier := notify.New()
+	notifier.UseServices(telegramService)
+	if err := notifier.Send(ctx, msg.Subject, msg.Body); err != nil {
+		return fmt.Errorf("failed to send telegram message: %w", err)
+	}
+
+	return nil
+}
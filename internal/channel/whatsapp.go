@@ -0,0 +1,111 @@
+package channel
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// WhatsApp provider names
+const (
+	WhatsAppProviderCloud = "cloud"
+)
+
+// whatsAppCloudURL is the base URL of the WhatsApp Business Cloud API
+const whatsAppCloudURL = "https://graph.facebook.com/v19.0"
+
+// WhatsAppProvider sends messages through the WhatsApp Business API
+type WhatsAppProvider interface {
+	// SendWhatsApp sends text to a phone number given as digits with country code
+	SendWhatsApp(ctx context.Context, phone, text string) error
+}
+
+// WhatsAppChannel sends notifications as WhatsApp messages
+type WhatsAppChannel struct {
+	provider WhatsAppProvider
+}
+
+// NewWhatsAppChannel creates a new WhatsAppChannel
+func NewWhatsAppChannel(provider WhatsAppProvider) *WhatsAppChannel {
+	return &WhatsAppChannel{provider: provider}
+}
+
+// Name returns the channel name
+func (c *WhatsAppChannel) Name() string {
+	return ChannelWhatsApp
+}
+
+// Address returns the recipient's normalized phone number
+func (c *WhatsAppChannel) Address(to Recipient) string {
+	return NormalizePhone(to.Phone)
+}
+
+// Send sends the message body over WhatsApp (the subject is not sent)
+func (c *WhatsAppChannel) Send(ctx context.Context, address string, msg Message) error {
+	if err := c.provider.SendWhatsApp(ctx, address, msg.Body); err != nil {
+		return fmt.Errorf("failed to send whatsapp message: %w", err)
+	}
+	return nil
+}
+
+// CloudAPIProvider sends messages through the WhatsApp Business Cloud API
+// Business-initiated messages outside a 24-hour conversation window must use an approved template;
+// when templateName is set the text is sent as the template's single body parameter, otherwise as a plain text message
+type CloudAPIProvider struct {
+	baseURL       string
+	phoneNumberID string
+	accessToken   string
+	templateName  string
+	language      string
+	client        *http.Client
+}
+
+// NewCloudAPIProvider creates a new CloudAPIProvider
+// baseURL defaults to the Graph API; point it at FakeProvider for offline testing
+func NewCloudAPIProvider(baseURL, phoneNumberID, accessToken, templateName, language string) *CloudAPIProvider {
+	if baseURL == "" {
+		baseURL = whatsAppCloudURL
+	}
+	if language == "" {
+		language = "en"
+	}
+	return &CloudAPIProvider{
+		baseURL:       strings.TrimRight(baseURL, "/"),
+		phoneNumberID: phoneNumberID,
+		accessToken:   accessToken,
+		templateName:  templateName,
+		language:      language,
+		client:        &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+// SendWhatsApp posts a message to the Cloud API
+func (p *CloudAPIProvider) SendWhatsApp(ctx context.Context, phone, text string) error {
+	payload := map[string]interface{}{
+		"messaging_product": "whatsapp",
+		"to":                phone,
+	}
+	if p.templateName != "" {
+		payload["type"] = "template"
+		payload["template"] = map[string]interface{}{
+			"name":     p.templateName,
+			"language": map[string]string{"code": p.language},
+			"components": []map[string]interface{}{{
+				"type":       "body",
+				"parameters": []map[string]string{{"type": "text", "text": text}},
+			}},
+		}
+	} else {
+		payload["type"] = "text"
+		payload["text"] = map[string]string{"body": text}
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	return postJSON(ctx, p.client, p.baseURL+"/"+p.phoneNumberID+"/messages", p.accessToken, body)
+}
@@ -3,10 +3,14 @@ package config
 import (
 	"fmt"
 	"os"
+	"regexp"
 	"strconv"
 	"strings"
 )
 
+// panPattern matches an Indian Permanent Account Number, e.g. ABCDE1234F
+var panPattern = regexp.MustCompile(`^[A-Z]{5}[0-9]{4}[A-Z]$`)
+
 type Config struct {
 	// Server Configuration
 	Port     string
@@ -26,8 +30,34 @@ type Config struct {
 	ConnectionTimeout int
 
 	// Notification Configuration
-	TelegramBotToken string
-	OwnerChatID      string
+	TelegramBotToken      string
+	OwnerChatID           string
+	TelegramBotUsername   string // Bot username used in tenant link deep links (t.me/<username>?start=<token>)
+	TelegramWebhookSecret string // Secret token Telegram sends with every webhook call
+	NotificationChannels  string // Comma-separated channels in the order they are tried (default: telegram,whatsapp,sms,email)
+	OwnerEmail            string // Owner's email address for owner notifications
+	OwnerPhone            string // Owner's phone number for owner SMS/WhatsApp notifications
+
+	// Email channel (SMTP)
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+	SMTPFrom     string
+
+	// SMS channel
+	SMSProvider string // "" (disabled), http
+	SMSAPIURL   string
+	SMSAPIKey   string
+	SMSSender   string
+
+	// WhatsApp channel
+	WhatsAppProvider      string // "" (disabled), cloud
+	WhatsAppAPIURL        string // Defaults to the WhatsApp Business Cloud API
+	WhatsAppPhoneNumberID string
+	WhatsAppAccessToken   string
+	WhatsAppTemplate      string // Approved template with a single body parameter (optional)
+	WhatsAppLanguage      string
 
 	// Security Configuration
 	Environment    string // "development" or "production"
@@ -38,6 +68,22 @@ type Config struct {
 	// Payment Configuration
 	DefaultPaymentMethod string // Default payment method (e.g., "UPI")
 	DefaultUPIID         string // Default UPI ID for payments
+	UPIPayeeName         string // Payee name in generated UPI QR codes and intent links
+	// How partial first/last months are charged (RENT_PRORATION_POLICY). Defaults to none, which charges the
+	// full month as before; opt in with actual_days (rent / days in the month) or thirty_day (rent / 30) to
+	// prorate the first payment of tenants moving in after the 1st and the last of tenants moving out early
+	RentProrationPolicy string
+
+	// Landlord details printed on annual rent statements (needed by tenants for HRA claims)
+	LandlordName    string // Defaults to the UPI payee name
+	LandlordPAN     string // Permanent Account Number (optional)
+	LandlordAddress string // Defaults to the property address
+
+	// Payment Gateway Configuration
+	PaymentGateway       string // Online payment gateway: "" (disabled), mock, razorpay
+	GatewayKeyID         string // Gateway API key ID
+	GatewayKeySecret     string // Gateway API key secret
+	GatewayWebhookSecret string // Secret used to verify webhook signatures
 
 	// Server Timeouts
 	ReadTimeout  int // HTTP read timeout in seconds
@@ -65,8 +111,34 @@ func Load() *Config {
 		ConnectionTimeout: getEnvAsInt("DB_CONNECTION_TIMEOUT", 30),
 
 		// Notification settings
-		TelegramBotToken: getEnv("TELEGRAM_BOT_TOKEN", ""),
-		OwnerChatID:      getEnv("TELEGRAM_OWNER_CHAT_ID", ""),
+		TelegramBotToken:      getEnv("TELEGRAM_BOT_TOKEN", ""),
+		OwnerChatID:           getEnv("TELEGRAM_OWNER_CHAT_ID", ""),
+		TelegramBotUsername:   getEnv("TELEGRAM_BOT_USERNAME", ""),
+		TelegramWebhookSecret: getEnv("TELEGRAM_WEBHOOK_SECRET", ""),
+		NotificationChannels:  getEnv("NOTIFICATION_CHANNELS", ""),
+		OwnerEmail:            getEnv("OWNER_EMAIL", ""),
+		OwnerPhone:            getEnv("OWNER_PHONE", ""),
+
+		// Email channel settings
+		SMTPHost:     getEnv("SMTP_HOST", ""),
+		SMTPPort:     getEnv("SMTP_PORT", "587"),
+		SMTPUsername: getEnv("SMTP_USERNAME", ""),
+		SMTPPassword: getEnv("SMTP_PASSWORD", ""),
+		SMTPFrom:     getEnv("SMTP_FROM", ""),
+
+		// SMS channel settings
+		SMSProvider: getEnv("SMS_PROVIDER", ""),
+		SMSAPIURL:   getEnv("SMS_API_URL", ""),
+		SMSAPIKey:   getEnv("SMS_API_KEY", ""),
+		SMSSender:   getEnv("SMS_SENDER", ""),
+
+		// WhatsApp channel settings
+		WhatsAppProvider:      getEnv("WHATSAPP_PROVIDER", ""),
+		WhatsAppAPIURL:        getEnv("WHATSAPP_API_URL", ""),
+		WhatsAppPhoneNumberID: getEnv("WHATSAPP_PHONE_NUMBER_ID", ""),
+		WhatsAppAccessToken:   getEnv("WHATSAPP_ACCESS_TOKEN", ""),
+		WhatsAppTemplate:      getEnv("WHATSAPP_TEMPLATE", ""),
+		WhatsAppLanguage:      getEnv("WHATSAPP_LANGUAGE", "en"),
 
 		// Security settings
 		Environment:    getEnv("ENVIRONMENT", "development"),
@@ -77,6 +149,19 @@ func Load() *Config {
 		// Payment settings
 		DefaultPaymentMethod: getEnv("DEFAULT_PAYMENT_METHOD", "UPI"),
 		DefaultUPIID:         getEnv("DEFAULT_UPI_ID", "9848790200@ybl"),
+		UPIPayeeName:         getEnv("UPI_PAYEE_NAME", "Rent"),
+		RentProrationPolicy:  getEnv("RENT_PRORATION_POLICY", "none"),
+
+		// Landlord settings
+		LandlordName:    getEnv("LANDLORD_NAME", ""),
+		LandlordPAN:     strings.ToUpper(getEnv("LANDLORD_PAN", "")),
+		LandlordAddress: getEnv("LANDLORD_ADDRESS", ""),
+
+		// Payment gateway settings
+		PaymentGateway:       getEnv("PAYMENT_GATEWAY", ""),
+		GatewayKeyID:         getEnv("PAYMENT_GATEWAY_KEY_ID", ""),
+		GatewayKeySecret:     getEnv("PAYMENT_GATEWAY_KEY_SECRET", ""),
+		GatewayWebhookSecret: getEnv("PAYMENT_GATEWAY_WEBHOOK_SECRET", ""),
 
 		// Server timeout settings
 		ReadTimeout:  getEnvAsInt("READ_TIMEOUT", 15),
@@ -178,6 +263,60 @@ func (c *Config) Validate() error {
 	if c.DefaultUPIID == "" {
 		errors = append(errors, "DEFAULT_UPI_ID cannot be empty")
 	}
+	switch c.RentProrationPolicy {
+	case "none", "actual_days", "thirty_day":
+	default:
+		errors = append(errors, "RENT_PRORATION_POLICY must be one of: none, actual_days, thirty_day")
+	}
+
+	if c.LandlordPAN != "" && !panPattern.MatchString(c.LandlordPAN) {
+		errors = append(errors, "LANDLORD_PAN must be a valid PAN (e.g. ABCDE1234F)")
+	}
+
+	// Payment gateway validation
+	switch c.PaymentGateway {
+	case "":
+	case "mock":
+		if strings.ToLower(c.Environment) == "production" {
+			errors = append(errors, "PAYMENT_GATEWAY=mock cannot be used in production")
+		}
+	case "razorpay":
+		if c.GatewayKeyID == "" || c.GatewayKeySecret == "" || c.GatewayWebhookSecret == "" {
+			errors = append(errors, "PAYMENT_GATEWAY_KEY_ID, PAYMENT_GATEWAY_KEY_SECRET and PAYMENT_GATEWAY_WEBHOOK_SECRET are required for razorpay")
+		}
+	default:
+		errors = append(errors, "PAYMENT_GATEWAY must be one of: mock, razorpay (or empty to disable)")
+	}
+
+	// Notification channel validation
+	if c.SMTPHost != "" && c.SMTPFrom == "" {
+		errors = append(errors, "SMTP_FROM is required when SMTP_HOST is set")
+	}
+	switch c.SMSProvider {
+	case "":
+	case "http":
+		if c.SMSAPIURL == "" {
+			errors = append(errors, "SMS_API_URL is required for SMS_PROVIDER=http")
+		}
+	default:
+		errors = append(errors, "SMS_PROVIDER must be one of: http (or empty to disable)")
+	}
+	switch c.WhatsAppProvider {
+	case "":
+	case "cloud":
+		if c.WhatsAppPhoneNumberID == "" || c.WhatsAppAccessToken == "" {
+			errors = append(errors, "WHATSAPP_PHONE_NUMBER_ID and WHATSAPP_ACCESS_TOKEN are required for WHATSAPP_PROVIDER=cloud")
+		}
+	default:
+		errors = append(errors, "WHATSAPP_PROVIDER must be one of: cloud (or empty to disable)")
+	}
+	for _, name := range strings.Split(c.NotificationChannels, ",") {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "", "telegram", "whatsapp", "sms", "email":
+		default:
+			errors = append(errors, fmt.Sprintf("NOTIFICATION_CHANNELS may only contain: telegram, whatsapp, sms, email, got: %s", name))
+		}
+	}
 
 	// Cookie name validation
 	if c.CookieName == "" {
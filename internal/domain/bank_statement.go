@@ -0,0 +1,440 @@
+package domain
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// StatementImport is one bank statement file uploaded by the owner
+type StatementImport struct {
+	ID               int       `json:"id" db:"id"`
+	FileName         string    `json:"file_name" db:"file_name"`
+	Format           string    `json:"format" db:"format"` // csv, ofx, camt053
+	ImportedByUserID int       `json:"imported_by_user_id" db:"imported_by_user_id"`
+	LineCount        int       `json:"line_count" db:"line_count"`       // Credit lines imported
+	MatchedCount     int       `json:"matched_count" db:"matched_count"` // Lines verified automatically
+	DuplicateCount   int       `json:"duplicate_count" db:"duplicate_count"`
+	CreatedAt        time.Time `json:"created_at" db:"created_at"`
+
+	// Related data (populated on import)
+	Lines []*StatementLine `json:"lines,omitempty"`
+}
+
+// StatementLine is a single credit on an imported bank statement
+type StatementLine struct {
+	ID                   int       `json:"id" db:"id"`
+	ImportID             int       `json:"import_id" db:"import_id"`
+	TxnDate              time.Time `json:"txn_date" db:"txn_date"`
+	Amount               int       `json:"amount" db:"amount"`
+	Reference            string    `json:"reference" db:"reference"`     // Bank reference / UTR column if present
+	Description          string    `json:"description" db:"description"` // Narration, usually contains the UPI UTR
+	Status               string    `json:"status" db:"status"`           // matched, review, unmatched, ignored
+	MatchedTransactionID *string   `json:"matched_transaction_id,omitempty" db:"matched_transaction_id"`
+	Note                 string    `json:"note" db:"note"` // Why the line needs review, or why it was ignored
+	CreatedAt            time.Time `json:"created_at" db:"created_at"`
+}
+
+// Statement format constants
+const (
+	StatementFormatCSV     = "csv"
+	StatementFormatOFX     = "ofx"
+	StatementFormatCAMT053 = "camt053"
+)
+
+// Statement line status constants
+const (
+	StatementLineMatched   = "matched"   // Verified against a submitted transaction
+	StatementLineReview    = "review"    // Reference matched but amount/date did not, or verification failed
+	StatementLineUnmatched = "unmatched" // No submitted transaction carries this reference
+	StatementLineIgnored   = "ignored"   // Dismissed by the owner (e.g. not rent)
+)
+
+// statementMatchWindowBefore/After bound how far a bank credit may be from the tenant's submission
+const (
+	statementMatchWindowBefore = 7 * 24 * time.Hour // Tenants submit after paying
+	statementMatchWindowAfter  = 3 * 24 * time.Hour // Banks may post a credit a few days later
+)
+
+// DetectStatementFormat guesses the format of a statement from its file name
+func DetectStatementFormat(fileName string) string {
+	lower := strings.ToLower(fileName)
+	switch {
+	case strings.HasSuffix(lower, ".ofx"), strings.HasSuffix(lower, ".qfx"):
+		return StatementFormatOFX
+	case strings.HasSuffix(lower, ".xml"):
+		return StatementFormatCAMT053
+	default:
+		return StatementFormatCSV
+	}
+}
+
+// ParseStatement parses the credit lines of a bank statement
+// Debits are skipped; amounts are rounded to whole rupees.
+func ParseStatement(format string, r io.Reader) ([]*StatementLine, error) {
+	switch format {
+	case StatementFormatCSV:
+		return parseCSVStatement(r)
+	case StatementFormatOFX:
+		return parseOFXStatement(r)
+	case StatementFormatCAMT053:
+		return parseCAMT053Statement(r)
+	}
+	return nil, fmt.Errorf("invalid statement format: %s. Must be one of: csv, ofx, camt053", format)
+}
+
+// Header names used by common bank CSV exports
+var (
+	csvDateHeaders        = []string{"txn date", "transaction date", "value date", "date", "posting date"}
+	csvDescriptionHeaders = []string{"description", "narration", "particulars", "remarks", "details"}
+	csvReferenceHeaders   = []string{"utr", "reference", "ref no", "chq/ref no", "cheque/ref no", "ref no./cheque no.", "reference no"}
+	csvCreditHeaders      = []string{"credit", "deposit", "credit amount", "deposit amt", "cr amount", "deposits"}
+	csvAmountHeaders      = []string{"amount", "transaction amount"}
+	csvTypeHeaders        = []string{"type", "cr/dr", "dr/cr", "transaction type"}
+)
+
+// findColumn returns the index of the first header matching one of the names (-1 if none)
+func findColumn(headers []string, names []string) int {
+	for _, name := range names {
+		for i, header := range headers {
+			if header == name {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// parseCSVStatement parses a CSV export with a header row
+// Either a credit column, or an amount column with an optional CR/DR type column, is required
+func parseCSVStatement(r io.Reader) ([]*StatementLine, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV: %w", err)
+	}
+
+	// Banks often put account details above the table, so find the header row
+	headerRow := -1
+	var headers []string
+	for i, record := range records {
+		normalized := make([]string, len(record))
+		for j, field := range record {
+			normalized[j] = strings.ToLower(strings.TrimSpace(strings.Trim(field, "\ufeff")))
+		}
+		if findColumn(normalized, csvDateHeaders) >= 0 &&
+			(findColumn(normalized, csvCreditHeaders) >= 0 || findColumn(normalized, csvAmountHeaders) >= 0) {
+			headerRow = i
+			headers = normalized
+			break
+		}
+	}
+	if headerRow < 0 {
+		return nil, fmt.Errorf("CSV has no header row with a date and a credit or amount column")
+	}
+
+	dateCol := findColumn(headers, csvDateHeaders)
+	descCol := findColumn(headers, csvDescriptionHeaders)
+	refCol := findColumn(headers, csvReferenceHeaders)
+	creditCol := findColumn(headers, csvCreditHeaders)
+	amountCol := findColumn(headers, csvAmountHeaders)
+	typeCol := findColumn(headers, csvTypeHeaders)
+
+	field := func(record []string, col int) string {
+		if col < 0 || col >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[col])
+	}
+
+	var lines []*StatementLine
+	for i, record := range records[headerRow+1:] {
+		// Blank rows and summary or footer rows ("Total", "Closing balance") have no transaction date
+		txnDate, err := parseStatementDate(field(record, dateCol))
+		if err != nil {
+			continue
+		}
+
+		var amount float64
+		if creditCol >= 0 {
+			amount, err = parseStatementAmount(field(record, creditCol))
+		} else {
+			amount, err = parseStatementAmount(field(record, amountCol))
+			txnType := strings.ToLower(field(record, typeCol))
+			if strings.HasPrefix(txnType, "d") {
+				amount = -amount
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", headerRow+i+2, err)
+		}
+		if amount <= 0 {
+			continue // Debit
+		}
+
+		lines = append(lines, &StatementLine{
+			TxnDate:     txnDate,
+			Amount:      int(math.Round(amount)),
+			Reference:   field(record, refCol),
+			Description: field(record, descCol),
+		})
+	}
+
+	return lines, nil
+}
+
+// ofxTagPattern matches an OFX element, closed or not (OFX 1.x is SGML without closing tags)
+var ofxTagPattern = regexp.MustCompile(`<([A-Z0-9.]+)>([^<\r\n]*)`)
+
+// parseOFXStatement parses the STMTTRN records of an OFX 1.x or 2.x file
+func parseOFXStatement(r io.Reader) ([]*StatementLine, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OFX: %w", err)
+	}
+	content := string(data)
+
+	// Each transaction starts at <STMTTRN>; fields run until </STMTTRN> or the next transaction
+	var lines []*StatementLine
+	for _, block := range strings.Split(content, "<STMTTRN>")[1:] {
+		if end := strings.Index(block, "</STMTTRN>"); end >= 0 {
+			block = block[:end]
+		}
+		fields := make(map[string]string)
+		for _, match := range ofxTagPattern.FindAllStringSubmatch(block, -1) {
+			if value := strings.TrimSpace(match[2]); value != "" {
+				fields[match[1]] = value
+			}
+		}
+
+		amount, err := parseStatementAmount(fields["TRNAMT"])
+		if err != nil {
+			return nil, fmt.Errorf("transaction %s: %w", fields["FITID"], err)
+		}
+		if amount <= 0 {
+			continue // Debit
+		}
+
+		dateStr := fields["DTPOSTED"]
+		if len(dateStr) < 8 {
+			return nil, fmt.Errorf("transaction %s: invalid DTPOSTED %q", fields["FITID"], dateStr)
+		}
+		txnDate, err := time.Parse("20060102", dateStr[:8])
+		if err != nil {
+			return nil, fmt.Errorf("transaction %s: invalid DTPOSTED %q", fields["FITID"], dateStr)
+		}
+
+		reference := fields["REFNUM"]
+		if reference == "" {
+			reference = fields["FITID"]
+		}
+		description := strings.TrimSpace(fields["NAME"] + " " + fields["MEMO"])
+
+		lines = append(lines, &StatementLine{
+			TxnDate:     txnDate,
+			Amount:      int(math.Round(amount)),
+			Reference:   reference,
+			Description: description,
+		})
+	}
+
+	return lines, nil
+}
+
+// camtDocument is the subset of an ISO 20022 camt.053 statement used for reconciliation
+type camtDocument struct {
+	Statements []struct {
+		Entries []struct {
+			Amount        string `xml:"Amt"`
+			CreditDebit   string `xml:"CdtDbtInd"`
+			BookingDate   string `xml:"BookgDt>Dt"`
+			BookingDtTm   string `xml:"BookgDt>DtTm"`
+			ValueDate     string `xml:"ValDt>Dt"`
+			ServicerRef   string `xml:"AcctSvcrRef"`
+			AdditionalInf string `xml:"AddtlNtryInf"`
+			Transactions  []struct {
+				EndToEndID  string   `xml:"Refs>EndToEndId"`
+				TxID        string   `xml:"Refs>TxId"`
+				ServicerRef string   `xml:"Refs>AcctSvcrRef"`
+				Remittance  []string `xml:"RmtInf>Ustrd"`
+			} `xml:"NtryDtls>TxDtls"`
+		} `xml:"Ntry"`
+	} `xml:"BkToCstmrStmt>Stmt"`
+}
+
+// parseCAMT053Statement parses the credit entries of an ISO 20022 camt.053 XML statement
+func parseCAMT053Statement(r io.Reader) ([]*StatementLine, error) {
+	var doc camtDocument
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to read camt.053 XML: %w", err)
+	}
+
+	var lines []*StatementLine
+	for _, stmt := range doc.Statements {
+		for _, entry := range stmt.Entries {
+			if entry.CreditDebit != "CRDT" {
+				continue
+			}
+
+			amount, err := parseStatementAmount(entry.Amount)
+			if err != nil {
+				return nil, fmt.Errorf("entry %s: %w", entry.ServicerRef, err)
+			}
+
+			dateStr := entry.BookingDate
+			if dateStr == "" && len(entry.BookingDtTm) >= 10 {
+				dateStr = entry.BookingDtTm[:10]
+			}
+			if dateStr == "" {
+				dateStr = entry.ValueDate
+			}
+			txnDate, err := time.Parse("2006-01-02", dateStr)
+			if err != nil {
+				return nil, fmt.Errorf("entry %s: invalid booking date %q", entry.ServicerRef, dateStr)
+			}
+
+			reference := entry.ServicerRef
+			description := []string{entry.AdditionalInf}
+			for _, txn := range entry.Transactions {
+				for _, ref := range []string{txn.TxID, txn.EndToEndID, txn.ServicerRef} {
+					if ref != "" && ref != "NOTPROVIDED" {
+						if reference == "" {
+							reference = ref
+						} else {
+							description = append(description, ref)
+						}
+					}
+				}
+				description = append(description, txn.Remittance...)
+			}
+
+			lines = append(lines, &StatementLine{
+				TxnDate:     txnDate,
+				Amount:      int(math.Round(amount)),
+				Reference:   reference,
+				Description: strings.TrimSpace(strings.Join(description, " ")),
+			})
+		}
+	}
+
+	return lines, nil
+}
+
+// parseStatementAmount parses an amount such as "₹10,000.00", "10000" or "-500.50" (empty = 0)
+func parseStatementAmount(value string) (float64, error) {
+	cleaned := strings.NewReplacer("₹", "", ",", "", "INR", "", "Rs.", "", " ", "").Replace(strings.TrimSpace(value))
+	if cleaned == "" || cleaned == "-" {
+		return 0, nil
+	}
+	amount, err := strconv.ParseFloat(cleaned, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q", value)
+	}
+	return amount, nil
+}
+
+// statementDateLayouts are the date formats seen in Indian bank CSV exports
+var statementDateLayouts = []string{
+	"2006-01-02",
+	"02/01/2006",
+	"2/1/2006",
+	"02-01-2006",
+	"02/01/06",
+	"02-01-06",
+	"02 Jan 2006",
+	"02-Jan-2006",
+	"02 Jan 06",
+	"02-Jan-06",
+	"Jan 2, 2006",
+	"2006-01-02 15:04:05",
+	"02/01/2006 15:04:05",
+}
+
+// parseStatementDate parses a statement date in any of the known layouts (day before month)
+func parseStatementDate(value string) (time.Time, error) {
+	for _, layout := range statementDateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", value)
+}
+
+// normalizeReference upper-cases a reference and strips everything but letters and digits
+func normalizeReference(value string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(value) {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// ContainsReference returns true if the line's reference or narration carries the transaction ID
+func (l *StatementLine) ContainsReference(transactionID string) bool {
+	id := normalizeReference(transactionID)
+	if len(id) < 6 {
+		return false // Too short to match safely inside a narration
+	}
+	return normalizeReference(l.Reference) == id || strings.Contains(normalizeReference(l.Description), id)
+}
+
+// MatchStatementLine finds the pending transaction a statement credit pays
+// Transactions are matched by their UPI transaction ID, or failing that by the payment
+// reference (see PaymentReference) carried in the narration.
+// Returns the match and an empty note when it can be verified automatically, the match and a note
+// when the reference matched but the amount or date did not, or nil if no reference matched.
+func MatchStatementLine(line *StatementLine, pending []*PaymentTransaction) (*PaymentTransaction, string) {
+	for _, tx := range pending {
+		if tx.IsPending() && line.ContainsReference(tx.TransactionID) {
+			return tx, checkStatementMatch(line, tx)
+		}
+	}
+
+	paymentID, ok := ParsePaymentReference(line.Reference + " " + line.Description)
+	if !ok {
+		return nil, ""
+	}
+	for _, tx := range pending {
+		if !tx.IsPending() || tx.PaymentID != paymentID {
+			continue
+		}
+		if _, reported := tx.GetSuggestedAmount(); !reported {
+			return tx, fmt.Sprintf("matched by payment reference %s but tenant did not report an amount", PaymentReference(paymentID))
+		}
+		return tx, checkStatementMatch(line, tx)
+	}
+	return nil, ""
+}
+
+// checkStatementMatch returns why a matched line needs review, or "" if its date and amount agree
+func checkStatementMatch(line *StatementLine, tx *PaymentTransaction) string {
+	if line.TxnDate.Before(tx.SubmittedAt.Add(-statementMatchWindowBefore)) ||
+		line.TxnDate.After(tx.SubmittedAt.Add(statementMatchWindowAfter)) {
+		return fmt.Sprintf("credited %s but submitted %s", line.TxnDate.Format("Jan 2, 2006"), tx.SubmittedAt.Format("Jan 2, 2006"))
+	}
+	if suggested, ok := tx.GetSuggestedAmount(); ok && suggested != line.Amount {
+		return fmt.Sprintf("credited ₹%d but tenant reported ₹%d", line.Amount, suggested)
+	}
+	return ""
+}
+
+// IsOpen returns true if the line still needs the owner's attention
+func (l *StatementLine) IsOpen() bool {
+	return l.Status == StatementLineReview || l.Status == StatementLineUnmatched
+}
+
+// GetFormattedAmount returns the amount formatted as currency
+func (l *StatementLine) GetFormattedAmount() string {
+	return fmt.Sprintf("₹%d", l.Amount)
+}
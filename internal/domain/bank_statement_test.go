@@ -0,0 +1,137 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseCSVStatement(t *testing.T) {
+	csv := `Account Statement,,,,,
+Account No,XXXX1234,,,,
+Txn Date,Narration,Chq/Ref No,Withdrawal Amt,Deposit Amt,Closing Balance
+05/03/2025,UPI/412345678901/RAVI KUMAR/rent,412345678901,,"10,000.00","25,000.00"
+06/03/2025,ATM WDL,000123,"2,000.00",,"23,000.00"
+07-Mar-2025,UPI/498765432109/SITA/water,,,450.50,"23,450.50"
+Total,,,"2,000.00","10,450.50",
+Closing balance,,,,"23,450.50",
+`
+	lines, err := ParseStatement(StatementFormatCSV, strings.NewReader(csv))
+	if err != nil {
+		t.Fatalf("ParseStatement() error = %v", err)
+	}
+	if len(lines) != 2 {
+		t.Fatalf("ParseStatement() returned %d lines, want 2 credits", len(lines))
+	}
+	if lines[0].Amount != 10000 || lines[0].Reference != "412345678901" || !lines[0].TxnDate.Equal(time.Date(2025, 3, 5, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("first line = %+v", lines[0])
+	}
+	if lines[1].Amount != 451 || !lines[1].ContainsReference("498765432109") {
+		t.Errorf("second line = %+v", lines[1])
+	}
+}
+
+func TestParseOFXStatement(t *testing.T) {
+	ofx := `OFXHEADER:100
+<OFX><BANKMSGSRSV1><STMTTRNRS><STMTRS><BANKTRANLIST>
+<STMTTRN>
+<TRNTYPE>CREDIT
+<DTPOSTED>20250305120000
+<TRNAMT>10000.00
+<FITID>F1
+<NAME>RAVI KUMAR
+<MEMO>UPI/412345678901
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>20250306
+<TRNAMT>-2000.00
+<FITID>F2
+</STMTTRN>
+</BANKTRANLIST></STMTRS></STMTTRNRS></BANKMSGSRSV1></OFX>`
+
+	lines, err := ParseStatement(StatementFormatOFX, strings.NewReader(ofx))
+	if err != nil {
+		t.Fatalf("ParseStatement() error = %v", err)
+	}
+	if len(lines) != 1 || lines[0].Amount != 10000 || !lines[0].ContainsReference("412345678901") {
+		t.Fatalf("ParseStatement() = %+v", lines)
+	}
+}
+
+func TestParseCAMT053Statement(t *testing.T) {
+	camt := `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.02">
+  <BkToCstmrStmt><Stmt>
+    <Ntry>
+      <Amt Ccy="INR">10000.00</Amt><CdtDbtInd>CRDT</CdtDbtInd>
+      <BookgDt><Dt>2025-03-05</Dt></BookgDt>
+      <NtryDtls><TxDtls><Refs><TxId>412345678901</TxId></Refs><RmtInf><Ustrd>Rent March</Ustrd></RmtInf></TxDtls></NtryDtls>
+    </Ntry>
+    <Ntry>
+      <Amt Ccy="INR">500.00</Amt><CdtDbtInd>DBIT</CdtDbtInd>
+      <BookgDt><Dt>2025-03-06</Dt></BookgDt>
+    </Ntry>
+  </Stmt></BkToCstmrStmt>
+</Document>`
+
+	lines, err := ParseStatement(StatementFormatCAMT053, strings.NewReader(camt))
+	if err != nil {
+		t.Fatalf("ParseStatement() error = %v", err)
+	}
+	if len(lines) != 1 || lines[0].Amount != 10000 || lines[0].Reference != "412345678901" {
+		t.Fatalf("ParseStatement() = %+v", lines)
+	}
+}
+
+func TestMatchStatementLine(t *testing.T) {
+	submitted := time.Date(2025, 3, 6, 10, 0, 0, 0, time.UTC)
+	pending := []*PaymentTransaction{
+		{TransactionID: "111122223333", SubmittedAt: submitted},
+		{TransactionID: "412345678901", SubmittedAt: submitted, Notes: "Suggested amount: ₹10000"},
+	}
+
+	line := &StatementLine{TxnDate: time.Date(2025, 3, 5, 0, 0, 0, 0, time.UTC), Amount: 10000, Description: "UPI/412345678901/RAVI"}
+	if tx, note := MatchStatementLine(line, pending); tx != pending[1] || note != "" {
+		t.Errorf("MatchStatementLine() = (%v, %q), want automatic match", tx, note)
+	}
+
+	line.Amount = 9000
+	if tx, note := MatchStatementLine(line, pending); tx != pending[1] || note == "" {
+		t.Errorf("MatchStatementLine() with wrong amount = (%v, %q), want review note", tx, note)
+	}
+
+	line.Amount = 10000
+	line.TxnDate = submitted.AddDate(0, 1, 0)
+	if _, note := MatchStatementLine(line, pending); note == "" {
+		t.Error("MatchStatementLine() outside date window should need review")
+	}
+
+	line.Description = "NEFT FROM SOMEONE"
+	if tx, _ := MatchStatementLine(line, pending); tx != nil {
+		t.Errorf("MatchStatementLine() without reference = %v, want nil", tx)
+	}
+}
+
+func TestMatchStatementLineByPaymentReference(t *testing.T) {
+	submitted := time.Date(2025, 3, 6, 10, 0, 0, 0, time.UTC)
+	pending := []*PaymentTransaction{
+		{PaymentID: 41, TransactionID: "TYPO1", SubmittedAt: submitted, Notes: "Suggested amount: ₹10000"},
+		{PaymentID: 42, TransactionID: "TYPO2", SubmittedAt: submitted, Notes: "Suggested amount: ₹10000"},
+	}
+
+	line := &StatementLine{TxnDate: time.Date(2025, 3, 6, 0, 0, 0, 0, time.UTC), Amount: 10000, Description: "UPI/512345678901/RENTPAY42/RAVI"}
+	if tx, note := MatchStatementLine(line, pending); tx != pending[1] || note != "" {
+		t.Errorf("MatchStatementLine() = (%v, %q), want automatic match on payment 42", tx, note)
+	}
+
+	pending[1].Notes = ""
+	if tx, note := MatchStatementLine(line, pending); tx != pending[1] || note == "" {
+		t.Errorf("MatchStatementLine() without reported amount = (%v, %q), want review note", tx, note)
+	}
+
+	line.Description = "UPI/512345678901/RENTPAY99/RAVI"
+	if tx, _ := MatchStatementLine(line, pending); tx != nil {
+		t.Errorf("MatchStatementLine() for unknown payment = %v, want nil", tx)
+	}
+}
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ChargeCategory is an owner-managed payment label (rent, parking, internet, ...)
+type ChargeCategory struct {
+	ID            int       `json:"id" db:"id"`
+	Code          string    `json:"code" db:"code"`                     // Stored in payments.label, e.g. "society_fees"
+	DisplayName   string    `json:"display_name" db:"display_name"`     // e.g. "Society Fees"
+	DefaultAmount int       `json:"default_amount" db:"default_amount"` // Used when a charge is created without an amount (0 = none)
+	IsRecurring   bool      `json:"is_recurring" db:"is_recurring"`     // Recurring charges are limited to one per month
+	IsSystem      bool      `json:"is_system" db:"is_system"`           // Built-in categories (rent, late_fee) cannot be deactivated
+	IsActive      bool      `json:"is_active" db:"is_active"`
+	CreatedAt     time.Time `json:"created_at" db:"created_at"`
+}
+
+var chargeCategoryCodePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// NormalizeChargeCategoryCode turns a display name or code into a label code ("Society Fees" -> "society_fees")
+func NormalizeChargeCategoryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.Join(strings.Fields(strings.ReplaceAll(code, "-", " ")), "_")
+}
+
+// Validate validates the charge category data
+func (c *ChargeCategory) Validate() error {
+	if !chargeCategoryCodePattern.MatchString(c.Code) {
+		return fmt.Errorf("code must start with a letter and contain only lowercase letters, digits and underscores")
+	}
+	if len(c.Code) > 50 {
+		return fmt.Errorf("code must be at most 50 characters")
+	}
+	if strings.TrimSpace(c.DisplayName) == "" {
+		return fmt.Errorf("display name is required")
+	}
+	if c.DefaultAmount < 0 {
+		return fmt.Errorf("default amount cannot be negative")
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CreditLedger holds a tenant's credit balance built from overpayments
+// Credit is drawn down automatically against payments as they are generated
+type CreditLedger struct {
+	TenantID int            `json:"tenant_id"`
+	Entries  []*CreditEntry `json:"entries"`
+}
+
+// CreditEntry is a single movement on a tenant's credit ledger
+type CreditEntry struct {
+	ID            int       `json:"id" db:"id"`
+	TenantID      int       `json:"tenant_id" db:"tenant_id"`
+	EntryType     string    `json:"entry_type" db:"entry_type"` // overpayment, applied, refund
+	Amount        int       `json:"amount" db:"amount"`         // Always positive, direction comes from EntryType
+	Reason        string    `json:"reason" db:"reason"`
+	TransactionID *string   `json:"transaction_id,omitempty" db:"transaction_id"` // Set for overpayment entries
+	PaymentID     *int      `json:"payment_id,omitempty" db:"payment_id"`         // Set for applied entries
+	EntryDate     time.Time `json:"entry_date" db:"entry_date"`
+	CreatedAt     time.Time `json:"created_at" db:"created_at"`
+}
+
+// Credit entry type constants
+const (
+	CreditEntryOverpayment = "overpayment" // Excess of a verified transaction (credit)
+	CreditEntryApplied     = "applied"     // Credit used to pay a payment (debit)
+	CreditEntryRefund      = "refund"      // Credit paid back to the tenant (debit)
+)
+
+// Validate validates the credit entry data
+func (e *CreditEntry) Validate() error {
+	if e.TenantID <= 0 {
+		return fmt.Errorf("tenant ID is required")
+	}
+	if e.Amount <= 0 {
+		return fmt.Errorf("amount must be greater than 0")
+	}
+	switch e.EntryType {
+	case CreditEntryOverpayment:
+		if e.TransactionID == nil {
+			return fmt.Errorf("transaction ID is required for overpayments")
+		}
+	case CreditEntryApplied:
+		if e.PaymentID == nil {
+			return fmt.Errorf("payment ID is required for applied credit")
+		}
+	case CreditEntryRefund:
+		if strings.TrimSpace(e.Reason) == "" {
+			return fmt.Errorf("reason is required for refunds")
+		}
+	default:
+		return fmt.Errorf("invalid credit entry type: %s", e.EntryType)
+	}
+	return nil
+}
+
+// IsCredit returns true if the entry adds to the balance
+func (e *CreditEntry) IsCredit() bool {
+	return e.EntryType == CreditEntryOverpayment
+}
+
+// GetFormattedAmount returns the amount signed by direction and formatted as currency
+func (e *CreditEntry) GetFormattedAmount() string {
+	if e.IsCredit() {
+		return fmt.Sprintf("+₹%d", e.Amount)
+	}
+	return fmt.Sprintf("-₹%d", e.Amount)
+}
+
+// GetFormattedEntryDate returns the entry date formatted
+func (e *CreditEntry) GetFormattedEntryDate() string {
+	return e.EntryDate.Format("Jan 2, 2006")
+}
+
+// GetBalance returns the credit available to the tenant
+func (l *CreditLedger) GetBalance() int {
+	balance := 0
+	for _, entry := range l.Entries {
+		if entry.IsCredit() {
+			balance += entry.Amount
+		} else {
+			balance -= entry.Amount
+		}
+	}
+	return balance
+}
+
+// GetFormattedBalance returns the balance formatted as currency
+func (l *CreditLedger) GetFormattedBalance() string {
+	return fmt.Sprintf("₹%d", l.GetBalance())
+}
@@ -0,0 +1,44 @@
+package domain
+
+import "testing"
+
+func TestCreditLedgerGetBalance(t *testing.T) {
+	ledger := &CreditLedger{
+		TenantID: 1,
+		Entries: []*CreditEntry{
+			{EntryType: CreditEntryOverpayment, Amount: 3000},
+			{EntryType: CreditEntryApplied, Amount: 2000},
+			{EntryType: CreditEntryOverpayment, Amount: 500},
+			{EntryType: CreditEntryRefund, Amount: 1000},
+		},
+	}
+
+	if got := ledger.GetBalance(); got != 500 {
+		t.Errorf("GetBalance() = %d, want 500", got)
+	}
+}
+
+func TestCreditEntryValidate(t *testing.T) {
+	txnID := "UTR123"
+	paymentID := 7
+
+	tests := []struct {
+		name    string
+		entry   CreditEntry
+		wantErr bool
+	}{
+		{"overpayment", CreditEntry{TenantID: 1, EntryType: CreditEntryOverpayment, Amount: 100, TransactionID: &txnID}, false},
+		{"overpayment without transaction", CreditEntry{TenantID: 1, EntryType: CreditEntryOverpayment, Amount: 100}, true},
+		{"applied", CreditEntry{TenantID: 1, EntryType: CreditEntryApplied, Amount: 100, PaymentID: &paymentID}, false},
+		{"refund without reason", CreditEntry{TenantID: 1, EntryType: CreditEntryRefund, Amount: 100}, true},
+		{"zero amount", CreditEntry{TenantID: 1, EntryType: CreditEntryRefund, Amount: 0, Reason: "cash"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.entry.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
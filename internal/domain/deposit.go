@@ -0,0 +1,160 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SecurityDeposit represents the deposit ledger for a single tenancy
+type SecurityDeposit struct {
+	ID             int        `json:"id" db:"id"`
+	TenantID       int        `json:"tenant_id" db:"tenant_id"`
+	UnitID         int        `json:"unit_id" db:"unit_id"`
+	RequiredAmount int        `json:"required_amount" db:"required_amount"` // Copied from unit.SecurityDeposit at move-in
+	Status         string     `json:"status" db:"status"`                   // pending, held, settled
+	SettledAt      *time.Time `json:"settled_at,omitempty" db:"settled_at"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+
+	// Related data (populated by joins)
+	Entries []*DepositEntry `json:"entries,omitempty"`
+}
+
+// DepositEntry is a single movement on a deposit ledger
+type DepositEntry struct {
+	ID        int       `json:"id" db:"id"`
+	DepositID int       `json:"deposit_id" db:"deposit_id"`
+	EntryType string    `json:"entry_type" db:"entry_type"` // collection, deduction, arrears_offset, refund
+	Amount    int       `json:"amount" db:"amount"`         // Always positive, direction comes from EntryType
+	Reason    string    `json:"reason" db:"reason"`
+	PaymentID *int      `json:"payment_id,omitempty" db:"payment_id"` // Set for arrears_offset entries
+	EntryDate time.Time `json:"entry_date" db:"entry_date"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// DepositDeduction is a deduction requested by the owner (damages, cleaning, etc.)
+type DepositDeduction struct {
+	Amount int    `json:"amount"`
+	Reason string `json:"reason"`
+}
+
+// DepositSettlement is the statement produced when a deposit is settled on move-out
+type DepositSettlement struct {
+	TenantID           int             `json:"tenant_id"`
+	UnitID             int             `json:"unit_id"`
+	RequiredAmount     int             `json:"required_amount"`
+	CollectedAmount    int             `json:"collected_amount"`
+	Deductions         []*DepositEntry `json:"deductions"`
+	ArrearsOffsets     []*DepositEntry `json:"arrears_offsets"`
+	TotalDeductions    int             `json:"total_deductions"`
+	TotalArrearsOffset int             `json:"total_arrears_offset"`
+	RefundAmount       int             `json:"refund_amount"`
+	AmountStillOwed    int             `json:"amount_still_owed"` // Unpaid balance not covered by the deposit
+	SettledAt          *time.Time      `json:"settled_at,omitempty"`
+}
+
+// Deposit status constants
+const (
+	DepositStatusPending = "pending"
+	DepositStatusHeld    = "held"
+	DepositStatusSettled = "settled"
+)
+
+// Deposit entry type constants
+const (
+	DepositEntryCollection    = "collection"
+	DepositEntryDeduction     = "deduction"
+	DepositEntryArrearsOffset = "arrears_offset"
+	DepositEntryRefund        = "refund"
+)
+
+// Validate validates the deposit entry data
+func (e *DepositEntry) Validate() error {
+	if e.DepositID <= 0 {
+		return fmt.Errorf("deposit ID is required")
+	}
+	if e.Amount <= 0 {
+		return fmt.Errorf("amount must be greater than 0")
+	}
+	switch e.EntryType {
+	case DepositEntryCollection, DepositEntryRefund:
+	case DepositEntryDeduction:
+		if strings.TrimSpace(e.Reason) == "" {
+			return fmt.Errorf("reason is required for deductions")
+		}
+	case DepositEntryArrearsOffset:
+		if e.PaymentID == nil {
+			return fmt.Errorf("payment ID is required for arrears offsets")
+		}
+	default:
+		return fmt.Errorf("invalid deposit entry type: %s", e.EntryType)
+	}
+	return nil
+}
+
+// IsSettled returns true once the deposit has been settled on move-out
+func (d *SecurityDeposit) IsSettled() bool {
+	return d.Status == DepositStatusSettled
+}
+
+// sumEntries totals entries of the given types
+func (d *SecurityDeposit) sumEntries(entryTypes ...string) int {
+	total := 0
+	for _, entry := range d.Entries {
+		for _, entryType := range entryTypes {
+			if entry.EntryType == entryType {
+				total += entry.Amount
+			}
+		}
+	}
+	return total
+}
+
+// GetCollectedAmount returns the total amount collected from the tenant
+func (d *SecurityDeposit) GetCollectedAmount() int {
+	return d.sumEntries(DepositEntryCollection)
+}
+
+// GetBalance returns the amount currently held (collected minus deductions, offsets and refunds)
+func (d *SecurityDeposit) GetBalance() int {
+	return d.GetCollectedAmount() - d.sumEntries(DepositEntryDeduction, DepositEntryArrearsOffset, DepositEntryRefund)
+}
+
+// GetOutstandingAmount returns how much of the required deposit is still to be collected
+func (d *SecurityDeposit) GetOutstandingAmount() int {
+	outstanding := d.RequiredAmount - d.GetCollectedAmount()
+	if outstanding < 0 {
+		return 0
+	}
+	return outstanding
+}
+
+// BuildSettlement builds a settlement statement from the ledger
+// amountStillOwed is the unpaid balance left after offsetting against the deposit
+func (d *SecurityDeposit) BuildSettlement(amountStillOwed int) *DepositSettlement {
+	settlement := &DepositSettlement{
+		TenantID:        d.TenantID,
+		UnitID:          d.UnitID,
+		RequiredAmount:  d.RequiredAmount,
+		CollectedAmount: d.GetCollectedAmount(),
+		Deductions:      []*DepositEntry{},
+		ArrearsOffsets:  []*DepositEntry{},
+		AmountStillOwed: amountStillOwed,
+		SettledAt:       d.SettledAt,
+	}
+
+	for _, entry := range d.Entries {
+		switch entry.EntryType {
+		case DepositEntryDeduction:
+			settlement.Deductions = append(settlement.Deductions, entry)
+			settlement.TotalDeductions += entry.Amount
+		case DepositEntryArrearsOffset:
+			settlement.ArrearsOffsets = append(settlement.ArrearsOffsets, entry)
+			settlement.TotalArrearsOffset += entry.Amount
+		case DepositEntryRefund:
+			settlement.RefundAmount += entry.Amount
+		}
+	}
+
+	return settlement
+}
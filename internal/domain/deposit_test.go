@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"testing"
+)
+
+func TestSecurityDeposit_BuildSettlement(t *testing.T) {
+	paymentID := 7
+	deposit := &SecurityDeposit{
+		TenantID:       1,
+		UnitID:         2,
+		RequiredAmount: 20000,
+		Status:         DepositStatusSettled,
+		Entries: []*DepositEntry{
+			{EntryType: DepositEntryCollection, Amount: 15000},
+			{EntryType: DepositEntryCollection, Amount: 5000},
+			{EntryType: DepositEntryDeduction, Amount: 2000, Reason: "Wall painting"},
+			{EntryType: DepositEntryArrearsOffset, Amount: 8000, PaymentID: &paymentID},
+			{EntryType: DepositEntryRefund, Amount: 10000},
+		},
+	}
+
+	if got := deposit.GetCollectedAmount(); got != 20000 {
+		t.Errorf("GetCollectedAmount() = %d, want 20000", got)
+	}
+	if got := deposit.GetBalance(); got != 0 {
+		t.Errorf("GetBalance() = %d, want 0", got)
+	}
+	if got := deposit.GetOutstandingAmount(); got != 0 {
+		t.Errorf("GetOutstandingAmount() = %d, want 0", got)
+	}
+
+	settlement := deposit.BuildSettlement(500)
+	if settlement.TotalDeductions != 2000 {
+		t.Errorf("TotalDeductions = %d, want 2000", settlement.TotalDeductions)
+	}
+	if settlement.TotalArrearsOffset != 8000 {
+		t.Errorf("TotalArrearsOffset = %d, want 8000", settlement.TotalArrearsOffset)
+	}
+	if settlement.RefundAmount != 10000 {
+		t.Errorf("RefundAmount = %d, want 10000", settlement.RefundAmount)
+	}
+	if settlement.AmountStillOwed != 500 {
+		t.Errorf("AmountStillOwed = %d, want 500", settlement.AmountStillOwed)
+	}
+	if len(settlement.Deductions) != 1 || len(settlement.ArrearsOffsets) != 1 {
+		t.Errorf("expected 1 deduction and 1 arrears offset, got %d and %d", len(settlement.Deductions), len(settlement.ArrearsOffsets))
+	}
+}
+
+func TestDepositEntry_Validate(t *testing.T) {
+	paymentID := 3
+	tests := []struct {
+		name    string
+		entry   *DepositEntry
+		wantErr bool
+	}{
+		{"valid collection", &DepositEntry{DepositID: 1, EntryType: DepositEntryCollection, Amount: 100}, false},
+		{"zero amount", &DepositEntry{DepositID: 1, EntryType: DepositEntryCollection, Amount: 0}, true},
+		{"deduction without reason", &DepositEntry{DepositID: 1, EntryType: DepositEntryDeduction, Amount: 100}, true},
+		{"deduction with reason", &DepositEntry{DepositID: 1, EntryType: DepositEntryDeduction, Amount: 100, Reason: "Broken tap"}, false},
+		{"offset without payment", &DepositEntry{DepositID: 1, EntryType: DepositEntryArrearsOffset, Amount: 100}, true},
+		{"offset with payment", &DepositEntry{DepositID: 1, EntryType: DepositEntryArrearsOffset, Amount: 100, PaymentID: &paymentID}, false},
+		{"unknown type", &DepositEntry{DepositID: 1, EntryType: "bonus", Amount: 100}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.entry.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,270 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ExpenseCategory is an owner-managed type of expense (property tax, repairs, salaries, ...)
+type ExpenseCategory struct {
+	ID          int       `json:"id" db:"id"`
+	Code        string    `json:"code" db:"code"`                 // Stored in expenses.category, e.g. "property_tax"
+	DisplayName string    `json:"display_name" db:"display_name"` // e.g. "Property Tax"
+	IsActive    bool      `json:"is_active" db:"is_active"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
+
+// Expense is money the owner paid out, optionally allocated to a property or a unit
+// An expense allocated to neither is a general expense of the whole rental business.
+type Expense struct {
+	ID              int       `json:"id" db:"id"`
+	Category        string    `json:"category" db:"category"`                 // Expense category code
+	PropertyID      *int      `json:"property_id,omitempty" db:"property_id"` // Set for property (and unit) expenses
+	UnitID          *int      `json:"unit_id,omitempty" db:"unit_id"`         // Set for unit expenses
+	Amount          int       `json:"amount" db:"amount"`                     // In rupees
+	ExpenseDate     time.Time `json:"expense_date" db:"expense_date"`         // Date the money was paid
+	Vendor          string    `json:"vendor" db:"vendor"`                     // Who was paid, e.g. the electricity board
+	Description     string    `json:"description" db:"description"`
+	PaymentMethod   string    `json:"payment_method" db:"payment_method"` // e.g. UPI, cash, cheque
+	Reference       string    `json:"reference" db:"reference"`           // UTR, cheque or bill number
+	CreatedByUserID *int      `json:"created_by_user_id,omitempty" db:"created_by_user_id"`
+	CreatedAt       time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at" db:"updated_at"`
+
+	// Related data (populated by joins)
+	Attachments []*ExpenseAttachment `json:"attachments,omitempty"`
+}
+
+// ExpenseAttachment is a bill or receipt file kept with an expense
+type ExpenseAttachment struct {
+	ID          int       `json:"id" db:"id"`
+	ExpenseID   int       `json:"expense_id" db:"expense_id"`
+	FileName    string    `json:"file_name" db:"file_name"`
+	ContentType string    `json:"content_type" db:"content_type"`
+	SizeBytes   int       `json:"size_bytes" db:"size_bytes"`
+	Data        []byte    `json:"-" db:"data"` // Only loaded when the file is downloaded
+	UploadedAt  time.Time `json:"uploaded_at" db:"uploaded_at"`
+}
+
+// MaxExpenseAttachmentSize is the largest bill or receipt file accepted (5MB)
+const MaxExpenseAttachmentSize = 5 << 20
+
+// expenseAttachmentTypes are the file types accepted as expense attachments
+var expenseAttachmentTypes = map[string]bool{
+	"application/pdf": true,
+	"image/jpeg":      true,
+	"image/png":       true,
+	"image/webp":      true,
+}
+
+// Validate validates the expense category data
+func (c *ExpenseCategory) Validate() error {
+	if !chargeCategoryCodePattern.MatchString(c.Code) {
+		return fmt.Errorf("code must start with a letter and contain only lowercase letters, digits and underscores")
+	}
+	if len(c.Code) > 50 {
+		return fmt.Errorf("code must be at most 50 characters")
+	}
+	if strings.TrimSpace(c.DisplayName) == "" {
+		return fmt.Errorf("display name is required")
+	}
+	return nil
+}
+
+// Validate validates the expense data
+func (e *Expense) Validate() error {
+	if strings.TrimSpace(e.Category) == "" {
+		return fmt.Errorf("category is required")
+	}
+	if e.Amount <= 0 {
+		return fmt.Errorf("amount must be greater than 0")
+	}
+	if e.ExpenseDate.IsZero() {
+		return fmt.Errorf("expense date is required")
+	}
+	if e.ExpenseDate.After(time.Now()) {
+		return fmt.Errorf("expense date cannot be in the future")
+	}
+	if e.UnitID != nil && e.PropertyID == nil {
+		return fmt.Errorf("property is required for a unit expense")
+	}
+	return nil
+}
+
+// GetFormattedAmount returns the amount formatted as currency
+func (e *Expense) GetFormattedAmount() string {
+	return fmt.Sprintf("₹%d", e.Amount)
+}
+
+// GetFormattedExpenseDate returns the expense date formatted
+func (e *Expense) GetFormattedExpenseDate() string {
+	return e.ExpenseDate.Format("Jan 2, 2006")
+}
+
+// Validate validates an uploaded attachment
+func (a *ExpenseAttachment) Validate() error {
+	if strings.TrimSpace(a.FileName) == "" {
+		return fmt.Errorf("file name is required")
+	}
+	if len(a.Data) == 0 {
+		return fmt.Errorf("file is empty")
+	}
+	if len(a.Data) > MaxExpenseAttachmentSize {
+		return fmt.Errorf("file is larger than %dMB", MaxExpenseAttachmentSize>>20)
+	}
+	if !expenseAttachmentTypes[a.ContentType] {
+		return fmt.Errorf("unsupported file type: %s. Must be a PDF, JPEG, PNG or WebP file", a.ContentType)
+	}
+	return nil
+}
+
+// ============================================
+// Income and expense reports
+// ============================================
+
+// IncomeEntry is money received from a tenant, taken from a verified payment transaction
+type IncomeEntry struct {
+	TransactionID string    `json:"transaction_id"`
+	PropertyID    int       `json:"property_id"`
+	UnitID        int       `json:"unit_id"`
+	Amount        int       `json:"amount"`
+	ReceivedAt    time.Time `json:"received_at"` // Verification date
+}
+
+// Report granularity constants
+const (
+	ReportMonthly = "monthly"
+	ReportYearly  = "yearly"
+)
+
+// IncomeExpenseReport shows income, expenses and net income per month or per financial year
+// Discounts, waivers and write-offs are listed separately and do not count towards income or net
+type IncomeExpenseReport struct {
+	Granularity        string            `json:"granularity"`              // monthly, yearly
+	FinancialYear      string            `json:"financial_year,omitempty"` // Monthly reports cover one financial year
+	PropertyID         *int              `json:"property_id,omitempty"`    // Set when limited to one property
+	Rows               []*ReportRow      `json:"rows"`
+	TotalIncome        int               `json:"total_income"`
+	TotalExpenses      int               `json:"total_expenses"`
+	Net                int               `json:"net"`
+	TotalAdjustments   int               `json:"total_adjustments"` // Forgiven, not received
+	ExpensesByCategory map[string]int    `json:"expenses_by_category"`
+	CategoryNames      map[string]string `json:"category_names"` // Category code -> display name
+}
+
+// ReportRow is one month or financial year of a report
+type ReportRow struct {
+	Period             string         `json:"period"` // e.g. "April 2025" or "2025-26"
+	Income             int            `json:"income"`
+	Expenses           int            `json:"expenses"`
+	Net                int            `json:"net"`
+	Adjustments        int            `json:"adjustments"`
+	ExpensesByCategory map[string]int `json:"expenses_by_category"`
+}
+
+// BuildMonthlyReport builds a report with one row per month (April to March) of a financial year
+func BuildMonthlyReport(financialYear string, income []*IncomeEntry, expenses []*Expense, adjustments []*PaymentAdjustment) (*IncomeExpenseReport, error) {
+	first, _, err := ParseFinancialYear(financialYear)
+	if err != nil {
+		return nil, err
+	}
+
+	report := newIncomeExpenseReport(ReportMonthly)
+	report.FinancialYear = financialYear
+	for i := 0; i < 12; i++ {
+		report.Rows = append(report.Rows, newReportRow(first.AddDate(0, i, 0).Format("January 2006")))
+	}
+
+	rowFor := func(t time.Time) *ReportRow {
+		if FinancialYear(t) != financialYear {
+			return nil
+		}
+		return report.Rows[(int(t.Month())-int(time.April)+12)%12]
+	}
+	report.add(income, expenses, adjustments, rowFor)
+	return report, nil
+}
+
+// BuildYearlyReport builds a report with one row per financial year, oldest first
+func BuildYearlyReport(income []*IncomeEntry, expenses []*Expense, adjustments []*PaymentAdjustment) *IncomeExpenseReport {
+	report := newIncomeExpenseReport(ReportYearly)
+	rows := make(map[string]*ReportRow)
+
+	rowFor := func(t time.Time) *ReportRow {
+		financialYear := FinancialYear(t)
+		row, ok := rows[financialYear]
+		if !ok {
+			row = newReportRow(financialYear)
+			rows[financialYear] = row
+			report.Rows = append(report.Rows, row)
+		}
+		return row
+	}
+	report.add(income, expenses, adjustments, rowFor)
+
+	sort.Slice(report.Rows, func(i, j int) bool {
+		return report.Rows[i].Period < report.Rows[j].Period
+	})
+	return report
+}
+
+func newIncomeExpenseReport(granularity string) *IncomeExpenseReport {
+	return &IncomeExpenseReport{
+		Granularity:        granularity,
+		Rows:               []*ReportRow{},
+		ExpensesByCategory: make(map[string]int),
+		CategoryNames:      make(map[string]string),
+	}
+}
+
+func newReportRow(period string) *ReportRow {
+	return &ReportRow{Period: period, ExpensesByCategory: make(map[string]int)}
+}
+
+// add totals income, expenses and adjustments into the row returned for their date (nil = outside the report)
+func (r *IncomeExpenseReport) add(income []*IncomeEntry, expenses []*Expense, adjustments []*PaymentAdjustment, rowFor func(time.Time) *ReportRow) {
+	for _, entry := range income {
+		row := rowFor(entry.ReceivedAt)
+		if row == nil {
+			continue
+		}
+		row.Income += entry.Amount
+		row.Net += entry.Amount
+		r.TotalIncome += entry.Amount
+	}
+
+	for _, expense := range expenses {
+		row := rowFor(expense.ExpenseDate)
+		if row == nil {
+			continue
+		}
+		row.Expenses += expense.Amount
+		row.Net -= expense.Amount
+		row.ExpensesByCategory[expense.Category] += expense.Amount
+		r.TotalExpenses += expense.Amount
+		r.ExpensesByCategory[expense.Category] += expense.Amount
+	}
+
+	for _, adjustment := range adjustments {
+		row := rowFor(adjustment.CreatedAt)
+		if row == nil {
+			continue
+		}
+		row.Adjustments += adjustment.Amount
+		r.TotalAdjustments += adjustment.Amount
+	}
+
+	r.Net = r.TotalIncome - r.TotalExpenses
+}
+
+// GetCategoryCodes returns the codes of all categories with expenses in the report, sorted
+func (r *IncomeExpenseReport) GetCategoryCodes() []string {
+	codes := make([]string, 0, len(r.ExpensesByCategory))
+	for code := range r.ExpensesByCategory {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
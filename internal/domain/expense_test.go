@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExpenseValidate(t *testing.T) {
+	propertyID, unitID := 1, 2
+	expense := &Expense{Category: "repairs", Amount: 1500, ExpenseDate: time.Now().AddDate(0, 0, -1), PropertyID: &propertyID, UnitID: &unitID}
+	if err := expense.Validate(); err != nil {
+		t.Fatalf("valid expense: %v", err)
+	}
+
+	expense.PropertyID = nil
+	if err := expense.Validate(); err == nil {
+		t.Error("unit expense without property should fail")
+	}
+
+	future := &Expense{Category: "repairs", Amount: 1500, ExpenseDate: time.Now().AddDate(0, 1, 0)}
+	if err := future.Validate(); err == nil {
+		t.Error("future expense should fail")
+	}
+}
+
+func TestExpenseAttachmentValidate(t *testing.T) {
+	attachment := &ExpenseAttachment{FileName: "bill.pdf", ContentType: "application/pdf", Data: []byte("%PDF")}
+	if err := attachment.Validate(); err != nil {
+		t.Fatalf("valid attachment: %v", err)
+	}
+
+	attachment.ContentType = "application/zip"
+	if err := attachment.Validate(); err == nil {
+		t.Error("zip attachment should fail")
+	}
+}
+
+func TestBuildMonthlyReport(t *testing.T) {
+	income := []*IncomeEntry{
+		{Amount: 10000, ReceivedAt: time.Date(2025, 4, 5, 0, 0, 0, 0, time.UTC)},
+		{Amount: 10000, ReceivedAt: time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC)},
+		{Amount: 9999, ReceivedAt: time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)}, // Next year
+	}
+	expenses := []*Expense{
+		{Category: "repairs", Amount: 2000, ExpenseDate: time.Date(2025, 4, 20, 0, 0, 0, 0, time.UTC)},
+		{Category: "property_tax", Amount: 5000, ExpenseDate: time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)},
+	}
+
+	adjustments := []*PaymentAdjustment{
+		{AdjustmentType: PaymentAdjustmentWaiver, Amount: 500, CreatedAt: time.Date(2025, 4, 25, 0, 0, 0, 0, time.UTC)},
+	}
+
+	report, err := BuildMonthlyReport("2025-26", income, expenses, adjustments)
+	if err != nil {
+		t.Fatalf("BuildMonthlyReport: %v", err)
+	}
+	if len(report.Rows) != 12 || report.Rows[0].Period != "April 2025" || report.Rows[11].Period != "March 2026" {
+		t.Fatalf("rows = %d, first %s", len(report.Rows), report.Rows[0].Period)
+	}
+	if april := report.Rows[0]; april.Income != 10000 || april.Expenses != 2000 || april.Net != 8000 || april.Adjustments != 500 {
+		t.Errorf("april = %+v", april)
+	}
+	if january := report.Rows[9]; january.ExpensesByCategory["property_tax"] != 5000 || january.Net != -5000 {
+		t.Errorf("january = %+v", january)
+	}
+	if report.TotalIncome != 20000 || report.TotalExpenses != 7000 || report.Net != 13000 || report.TotalAdjustments != 500 {
+		t.Errorf("totals = %d income, %d expenses, %d net, %d adjusted", report.TotalIncome, report.TotalExpenses, report.Net, report.TotalAdjustments)
+	}
+	if codes := report.GetCategoryCodes(); len(codes) != 2 || codes[0] != "property_tax" {
+		t.Errorf("category codes = %v", codes)
+	}
+}
+
+func TestBuildYearlyReport(t *testing.T) {
+	income := []*IncomeEntry{
+		{Amount: 10000, ReceivedAt: time.Date(2026, 4, 5, 0, 0, 0, 0, time.UTC)},
+		{Amount: 10000, ReceivedAt: time.Date(2025, 3, 5, 0, 0, 0, 0, time.UTC)},
+	}
+	expenses := []*Expense{
+		{Category: "repairs", Amount: 2000, ExpenseDate: time.Date(2025, 4, 20, 0, 0, 0, 0, time.UTC)},
+	}
+
+	report := BuildYearlyReport(income, expenses, nil)
+	if len(report.Rows) != 3 {
+		t.Fatalf("rows = %d", len(report.Rows))
+	}
+	if report.Rows[0].Period != "2024-25" || report.Rows[1].Period != "2025-26" || report.Rows[2].Period != "2026-27" {
+		t.Errorf("periods = %s, %s, %s", report.Rows[0].Period, report.Rows[1].Period, report.Rows[2].Period)
+	}
+	if report.Rows[1].Net != -2000 || report.Net != 18000 {
+		t.Errorf("net = %d, total %d", report.Rows[1].Net, report.Net)
+	}
+}
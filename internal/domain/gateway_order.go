@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// GatewayOrder is an order created on a payment gateway for a payment's remaining balance
+// The gateway's webhook marks it paid, which verifies a transaction against the payment
+type GatewayOrder struct {
+	ID               int        `json:"id" db:"id"`
+	PaymentID        int        `json:"payment_id" db:"payment_id"`
+	TenantID         int        `json:"tenant_id" db:"tenant_id"`
+	Gateway          string     `json:"gateway" db:"gateway"`                   // mock, razorpay
+	GatewayOrderID   string     `json:"gateway_order_id" db:"gateway_order_id"` // Order ID on the gateway
+	Amount           int        `json:"amount" db:"amount"`                     // Amount ordered in rupees
+	Currency         string     `json:"currency" db:"currency"`
+	Status           string     `json:"status" db:"status"`                                   // created, paid, failed
+	GatewayPaymentID *string    `json:"gateway_payment_id,omitempty" db:"gateway_payment_id"` // Set once paid; also the transaction ID
+	AmountPaid       int        `json:"amount_paid" db:"amount_paid"`                         // Amount the gateway reported as paid
+	FailureReason    string     `json:"failure_reason,omitempty" db:"failure_reason"`
+	CheckoutURL      string     `json:"checkout_url,omitempty" db:"-"` // Returned by the gateway when the order is created
+	CheckoutKey      string     `json:"checkout_key,omitempty" db:"-"` // Public key for the gateway's browser checkout
+	PaidAt           *time.Time `json:"paid_at,omitempty" db:"paid_at"`
+	CreatedAt        time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// Gateway order status constants
+const (
+	GatewayOrderStatusCreated = "created"
+	GatewayOrderStatusPaid    = "paid"
+	GatewayOrderStatusFailed  = "failed"
+)
+
+// IsPaid returns true once the gateway has confirmed payment
+func (o *GatewayOrder) IsPaid() bool {
+	return o.Status == GatewayOrderStatusPaid
+}
+
+// IsOpen returns true while the order can still be paid
+func (o *GatewayOrder) IsOpen() bool {
+	return o.Status == GatewayOrderStatusCreated
+}
+
+// GetFormattedAmount returns the order amount formatted as currency
+func (o *GatewayOrder) GetFormattedAmount() string {
+	return fmt.Sprintf("₹%d", o.Amount)
+}
@@ -0,0 +1,222 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// InstallmentPlan restructures a tenant's arrears into a schedule of installment payments
+// The original payments are settled when the plan is created, the balance moved into the plan
+// counting as adjusted; each installment is an ordinary payment with the installment label,
+// so it is paid and verified like any other. The plan completes when its last installment is paid
+type InstallmentPlan struct {
+	ID              int        `json:"id" db:"id"`
+	TenantID        int        `json:"tenant_id" db:"tenant_id"`
+	UnitID          int        `json:"unit_id" db:"unit_id"`
+	TotalAmount     int        `json:"total_amount" db:"total_amount"` // Arrears folded into the plan
+	Status          string     `json:"status" db:"status"`             // active, completed, cancelled
+	Notes           string     `json:"notes" db:"notes"`
+	CancelReason    string     `json:"cancel_reason" db:"cancel_reason"`
+	CreatedByUserID *int       `json:"created_by_user_id,omitempty" db:"created_by_user_id"`
+	CompletedAt     *time.Time `json:"completed_at,omitempty" db:"completed_at"`
+	CancelledAt     *time.Time `json:"cancelled_at,omitempty" db:"cancelled_at"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at" db:"updated_at"`
+
+	// Related data
+	Arrears      []*InstallmentPlanArrear `json:"arrears,omitempty"`
+	Installments []*Installment           `json:"installments,omitempty"`
+}
+
+// InstallmentPlanArrear records how much of an original payment was restructured into a plan
+type InstallmentPlanArrear struct {
+	ID             int       `json:"id" db:"id"`
+	PlanID         int       `json:"plan_id" db:"plan_id"`
+	PaymentID      int       `json:"payment_id" db:"payment_id"`
+	Amount         int       `json:"amount" db:"amount"`                   // Unpaid balance moved into the plan
+	RestoredAmount int       `json:"restored_amount" db:"restored_amount"` // Put back on the payment when the plan was cancelled
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+
+	Payment *Payment `json:"payment,omitempty"`
+}
+
+// Installment is one scheduled payment of a plan
+type Installment struct {
+	ID        int       `json:"id" db:"id"`
+	PlanID    int       `json:"plan_id" db:"plan_id"`
+	PaymentID *int      `json:"payment_id,omitempty" db:"payment_id"` // NULL once an unpaid installment is cancelled
+	Sequence  int       `json:"sequence" db:"sequence"`
+	Amount    int       `json:"amount" db:"amount"`
+	DueDate   time.Time `json:"due_date" db:"due_date"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+
+	Payment *Payment `json:"payment,omitempty"`
+}
+
+// Installment plan status constants
+const (
+	InstallmentPlanStatusActive    = "active"
+	InstallmentPlanStatusCompleted = "completed"
+	InstallmentPlanStatusCancelled = "cancelled"
+)
+
+// MaxInstallments caps the length of a plan
+const MaxInstallments = 36
+
+// BuildInstallmentSchedule splits a total into equal monthly installments starting at firstDue
+// Any remainder is spread over the first installments, one rupee each
+func BuildInstallmentSchedule(total, count int, firstDue time.Time) ([]*Installment, error) {
+	if total <= 0 {
+		return nil, fmt.Errorf("total must be greater than 0")
+	}
+	if count <= 0 || count > MaxInstallments {
+		return nil, fmt.Errorf("installment count must be between 1 and %d", MaxInstallments)
+	}
+	if count > total {
+		return nil, fmt.Errorf("installment count cannot exceed the total amount")
+	}
+	if firstDue.IsZero() {
+		return nil, fmt.Errorf("first due date is required")
+	}
+
+	base, remainder := total/count, total%count
+	installments := make([]*Installment, 0, count)
+	for i := 0; i < count; i++ {
+		amount := base
+		if i < remainder {
+			amount++
+		}
+		installments = append(installments, &Installment{
+			Sequence: i + 1,
+			Amount:   amount,
+			DueDate:  addMonthsClamped(firstDue, i),
+		})
+	}
+	return installments, nil
+}
+
+// addMonthsClamped adds months to t, keeping the day within the target month (Jan 31 + 1 -> Feb 28)
+func addMonthsClamped(t time.Time, months int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(months), 1, 0, 0, 0, 0, t.Location())
+	day := t.Day()
+	if maxDay := daysInMonth(first); day > maxDay {
+		day = maxDay
+	}
+	return first.AddDate(0, 0, day-1)
+}
+
+// ValidateInstallmentSchedule checks a schedule covers exactly the total with increasing due dates
+// Sequence numbers are assigned in order
+func ValidateInstallmentSchedule(total int, installments []*Installment) error {
+	if len(installments) == 0 {
+		return fmt.Errorf("at least one installment is required")
+	}
+	if len(installments) > MaxInstallments {
+		return fmt.Errorf("a plan can have at most %d installments", MaxInstallments)
+	}
+
+	sum := 0
+	for i, installment := range installments {
+		if installment.Amount <= 0 {
+			return fmt.Errorf("installment %d: amount must be greater than 0", i+1)
+		}
+		if installment.DueDate.IsZero() {
+			return fmt.Errorf("installment %d: due date is required", i+1)
+		}
+		if i > 0 && !installment.DueDate.After(installments[i-1].DueDate) {
+			return fmt.Errorf("installment %d: due dates must be in increasing order", i+1)
+		}
+		installment.Sequence = i + 1
+		sum += installment.Amount
+	}
+
+	if sum != total {
+		return fmt.Errorf("installments add up to ₹%d but the arrears are ₹%d", sum, total)
+	}
+	return nil
+}
+
+// MovedToPlanNote returns the notes of an original payment after its balance was moved into a plan
+func MovedToPlanNote(notes string, amount, planID int) string {
+	return strings.TrimSpace(fmt.Sprintf("%s (₹%d moved to installment plan #%d)", notes, amount, planID))
+}
+
+// InstallmentNote returns the notes of an installment payment
+func InstallmentNote(sequence, count, planID int) string {
+	return fmt.Sprintf("Installment %d of %d (plan #%d)", sequence, count, planID)
+}
+
+// IsActive returns true while the plan's installments are still being collected
+func (p *InstallmentPlan) IsActive() bool {
+	return p.Status == InstallmentPlanStatusActive
+}
+
+// IsSettled returns true once every installment payment has been paid in full
+// Requires installments with their payments loaded
+func (p *InstallmentPlan) IsSettled() bool {
+	if len(p.Installments) == 0 {
+		return false
+	}
+	for _, installment := range p.Installments {
+		if installment.Payment == nil || !installment.Payment.IsFullyPaid {
+			return false
+		}
+	}
+	return true
+}
+
+// GetPaidAmount returns how much has been paid towards the plan's installments
+func (p *InstallmentPlan) GetPaidAmount() int {
+	paid := 0
+	for _, installment := range p.Installments {
+		if installment.Payment != nil {
+			paid += installment.Payment.AmountPaid
+		}
+	}
+	return paid
+}
+
+// GetRemainingAmount returns the plan total still to be paid
+func (p *InstallmentPlan) GetRemainingAmount() int {
+	if !p.IsActive() {
+		return 0
+	}
+	if remaining := p.TotalAmount - p.GetPaidAmount(); remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
+// GetNextInstallment returns the earliest installment not yet fully paid (nil if none)
+func (p *InstallmentPlan) GetNextInstallment() *Installment {
+	if !p.IsActive() {
+		return nil
+	}
+	for _, installment := range p.Installments {
+		if installment.Payment != nil && !installment.Payment.IsFullyPaid {
+			return installment
+		}
+	}
+	return nil
+}
+
+// GetFormattedTotalAmount returns the plan total formatted as currency
+func (p *InstallmentPlan) GetFormattedTotalAmount() string {
+	return fmt.Sprintf("₹%d", p.TotalAmount)
+}
+
+// GetFormattedRemainingAmount returns the amount still owed formatted as currency
+func (p *InstallmentPlan) GetFormattedRemainingAmount() string {
+	return fmt.Sprintf("₹%d", p.GetRemainingAmount())
+}
+
+// GetFormattedDueDate returns the installment due date formatted for display
+func (i *Installment) GetFormattedDueDate() string {
+	return i.DueDate.Format("Jan 2, 2006")
+}
+
+// GetFormattedAmount returns the installment amount formatted as currency
+func (i *Installment) GetFormattedAmount() string {
+	return fmt.Sprintf("₹%d", i.Amount)
+}
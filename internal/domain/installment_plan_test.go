@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBuildInstallmentSchedule(t *testing.T) {
+	firstDue := time.Date(2025, time.January, 31, 0, 0, 0, 0, time.UTC)
+
+	installments, err := BuildInstallmentSchedule(10000, 3, firstDue)
+	if err != nil {
+		t.Fatalf("BuildInstallmentSchedule() error = %v", err)
+	}
+
+	wantAmounts := []int{3334, 3333, 3333}
+	wantDates := []time.Time{
+		firstDue,
+		time.Date(2025, time.February, 28, 0, 0, 0, 0, time.UTC), // Clamped to the end of February
+		time.Date(2025, time.March, 31, 0, 0, 0, 0, time.UTC),
+	}
+	if len(installments) != len(wantAmounts) {
+		t.Fatalf("got %d installments, want %d", len(installments), len(wantAmounts))
+	}
+	for i, installment := range installments {
+		if installment.Sequence != i+1 {
+			t.Errorf("installment %d: sequence = %d", i+1, installment.Sequence)
+		}
+		if installment.Amount != wantAmounts[i] {
+			t.Errorf("installment %d: amount = %d, want %d", i+1, installment.Amount, wantAmounts[i])
+		}
+		if !installment.DueDate.Equal(wantDates[i]) {
+			t.Errorf("installment %d: due date = %s, want %s", i+1, installment.DueDate, wantDates[i])
+		}
+	}
+
+	if _, err := BuildInstallmentSchedule(10000, 0, firstDue); err == nil {
+		t.Errorf("expected error for zero installments")
+	}
+	if _, err := BuildInstallmentSchedule(10000, MaxInstallments+1, firstDue); err == nil {
+		t.Errorf("expected error for too many installments")
+	}
+}
+
+func TestValidateInstallmentSchedule(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, time.May, d, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name         string
+		installments []*Installment
+		wantErr      bool
+	}{
+		{
+			name:         "covers total",
+			installments: []*Installment{{Amount: 6000, DueDate: day(5)}, {Amount: 4000, DueDate: day(20)}},
+		},
+		{
+			name:         "short of total",
+			installments: []*Installment{{Amount: 6000, DueDate: day(5)}, {Amount: 3000, DueDate: day(20)}},
+			wantErr:      true,
+		},
+		{
+			name:         "due dates out of order",
+			installments: []*Installment{{Amount: 6000, DueDate: day(20)}, {Amount: 4000, DueDate: day(5)}},
+			wantErr:      true,
+		},
+		{
+			name:         "zero amount",
+			installments: []*Installment{{Amount: 10000, DueDate: day(5)}, {Amount: 0, DueDate: day(20)}},
+			wantErr:      true,
+		},
+		{
+			name:    "empty",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateInstallmentSchedule(10000, tt.installments)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateInstallmentSchedule() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestInstallmentPlan_Progress(t *testing.T) {
+	plan := &InstallmentPlan{
+		TotalAmount: 9000,
+		Status:      InstallmentPlanStatusActive,
+		Installments: []*Installment{
+			{Sequence: 1, Amount: 3000, Payment: &Payment{Amount: 3000, AmountPaid: 3000, IsFullyPaid: true}},
+			{Sequence: 2, Amount: 3000, Payment: &Payment{Amount: 3000, AmountPaid: 1000}},
+			{Sequence: 3, Amount: 3000, Payment: &Payment{Amount: 3000}},
+		},
+	}
+
+	if got := plan.GetRemainingAmount(); got != 5000 {
+		t.Errorf("GetRemainingAmount() = %d, want 5000", got)
+	}
+	if next := plan.GetNextInstallment(); next == nil || next.Sequence != 2 {
+		t.Errorf("GetNextInstallment() = %+v, want sequence 2", next)
+	}
+	if plan.IsSettled() {
+		t.Errorf("plan with unpaid installments should not be settled")
+	}
+
+	for _, installment := range plan.Installments {
+		installment.Payment.AmountPaid = installment.Payment.Amount
+		installment.Payment.IsFullyPaid = true
+	}
+	if !plan.IsSettled() {
+		t.Errorf("plan with all installments paid should be settled")
+	}
+}
+
+func TestMovedToPlanNote(t *testing.T) {
+	if got := MovedToPlanNote("", 4500, 7); got != "(₹4500 moved to installment plan #7)" {
+		t.Errorf("MovedToPlanNote() = %q", got)
+	}
+	if got := MovedToPlanNote("March rent", 4500, 7); got != "March rent (₹4500 moved to installment plan #7)" {
+		t.Errorf("MovedToPlanNote() = %q", got)
+	}
+}
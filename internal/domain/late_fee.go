@@ -0,0 +1,133 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// LateFeePolicy defines how late fees are charged for a unit or a whole property
+// A unit policy takes precedence over the policy of its property
+type LateFeePolicy struct {
+	ID         int       `json:"id" db:"id"`
+	PropertyID *int      `json:"property_id,omitempty" db:"property_id"` // Set for property-wide policies
+	UnitID     *int      `json:"unit_id,omitempty" db:"unit_id"`         // Set for unit-specific policies
+	FeeType    string    `json:"fee_type" db:"fee_type"`                 // flat, per_day, percentage
+	Amount     int       `json:"amount" db:"amount"`                     // Flat fee or fee per day late
+	Percentage float64   `json:"percentage" db:"percentage"`             // Percentage of the overdue amount
+	GraceDays  int       `json:"grace_days" db:"grace_days"`             // Days after due date before a fee applies
+	MaxFee     int       `json:"max_fee" db:"max_fee"`                   // Cap on the fee per payment (0 = no cap)
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
+
+// LateFee links an overdue payment to the late_fee payment charged for it
+type LateFee struct {
+	ID               int        `json:"id" db:"id"`
+	PaymentID        int        `json:"payment_id" db:"payment_id"`                   // The overdue payment
+	FeePaymentID     *int       `json:"fee_payment_id,omitempty" db:"fee_payment_id"` // The late_fee payment (NULL once reversed)
+	PolicyID         int        `json:"policy_id" db:"policy_id"`
+	TenantID         int        `json:"tenant_id" db:"tenant_id"`
+	Amount           int        `json:"amount" db:"amount"`
+	Status           string     `json:"status" db:"status"` // applied, waived, reversed
+	Reason           string     `json:"reason" db:"reason"` // Why the fee was waived or reversed
+	ActionedByUserID *int       `json:"actioned_by_user_id,omitempty" db:"actioned_by_user_id"`
+	ActionedAt       *time.Time `json:"actioned_at,omitempty" db:"actioned_at"`
+	CreatedAt        time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// Late fee policy type constants
+const (
+	LateFeeTypeFlat       = "flat"
+	LateFeeTypePerDay     = "per_day"
+	LateFeeTypePercentage = "percentage"
+)
+
+// Late fee status constants
+const (
+	LateFeeStatusApplied  = "applied"
+	LateFeeStatusWaived   = "waived"
+	LateFeeStatusReversed = "reversed"
+)
+
+// Validate validates the late fee policy data
+func (p *LateFeePolicy) Validate() error {
+	if (p.PropertyID == nil) == (p.UnitID == nil) {
+		return fmt.Errorf("policy must apply to either a property or a unit")
+	}
+	switch p.FeeType {
+	case LateFeeTypeFlat, LateFeeTypePerDay:
+		if p.Amount <= 0 {
+			return fmt.Errorf("amount must be greater than 0")
+		}
+	case LateFeeTypePercentage:
+		if p.Percentage <= 0 || p.Percentage > 100 {
+			return fmt.Errorf("percentage must be between 0 and 100")
+		}
+	default:
+		return fmt.Errorf("invalid fee type: %s. Must be one of: flat, per_day, percentage", p.FeeType)
+	}
+	if p.GraceDays < 0 {
+		return fmt.Errorf("grace days cannot be negative")
+	}
+	if p.MaxFee < 0 {
+		return fmt.Errorf("max fee cannot be negative")
+	}
+	return nil
+}
+
+// CalculateFee returns the late fee owed for a payment as of the given date (0 if none)
+func (p *LateFeePolicy) CalculateFee(payment *Payment, asOf time.Time) int {
+	if payment.IsFullyPaid {
+		return 0
+	}
+
+	daysLate := int(asOf.Sub(payment.DueDate).Hours()/24) - p.GraceDays
+	if daysLate <= 0 {
+		return 0
+	}
+
+	fee := 0
+	switch p.FeeType {
+	case LateFeeTypeFlat:
+		fee = p.Amount
+	case LateFeeTypePerDay:
+		fee = p.Amount * daysLate
+	case LateFeeTypePercentage:
+		fee = int(float64(payment.RemainingBalance) * p.Percentage / 100) // Of what is still overdue, not the full amount
+	}
+
+	if p.MaxFee > 0 && fee > p.MaxFee {
+		fee = p.MaxFee
+	}
+	return fee
+}
+
+// GetDescription returns a human-readable summary of the policy
+func (p *LateFeePolicy) GetDescription() string {
+	var desc string
+	switch p.FeeType {
+	case LateFeeTypeFlat:
+		desc = fmt.Sprintf("₹%d flat", p.Amount)
+	case LateFeeTypePerDay:
+		desc = fmt.Sprintf("₹%d per day", p.Amount)
+	case LateFeeTypePercentage:
+		desc = fmt.Sprintf("%.1f%% of amount due", p.Percentage)
+	}
+	if p.GraceDays > 0 {
+		desc += fmt.Sprintf(" after %d day grace period", p.GraceDays)
+	}
+	if p.MaxFee > 0 {
+		desc += fmt.Sprintf(" (max ₹%d)", p.MaxFee)
+	}
+	return desc
+}
+
+// IsActive returns true if the fee is still charged to the tenant
+func (f *LateFee) IsActive() bool {
+	return f.Status == LateFeeStatusApplied
+}
+
+// GetFormattedAmount returns the fee amount formatted as currency
+func (f *LateFee) GetFormattedAmount() string {
+	return fmt.Sprintf("₹%d", f.Amount)
+}
@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLateFeePolicy_CalculateFee(t *testing.T) {
+	dueDate := time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC)
+	payment := &Payment{Amount: 10000, RemainingBalance: 10000, DueDate: dueDate}
+	unitID := 1
+
+	tests := []struct {
+		name    string
+		policy  *LateFeePolicy
+		payment *Payment // Defaults to the unpaid payment above
+		asOf    time.Time
+		want    int
+	}{
+		{
+			name:   "flat fee after due date",
+			policy: &LateFeePolicy{UnitID: &unitID, FeeType: LateFeeTypeFlat, Amount: 500},
+			asOf:   dueDate.AddDate(0, 0, 1),
+			want:   500,
+		},
+		{
+			name:   "within grace period",
+			policy: &LateFeePolicy{UnitID: &unitID, FeeType: LateFeeTypeFlat, Amount: 500, GraceDays: 5},
+			asOf:   dueDate.AddDate(0, 0, 5),
+			want:   0,
+		},
+		{
+			name:   "per day after grace period",
+			policy: &LateFeePolicy{UnitID: &unitID, FeeType: LateFeeTypePerDay, Amount: 50, GraceDays: 3},
+			asOf:   dueDate.AddDate(0, 0, 10),
+			want:   350,
+		},
+		{
+			name:   "per day capped",
+			policy: &LateFeePolicy{UnitID: &unitID, FeeType: LateFeeTypePerDay, Amount: 50, MaxFee: 200},
+			asOf:   dueDate.AddDate(0, 0, 10),
+			want:   200,
+		},
+		{
+			name:   "percentage of amount",
+			policy: &LateFeePolicy{UnitID: &unitID, FeeType: LateFeeTypePercentage, Percentage: 2.5},
+			asOf:   dueDate.AddDate(0, 0, 2),
+			want:   250,
+		},
+		{
+			name:    "percentage of what is still overdue after a partial payment",
+			policy:  &LateFeePolicy{UnitID: &unitID, FeeType: LateFeeTypePercentage, Percentage: 2.5},
+			payment: &Payment{Amount: 10000, AmountPaid: 9000, RemainingBalance: 1000, DueDate: dueDate},
+			asOf:    dueDate.AddDate(0, 0, 2),
+			want:    25,
+		},
+		{
+			name:   "not yet due",
+			policy: &LateFeePolicy{UnitID: &unitID, FeeType: LateFeeTypeFlat, Amount: 500},
+			asOf:   dueDate.AddDate(0, 0, -1),
+			want:   0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := payment
+			if tt.payment != nil {
+				p = tt.payment
+			}
+			if got := tt.policy.CalculateFee(p, tt.asOf); got != tt.want {
+				t.Errorf("CalculateFee() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLateFeePolicy_Validate(t *testing.T) {
+	unitID, propertyID := 1, 2
+
+	if err := (&LateFeePolicy{FeeType: LateFeeTypeFlat, Amount: 100}).Validate(); err == nil {
+		t.Error("expected error for policy without unit or property")
+	}
+	if err := (&LateFeePolicy{UnitID: &unitID, PropertyID: &propertyID, FeeType: LateFeeTypeFlat, Amount: 100}).Validate(); err == nil {
+		t.Error("expected error for policy with both unit and property")
+	}
+	if err := (&LateFeePolicy{UnitID: &unitID, FeeType: LateFeeTypePercentage, Percentage: 120}).Validate(); err == nil {
+		t.Error("expected error for percentage above 100")
+	}
+	if err := (&LateFeePolicy{PropertyID: &propertyID, FeeType: LateFeeTypePerDay, Amount: 20, GraceDays: 3}).Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
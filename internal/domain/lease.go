@@ -0,0 +1,179 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Lease is the rental agreement of a tenant: its term, rent, escalation and notice terms
+// Renewing a lease closes it and starts a new one linked through PreviousLeaseID
+type Lease struct {
+	ID                       int       `json:"id" db:"id"`
+	TenantID                 int       `json:"tenant_id" db:"tenant_id"`
+	UnitID                   int       `json:"unit_id" db:"unit_id"`
+	StartDate                time.Time `json:"start_date" db:"start_date"`
+	EndDate                  time.Time `json:"end_date" db:"end_date"`
+	MonthlyRent              int       `json:"monthly_rent" db:"monthly_rent"`                             // Rent at the start of the lease
+	EscalationPercent        float64   `json:"escalation_percent" db:"escalation_percent"`                 // Rent increase applied every interval (0 = none)
+	EscalationIntervalMonths int       `json:"escalation_interval_months" db:"escalation_interval_months"` // Usually 12
+	NoticePeriodDays         int       `json:"notice_period_days" db:"notice_period_days"`
+	LockInMonths             int       `json:"lock_in_months" db:"lock_in_months"` // Minimum stay from the start date
+	Status                   string    `json:"status" db:"status"`                 // active, renewed, ended
+	PreviousLeaseID          *int      `json:"previous_lease_id,omitempty" db:"previous_lease_id"`
+	Notes                    string    `json:"notes" db:"notes"`
+	CreatedAt                time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt                time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// LeaseEvent is an upcoming lease expiry or rent escalation
+type LeaseEvent struct {
+	Lease   *Lease    `json:"lease"`
+	Type    string    `json:"type"` // expiry, escalation
+	Date    time.Time `json:"date"`
+	NewRent int       `json:"new_rent,omitempty"` // Rent from the escalation date
+}
+
+// Lease status constants
+const (
+	LeaseStatusActive  = "active"
+	LeaseStatusRenewed = "renewed"
+	LeaseStatusEnded   = "ended"
+)
+
+// Lease event type constants
+const (
+	LeaseEventExpiry     = "expiry"
+	LeaseEventEscalation = "escalation"
+)
+
+// Validate validates the lease data
+func (l *Lease) Validate() error {
+	if l.TenantID <= 0 {
+		return fmt.Errorf("tenant ID is required")
+	}
+	if l.UnitID <= 0 {
+		return fmt.Errorf("unit ID is required")
+	}
+	if l.StartDate.IsZero() || l.EndDate.IsZero() {
+		return fmt.Errorf("start and end dates are required")
+	}
+	if !l.EndDate.After(l.StartDate) {
+		return fmt.Errorf("end date must be after start date")
+	}
+	if l.MonthlyRent <= 0 {
+		return fmt.Errorf("monthly rent must be greater than 0")
+	}
+	if l.EscalationPercent < 0 || l.EscalationPercent > 100 {
+		return fmt.Errorf("escalation percent must be between 0 and 100")
+	}
+	if l.EscalationPercent > 0 && l.EscalationIntervalMonths <= 0 {
+		return fmt.Errorf("escalation interval is required when escalation is set")
+	}
+	if l.NoticePeriodDays < 0 {
+		return fmt.Errorf("notice period cannot be negative")
+	}
+	if l.LockInMonths < 0 {
+		return fmt.Errorf("lock-in period cannot be negative")
+	}
+	return nil
+}
+
+// monthsBetween returns the number of whole months from start to date
+func monthsBetween(start, date time.Time) int {
+	months := (date.Year()-start.Year())*12 + int(date.Month()) - int(start.Month())
+	if date.Day() < start.Day() {
+		months--
+	}
+	return months
+}
+
+// escalationsBy returns how many escalations have taken effect on the given date
+func (l *Lease) escalationsBy(date time.Time) int {
+	if l.EscalationPercent <= 0 || l.EscalationIntervalMonths <= 0 || date.Before(l.StartDate) {
+		return 0
+	}
+	return monthsBetween(l.StartDate, date) / l.EscalationIntervalMonths
+}
+
+// rentAfter returns the rent after the given number of escalations
+func (l *Lease) rentAfter(escalations int) int {
+	return int(math.Round(float64(l.MonthlyRent) * math.Pow(1+l.EscalationPercent/100, float64(escalations))))
+}
+
+// GetRentFor returns the rent in force on the given date
+func (l *Lease) GetRentFor(date time.Time) int {
+	return l.rentAfter(l.escalationsBy(date))
+}
+
+// GetNextEscalationDate returns the next escalation on or after asOf within the lease term (nil if none)
+func (l *Lease) GetNextEscalationDate(asOf time.Time) *time.Time {
+	if l.EscalationPercent <= 0 || l.EscalationIntervalMonths <= 0 {
+		return nil
+	}
+	n := l.escalationsBy(asOf)
+	// An escalation falling on asOf itself is still upcoming
+	if n > 0 && sameDay(l.StartDate.AddDate(0, l.EscalationIntervalMonths*n, 0), asOf) {
+		n--
+	}
+	next := l.StartDate.AddDate(0, l.EscalationIntervalMonths*(n+1), 0)
+	if next.After(l.EndDate) {
+		return nil
+	}
+	return &next
+}
+
+// sameDay returns true if both times fall on the same calendar day
+func sameDay(a, b time.Time) bool {
+	return a.Year() == b.Year() && a.YearDay() == b.YearDay()
+}
+
+// GetLockInEndDate returns the first day the tenant may leave without breaking the lock-in
+func (l *Lease) GetLockInEndDate() time.Time {
+	return l.StartDate.AddDate(0, l.LockInMonths, 0)
+}
+
+// IsInLockIn returns true if leaving on the given date would break the lock-in period
+func (l *Lease) IsInLockIn(date time.Time) bool {
+	return date.Before(l.GetLockInEndDate())
+}
+
+// GetNoticeDeadline returns the last day notice can be given to leave at the end of the lease
+func (l *Lease) GetNoticeDeadline() time.Time {
+	return l.EndDate.AddDate(0, 0, -l.NoticePeriodDays)
+}
+
+// Covers returns true if the date falls within the lease term
+func (l *Lease) Covers(date time.Time) bool {
+	return !date.Before(l.StartDate) && !date.After(l.EndDate)
+}
+
+// IsActive returns true for the lease currently in force
+func (l *Lease) IsActive() bool {
+	return l.Status == LeaseStatusActive
+}
+
+// GetUpcomingEvents returns the expiry and escalations falling within the next days from asOf
+func (l *Lease) GetUpcomingEvents(asOf time.Time, days int) []LeaseEvent {
+	var events []LeaseEvent
+	horizon := asOf.AddDate(0, 0, days)
+
+	if next := l.GetNextEscalationDate(asOf); next != nil && !next.After(horizon) {
+		events = append(events, LeaseEvent{
+			Lease:   l,
+			Type:    LeaseEventEscalation,
+			Date:    *next,
+			NewRent: l.GetRentFor(*next),
+		})
+	}
+	if !l.EndDate.Before(asOf) && !l.EndDate.After(horizon) {
+		events = append(events, LeaseEvent{Lease: l, Type: LeaseEventExpiry, Date: l.EndDate})
+	}
+
+	return events
+}
+
+// GetFormattedEndDate returns the lease end date formatted
+func (l *Lease) GetFormattedEndDate() string {
+	return l.EndDate.Format("Jan 2, 2006")
+}
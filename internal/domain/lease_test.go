@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func newTestLease() *Lease {
+	return &Lease{
+		TenantID:                 1,
+		UnitID:                   1,
+		StartDate:                time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:                  time.Date(2027, time.March, 31, 0, 0, 0, 0, time.UTC),
+		MonthlyRent:              10000,
+		EscalationPercent:        5,
+		EscalationIntervalMonths: 12,
+		NoticePeriodDays:         30,
+		LockInMonths:             6,
+		Status:                   LeaseStatusActive,
+	}
+}
+
+func TestLease_GetRentFor(t *testing.T) {
+	lease := newTestLease()
+
+	tests := []struct {
+		date time.Time
+		want int
+	}{
+		{time.Date(2024, time.April, 10, 0, 0, 0, 0, time.UTC), 10000},
+		{time.Date(2025, time.March, 31, 0, 0, 0, 0, time.UTC), 10000},
+		{time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC), 10500},
+		{time.Date(2026, time.May, 10, 0, 0, 0, 0, time.UTC), 11025},
+	}
+
+	for _, tt := range tests {
+		if got := lease.GetRentFor(tt.date); got != tt.want {
+			t.Errorf("GetRentFor(%s) = %d, want %d", tt.date.Format("2006-01-02"), got, tt.want)
+		}
+	}
+
+	lease.EscalationPercent = 0
+	if got := lease.GetRentFor(time.Date(2026, time.May, 10, 0, 0, 0, 0, time.UTC)); got != 10000 {
+		t.Errorf("rent without escalation = %d, want 10000", got)
+	}
+}
+
+func TestLease_GetNextEscalationDate(t *testing.T) {
+	lease := newTestLease()
+
+	next := lease.GetNextEscalationDate(time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC))
+	if next == nil || !next.Equal(time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("next escalation = %v, want 2025-04-01", next)
+	}
+
+	onTheDay := lease.GetNextEscalationDate(time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC))
+	if onTheDay == nil || !onTheDay.Equal(time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("escalation on asOf should still be reported, got %v", onTheDay)
+	}
+
+	// The escalation on 2027-04-01 falls after the lease ends
+	if after := lease.GetNextEscalationDate(time.Date(2026, time.June, 1, 0, 0, 0, 0, time.UTC)); after != nil {
+		t.Errorf("escalation after lease end should be nil, got %v", after)
+	}
+}
+
+func TestLease_GetUpcomingEvents(t *testing.T) {
+	lease := newTestLease()
+
+	events := lease.GetUpcomingEvents(time.Date(2027, time.March, 1, 0, 0, 0, 0, time.UTC), 30)
+	if len(events) != 1 || events[0].Type != LeaseEventExpiry {
+		t.Fatalf("expected a single expiry event, got %+v", events)
+	}
+
+	events = lease.GetUpcomingEvents(time.Date(2025, time.March, 15, 0, 0, 0, 0, time.UTC), 30)
+	if len(events) != 1 || events[0].Type != LeaseEventEscalation || events[0].NewRent != 10500 {
+		t.Fatalf("expected an escalation to 10500, got %+v", events)
+	}
+}
@@ -1,12 +1,26 @@
 package domain
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 // NotificationType represents the type of notification
 type NotificationType string
 
 const (
 	NotificationTypeDueDateReminder NotificationType = "due_date_reminder"
+	NotificationTypeLeaseExpiry     NotificationType = "lease_expiry"
+	NotificationTypeLeaseEscalation NotificationType = "lease_escalation"
+
+	// Payment lifecycle events
+	NotificationTypePaymentSubmitted NotificationType = "payment_submitted" // Tenant submitted a UTR for verification
+	NotificationTypePaymentVerified  NotificationType = "payment_verified"  // Owner verified a transaction and it cleared the payment
+	NotificationTypePaymentPartial   NotificationType = "payment_partial"   // Owner verified a transaction that left a balance due
+	NotificationTypePaymentRejected  NotificationType = "payment_rejected"  // Owner rejected a submitted transaction
+	NotificationTypePaymentOverdue   NotificationType = "payment_overdue"   // Payment still unpaid after its due date
+	NotificationTypeChargeCreated    NotificationType = "charge_created"    // New charge raised for the tenant
+	NotificationTypePasswordReset    NotificationType = "password_reset"    // Owner regenerated the tenant's password
 )
 
 // NotificationRecipient represents who should receive the notification
@@ -17,17 +31,189 @@ const (
 	NotificationRecipientTenant NotificationRecipient = "tenant"
 )
 
+// notificationTypeInfo describes a notification type for preferences and display
+type notificationTypeInfo struct {
+	Type        NotificationType
+	DisplayName string
+	Recipients  []NotificationRecipient // Who the type is sent to
+	Required    bool                    // Security notices that cannot be muted
+}
+
+// notificationTypes lists every notification type in display order
+var notificationTypes = []notificationTypeInfo{
+	{NotificationTypeDueDateReminder, "Rent due reminders", []NotificationRecipient{NotificationRecipientOwner, NotificationRecipientTenant}, false},
+	{NotificationTypePaymentOverdue, "Overdue notices", []NotificationRecipient{NotificationRecipientOwner, NotificationRecipientTenant}, false},
+	{NotificationTypePaymentSubmitted, "Payment submissions", []NotificationRecipient{NotificationRecipientOwner}, false},
+	{NotificationTypePaymentVerified, "Payment confirmations", []NotificationRecipient{NotificationRecipientTenant}, false},
+	{NotificationTypePaymentPartial, "Partial payment notices", []NotificationRecipient{NotificationRecipientTenant}, false},
+	{NotificationTypePaymentRejected, "Rejected payment alerts", []NotificationRecipient{NotificationRecipientTenant}, false},
+	{NotificationTypeChargeCreated, "New charges", []NotificationRecipient{NotificationRecipientTenant}, false},
+	{NotificationTypeLeaseExpiry, "Lease expiry alerts", []NotificationRecipient{NotificationRecipientOwner}, false},
+	{NotificationTypeLeaseEscalation, "Rent escalation alerts", []NotificationRecipient{NotificationRecipientOwner}, false},
+	{NotificationTypePasswordReset, "Password resets", []NotificationRecipient{NotificationRecipientTenant}, true},
+}
+
+// NotificationTypesFor returns the notification types a recipient can choose to receive
+// Required types are always sent and are not listed
+func NotificationTypesFor(recipient NotificationRecipient) []NotificationType {
+	var types []NotificationType
+	for _, info := range notificationTypes {
+		if info.Required {
+			continue
+		}
+		for _, r := range info.Recipients {
+			if r == recipient {
+				types = append(types, info.Type)
+				break
+			}
+		}
+	}
+	return types
+}
+
+// IsValid reports whether t is a known notification type
+func (t NotificationType) IsValid() bool {
+	for _, info := range notificationTypes {
+		if info.Type == t {
+			return true
+		}
+	}
+	return false
+}
+
+// IsRequired reports whether t is always sent, whatever the recipient's preferences
+func (t NotificationType) IsRequired() bool {
+	for _, info := range notificationTypes {
+		if info.Type == t {
+			return info.Required
+		}
+	}
+	return false
+}
+
+// GetDisplayName returns a human-readable name for the notification type
+func (t NotificationType) GetDisplayName() string {
+	for _, info := range notificationTypes {
+		if info.Type == t {
+			return info.DisplayName
+		}
+	}
+	return string(t)
+}
+
+// NotificationStatus is where a notification is in the delivery queue
+type NotificationStatus string
+
+const (
+	NotificationStatusPending   NotificationStatus = "pending"   // Queued; sent once next_attempt_at has passed
+	NotificationStatusSent      NotificationStatus = "sent"      // Delivered
+	NotificationStatusDead      NotificationStatus = "dead"      // Given up on after NotificationMaxAttempts or an undeliverable recipient; the owner can retry it
+	NotificationStatusCancelled NotificationStatus = "cancelled" // Dropped because the recipient muted the type before it was sent
+)
+
+// Retry policy: failed deliveries are retried after 2, 4, 8... minutes (about 4 hours in all)
+// until NotificationMaxAttempts attempts have failed
+const (
+	NotificationMaxAttempts    = 8
+	NotificationRetryBaseDelay = 2 * time.Minute
+	NotificationRetryMaxDelay  = 6 * time.Hour
+)
+
 // Notification represents a notification record
+// Notifications are queued (persisted) first and delivered by the notification worker
 type Notification struct {
-	ID        int                   `json:"id" db:"id"`
-	Type      NotificationType      `json:"type" db:"type"`
-	Recipient NotificationRecipient `json:"recipient" db:"recipient"`
-	TenantID  *int                  `json:"tenant_id,omitempty" db:"tenant_id"`
-	PaymentID *int                  `json:"payment_id,omitempty" db:"payment_id"`
-	Message   string                `json:"message" db:"message"`
-	SentAt    *time.Time            `json:"sent_at,omitempty" db:"sent_at"`
-	SentVia   string                `json:"sent_via" db:"sent_via"` // e.g., "telegram"
-	SentTo    string                `json:"sent_to" db:"sent_to"`   // e.g., telegram chat ID
-	Error     string                `json:"error,omitempty" db:"error"`
-	CreatedAt time.Time             `json:"created_at" db:"created_at"`
+	ID            int                   `json:"id" db:"id"`
+	Type          NotificationType      `json:"type" db:"type"`
+	Recipient     NotificationRecipient `json:"recipient" db:"recipient"`
+	TenantID      *int                  `json:"tenant_id,omitempty" db:"tenant_id"`
+	PaymentID     *int                  `json:"payment_id,omitempty" db:"payment_id"`
+	Message       string                `json:"message" db:"message"`
+	SentAt        *time.Time            `json:"sent_at,omitempty" db:"sent_at"`
+	SentVia       string                `json:"sent_via" db:"sent_via"` // e.g., "telegram"
+	SentTo        string                `json:"sent_to" db:"sent_to"`   // e.g., telegram chat ID
+	Error         string                `json:"error,omitempty" db:"error"`
+	CreatedAt     time.Time             `json:"created_at" db:"created_at"`
+	Status        NotificationStatus    `json:"status" db:"status"`
+	Attempts      int                   `json:"attempts" db:"attempts"`
+	NextAttemptAt *time.Time            `json:"next_attempt_at,omitempty" db:"next_attempt_at"` // Set while pending: after a failure or until quiet hours end
+	DedupeKey     string                `json:"-" db:"dedupe_key"`                              // Unique per event and recipient; empty for notifications that may repeat
+}
+
+// NotificationDedupeKey returns the key that makes queuing a notification idempotent
+// event tells apart notifications of the same type about the same payment (e.g. a UTR or "7d" for a lead time)
+func NotificationDedupeKey(t NotificationType, recipient NotificationRecipient, tenantID *int, paymentID *int, event string) string {
+	tenant, payment := 0, 0
+	if tenantID != nil {
+		tenant = *tenantID
+	}
+	if paymentID != nil {
+		payment = *paymentID
+	}
+	return fmt.Sprintf("%s:%s:%d:%d:%s", t, recipient, tenant, payment, event)
+}
+
+// NotificationRetryDelay returns how long to wait before retrying after the given number of failed attempts
+func NotificationRetryDelay(attempts int) time.Duration {
+	delay := NotificationRetryBaseDelay
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= NotificationRetryMaxDelay {
+			return NotificationRetryMaxDelay
+		}
+	}
+	return delay
+}
+
+// IsDueAt reports whether a pending notification should be attempted at t
+func (n *Notification) IsDueAt(t time.Time) bool {
+	return n.Status == NotificationStatusPending && (n.NextAttemptAt == nil || !n.NextAttemptAt.After(t))
+}
+
+// CanRetry reports whether the owner can put the notification back in the queue
+func (n *Notification) CanRetry() bool {
+	return n.Status == NotificationStatusDead
+}
+
+// RecordAttempt records a delivery attempt over the given channel and address
+// A failure schedules a retry with exponential backoff, or dead-letters the notification
+// once NotificationMaxAttempts attempts have failed
+func (n *Notification) RecordAttempt(via, to string, sendErr error, now time.Time) {
+	n.Attempts++
+	n.SentVia = via
+	n.SentTo = to
+
+	if sendErr == nil {
+		n.Status = NotificationStatusSent
+		n.SentAt = &now
+		n.NextAttemptAt = nil
+		n.Error = ""
+		return
+	}
+
+	n.Error = sendErr.Error()
+	if n.Attempts >= NotificationMaxAttempts {
+		n.Status = NotificationStatusDead
+		n.NextAttemptAt = nil
+		return
+	}
+	next := now.Add(NotificationRetryDelay(n.Attempts))
+	n.NextAttemptAt = &next
+}
+
+// MarkDead dead-letters the notification without retrying it
+func (n *Notification) MarkDead(reason string) {
+	n.Status = NotificationStatusDead
+	n.NextAttemptAt = nil
+	n.Error = reason
+}
+
+// Requeue puts a dead notification back in the queue with a fresh set of attempts
+func (n *Notification) Requeue(now time.Time) error {
+	if !n.CanRetry() {
+		return fmt.Errorf("only failed notifications can be retried (status: %s)", n.Status)
+	}
+	n.Status = NotificationStatusPending
+	n.Attempts = 0
+	n.NextAttemptAt = &now
+	return nil
 }
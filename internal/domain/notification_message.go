@@ -0,0 +1,167 @@
+package domain
+
+import "fmt"
+
+// Supported notification languages
+const (
+	LanguageEnglish = "en"
+	LanguageHindi   = "hi"
+	LanguageTelugu  = "te"
+)
+
+// NotificationLanguages lists the supported languages with their names, in display order
+var NotificationLanguages = []struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+}{
+	{LanguageEnglish, "English"},
+	{LanguageHindi, "हिन्दी"},
+	{LanguageTelugu, "తెలుగు"},
+}
+
+// IsSupportedLanguage reports whether notifications can be sent in the language
+func IsSupportedLanguage(language string) bool {
+	for _, l := range NotificationLanguages {
+		if l.Code == language {
+			return true
+		}
+	}
+	return false
+}
+
+// NotificationMessageKey identifies a notification text
+type NotificationMessageKey string
+
+const (
+	MessageDueDateReminderOwner  NotificationMessageKey = "due_date_reminder_owner"
+	MessageDueDateReminderTenant NotificationMessageKey = "due_date_reminder_tenant"
+	MessageLeaseExpiry           NotificationMessageKey = "lease_expiry"
+	MessageLeaseEscalation       NotificationMessageKey = "lease_escalation"
+	MessagePaymentSubmitted      NotificationMessageKey = "payment_submitted"
+	MessagePaymentVerified       NotificationMessageKey = "payment_verified"
+	MessagePaymentPartial        NotificationMessageKey = "payment_partial"
+	MessagePaymentRejected       NotificationMessageKey = "payment_rejected"
+	MessagePaymentOverdueOwner   NotificationMessageKey = "payment_overdue_owner"
+	MessagePaymentOverdueTenant  NotificationMessageKey = "payment_overdue_tenant"
+	MessageChargeCreated         NotificationMessageKey = "charge_created"
+	MessagePasswordReset         NotificationMessageKey = "password_reset"
+)
+
+// notificationText is the subject and body format of a notification in one language
+type notificationText struct {
+	Subject string
+	Body    string // fmt format; translations use explicit argument indexes to reorder arguments
+}
+
+// notificationMessages holds every notification text by key and language
+// English is required for every key; missing translations fall back to it
+var notificationMessages = map[NotificationMessageKey]map[string]notificationText{
+	// Args: due date, tenant name, unit code, amount
+	MessageDueDateReminderOwner: {
+		LanguageEnglish: {"Rent Reminder", "📅 Reminder: On %s, %s (%s) has to pay ₹%d"},
+		LanguageHindi:   {"किराया अनुस्मारक", "📅 अनुस्मारक: %[1]s को %[2]s (%[3]s) को ₹%[4]d का भुगतान करना है"},
+		LanguageTelugu:  {"అద్దె గుర్తు", "📅 గుర్తు: %[1]s న %[2]s (%[3]s) ₹%[4]d చెల్లించాలి"},
+	},
+	// Args: amount, unit code, due date, UPI ID
+	MessageDueDateReminderTenant: {
+		LanguageEnglish: {"Rent Reminder", "📅 Reminder: Your rent payment of ₹%d for %s is due on %s. Please make the payment to %s"},
+		LanguageHindi:   {"किराया अनुस्मारक", "📅 अनुस्मारक: %[2]s का आपका ₹%[1]d किराया %[3]s को देय है। कृपया भुगतान %[4]s पर करें"},
+		LanguageTelugu:  {"అద్దె గుర్తు", "📅 గుర్తు: %[2]s కోసం మీ ₹%[1]d అద్దె %[3]s న చెల్లించాలి. దయచేసి %[4]s కు చెల్లించండి"},
+	},
+	// Args: tenant name, unit code, end date, days ahead, notice deadline
+	MessageLeaseExpiry: {
+		LanguageEnglish: {"Lease Alert", "📄 Lease of %s (%s) ends on %s (in %d days). Notice deadline: %s"},
+		LanguageHindi:   {"लीज़ सूचना", "📄 %[1]s (%[2]s) की लीज़ %[3]s को समाप्त होगी (%[4]d दिन में)। नोटिस की अंतिम तिथि: %[5]s"},
+		LanguageTelugu:  {"లీజు హెచ్చరిక", "📄 %[1]s (%[2]s) లీజు %[3]s న ముగుస్తుంది (%[4]d రోజుల్లో). నోటీసు గడువు: %[5]s"},
+	},
+	// Args: tenant name, unit code, current rent, new rent, escalation date, days ahead
+	MessageLeaseEscalation: {
+		LanguageEnglish: {"Lease Alert", "📈 Rent of %s (%s) goes up from ₹%d to ₹%d on %s (in %d days)"},
+		LanguageHindi:   {"लीज़ सूचना", "📈 %[1]s (%[2]s) का किराया %[5]s से ₹%[3]d से बढ़कर ₹%[4]d हो जाएगा (%[6]d दिन में)"},
+		LanguageTelugu:  {"లీజు హెచ్చరిక", "📈 %[1]s (%[2]s) అద్దె %[5]s నుండి ₹%[3]d నుండి ₹%[4]d కి పెరుగుతుంది (%[6]d రోజుల్లో)"},
+	},
+	// Args: tenant name, unit code, UTR, payment label, balance due
+	MessagePaymentSubmitted: {
+		LanguageEnglish: {"Payment Submitted", "💸 %s (%s) submitted UTR %s for %s (₹%d due). Please verify it"},
+		LanguageHindi:   {"भुगतान जमा", "💸 %[1]s (%[2]s) ने %[4]s (₹%[5]d बकाया) के लिए UTR %[3]s जमा किया है। कृपया इसकी पुष्टि करें"},
+		LanguageTelugu:  {"చెల్లింపు సమర్పణ", "💸 %[1]s (%[2]s) %[4]s (₹%[5]d బాకీ) కోసం UTR %[3]s సమర్పించారు. దయచేసి ధృవీకరించండి"},
+	},
+	// Args: amount, UTR
+	MessagePaymentVerified: {
+		LanguageEnglish: {"Payment Verified", "✅ Your payment of ₹%d (UTR %s) has been verified. Thank you!"},
+		LanguageHindi:   {"भुगतान सत्यापित", "✅ आपका ₹%[1]d का भुगतान (UTR %[2]s) सत्यापित हो गया है। धन्यवाद!"},
+		LanguageTelugu:  {"చెల్లింపు ధృవీకరణ", "✅ మీ ₹%[1]d చెల్లింపు (UTR %[2]s) ధృవీకరించబడింది. ధన్యవాదాలు!"},
+	},
+	// Args: amount, UTR, balance due, payment label, due date
+	MessagePaymentPartial: {
+		LanguageEnglish: {"Payment Received", "🧾 Your payment of ₹%d (UTR %s) has been verified. ₹%d is still due for %s (due %s)"},
+		LanguageHindi:   {"भुगतान प्राप्त", "🧾 आपका ₹%[1]d का भुगतान (UTR %[2]s) सत्यापित हो गया है। %[4]s के लिए ₹%[3]d अभी बाकी है (देय तिथि %[5]s)"},
+		LanguageTelugu:  {"చెల్లింపు అందింది", "🧾 మీ ₹%[1]d చెల్లింపు (UTR %[2]s) ధృవీకరించబడింది. %[4]s కోసం ఇంకా ₹%[3]d బాకీ ఉంది (గడువు %[5]s)"},
+	},
+	// Args: UTR, reason
+	MessagePaymentRejected: {
+		LanguageEnglish: {"Payment Rejected", "❌ Your payment with UTR %s was rejected: %s. Please check the transaction ID and submit it again"},
+		LanguageHindi:   {"भुगतान अस्वीकृत", "❌ UTR %[1]s वाला आपका भुगतान अस्वीकार कर दिया गया: %[2]s। कृपया ट्रांज़ैक्शन ID जाँचें और फिर से जमा करें"},
+		LanguageTelugu:  {"చెల్లింపు తిరస్కరణ", "❌ UTR %[1]s తో మీ చెల్లింపు తిరస్కరించబడింది: %[2]s. దయచేసి ట్రాన్సాక్షన్ ID తనిఖీ చేసి మళ్లీ సమర్పించండి"},
+	},
+	// Args: tenant name, unit code, payment label, balance due, due date, days overdue
+	MessagePaymentOverdueOwner: {
+		LanguageEnglish: {"Payment Overdue", "⚠️ %s (%s) has not paid %s of ₹%d due on %s (%d days overdue)"},
+		LanguageHindi:   {"भुगतान बकाया", "⚠️ %[1]s (%[2]s) ने %[5]s को देय ₹%[4]d का %[3]s नहीं चुकाया है (%[6]d दिन से बकाया)"},
+		LanguageTelugu:  {"చెల్లింపు ఆలస్యం", "⚠️ %[1]s (%[2]s) %[5]s న చెల్లించాల్సిన ₹%[4]d %[3]s ఇంకా చెల్లించలేదు (%[6]d రోజులు ఆలస్యం)"},
+	},
+	// Args: payment label, balance due, due date, days overdue
+	MessagePaymentOverdueTenant: {
+		LanguageEnglish: {"Payment Overdue", "⚠️ Your %s of ₹%d was due on %s and is now %d days overdue. Please pay as soon as possible"},
+		LanguageHindi:   {"भुगतान बकाया", "⚠️ आपका ₹%[2]d का %[1]s %[3]s को देय था और अब %[4]d दिन से बकाया है। कृपया जल्द से जल्द भुगतान करें"},
+		LanguageTelugu:  {"చెల్లింపు ఆలస్యం", "⚠️ మీ ₹%[2]d %[1]s %[3]s న చెల్లించాల్సి ఉంది, ఇప్పుడు %[4]d రోజులు ఆలస్యమైంది. దయచేసి వీలైనంత త్వరగా చెల్లించండి"},
+	},
+	// Args: payment label, amount, due date
+	MessageChargeCreated: {
+		LanguageEnglish: {"New Charge", "🧾 A new charge has been added: %s of ₹%d, due on %s"},
+		LanguageHindi:   {"नया शुल्क", "🧾 नया शुल्क जोड़ा गया: %[1]s ₹%[2]d, देय तिथि %[3]s"},
+		LanguageTelugu:  {"కొత్త ఛార్జీ", "🧾 కొత్త ఛార్జీ జోడించబడింది: %[1]s ₹%[2]d, గడువు %[3]s"},
+	},
+	// No args; the new password is never sent, the owner hands it over
+	MessagePasswordReset: {
+		LanguageEnglish: {"Password Reset", "🔐 Your login password was reset by the owner. Ask them for your new temporary password and change it after logging in. If you did not ask for this, contact the owner"},
+		LanguageHindi:   {"पासवर्ड रीसेट", "🔐 मालिक ने आपका लॉगिन पासवर्ड रीसेट कर दिया है। नया अस्थायी पासवर्ड उनसे लें और लॉगिन के बाद उसे बदल दें। अगर आपने यह नहीं माँगा था, तो मालिक से संपर्क करें"},
+		LanguageTelugu:  {"పాస్‌వర్డ్ రీసెట్", "🔐 యజమాని మీ లాగిన్ పాస్‌వర్డ్‌ను రీసెట్ చేశారు. కొత్త తాత్కాలిక పాస్‌వర్డ్ కోసం వారిని అడగండి, లాగిన్ అయిన తర్వాత దాన్ని మార్చండి. మీరు దీన్ని అడగకపోతే, యజమానిని సంప్రదించండి"},
+	},
+}
+
+// FormatNotification returns the subject and body of a notification in the given language
+// Unknown languages and missing translations fall back to English
+func FormatNotification(language string, key NotificationMessageKey, args ...interface{}) (subject, body string) {
+	texts := notificationMessages[key]
+	text, ok := texts[language]
+	if !ok {
+		text = texts[LanguageEnglish]
+	}
+	return text.Subject, fmt.Sprintf(text.Body, args...)
+}
+
+// notificationSubjectKeys maps each notification type to the message whose subject it uses
+var notificationSubjectKeys = map[NotificationType]NotificationMessageKey{
+	NotificationTypeDueDateReminder:  MessageDueDateReminderTenant,
+	NotificationTypeLeaseExpiry:      MessageLeaseExpiry,
+	NotificationTypeLeaseEscalation:  MessageLeaseEscalation,
+	NotificationTypePaymentSubmitted: MessagePaymentSubmitted,
+	NotificationTypePaymentVerified:  MessagePaymentVerified,
+	NotificationTypePaymentPartial:   MessagePaymentPartial,
+	NotificationTypePaymentRejected:  MessagePaymentRejected,
+	NotificationTypePaymentOverdue:   MessagePaymentOverdueTenant,
+	NotificationTypeChargeCreated:    MessageChargeCreated,
+	NotificationTypePasswordReset:    MessagePasswordReset,
+}
+
+// NotificationSubject returns the subject of a notification type in the given language
+// Used when a stored notification is sent later and only its body was kept
+func NotificationSubject(language string, t NotificationType) string {
+	key, ok := notificationSubjectKeys[t]
+	if !ok {
+		return t.GetDisplayName()
+	}
+	subject, _ := FormatNotification(language, key)
+	return subject
+}
@@ -0,0 +1,171 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// NotificationPreferences are how the owner or a tenant wants to be notified
+// Users who never saved preferences get DefaultNotificationPreferences
+type NotificationPreferences struct {
+	Recipient       NotificationRecipient `json:"recipient" db:"recipient"`
+	TenantID        *int                  `json:"tenant_id,omitempty" db:"tenant_id"`       // Set for tenants, nil for the owner
+	Channels        []string              `json:"channels" db:"channels"`                   // Channels to use, in order; empty means every configured channel
+	MutedTypes      []NotificationType    `json:"muted_types" db:"muted_types"`             // Notification types the user does not want
+	QuietHoursStart *int                  `json:"quiet_hours_start" db:"quiet_hours_start"` // Hour (0-23) from which nothing is sent; nil for no quiet hours
+	QuietHoursEnd   *int                  `json:"quiet_hours_end" db:"quiet_hours_end"`     // Hour (0-23) from which sending resumes
+	Language        string                `json:"language" db:"language"`
+	ReminderDays    []int                 `json:"reminder_days" db:"reminder_days"` // Days before a due date that reminders are sent (0 = on the due date)
+	UpdatedAt       time.Time             `json:"updated_at" db:"updated_at"`
+}
+
+// MaxReminderLeadDays is how far ahead of a due date a reminder can be sent
+const MaxReminderLeadDays = 15
+
+// Default quiet hours: nothing is sent between 9 PM and 9 AM
+const (
+	DefaultQuietHoursStart = 21
+	DefaultQuietHoursEnd   = 9
+)
+
+// DefaultNotificationPreferences returns the preferences of a user who has not saved any
+// The owner is reminded on the due date and tenants 5 days before, as before preferences existed
+func DefaultNotificationPreferences(recipient NotificationRecipient, tenantID *int) *NotificationPreferences {
+	quietStart, quietEnd := DefaultQuietHoursStart, DefaultQuietHoursEnd
+	prefs := &NotificationPreferences{
+		Recipient:       recipient,
+		TenantID:        tenantID,
+		QuietHoursStart: &quietStart,
+		QuietHoursEnd:   &quietEnd,
+		Language:        LanguageEnglish,
+		ReminderDays:    []int{5},
+	}
+	if recipient == NotificationRecipientOwner {
+		prefs.ReminderDays = []int{0}
+	}
+	return prefs
+}
+
+// Validate validates the preferences and sorts the reminder days
+// Channel names are checked by the service, which knows the configured channels
+func (p *NotificationPreferences) Validate() error {
+	switch p.Recipient {
+	case NotificationRecipientOwner:
+		if p.TenantID != nil {
+			return fmt.Errorf("owner preferences cannot have a tenant")
+		}
+	case NotificationRecipientTenant:
+		if p.TenantID == nil {
+			return fmt.Errorf("tenant ID is required")
+		}
+	default:
+		return fmt.Errorf("invalid recipient: %s. Must be one of: owner, tenant", p.Recipient)
+	}
+
+	seenChannels := make(map[string]bool)
+	for _, ch := range p.Channels {
+		if seenChannels[ch] {
+			return fmt.Errorf("channel %s is listed twice", ch)
+		}
+		seenChannels[ch] = true
+	}
+
+	for _, t := range p.MutedTypes {
+		if !t.IsValid() {
+			return fmt.Errorf("invalid notification type: %s", t)
+		}
+		if t.IsRequired() {
+			return fmt.Errorf("%s cannot be turned off", t.GetDisplayName())
+		}
+	}
+
+	if (p.QuietHoursStart == nil) != (p.QuietHoursEnd == nil) {
+		return fmt.Errorf("quiet hours need both a start and an end")
+	}
+	if p.QuietHoursStart != nil {
+		if *p.QuietHoursStart < 0 || *p.QuietHoursStart > 23 || *p.QuietHoursEnd < 0 || *p.QuietHoursEnd > 23 {
+			return fmt.Errorf("quiet hours must be between 0 and 23")
+		}
+		if *p.QuietHoursStart == *p.QuietHoursEnd {
+			return fmt.Errorf("quiet hours cannot start and end at the same hour")
+		}
+	}
+
+	if !IsSupportedLanguage(p.Language) {
+		return fmt.Errorf("unsupported language: %s. Must be one of: en, hi, te", p.Language)
+	}
+
+	seenDays := make(map[int]bool)
+	for _, days := range p.ReminderDays {
+		if days < 0 || days > MaxReminderLeadDays {
+			return fmt.Errorf("reminder days must be between 0 and %d", MaxReminderLeadDays)
+		}
+		if seenDays[days] {
+			return fmt.Errorf("reminder day %d is listed twice", days)
+		}
+		seenDays[days] = true
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(p.ReminderDays)))
+
+	return nil
+}
+
+// Wants reports whether the user receives notifications of type t
+func (p *NotificationPreferences) Wants(t NotificationType) bool {
+	if t.IsRequired() {
+		return true
+	}
+	for _, muted := range p.MutedTypes {
+		if muted == t {
+			return false
+		}
+	}
+	return true
+}
+
+// WantsReminderDaysBefore reports whether a due date reminder is sent the given number of days ahead
+func (p *NotificationPreferences) WantsReminderDaysBefore(days int) bool {
+	if !p.Wants(NotificationTypeDueDateReminder) {
+		return false
+	}
+	for _, d := range p.ReminderDays {
+		if d == days {
+			return true
+		}
+	}
+	return false
+}
+
+// IsQuietAt reports whether t falls within the quiet hours
+// Quiet hours may wrap past midnight (e.g. 21 to 9)
+func (p *NotificationPreferences) IsQuietAt(t time.Time) bool {
+	if p.QuietHoursStart == nil || p.QuietHoursEnd == nil {
+		return false
+	}
+	hour, start, end := t.Hour(), *p.QuietHoursStart, *p.QuietHoursEnd
+	if start < end {
+		return hour >= start && hour < end
+	}
+	return hour >= start || hour < end
+}
+
+// QuietHoursEndAfter returns when the quiet hours that t falls in end (t itself if it is not in quiet hours)
+func (p *NotificationPreferences) QuietHoursEndAfter(t time.Time) time.Time {
+	if !p.IsQuietAt(t) {
+		return t
+	}
+	end := time.Date(t.Year(), t.Month(), t.Day(), *p.QuietHoursEnd, 0, 0, 0, t.Location())
+	if !end.After(t) {
+		end = end.AddDate(0, 0, 1)
+	}
+	return end
+}
+
+// GetFormattedQuietHours returns the quiet hours as "21:00–09:00" ("" if there are none)
+func (p *NotificationPreferences) GetFormattedQuietHours() string {
+	if p.QuietHoursStart == nil || p.QuietHoursEnd == nil {
+		return ""
+	}
+	return fmt.Sprintf("%02d:00–%02d:00", *p.QuietHoursStart, *p.QuietHoursEnd)
+}
@@ -0,0 +1,177 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func intPtr(v int) *int { return &v }
+
+func TestNotificationPreferences_IsQuietAt(t *testing.T) {
+	at := func(hour int) time.Time { return time.Date(2025, 7, 1, hour, 30, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name       string
+		start, end *int
+		hour       int
+		want       bool
+	}{
+		{"no quiet hours", nil, nil, 3, false},
+		{"overnight, late evening", intPtr(21), intPtr(9), 22, true},
+		{"overnight, early morning", intPtr(21), intPtr(9), 8, true},
+		{"overnight, end hour", intPtr(21), intPtr(9), 9, false},
+		{"overnight, daytime", intPtr(21), intPtr(9), 14, false},
+		{"daytime, inside", intPtr(13), intPtr(15), 14, true},
+		{"daytime, outside", intPtr(13), intPtr(15), 15, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &NotificationPreferences{QuietHoursStart: tt.start, QuietHoursEnd: tt.end}
+			if got := p.IsQuietAt(at(tt.hour)); got != tt.want {
+				t.Errorf("IsQuietAt(%d:30) = %v, want %v", tt.hour, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNotificationPreferences_Validate(t *testing.T) {
+	tenantID := 3
+	valid := func() *NotificationPreferences {
+		return DefaultNotificationPreferences(NotificationRecipientTenant, &tenantID)
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(p *NotificationPreferences)
+		wantErr string
+	}{
+		{"defaults", func(p *NotificationPreferences) {}, ""},
+		{"no quiet hours", func(p *NotificationPreferences) { p.QuietHoursStart, p.QuietHoursEnd = nil, nil }, ""},
+		{"tenant without ID", func(p *NotificationPreferences) { p.TenantID = nil }, "tenant ID is required"},
+		{"owner with tenant", func(p *NotificationPreferences) { p.Recipient = NotificationRecipientOwner }, "owner preferences cannot have a tenant"},
+		{"duplicate channel", func(p *NotificationPreferences) { p.Channels = []string{"sms", "sms"} }, "listed twice"},
+		{"unknown type", func(p *NotificationPreferences) { p.MutedTypes = []NotificationType{"birthday"} }, "invalid notification type"},
+		{"half quiet hours", func(p *NotificationPreferences) { p.QuietHoursEnd = nil }, "both a start and an end"},
+		{"quiet hour out of range", func(p *NotificationPreferences) { p.QuietHoursEnd = intPtr(24) }, "between 0 and 23"},
+		{"empty quiet hours", func(p *NotificationPreferences) { p.QuietHoursEnd = intPtr(21) }, "same hour"},
+		{"unsupported language", func(p *NotificationPreferences) { p.Language = "fr" }, "unsupported language"},
+		{"reminder too early", func(p *NotificationPreferences) { p.ReminderDays = []int{MaxReminderLeadDays + 1} }, "reminder days must be between"},
+		{"duplicate reminder day", func(p *NotificationPreferences) { p.ReminderDays = []int{1, 1} }, "listed twice"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := valid()
+			tt.modify(p)
+			err := p.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNotificationPreferences_WantsReminderDaysBefore(t *testing.T) {
+	p := DefaultNotificationPreferences(NotificationRecipientOwner, nil)
+	if !p.WantsReminderDaysBefore(0) || p.WantsReminderDaysBefore(5) {
+		t.Errorf("owner defaults should remind on the due date only")
+	}
+
+	p.ReminderDays = []int{1, 7, 3}
+	if err := p.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if p.ReminderDays[0] != 7 || p.ReminderDays[2] != 1 {
+		t.Errorf("ReminderDays = %v, want sorted descending", p.ReminderDays)
+	}
+	if !p.WantsReminderDaysBefore(3) {
+		t.Errorf("expected a reminder 3 days before")
+	}
+
+	p.MutedTypes = []NotificationType{NotificationTypeDueDateReminder}
+	if p.WantsReminderDaysBefore(3) || !p.Wants(NotificationTypeLeaseExpiry) {
+		t.Errorf("muting due date reminders should only mute those")
+	}
+}
+
+func TestFormatNotification(t *testing.T) {
+	subject, body := FormatNotification(LanguageHindi, MessageDueDateReminderTenant, 12000, "A-101", "Aug 5, 2025", "rent@upi")
+	if subject != "किराया अनुस्मारक" || !strings.Contains(body, "A-101 का आपका ₹12000 किराया Aug 5, 2025") || !strings.Contains(body, "rent@upi") {
+		t.Errorf("unexpected hindi message: %q, %q", subject, body)
+	}
+
+	_, body = FormatNotification("fr", MessageDueDateReminderOwner, "Aug 5, 2025", "Ravi", "A-101", 12000)
+	if body != "📅 Reminder: On Aug 5, 2025, Ravi (A-101) has to pay ₹12000" {
+		t.Errorf("unexpected fallback message: %q", body)
+	}
+
+	for key, texts := range notificationMessages {
+		if _, ok := texts[LanguageEnglish]; !ok {
+			t.Errorf("message %s has no English text", key)
+		}
+	}
+}
+
+func TestNotificationTypesFor(t *testing.T) {
+	for _, nt := range NotificationTypesFor(NotificationRecipientTenant) {
+		if nt == NotificationTypeLeaseExpiry {
+			t.Errorf("tenants should not be offered lease expiry alerts")
+		}
+	}
+	ownerTypes := make(map[NotificationType]bool)
+	for _, nt := range NotificationTypesFor(NotificationRecipientOwner) {
+		ownerTypes[nt] = true
+	}
+	if !ownerTypes[NotificationTypePaymentSubmitted] || !ownerTypes[NotificationTypePaymentOverdue] {
+		t.Errorf("owner should be offered payment submissions and overdue notices, got %v", ownerTypes)
+	}
+	if ownerTypes[NotificationTypePaymentRejected] || ownerTypes[NotificationTypePasswordReset] {
+		t.Errorf("owner should not be offered tenant-only or required types, got %v", ownerTypes)
+	}
+
+	for _, info := range notificationTypes {
+		if _, ok := notificationSubjectKeys[info.Type]; !ok {
+			t.Errorf("notification type %s has no subject", info.Type)
+		}
+	}
+}
+
+func TestNotificationPreferences_RequiredTypes(t *testing.T) {
+	tenantID := 1
+	p := DefaultNotificationPreferences(NotificationRecipientTenant, &tenantID)
+	p.MutedTypes = []NotificationType{NotificationTypePasswordReset}
+	if err := p.Validate(); err == nil || !strings.Contains(err.Error(), "cannot be turned off") {
+		t.Errorf("Validate() error = %v, want required type error", err)
+	}
+	if !p.Wants(NotificationTypePasswordReset) {
+		t.Errorf("password resets should always be wanted")
+	}
+}
+
+func TestNotificationPreferences_QuietHoursEndAfter(t *testing.T) {
+	p := &NotificationPreferences{QuietHoursStart: intPtr(21), QuietHoursEnd: intPtr(9)}
+	tests := []struct {
+		name string
+		at   time.Time
+		want time.Time
+	}{
+		{"late evening", time.Date(2025, 8, 5, 22, 30, 0, 0, time.UTC), time.Date(2025, 8, 6, 9, 0, 0, 0, time.UTC)},
+		{"early morning", time.Date(2025, 8, 6, 3, 0, 0, 0, time.UTC), time.Date(2025, 8, 6, 9, 0, 0, 0, time.UTC)},
+		{"daytime", time.Date(2025, 8, 6, 14, 0, 0, 0, time.UTC), time.Date(2025, 8, 6, 14, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := p.QuietHoursEndAfter(tt.at); !got.Equal(tt.want) {
+				t.Errorf("QuietHoursEndAfter(%v) = %v, want %v", tt.at, got, tt.want)
+			}
+		})
+	}
+}
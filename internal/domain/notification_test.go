@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNotificationRetryDelay(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{1, 2 * time.Minute},
+		{2, 4 * time.Minute},
+		{4, 16 * time.Minute},
+		{20, NotificationRetryMaxDelay},
+	}
+	for _, tt := range tests {
+		if got := NotificationRetryDelay(tt.attempts); got != tt.want {
+			t.Errorf("NotificationRetryDelay(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestNotification_RecordAttempt(t *testing.T) {
+	now := time.Date(2025, 8, 5, 10, 0, 0, 0, time.UTC)
+	n := &Notification{Status: NotificationStatusPending}
+
+	n.RecordAttempt("telegram", "123", errors.New("timeout"), now)
+	if n.Status != NotificationStatusPending || n.Attempts != 1 || n.Error != "timeout" {
+		t.Fatalf("after a failure: status=%s attempts=%d error=%q", n.Status, n.Attempts, n.Error)
+	}
+	if n.NextAttemptAt == nil || !n.NextAttemptAt.Equal(now.Add(2*time.Minute)) {
+		t.Errorf("NextAttemptAt = %v, want %v", n.NextAttemptAt, now.Add(2*time.Minute))
+	}
+	if n.IsDueAt(now) || !n.IsDueAt(now.Add(2*time.Minute)) {
+		t.Errorf("retry should be due after the backoff only")
+	}
+
+	for n.Status == NotificationStatusPending {
+		n.RecordAttempt("telegram", "123", errors.New("timeout"), now)
+	}
+	if n.Status != NotificationStatusDead || n.Attempts != NotificationMaxAttempts || n.NextAttemptAt != nil {
+		t.Errorf("expected dead letter after %d attempts, got status=%s attempts=%d", NotificationMaxAttempts, n.Status, n.Attempts)
+	}
+
+	if err := n.Requeue(now); err != nil {
+		t.Fatalf("Requeue() error = %v", err)
+	}
+	if n.Status != NotificationStatusPending || n.Attempts != 0 || !n.IsDueAt(now) {
+		t.Errorf("requeued notification should be pending and due, got status=%s attempts=%d", n.Status, n.Attempts)
+	}
+
+	n.RecordAttempt("email", "a@example.com", nil, now)
+	if n.Status != NotificationStatusSent || n.SentAt == nil || n.Error != "" || n.SentVia != "email" {
+		t.Errorf("expected sent notification, got status=%s error=%q via=%s", n.Status, n.Error, n.SentVia)
+	}
+	if err := n.Requeue(now); err == nil {
+		t.Errorf("sent notifications should not be retried")
+	}
+}
+
+func TestNotificationDedupeKey(t *testing.T) {
+	tenantID, paymentID := 3, 12
+	got := NotificationDedupeKey(NotificationTypePaymentVerified, NotificationRecipientTenant, &tenantID, &paymentID, "UTR123")
+	if got != "payment_verified:tenant:3:12:UTR123" {
+		t.Errorf("NotificationDedupeKey() = %q", got)
+	}
+	if NotificationDedupeKey(NotificationTypeLeaseExpiry, NotificationRecipientOwner, nil, nil, "x") != "lease_expiry:owner:0:0:x" {
+		t.Errorf("missing tenant and payment should be 0")
+	}
+}
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -12,6 +13,7 @@ type Payment struct {
 	UnitID           int        `json:"unit_id" db:"unit_id"`
 	Amount           int        `json:"amount" db:"amount"`
 	AmountPaid       int        `json:"amount_paid" db:"amount_paid"`
+	AmountAdjusted   int        `json:"amount_adjusted" db:"amount_adjusted"` // Discounts, waivers, write-offs and balance moved into installment plans (not cash)
 	RemainingBalance int        `json:"remaining_balance" db:"remaining_balance"`
 	PaymentDate      *time.Time `json:"payment_date" db:"payment_date"`
 	DueDate          time.Time  `json:"due_date" db:"due_date"`
@@ -21,21 +23,24 @@ type Payment struct {
 	PaymentMethod    string     `json:"payment_method" db:"payment_method"`
 	UPIID            string     `json:"upi_id" db:"upi_id"`
 	Notes            string     `json:"notes" db:"notes"`
-	Label            string     `json:"label" db:"label"` // Payment type: rent, water_bill, current_bill, maintenance
+	Label            string     `json:"label" db:"label"` // Charge category code: rent, water_bill, parking, late_fee, ...
 	CreatedAt        time.Time  `json:"created_at" db:"created_at"`
 
 	// Related data (populated by joins)
 	Tenant       *Tenant               `json:"tenant,omitempty"`
 	Unit         *Unit                 `json:"unit,omitempty"`
+	Category     *ChargeCategory       `json:"category,omitempty"`
 	Transactions []*PaymentTransaction `json:"transactions,omitempty"`
 }
 
-// Payment label constants
+// Built-in payment label constants (see charge_categories for the full, owner-managed list)
 const (
 	PaymentLabelRent        = "rent"
 	PaymentLabelWaterBill   = "water_bill"
 	PaymentLabelCurrentBill = "current_bill"
 	PaymentLabelMaintenance = "maintenance"
+	PaymentLabelLateFee     = "late_fee"
+	PaymentLabelInstallment = "installment"
 )
 
 // Validate validates the payment data
@@ -55,21 +60,19 @@ func (p *Payment) Validate() error {
 	if p.Label == "" {
 		p.Label = PaymentLabelRent // Default to rent if not specified
 	}
-	// Validate label is one of the allowed values
-	validLabels := map[string]bool{
-		PaymentLabelRent:        true,
-		PaymentLabelWaterBill:   true,
-		PaymentLabelCurrentBill: true,
-		PaymentLabelMaintenance: true,
-	}
-	if !validLabels[p.Label] {
-		return fmt.Errorf("invalid payment label: %s. Must be one of: rent, water_bill, current_bill, maintenance", p.Label)
+	// Labels are owner-managed charge categories; existence is checked by the service
+	if !chargeCategoryCodePattern.MatchString(p.Label) {
+		return fmt.Errorf("invalid payment label: %s", p.Label)
 	}
 	return nil
 }
 
 // GetLabelDisplayName returns a human-readable label name
+// Uses the charge category when loaded, otherwise derives it from the label code
 func (p *Payment) GetLabelDisplayName() string {
+	if p.Category != nil && p.Category.DisplayName != "" {
+		return p.Category.DisplayName
+	}
 	switch p.Label {
 	case PaymentLabelRent:
 		return "Rent"
@@ -79,8 +82,19 @@ func (p *Payment) GetLabelDisplayName() string {
 		return "Current Bill"
 	case PaymentLabelMaintenance:
 		return "Maintenance"
+	case PaymentLabelLateFee:
+		return "Late Fee"
+	case PaymentLabelInstallment:
+		return "Installment"
 	default:
-		return p.Label
+		// "society_fees" -> "Society Fees"
+		words := strings.Split(p.Label, "_")
+		for i, word := range words {
+			if word != "" {
+				words[i] = strings.ToUpper(word[:1]) + word[1:]
+			}
+		}
+		return strings.Join(words, " ")
 	}
 }
 
@@ -96,8 +110,9 @@ func (p *Payment) GetStatus() string {
 }
 
 // RecalculateBalance recalculates remaining balance and is_fully_paid status
+// Adjustments reduce what is owed without counting as money received
 func (p *Payment) RecalculateBalance() {
-	p.RemainingBalance = p.Amount - p.AmountPaid
+	p.RemainingBalance = p.Amount - p.AmountAdjusted - p.AmountPaid
 	p.IsFullyPaid = (p.RemainingBalance <= 0)
 	if p.IsFullyPaid && p.FullyPaidDate == nil {
 		now := time.Now()
@@ -106,9 +121,10 @@ func (p *Payment) RecalculateBalance() {
 }
 
 // HasPendingVerification checks if payment has any unverified transactions
+// Rejected transactions are not awaiting verification
 func (p *Payment) HasPendingVerification() bool {
 	for _, tx := range p.Transactions {
-		if tx != nil && tx.VerifiedAt == nil {
+		if tx != nil && tx.IsPending() {
 			return true
 		}
 	}
@@ -170,6 +186,11 @@ func (p *Payment) GetFormattedAmountPaid() string {
 	return fmt.Sprintf("₹%d", p.AmountPaid)
 }
 
+// GetFormattedAmountAdjusted returns the total of discounts, waivers and write-offs formatted as currency
+func (p *Payment) GetFormattedAmountAdjusted() string {
+	return fmt.Sprintf("₹%d", p.AmountAdjusted)
+}
+
 // GetFormattedRemainingBalance returns the remaining balance formatted as currency
 func (p *Payment) GetFormattedRemainingBalance() string {
 	return fmt.Sprintf("₹%d", p.RemainingBalance)
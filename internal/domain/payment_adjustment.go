@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// PaymentAdjustment reduces what a tenant owes on a payment without any money changing hands
+// Adjustments are never edited or deleted, so together they form the audit trail of forgiven amounts
+type PaymentAdjustment struct {
+	ID              int       `json:"id" db:"id"`
+	PaymentID       int       `json:"payment_id" db:"payment_id"`
+	TenantID        int       `json:"tenant_id" db:"tenant_id"`
+	UnitID          int       `json:"unit_id" db:"unit_id"`
+	AdjustmentType  string    `json:"adjustment_type" db:"adjustment_type"` // discount, waiver, write_off
+	Amount          int       `json:"amount" db:"amount"`
+	Reason          string    `json:"reason" db:"reason"`
+	CreatedByUserID *int      `json:"created_by_user_id,omitempty" db:"created_by_user_id"` // The owner who made the adjustment
+	CreatedAt       time.Time `json:"created_at" db:"created_at"`
+
+	// Related data (populated by joins)
+	PropertyID int `json:"property_id,omitempty"`
+}
+
+// Payment adjustment type constants
+const (
+	PaymentAdjustmentDiscount = "discount"  // Agreed reduction, e.g. for a repair the tenant paid for
+	PaymentAdjustmentWaiver   = "waiver"    // Charge forgiven as a goodwill gesture
+	PaymentAdjustmentWriteOff = "write_off" // Amount given up as uncollectable
+)
+
+// Validate validates the adjustment data
+func (a *PaymentAdjustment) Validate() error {
+	if a.PaymentID <= 0 {
+		return fmt.Errorf("payment ID is required")
+	}
+	switch a.AdjustmentType {
+	case PaymentAdjustmentDiscount, PaymentAdjustmentWaiver, PaymentAdjustmentWriteOff:
+	default:
+		return fmt.Errorf("invalid adjustment type: %s. Must be one of: discount, waiver, write_off", a.AdjustmentType)
+	}
+	if a.Amount <= 0 {
+		return fmt.Errorf("amount must be greater than 0")
+	}
+	if strings.TrimSpace(a.Reason) == "" {
+		return fmt.Errorf("reason is required")
+	}
+	return nil
+}
+
+// ApplyAdjustment reduces the balance of a payment by an adjustment amount
+// An adjustment can never exceed what is still owed
+func (p *Payment) ApplyAdjustment(amount int) error {
+	if amount <= 0 {
+		return fmt.Errorf("amount must be greater than 0")
+	}
+	if amount > p.RemainingBalance {
+		return fmt.Errorf("adjustment of ₹%d exceeds the ₹%d still owed", amount, p.RemainingBalance)
+	}
+	p.AmountAdjusted += amount
+	p.RecalculateBalance()
+	return nil
+}
+
+// GetTypeDisplayName returns a human-readable adjustment type
+func (a *PaymentAdjustment) GetTypeDisplayName() string {
+	switch a.AdjustmentType {
+	case PaymentAdjustmentDiscount:
+		return "Discount"
+	case PaymentAdjustmentWaiver:
+		return "Waiver"
+	case PaymentAdjustmentWriteOff:
+		return "Write-off"
+	default:
+		return a.AdjustmentType
+	}
+}
+
+// GetFormattedAmount returns the adjustment amount formatted as currency
+func (a *PaymentAdjustment) GetFormattedAmount() string {
+	return fmt.Sprintf("₹%d", a.Amount)
+}
+
+// GetFormattedCreatedAt returns the adjustment date formatted for display
+func (a *PaymentAdjustment) GetFormattedCreatedAt() string {
+	return a.CreatedAt.Format("Jan 2, 2006")
+}
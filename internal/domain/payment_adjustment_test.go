@@ -0,0 +1,51 @@
+package domain
+
+import "testing"
+
+func TestPayment_ApplyAdjustment(t *testing.T) {
+	payment := &Payment{Amount: 10000, AmountPaid: 4000}
+	payment.RecalculateBalance()
+
+	if err := payment.ApplyAdjustment(1000); err != nil {
+		t.Fatalf("ApplyAdjustment() error = %v", err)
+	}
+	if payment.RemainingBalance != 5000 || payment.IsFullyPaid {
+		t.Errorf("after discount: remaining = %d, fully paid = %v; want 5000, false", payment.RemainingBalance, payment.IsFullyPaid)
+	}
+	if payment.AmountPaid != 4000 {
+		t.Errorf("adjustments must not count as cash: amount paid = %d, want 4000", payment.AmountPaid)
+	}
+
+	if err := payment.ApplyAdjustment(5001); err == nil {
+		t.Errorf("expected error when adjusting more than is owed")
+	}
+
+	if err := payment.ApplyAdjustment(5000); err != nil {
+		t.Fatalf("ApplyAdjustment() error = %v", err)
+	}
+	if payment.RemainingBalance != 0 || !payment.IsFullyPaid || payment.AmountAdjusted != 6000 {
+		t.Errorf("after write-off: remaining = %d, fully paid = %v, adjusted = %d; want 0, true, 6000",
+			payment.RemainingBalance, payment.IsFullyPaid, payment.AmountAdjusted)
+	}
+}
+
+func TestPaymentAdjustment_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		adjustment *PaymentAdjustment
+		wantErr    bool
+	}{
+		{"valid waiver", &PaymentAdjustment{PaymentID: 1, AdjustmentType: PaymentAdjustmentWaiver, Amount: 500, Reason: "Festival goodwill"}, false},
+		{"unknown type", &PaymentAdjustment{PaymentID: 1, AdjustmentType: "refund", Amount: 500, Reason: "x"}, true},
+		{"missing reason", &PaymentAdjustment{PaymentID: 1, AdjustmentType: PaymentAdjustmentDiscount, Amount: 500, Reason: "  "}, true},
+		{"zero amount", &PaymentAdjustment{PaymentID: 1, AdjustmentType: PaymentAdjustmentWriteOff, Reason: "Tenant absconded"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.adjustment.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
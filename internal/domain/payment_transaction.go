@@ -7,6 +7,7 @@ import (
 
 // PaymentTransaction represents a transaction submitted by a tenant
 // for a payment. The amount is NULL until the owner verifies it.
+// Rejected transactions are kept with the reason so the tenant can resubmit.
 type PaymentTransaction struct {
 	ID               int        `json:"id" db:"id"`
 	PaymentID        int        `json:"payment_id" db:"payment_id"`
@@ -15,6 +16,9 @@ type PaymentTransaction struct {
 	SubmittedAt      time.Time  `json:"submitted_at" db:"submitted_at"`
 	VerifiedAt       *time.Time `json:"verified_at" db:"verified_at"`
 	VerifiedByUserID *int       `json:"verified_by_user_id" db:"verified_by_user_id"`
+	RejectedAt       *time.Time `json:"rejected_at,omitempty" db:"rejected_at"`
+	RejectedByUserID *int       `json:"rejected_by_user_id,omitempty" db:"rejected_by_user_id"`
+	RejectionReason  string     `json:"rejection_reason,omitempty" db:"rejection_reason"`
 	Notes            string     `json:"notes" db:"notes"`
 	CreatedAt        time.Time  `json:"created_at" db:"created_at"`
 
@@ -27,9 +31,14 @@ func (pt *PaymentTransaction) IsVerified() bool {
 	return pt.VerifiedAt != nil && pt.Amount != nil
 }
 
+// IsRejected returns true if the owner rejected the transaction
+func (pt *PaymentTransaction) IsRejected() bool {
+	return pt.RejectedAt != nil
+}
+
 // IsPending returns true if transaction is pending verification
 func (pt *PaymentTransaction) IsPending() bool {
-	return !pt.IsVerified()
+	return !pt.IsVerified() && !pt.IsRejected()
 }
 
 // GetFormattedAmount returns the amount formatted as currency, or "Not verified" if NULL
@@ -52,3 +61,20 @@ func (pt *PaymentTransaction) GetFormattedVerifiedAt() string {
 	}
 	return pt.VerifiedAt.Format("Jan 2, 2006 3:04 PM")
 }
+
+// GetFormattedRejectedAt returns the rejected date formatted, or "Not rejected"
+func (pt *PaymentTransaction) GetFormattedRejectedAt() string {
+	if pt.RejectedAt == nil {
+		return "Not rejected"
+	}
+	return pt.RejectedAt.Format("Jan 2, 2006 3:04 PM")
+}
+
+// GetSuggestedAmount returns the amount the tenant reported when submitting, if any
+func (pt *PaymentTransaction) GetSuggestedAmount() (int, bool) {
+	var amount int
+	if _, err := fmt.Sscanf(pt.Notes, "Suggested amount: ₹%d", &amount); err != nil {
+		return 0, false
+	}
+	return amount, true
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Property represents a building that groups rental units
+type Property struct {
+	ID        int       `json:"id" db:"id"`
+	Name      string    `json:"name" db:"name"`
+	Address   string    `json:"address" db:"address"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+
+	// Related data (populated by joins)
+	Units []*Unit `json:"units,omitempty"`
+}
+
+// Validate validates the property data
+func (p *Property) Validate() error {
+	if strings.TrimSpace(p.Name) == "" {
+		return fmt.Errorf("property name is required")
+	}
+	return nil
+}
+
+// GetDisplayName returns a formatted display name for the property
+func (p *Property) GetDisplayName() string {
+	if p.Address == "" {
+		return p.Name
+	}
+	return fmt.Sprintf("%s (%s)", p.Name, p.Address)
+}
@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// RentProration is the breakdown of a rent payment charged for part of a month
+// The first payment covers the days from move-in to the end of that month; the final
+// payment covers the days from the start of the month to move-out.
+type RentProration struct {
+	ID               int       `json:"id" db:"id"`
+	PaymentID        int       `json:"payment_id" db:"payment_id"`
+	TenantID         int       `json:"tenant_id" db:"tenant_id"`
+	Kind             string    `json:"kind" db:"kind"`                           // first, final
+	Policy           string    `json:"policy" db:"policy"`                       // actual_days, thirty_day
+	MonthlyRent      int       `json:"monthly_rent" db:"monthly_rent"`           // Full rent the proration is based on
+	PeriodStart      time.Time `json:"period_start" db:"period_start"`           // First day charged
+	PeriodEnd        time.Time `json:"period_end" db:"period_end"`               // Last day charged
+	DaysCharged      int       `json:"days_charged" db:"days_charged"`           // Days in the period
+	DaysInMonth      int       `json:"days_in_month" db:"days_in_month"`         // Actual days, or 30 under the 30-day policy
+	ProratedAmount   int       `json:"prorated_amount" db:"prorated_amount"`     // Rent for the partial period
+	AdditionalAmount int       `json:"additional_amount" db:"additional_amount"` // Full month's rent charged on the same payment (0 if none)
+	CreatedAt        time.Time `json:"created_at" db:"created_at"`
+}
+
+// Proration policy constants
+const (
+	ProrationPolicyNone       = "none"        // Always charge the full month
+	ProrationPolicyActualDays = "actual_days" // Daily rate = rent / days in the calendar month
+	ProrationPolicyThirtyDay  = "thirty_day"  // Daily rate = rent / 30, every month counted as 30 days
+)
+
+// Proration kind constants
+const (
+	ProrationKindFirst = "first"
+	ProrationKindFinal = "final"
+)
+
+// ValidateProrationPolicy checks that a proration policy is known
+func ValidateProrationPolicy(policy string) error {
+	switch policy {
+	case ProrationPolicyNone, ProrationPolicyActualDays, ProrationPolicyThirtyDay:
+		return nil
+	}
+	return fmt.Errorf("invalid proration policy: %s. Must be one of: none, actual_days, thirty_day", policy)
+}
+
+// daysInMonth returns the number of days in the month of t
+func daysInMonth(t time.Time) int {
+	return time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, t.Location()).Day()
+}
+
+// ProrateRent returns the rent for the days from start to end (inclusive, within one month)
+// along with the days charged and the days the month counts for under the policy
+func ProrateRent(policy string, monthlyRent int, start, end time.Time) (amount, daysCharged, monthDays int) {
+	monthDays = daysInMonth(start)
+	startDay, endDay := start.Day(), end.Day()
+
+	if policy == ProrationPolicyThirtyDay {
+		// Every month counts as 30 days; the month's last day is day 30
+		if endDay == monthDays || endDay > 30 {
+			endDay = 30
+		}
+		if startDay > 30 {
+			startDay = 30
+		}
+		monthDays = 30
+	}
+
+	daysCharged = endDay - startDay + 1
+	if daysCharged <= 0 {
+		return 0, 0, monthDays
+	}
+	if daysCharged >= monthDays {
+		return monthlyRent, monthDays, monthDays
+	}
+
+	amount = int(math.Round(float64(monthlyRent) * float64(daysCharged) / float64(monthDays)))
+	return amount, daysCharged, monthDays
+}
+
+// IsPartialMonth returns true if the period does not cover the whole month
+func (p *RentProration) IsPartialMonth() bool {
+	return p.DaysCharged < p.DaysInMonth
+}
+
+// GetTotalAmount returns the amount charged on the payment
+func (p *RentProration) GetTotalAmount() int {
+	return p.ProratedAmount + p.AdditionalAmount
+}
+
+// GetDescription returns the calculation in words, stored in the payment notes
+func (p *RentProration) GetDescription() string {
+	desc := fmt.Sprintf(
+		"Prorated rent %s – %s: ₹%d × %d/%d days = ₹%d",
+		p.PeriodStart.Format("Jan 2"),
+		p.PeriodEnd.Format("Jan 2, 2006"),
+		p.MonthlyRent,
+		p.DaysCharged,
+		p.DaysInMonth,
+		p.ProratedAmount,
+	)
+	if p.AdditionalAmount > 0 {
+		desc += fmt.Sprintf(" + next month ₹%d = ₹%d", p.AdditionalAmount, p.GetTotalAmount())
+	}
+	return desc
+}
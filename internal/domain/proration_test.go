@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestProrateRent(t *testing.T) {
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2025, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name       string
+		policy     string
+		start, end time.Time
+		wantAmount int
+		wantDays   int
+		wantMonth  int
+	}{
+		{"actual days, move-in mid-month", ProrationPolicyActualDays, date(time.March, 20), date(time.March, 31), 3871, 12, 31},
+		{"actual days, february", ProrationPolicyActualDays, date(time.February, 15), date(time.February, 28), 5000, 14, 28},
+		{"thirty day, move-in mid-month", ProrationPolicyThirtyDay, date(time.March, 20), date(time.March, 31), 3667, 11, 30},
+		{"thirty day, february to month end", ProrationPolicyThirtyDay, date(time.February, 15), date(time.February, 28), 5333, 16, 30},
+		{"thirty day, move-out mid-month", ProrationPolicyThirtyDay, date(time.April, 1), date(time.April, 10), 3333, 10, 30},
+		{"whole month", ProrationPolicyActualDays, date(time.April, 1), date(time.April, 30), 10000, 30, 30},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			amount, days, monthDays := ProrateRent(tt.policy, 10000, tt.start, tt.end)
+			if amount != tt.wantAmount || days != tt.wantDays || monthDays != tt.wantMonth {
+				t.Errorf("ProrateRent() = (%d, %d, %d), want (%d, %d, %d)", amount, days, monthDays, tt.wantAmount, tt.wantDays, tt.wantMonth)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Receipt is an immutable proof of payment issued when a transaction is verified
+// One receipt is issued per payment a verified transaction was allocated to. Tenant, unit
+// and payee details are copied at issue time so the document never changes afterwards.
+// Corrections are made by issuing a credit note (a Receipt of type credit_note) against it.
+type Receipt struct {
+	ID                    int       `json:"id" db:"id"`
+	ReceiptNumber         string    `json:"receipt_number" db:"receipt_number"` // e.g. RCT/2025-26/000012
+	DocumentType          string    `json:"document_type" db:"document_type"`   // receipt, credit_note
+	FinancialYear         string    `json:"financial_year" db:"financial_year"` // e.g. 2025-26
+	TransactionID         string    `json:"transaction_id" db:"transaction_id"` // UTR of the verified transaction
+	PaymentID             int       `json:"payment_id" db:"payment_id"`
+	TenantID              int       `json:"tenant_id" db:"tenant_id"`
+	TenantName            string    `json:"tenant_name" db:"tenant_name"`
+	UnitCode              string    `json:"unit_code" db:"unit_code"`
+	PropertyName          string    `json:"property_name" db:"property_name"`
+	PropertyAddress       string    `json:"property_address" db:"property_address"`
+	PayeeName             string    `json:"payee_name" db:"payee_name"`
+	PayeeUPIID            string    `json:"payee_upi_id" db:"payee_upi_id"`
+	Label                 string    `json:"label" db:"label"`           // Payment label (rent, maintenance, ...)
+	LabelName             string    `json:"label_name" db:"label_name"` // Display name of the label at issue time
+	Period                string    `json:"period" db:"period"`         // e.g. March 2025
+	Amount                int       `json:"amount" db:"amount"`         // Amount received (or credited back for credit notes)
+	BalanceAfter          int       `json:"balance_after" db:"balance_after"`
+	VerifiedAt            time.Time `json:"verified_at" db:"verified_at"`
+	OriginalReceiptID     *int      `json:"original_receipt_id,omitempty" db:"original_receipt_id"` // Credit notes only
+	OriginalReceiptNumber string    `json:"original_receipt_number,omitempty" db:"original_receipt_number"`
+	Reason                string    `json:"reason,omitempty" db:"reason"` // Why a credit note was issued
+	IssuedByUserID        *int      `json:"issued_by_user_id,omitempty" db:"issued_by_user_id"`
+	IssuedAt              time.Time `json:"issued_at" db:"issued_at"`
+}
+
+// Receipt document type constants
+const (
+	ReceiptTypeReceipt    = "receipt"
+	ReceiptTypeCreditNote = "credit_note"
+)
+
+// FinancialYear returns the Indian financial year (April to March) a date falls in, e.g. "2025-26"
+func FinancialYear(t time.Time) string {
+	start := t.Year()
+	if t.Month() < time.April {
+		start--
+	}
+	return fmt.Sprintf("%d-%02d", start, (start+1)%100)
+}
+
+// FormatReceiptNumber returns the document number for a sequence within a financial year
+func FormatReceiptNumber(documentType, financialYear string, sequence int) string {
+	prefix := "RCT"
+	if documentType == ReceiptTypeCreditNote {
+		prefix = "CN"
+	}
+	return fmt.Sprintf("%s/%s/%06d", prefix, financialYear, sequence)
+}
+
+// Validate validates the receipt data before it is issued
+func (r *Receipt) Validate() error {
+	switch r.DocumentType {
+	case ReceiptTypeReceipt:
+		if strings.TrimSpace(r.TransactionID) == "" {
+			return fmt.Errorf("transaction ID is required")
+		}
+	case ReceiptTypeCreditNote:
+		if r.OriginalReceiptID == nil {
+			return fmt.Errorf("credit note must reference the original receipt")
+		}
+		if strings.TrimSpace(r.Reason) == "" {
+			return fmt.Errorf("reason is required")
+		}
+	default:
+		return fmt.Errorf("invalid document type: %s", r.DocumentType)
+	}
+	if r.Amount <= 0 {
+		return fmt.Errorf("amount must be greater than 0")
+	}
+	if r.PaymentID <= 0 || r.TenantID <= 0 {
+		return fmt.Errorf("payment and tenant are required")
+	}
+	return nil
+}
+
+// NewCreditNote builds a credit note reversing part or all of a receipt
+// alreadyCredited is the total of earlier credit notes against the same receipt
+func NewCreditNote(original *Receipt, amount int, alreadyCredited int, reason string) (*Receipt, error) {
+	if original.DocumentType != ReceiptTypeReceipt {
+		return nil, fmt.Errorf("credit notes can only be issued against receipts")
+	}
+	if amount <= 0 {
+		return nil, fmt.Errorf("amount must be greater than 0")
+	}
+	if remaining := original.Amount - alreadyCredited; amount > remaining {
+		return nil, fmt.Errorf("credit of ₹%d exceeds the ₹%d left on receipt %s", amount, remaining, original.ReceiptNumber)
+	}
+
+	originalID := original.ID
+	note := *original
+	note.ID = 0
+	note.ReceiptNumber = ""
+	note.DocumentType = ReceiptTypeCreditNote
+	note.Amount = amount
+	note.OriginalReceiptID = &originalID
+	note.OriginalReceiptNumber = original.ReceiptNumber
+	note.Reason = strings.TrimSpace(reason)
+	note.IssuedByUserID = nil
+	return &note, note.Validate()
+}
+
+// IsCreditNote returns true for credit notes
+func (r *Receipt) IsCreditNote() bool {
+	return r.DocumentType == ReceiptTypeCreditNote
+}
+
+// GetTitle returns the document title
+func (r *Receipt) GetTitle() string {
+	if r.IsCreditNote() {
+		return "Credit Note"
+	}
+	return "Rent Receipt"
+}
+
+// GetFormattedAmount returns the amount formatted as currency
+func (r *Receipt) GetFormattedAmount() string {
+	return fmt.Sprintf("₹%d", r.Amount)
+}
+
+// GetFormattedBalanceAfter returns the remaining balance formatted as currency
+func (r *Receipt) GetFormattedBalanceAfter() string {
+	return fmt.Sprintf("₹%d", r.BalanceAfter)
+}
+
+// GetFormattedVerifiedAt returns the verification date formatted
+func (r *Receipt) GetFormattedVerifiedAt() string {
+	return r.VerifiedAt.Format("Jan 2, 2006")
+}
+
+// GetFormattedIssuedAt returns the issue date formatted
+func (r *Receipt) GetFormattedIssuedAt() string {
+	return r.IssuedAt.Format("Jan 2, 2006")
+}
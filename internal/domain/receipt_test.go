@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFinancialYear(t *testing.T) {
+	cases := []struct {
+		date time.Time
+		want string
+	}{
+		{time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC), "2025-26"},
+		{time.Date(2026, 3, 31, 23, 0, 0, 0, time.UTC), "2025-26"},
+		{time.Date(2000, 1, 15, 0, 0, 0, 0, time.UTC), "1999-00"},
+		{time.Date(2099, 12, 1, 0, 0, 0, 0, time.UTC), "2099-00"},
+	}
+	for _, c := range cases {
+		if got := FinancialYear(c.date); got != c.want {
+			t.Errorf("FinancialYear(%s) = %s, want %s", c.date.Format("2006-01-02"), got, c.want)
+		}
+	}
+}
+
+func TestFormatReceiptNumber(t *testing.T) {
+	if got := FormatReceiptNumber(ReceiptTypeReceipt, "2025-26", 12); got != "RCT/2025-26/000012" {
+		t.Errorf("receipt number = %s", got)
+	}
+	if got := FormatReceiptNumber(ReceiptTypeCreditNote, "2025-26", 3); got != "CN/2025-26/000003" {
+		t.Errorf("credit note number = %s", got)
+	}
+}
+
+func TestNewCreditNote(t *testing.T) {
+	original := &Receipt{
+		ID:            7,
+		ReceiptNumber: "RCT/2025-26/000007",
+		DocumentType:  ReceiptTypeReceipt,
+		TransactionID: "412345678901",
+		PaymentID:     3,
+		TenantID:      2,
+		Amount:        10000,
+	}
+
+	note, err := NewCreditNote(original, 4000, 0, " Bounced transfer ")
+	if err != nil {
+		t.Fatalf("NewCreditNote: %v", err)
+	}
+	if note.DocumentType != ReceiptTypeCreditNote || note.Amount != 4000 || *note.OriginalReceiptID != 7 ||
+		note.OriginalReceiptNumber != original.ReceiptNumber || note.Reason != "Bounced transfer" || note.ReceiptNumber != "" {
+		t.Errorf("unexpected credit note: %+v", note)
+	}
+	if original.DocumentType != ReceiptTypeReceipt || original.Amount != 10000 {
+		t.Error("NewCreditNote must not modify the original receipt")
+	}
+
+	if _, err := NewCreditNote(original, 7000, 4000, "Too much"); err == nil {
+		t.Error("expected error when crediting more than is left on the receipt")
+	}
+	if _, err := NewCreditNote(original, 1000, 0, " "); err == nil {
+		t.Error("expected error without a reason")
+	}
+	if _, err := NewCreditNote(note, 1000, 0, "Credit of a credit"); err == nil {
+		t.Error("expected error when crediting a credit note")
+	}
+}
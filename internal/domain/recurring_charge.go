@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// RecurringCharge is a monthly non-rent charge (maintenance, internet, parking) generated alongside rent
+// A schedule applies either to one tenant or to whoever occupies a unit; a tenant schedule wins over
+// a unit schedule for the same category.
+type RecurringCharge struct {
+	ID           int        `json:"id" db:"id"`
+	TenantID     *int       `json:"tenant_id,omitempty" db:"tenant_id"` // Set for tenant-specific schedules
+	UnitID       *int       `json:"unit_id,omitempty" db:"unit_id"`     // Set for unit-wide schedules
+	CategoryCode string     `json:"category_code" db:"category_code"`   // Charge category code, stored as the payment label
+	Amount       int        `json:"amount" db:"amount"`                 // Amount from the start date until the first change
+	DueDay       int        `json:"due_day" db:"due_day"`               // Day of month (0 = same day as rent)
+	StartDate    time.Time  `json:"start_date" db:"start_date"`
+	EndDate      *time.Time `json:"end_date,omitempty" db:"end_date"` // Last month charged (NULL = open-ended)
+	IsActive     bool       `json:"is_active" db:"is_active"`
+	Notes        string     `json:"notes" db:"notes"`
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at" db:"updated_at"`
+
+	// Related data (populated by joins)
+	AmountChanges []*RecurringChargeAmountChange `json:"amount_changes,omitempty"`
+}
+
+// RecurringChargeAmountChange changes the amount of a schedule from a given month onwards
+type RecurringChargeAmountChange struct {
+	ID                int       `json:"id" db:"id"`
+	RecurringChargeID int       `json:"recurring_charge_id" db:"recurring_charge_id"`
+	Amount            int       `json:"amount" db:"amount"`
+	EffectiveFrom     time.Time `json:"effective_from" db:"effective_from"` // First month the new amount applies
+	CreatedAt         time.Time `json:"created_at" db:"created_at"`
+}
+
+// Validate validates the recurring charge data
+func (c *RecurringCharge) Validate() error {
+	if (c.TenantID == nil) == (c.UnitID == nil) {
+		return fmt.Errorf("recurring charge must apply to either a tenant or a unit")
+	}
+	if c.CategoryCode == "" {
+		return fmt.Errorf("category is required")
+	}
+	if c.CategoryCode == PaymentLabelRent || c.CategoryCode == PaymentLabelLateFee {
+		return fmt.Errorf("'%s' cannot be scheduled as a recurring charge", c.CategoryCode)
+	}
+	if c.Amount <= 0 {
+		return fmt.Errorf("amount must be greater than 0")
+	}
+	if c.DueDay < 0 || c.DueDay > 28 {
+		return fmt.Errorf("due day must be between 1 and 28 (or 0 to follow rent)")
+	}
+	if c.StartDate.IsZero() {
+		return fmt.Errorf("start date is required")
+	}
+	if c.EndDate != nil && monthStart(*c.EndDate).Before(monthStart(c.StartDate)) {
+		return fmt.Errorf("end date cannot be before start date")
+	}
+	return nil
+}
+
+// monthStart truncates a date to the first day of its month
+func monthStart(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// IsDueIn returns true if the schedule charges for the given month
+func (c *RecurringCharge) IsDueIn(month time.Month, year int) bool {
+	if !c.IsActive {
+		return false
+	}
+	target := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	if target.Before(monthStart(c.StartDate)) {
+		return false
+	}
+	if c.EndDate != nil && target.After(monthStart(*c.EndDate)) {
+		return false
+	}
+	return true
+}
+
+// GetAmountFor returns the amount charged for the given month, taking amount changes into account
+func (c *RecurringCharge) GetAmountFor(month time.Month, year int) int {
+	target := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	amount := c.Amount
+	var latest time.Time
+	for _, change := range c.AmountChanges {
+		effective := monthStart(change.EffectiveFrom)
+		if effective.After(target) || effective.Before(latest) {
+			continue
+		}
+		amount = change.Amount
+		latest = effective
+	}
+	return amount
+}
+
+// GetDueDate returns the due date of the charge for a month
+// rentDueDay is used when the schedule has no due day of its own
+func (c *RecurringCharge) GetDueDate(month time.Month, year int, rentDueDay int) time.Time {
+	day := c.DueDay
+	if day == 0 {
+		day = rentDueDay
+	}
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// IsTenantSpecific returns true if the schedule belongs to a single tenant
+func (c *RecurringCharge) IsTenantSpecific() bool {
+	return c.TenantID != nil
+}
@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRecurringCharge_IsDueIn(t *testing.T) {
+	endDate := time.Date(2025, time.June, 30, 0, 0, 0, 0, time.UTC)
+	charge := &RecurringCharge{
+		StartDate: time.Date(2025, time.March, 15, 0, 0, 0, 0, time.UTC),
+		EndDate:   &endDate,
+		IsActive:  true,
+	}
+
+	tests := []struct {
+		month time.Month
+		want  bool
+	}{
+		{time.February, false},
+		{time.March, true}, // Start month counts even when starting mid-month
+		{time.June, true},
+		{time.July, false},
+	}
+
+	for _, tt := range tests {
+		if got := charge.IsDueIn(tt.month, 2025); got != tt.want {
+			t.Errorf("IsDueIn(%s) = %v, want %v", tt.month, got, tt.want)
+		}
+	}
+
+	charge.IsActive = false
+	if charge.IsDueIn(time.April, 2025) {
+		t.Errorf("inactive schedule should never be due")
+	}
+}
+
+func TestRecurringCharge_GetAmountFor(t *testing.T) {
+	charge := &RecurringCharge{
+		Amount:    1000,
+		StartDate: time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC),
+		AmountChanges: []*RecurringChargeAmountChange{
+			{Amount: 1500, EffectiveFrom: time.Date(2025, time.July, 1, 0, 0, 0, 0, time.UTC)},
+			{Amount: 1200, EffectiveFrom: time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC)},
+		},
+	}
+
+	tests := []struct {
+		month time.Month
+		want  int
+	}{
+		{time.March, 1000},
+		{time.April, 1200},
+		{time.June, 1200},
+		{time.July, 1500},
+	}
+
+	for _, tt := range tests {
+		if got := charge.GetAmountFor(tt.month, 2025); got != tt.want {
+			t.Errorf("GetAmountFor(%s) = %d, want %d", tt.month, got, tt.want)
+		}
+	}
+}
+
+func TestRecurringCharge_Validate(t *testing.T) {
+	tenantID, unitID := 1, 2
+	start := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	if err := (&RecurringCharge{TenantID: &tenantID, CategoryCode: "maintenance", Amount: 500, StartDate: start}).Validate(); err != nil {
+		t.Errorf("valid charge returned error: %v", err)
+	}
+	if err := (&RecurringCharge{TenantID: &tenantID, UnitID: &unitID, CategoryCode: "maintenance", Amount: 500, StartDate: start}).Validate(); err == nil {
+		t.Errorf("charge for both tenant and unit should be invalid")
+	}
+	if err := (&RecurringCharge{UnitID: &unitID, CategoryCode: PaymentLabelRent, Amount: 500, StartDate: start}).Validate(); err == nil {
+		t.Errorf("rent should not be schedulable as a recurring charge")
+	}
+}
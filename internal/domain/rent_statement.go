@@ -0,0 +1,224 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// RentStatement summarises the rent a tenant paid over one financial year (April to March)
+// Tenants use it for HRA and income tax declarations, which need the landlord's name, PAN and address.
+type RentStatement struct {
+	FinancialYear   string                `json:"financial_year"` // e.g. 2025-26
+	PeriodStart     time.Time             `json:"period_start"`   // April 1
+	PeriodEnd       time.Time             `json:"period_end"`     // March 31
+	TenantName      string                `json:"tenant_name"`
+	UnitCode        string                `json:"unit_code"`
+	PropertyName    string                `json:"property_name"`
+	PropertyAddress string                `json:"property_address"`
+	LandlordName    string                `json:"landlord_name"`
+	LandlordPAN     string                `json:"landlord_pan,omitempty"`
+	LandlordAddress string                `json:"landlord_address"`
+	Months          []*RentStatementMonth `json:"months"`
+	TotalRent       int                   `json:"total_rent"` // Rent charged for the months listed
+	TotalPaid       int                   `json:"total_paid"`
+	TotalAdjusted   int                   `json:"total_adjusted"` // Discounts, waivers and write-offs, not paid
+	GeneratedAt     time.Time             `json:"generated_at"`
+}
+
+// RentStatementMonth is one month of rent on a statement
+type RentStatementMonth struct {
+	PaymentID      int        `json:"payment_id"`
+	Period         string     `json:"period"` // e.g. April 2025
+	DueDate        time.Time  `json:"due_date"`
+	RentDue        int        `json:"rent_due"`
+	AmountPaid     int        `json:"amount_paid"`
+	AmountAdjusted int        `json:"amount_adjusted"`           // Forgiven rather than paid
+	PaidOn         *time.Time `json:"paid_on,omitempty"`         // Latest verification date, including installments
+	TransactionIDs []string   `json:"transaction_ids,omitempty"` // UTRs of the verified transactions, including installments
+}
+
+// ParseFinancialYear returns the first and last day of a financial year given as "2025-26"
+func ParseFinancialYear(financialYear string) (time.Time, time.Time, error) {
+	var start, end int
+	if _, err := fmt.Sscanf(strings.TrimSpace(financialYear), "%4d-%2d", &start, &end); err != nil || (start+1)%100 != end {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid financial year: %q (expected e.g. 2025-26)", financialYear)
+	}
+	first := time.Date(start, time.April, 1, 0, 0, 0, 0, time.UTC)
+	last := time.Date(start+1, time.March, 31, 0, 0, 0, 0, time.UTC)
+	return first, last, nil
+}
+
+// FinancialYearsBetween returns the financial years from one date to another, newest first
+func FinancialYearsBetween(from, to time.Time) []string {
+	var years []string
+	if to.Before(from) {
+		return years
+	}
+	first, _, _ := ParseFinancialYear(FinancialYear(from))
+	for fyStart := first; !fyStart.After(to); fyStart = fyStart.AddDate(1, 0, 0) {
+		years = append([]string{FinancialYear(fyStart)}, years...)
+	}
+	return years
+}
+
+// BuildRentStatement lists the rent paid for each month whose rent fell due in the financial year
+// Only rent payments with something paid towards them are included. Rent moved into an installment plan is
+// paid through the plan's installments: their cash is attributed back to the months the plan covers (see
+// planPaymentsByArrear), so those months show the rent actually paid and when, not the plan's creation.
+func BuildRentStatement(financialYear string, payments []*Payment, plans []*InstallmentPlan) (*RentStatement, error) {
+	first, last, err := ParseFinancialYear(financialYear)
+	if err != nil {
+		return nil, err
+	}
+
+	statement := &RentStatement{
+		FinancialYear: financialYear,
+		PeriodStart:   first,
+		PeriodEnd:     last,
+		Months:        []*RentStatementMonth{},
+		GeneratedAt:   time.Now(),
+	}
+
+	planPayments := planPaymentsByArrear(plans)
+	for _, payment := range payments {
+		if payment.Label != PaymentLabelRent || FinancialYear(payment.DueDate) != financialYear {
+			continue
+		}
+		viaPlan := planPayments[payment.ID]
+		if payment.AmountPaid+viaPlan.Paid <= 0 {
+			continue
+		}
+
+		month := &RentStatementMonth{
+			PaymentID:      payment.ID,
+			Period:         payment.DueDate.Format("January 2006"),
+			DueDate:        payment.DueDate,
+			RentDue:        payment.Amount,
+			AmountPaid:     payment.AmountPaid + viaPlan.Paid,
+			AmountAdjusted: payment.AmountAdjusted - viaPlan.Moved, // Moved into a plan is not forgiven
+		}
+		if viaPlan.Moved == 0 {
+			// A month moved into a plan was marked fully paid when the plan was created, not when it was paid
+			month.PaidOn = payment.FullyPaidDate
+		}
+		for _, tx := range payment.Transactions {
+			if !tx.IsVerified() {
+				continue
+			}
+			month.addPayment(tx.TransactionID, tx.VerifiedAt)
+		}
+		for _, tx := range viaPlan.Transactions {
+			month.addPayment(tx.TransactionID, tx.VerifiedAt)
+		}
+
+		statement.Months = append(statement.Months, month)
+		statement.TotalRent += month.RentDue
+		statement.TotalPaid += month.AmountPaid
+		statement.TotalAdjusted += month.AmountAdjusted
+	}
+
+	sort.Slice(statement.Months, func(i, j int) bool {
+		return statement.Months[i].DueDate.Before(statement.Months[j].DueDate)
+	})
+
+	return statement, nil
+}
+
+// addPayment records a verified transaction that paid towards the month
+func (m *RentStatementMonth) addPayment(transactionID string, verifiedAt *time.Time) {
+	for _, id := range m.TransactionIDs {
+		if id == transactionID {
+			return
+		}
+	}
+	m.TransactionIDs = append(m.TransactionIDs, transactionID)
+	if verifiedAt != nil && (m.PaidOn == nil || verifiedAt.After(*m.PaidOn)) {
+		m.PaidOn = verifiedAt
+	}
+}
+
+// planPayment is what an installment plan paid towards one of the payments it restructured
+type planPayment struct {
+	Moved        int                   // Balance moved into the plan and not restored by a cancellation
+	Paid         int                   // Installment cash attributed to the payment
+	Transactions []*PaymentTransaction // Verified installment transactions that paid it
+}
+
+// planPaymentsByArrear attributes the cash paid on installments back to the payments each plan restructured,
+// keyed by original payment ID. Installments are applied in sequence to the oldest arrears first.
+func planPaymentsByArrear(plans []*InstallmentPlan) map[int]planPayment {
+	result := make(map[int]planPayment)
+	for _, plan := range plans {
+		arrears := make([]*InstallmentPlanArrear, len(plan.Arrears))
+		copy(arrears, plan.Arrears)
+		sort.SliceStable(arrears, func(i, j int) bool {
+			if arrears[i].Payment == nil || arrears[j].Payment == nil {
+				return arrears[i].PaymentID < arrears[j].PaymentID
+			}
+			return arrears[i].Payment.DueDate.Before(arrears[j].Payment.DueDate)
+		})
+
+		owed := make([]int, len(arrears))
+		for i, arrear := range arrears {
+			owed[i] = arrear.Amount - arrear.RestoredAmount
+			entry := result[arrear.PaymentID]
+			entry.Moved += owed[i]
+			result[arrear.PaymentID] = entry
+		}
+
+		installments := make([]*Installment, len(plan.Installments))
+		copy(installments, plan.Installments)
+		sort.SliceStable(installments, func(i, j int) bool { return installments[i].Sequence < installments[j].Sequence })
+
+		next := 0
+		for _, installment := range installments {
+			if installment.Payment == nil {
+				continue
+			}
+			var verified []*PaymentTransaction
+			for _, tx := range installment.Payment.Transactions {
+				if tx.IsVerified() {
+					verified = append(verified, tx)
+				}
+			}
+
+			cash := installment.Payment.AmountPaid
+			for cash > 0 && next < len(arrears) {
+				paid := cash
+				if paid > owed[next] {
+					paid = owed[next]
+				}
+				entry := result[arrears[next].PaymentID]
+				entry.Paid += paid
+				entry.Transactions = append(entry.Transactions, verified...)
+				result[arrears[next].PaymentID] = entry
+
+				cash -= paid
+				if owed[next] -= paid; owed[next] == 0 {
+					next++
+				}
+			}
+		}
+	}
+	return result
+}
+
+// GetFormattedPeriod returns the statement period formatted, e.g. "Apr 1, 2025 - Mar 31, 2026"
+func (s *RentStatement) GetFormattedPeriod() string {
+	return s.PeriodStart.Format("Jan 2, 2006") + " - " + s.PeriodEnd.Format("Jan 2, 2006")
+}
+
+// GetFormattedTotalPaid returns the total rent paid formatted as currency
+func (s *RentStatement) GetFormattedTotalPaid() string {
+	return fmt.Sprintf("₹%d", s.TotalPaid)
+}
+
+// GetFormattedPaidOn returns the date the month's rent was last paid ("" if unknown)
+func (m *RentStatementMonth) GetFormattedPaidOn() string {
+	if m.PaidOn == nil {
+		return ""
+	}
+	return m.PaidOn.Format("Jan 2, 2006")
+}
@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseFinancialYear(t *testing.T) {
+	first, last, err := ParseFinancialYear("2025-26")
+	if err != nil {
+		t.Fatalf("ParseFinancialYear: %v", err)
+	}
+	if first != time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC) || last != time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC) {
+		t.Errorf("period = %s - %s", first, last)
+	}
+
+	for _, bad := range []string{"", "2025", "2025-27", "twenty"} {
+		if _, _, err := ParseFinancialYear(bad); err == nil {
+			t.Errorf("ParseFinancialYear(%q) should fail", bad)
+		}
+	}
+}
+
+func TestFinancialYearsBetween(t *testing.T) {
+	from := time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	got := FinancialYearsBetween(from, to)
+	want := []string{"2025-26", "2024-25", "2023-24"}
+	if len(got) != len(want) {
+		t.Fatalf("years = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("years = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestBuildRentStatement(t *testing.T) {
+	verifiedAt := time.Date(2025, 4, 6, 0, 0, 0, 0, time.UTC)
+	amount := 10000
+	payments := []*Payment{
+		{ID: 3, Label: PaymentLabelRent, Amount: 10000, AmountPaid: 4000, DueDate: time.Date(2025, 5, 5, 0, 0, 0, 0, time.UTC)},
+		{ID: 2, Label: PaymentLabelRent, Amount: 10000, AmountPaid: 10000, DueDate: time.Date(2025, 4, 5, 0, 0, 0, 0, time.UTC),
+			Transactions: []*PaymentTransaction{
+				{TransactionID: "UTR1", Amount: &amount, VerifiedAt: &verifiedAt},
+				{TransactionID: "PENDING"},
+			}},
+		{ID: 1, Label: PaymentLabelRent, Amount: 10000, AmountPaid: 10000, DueDate: time.Date(2025, 3, 5, 0, 0, 0, 0, time.UTC)},  // Previous year
+		{ID: 4, Label: PaymentLabelWaterBill, Amount: 500, AmountPaid: 500, DueDate: time.Date(2025, 6, 5, 0, 0, 0, 0, time.UTC)}, // Not rent
+		{ID: 5, Label: PaymentLabelRent, Amount: 10000, AmountPaid: 0, DueDate: time.Date(2025, 6, 5, 0, 0, 0, 0, time.UTC)},      // Unpaid
+	}
+
+	statement, err := BuildRentStatement("2025-26", payments, nil)
+	if err != nil {
+		t.Fatalf("BuildRentStatement: %v", err)
+	}
+	if len(statement.Months) != 2 || statement.Months[0].PaymentID != 2 || statement.Months[1].PaymentID != 3 {
+		t.Fatalf("months = %+v", statement.Months)
+	}
+	if statement.TotalPaid != 14000 || statement.TotalRent != 20000 {
+		t.Errorf("totals = %d paid of %d", statement.TotalPaid, statement.TotalRent)
+	}
+	april := statement.Months[0]
+	if len(april.TransactionIDs) != 1 || april.TransactionIDs[0] != "UTR1" || april.GetFormattedPaidOn() != "Apr 6, 2025" {
+		t.Errorf("april = %+v", april)
+	}
+}
+
+func TestBuildRentStatement_InstallmentPlan(t *testing.T) {
+	planCreated := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	firstPaid := time.Date(2025, 8, 3, 0, 0, 0, 0, time.UTC)
+	secondPaid := time.Date(2025, 9, 4, 0, 0, 0, 0, time.UTC)
+	amount := 7000
+
+	may := &Payment{ID: 1, Label: PaymentLabelRent, Amount: 10000, AmountPaid: 4000, AmountAdjusted: 6000,
+		FullyPaidDate: &planCreated, DueDate: time.Date(2025, 5, 5, 0, 0, 0, 0, time.UTC)}
+	june := &Payment{ID: 2, Label: PaymentLabelRent, Amount: 10000, AmountAdjusted: 10000,
+		FullyPaidDate: &planCreated, DueDate: time.Date(2025, 6, 5, 0, 0, 0, 0, time.UTC)}
+	first := &Payment{ID: 3, Label: PaymentLabelInstallment, Amount: 8000, AmountPaid: 8000,
+		Transactions: []*PaymentTransaction{{TransactionID: "INST1", Amount: &amount, VerifiedAt: &firstPaid}}}
+	second := &Payment{ID: 4, Label: PaymentLabelInstallment, Amount: 8000, AmountPaid: 7000,
+		Transactions: []*PaymentTransaction{{TransactionID: "INST2", Amount: &amount, VerifiedAt: &secondPaid}}}
+
+	plans := []*InstallmentPlan{{
+		Arrears: []*InstallmentPlanArrear{
+			{PaymentID: 2, Amount: 10000, Payment: june},
+			{PaymentID: 1, Amount: 6000, Payment: may},
+		},
+		Installments: []*Installment{
+			{Sequence: 2, PaymentID: &second.ID, Payment: second},
+			{Sequence: 1, PaymentID: &first.ID, Payment: first},
+		},
+	}}
+
+	statement, err := BuildRentStatement("2025-26", []*Payment{may, june, first, second}, plans)
+	if err != nil {
+		t.Fatalf("BuildRentStatement: %v", err)
+	}
+	if len(statement.Months) != 2 {
+		t.Fatalf("months = %+v", statement.Months)
+	}
+
+	// Installments pay the oldest arrears first: May's ₹6000 from the first, June from the rest
+	gotMay, gotJune := statement.Months[0], statement.Months[1]
+	if gotMay.AmountPaid != 10000 || gotMay.AmountAdjusted != 0 || gotMay.GetFormattedPaidOn() != "Aug 3, 2025" {
+		t.Errorf("may = %+v", gotMay)
+	}
+	if gotJune.AmountPaid != 9000 || gotJune.AmountAdjusted != 0 || gotJune.GetFormattedPaidOn() != "Sep 4, 2025" {
+		t.Errorf("june = %+v", gotJune)
+	}
+	if len(gotJune.TransactionIDs) != 2 || gotJune.TransactionIDs[0] != "INST1" || gotJune.TransactionIDs[1] != "INST2" {
+		t.Errorf("june transactions = %v", gotJune.TransactionIDs)
+	}
+	if statement.TotalPaid != 19000 || statement.TotalAdjusted != 0 {
+		t.Errorf("totals = %d paid, %d adjusted", statement.TotalPaid, statement.TotalAdjusted)
+	}
+}
@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TelegramLinkTokenTTL is how long a deep link generated on /me stays valid
+const TelegramLinkTokenTTL = 30 * time.Minute
+
+// TelegramLinkMaxFailures is how many deliveries in a row may fail before a chat is no longer used
+// The tenant has to link again (e.g. after unblocking the bot) to resume reminders
+const TelegramLinkMaxFailures = 5
+
+// TelegramLinkToken is a one-time token a tenant sends to the bot to link their chat
+type TelegramLinkToken struct {
+	Token     string     `json:"token" db:"token"`
+	TenantID  int        `json:"tenant_id" db:"tenant_id"`
+	ExpiresAt time.Time  `json:"expires_at" db:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty" db:"used_at"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+}
+
+// TelegramLink is the Telegram chat a tenant's notifications are sent to
+type TelegramLink struct {
+	TenantID         int        `json:"tenant_id" db:"tenant_id"`
+	ChatID           string     `json:"chat_id" db:"chat_id"`
+	TelegramUsername string     `json:"telegram_username,omitempty" db:"telegram_username"`
+	LinkedAt         time.Time  `json:"linked_at" db:"linked_at"`
+	FailureCount     int        `json:"failure_count" db:"failure_count"` // Consecutive failed deliveries
+	LastError        string     `json:"last_error,omitempty" db:"last_error"`
+	LastFailureAt    *time.Time `json:"last_failure_at,omitempty" db:"last_failure_at"`
+}
+
+// NewTelegramLinkToken generates a random one-time link token for a tenant
+// Tokens are hex so they fit Telegram's start parameter (A-Z, a-z, 0-9, _ and -, up to 64 characters)
+func NewTelegramLinkToken(tenantID int, now time.Time) (*TelegramLinkToken, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, fmt.Errorf("failed to generate link token: %w", err)
+	}
+	return &TelegramLinkToken{
+		Token:     hex.EncodeToString(buf),
+		TenantID:  tenantID,
+		ExpiresAt: now.Add(TelegramLinkTokenTTL),
+		CreatedAt: now,
+	}, nil
+}
+
+// IsUsable reports whether the token can still link a chat
+func (t *TelegramLinkToken) IsUsable(now time.Time) bool {
+	return t.UsedAt == nil && now.Before(t.ExpiresAt)
+}
+
+// GetDeepLink returns the t.me link that opens the bot with the token as start parameter
+func (t *TelegramLinkToken) GetDeepLink(botUsername string) string {
+	return fmt.Sprintf("https://t.me/%s?start=%s", strings.TrimPrefix(botUsername, "@"), t.Token)
+}
+
+// ParseTelegramCommand splits a bot command such as "/start abc123" or "/start@RentBot abc123"
+// into the command name and its argument; ok is false when the text is not a command
+func ParseTelegramCommand(text string) (command, argument string, ok bool) {
+	text = strings.TrimSpace(text)
+	if !strings.HasPrefix(text, "/") {
+		return "", "", false
+	}
+	fields := strings.Fields(text)
+	command = strings.ToLower(strings.SplitN(strings.TrimPrefix(fields[0], "/"), "@", 2)[0])
+	if len(fields) > 1 {
+		argument = fields[1]
+	}
+	return command, argument, command != ""
+}
+
+// IsActive reports whether notifications should still be sent to the chat
+func (l *TelegramLink) IsActive() bool {
+	return l.ChatID != "" && l.FailureCount < TelegramLinkMaxFailures
+}
+
+// RecordDelivery updates the failure tracking after a send attempt
+func (l *TelegramLink) RecordDelivery(sendErr error, now time.Time) {
+	if sendErr == nil {
+		l.FailureCount = 0
+		l.LastError = ""
+		return
+	}
+	l.FailureCount++
+	l.LastError = sendErr.Error()
+	l.LastFailureAt = &now
+}
+
+// GetFormattedLinkedAt returns the link date formatted for display
+func (l *TelegramLink) GetFormattedLinkedAt() string {
+	return l.LinkedAt.Format("Jan 2, 2006")
+}
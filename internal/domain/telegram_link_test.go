@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseTelegramCommand(t *testing.T) {
+	tests := []struct {
+		text        string
+		wantCommand string
+		wantArg     string
+		wantOK      bool
+	}{
+		{"/start 0123abcd", "start", "0123abcd", true},
+		{"/start@RentReminderBot 0123abcd", "start", "0123abcd", true},
+		{"  /STOP  ", "stop", "", true},
+		{"/start", "start", "", true},
+		{"hello", "", "", false},
+		{"/", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			command, arg, ok := ParseTelegramCommand(tt.text)
+			if command != tt.wantCommand || arg != tt.wantArg || ok != tt.wantOK {
+				t.Errorf("ParseTelegramCommand(%q) = (%q, %q, %v), want (%q, %q, %v)",
+					tt.text, command, arg, ok, tt.wantCommand, tt.wantArg, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestTelegramLinkToken_IsUsable(t *testing.T) {
+	now := time.Date(2025, 7, 1, 10, 0, 0, 0, time.UTC)
+	token, err := NewTelegramLinkToken(7, now)
+	if err != nil {
+		t.Fatalf("NewTelegramLinkToken() error = %v", err)
+	}
+	if len(token.Token) != 32 {
+		t.Errorf("token length = %d, want 32", len(token.Token))
+	}
+	if got := token.GetDeepLink("@RentReminderBot"); got != "https://t.me/RentReminderBot?start="+token.Token {
+		t.Errorf("GetDeepLink() = %s", got)
+	}
+
+	if !token.IsUsable(now.Add(TelegramLinkTokenTTL - time.Second)) {
+		t.Errorf("token should be usable before it expires")
+	}
+	if token.IsUsable(now.Add(TelegramLinkTokenTTL)) {
+		t.Errorf("token should not be usable once expired")
+	}
+	token.UsedAt = &now
+	if token.IsUsable(now) {
+		t.Errorf("token should not be usable twice")
+	}
+}
+
+func TestTelegramLink_RecordDelivery(t *testing.T) {
+	now := time.Now()
+	link := &TelegramLink{TenantID: 1, ChatID: "12345"}
+
+	for i := 0; i < TelegramLinkMaxFailures-1; i++ {
+		link.RecordDelivery(errors.New("Forbidden: bot was blocked by the user"), now)
+	}
+	if !link.IsActive() {
+		t.Fatalf("link should stay active below %d failures", TelegramLinkMaxFailures)
+	}
+
+	link.RecordDelivery(nil, now)
+	if link.FailureCount != 0 || link.LastError != "" {
+		t.Errorf("successful delivery should reset failures: count = %d, error = %q", link.FailureCount, link.LastError)
+	}
+
+	for i := 0; i < TelegramLinkMaxFailures; i++ {
+		link.RecordDelivery(errors.New("chat not found"), now)
+	}
+	if link.IsActive() {
+		t.Errorf("link should be inactive after %d failures in a row", TelegramLinkMaxFailures)
+	}
+}
@@ -2,26 +2,36 @@ package domain
 
 import (
 	"fmt"
+	"net/mail"
 	"strings"
 	"time"
 )
 
 // Tenant represents the primary rent payer
 type Tenant struct {
-	ID             int       `json:"id" db:"id"`
-	Name           string    `json:"name" db:"name"`
-	Phone          string    `json:"phone" db:"phone"`
-	AadharNumber   string    `json:"aadhar_number" db:"aadhar_number"`
-	MoveInDate     time.Time `json:"move_in_date" db:"move_in_date"`
-	NumberOfPeople int       `json:"number_of_people" db:"number_of_people"`
-	UnitID         int       `json:"unit_id" db:"unit_id"`
-	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+	ID             int        `json:"id" db:"id"`
+	Name           string     `json:"name" db:"name"`
+	Phone          string     `json:"phone" db:"phone"`
+	Email          string     `json:"email,omitempty" db:"email"` // Optional, used for email notifications
+	AadharNumber   string     `json:"aadhar_number" db:"aadhar_number"`
+	MoveInDate     time.Time  `json:"move_in_date" db:"move_in_date"`
+	NumberOfPeople int        `json:"number_of_people" db:"number_of_people"`
+	UnitID         int        `json:"unit_id" db:"unit_id"`
+	Status         string     `json:"status" db:"status"`                         // active or archived
+	MoveOutDate    *time.Time `json:"move_out_date,omitempty" db:"move_out_date"` // Set when the tenant is archived
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
 
 	// Related data (populated by joins)
 	Unit          *Unit           `json:"unit,omitempty"`
 	FamilyMembers []*FamilyMember `json:"family_members,omitempty"`
 }
 
+// Tenant status constants
+const (
+	TenantStatusActive   = "active"
+	TenantStatusArchived = "archived"
+)
+
 // Validate validates the tenant data
 func (t *Tenant) Validate() error {
 	if strings.TrimSpace(t.Name) == "" {
@@ -36,6 +46,9 @@ func (t *Tenant) Validate() error {
 	if len(t.AadharNumber) != 12 {
 		return fmt.Errorf("aadhar number must be 12 digits")
 	}
+	if err := ValidateEmail(t.Email); err != nil {
+		return err
+	}
 	if t.MoveInDate.IsZero() {
 		return fmt.Errorf("move-in date is required")
 	}
@@ -48,6 +61,17 @@ func (t *Tenant) Validate() error {
 	return nil
 }
 
+// ValidateEmail checks an optional email address ("" is allowed)
+func ValidateEmail(email string) error {
+	if email == "" {
+		return nil
+	}
+	if _, err := mail.ParseAddress(email); err != nil || strings.ContainsAny(email, "<> ") {
+		return fmt.Errorf("invalid email address: %s", email)
+	}
+	return nil
+}
+
 // GetDisplayName returns a formatted display name for the tenant
 func (t *Tenant) GetDisplayName() string {
 	return fmt.Sprintf("%s (%s)", t.Name, t.Phone)
@@ -62,3 +86,30 @@ func (t *Tenant) GetMoveInDuration() string {
 	}
 	return fmt.Sprintf("%d months", months)
 }
+
+// IsArchived returns true if the tenant has moved out and been archived
+func (t *Tenant) IsArchived() bool {
+	return t.Status == TenantStatusArchived
+}
+
+// GetFormattedMoveOutDate returns the move-out date formatted, or "Current tenant"
+func (t *Tenant) GetFormattedMoveOutDate() string {
+	if t.MoveOutDate == nil {
+		return "Current tenant"
+	}
+	return t.MoveOutDate.Format("Jan 2, 2006")
+}
+
+// MoveOut is everything that changes when a tenant moves out
+// It is worked out and validated up front, then saved in a single transaction so a
+// rejected or failed move-out leaves nothing behind
+type MoveOut struct {
+	TenantID    int
+	UnitID      int
+	MoveOutDate time.Time
+
+	FinalProration *RentProration   // Final-month proration, nil if rent was not prorated
+	FinalPayment   *Payment         // The prorated rent payment with its new amount, balance and notes
+	Deposit        *SecurityDeposit // Marked settled with the settlement entries (ID 0) appended, nil if there is none
+	Lease          *Lease           // The active lease with its end date and status updated, nil if there is none
+}
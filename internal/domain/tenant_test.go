@@ -180,6 +180,20 @@ func TestTenant_Validate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "unit ID is required",
 		},
+		{
+			name: "invalid email",
+			tenant: &Tenant{
+				Name:           "John Doe",
+				Phone:          "9876543210",
+				Email:          "john.example.com",
+				AadharNumber:   "123456789012",
+				MoveInDate:     time.Now(),
+				NumberOfPeople: 2,
+				UnitID:         1,
+			},
+			wantErr: true,
+			errMsg:  "invalid email address",
+		},
 		{
 			name: "all fields invalid",
 			tenant: &Tenant{
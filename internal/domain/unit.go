@@ -7,19 +7,24 @@ import (
 
 // Unit represents a rental unit in the property
 type Unit struct {
-	ID              int       `json:"id" db:"id"`
-	UnitCode        string    `json:"unit_code" db:"unit_code"`
-	Floor           string    `json:"floor" db:"floor"`
-	UnitType        string    `json:"unit_type" db:"unit_type"`
-	MonthlyRent     int       `json:"monthly_rent" db:"monthly_rent"`
-	SecurityDeposit int       `json:"security_deposit" db:"security_deposit"`
-	PaymentDueDay   int       `json:"payment_due_day" db:"payment_due_day"`
-	IsOccupied      bool      `json:"is_occupied" db:"is_occupied"`
-	CreatedAt       time.Time `json:"created_at" db:"created_at"`
+	ID              int        `json:"id" db:"id"`
+	PropertyID      int        `json:"property_id" db:"property_id"`
+	UnitCode        string     `json:"unit_code" db:"unit_code"`
+	Floor           string     `json:"floor" db:"floor"`
+	UnitType        string     `json:"unit_type" db:"unit_type"`
+	MonthlyRent     int        `json:"monthly_rent" db:"monthly_rent"`
+	SecurityDeposit int        `json:"security_deposit" db:"security_deposit"`
+	PaymentDueDay   int        `json:"payment_due_day" db:"payment_due_day"`
+	IsOccupied      bool       `json:"is_occupied" db:"is_occupied"`
+	RetiredAt       *time.Time `json:"retired_at,omitempty" db:"retired_at"` // NULL while the unit is in service
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
 }
 
 // Validate validates the unit data
 func (u *Unit) Validate() error {
+	if u.PropertyID <= 0 {
+		return fmt.Errorf("property ID is required")
+	}
 	if u.UnitCode == "" {
 		return fmt.Errorf("unit code is required")
 	}
@@ -41,6 +46,11 @@ func (u *Unit) Validate() error {
 	return nil
 }
 
+// IsRetired returns true if the unit has been taken out of service
+func (u *Unit) IsRetired() bool {
+	return u.RetiredAt != nil
+}
+
 // GetDisplayName returns a formatted display name for the unit
 func (u *Unit) GetDisplayName() string {
 	return fmt.Sprintf("%s - %s (%s)", u.UnitCode, u.UnitType, u.Floor)
@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// PaymentReferencePrefix starts the reference that ties a UPI payment to a payment record
+// It is sent as the UPI transaction reference and note, so it shows up in the owner's
+// bank statement narration and lets statement lines be matched to the payment.
+const PaymentReferencePrefix = "RENTPAY"
+
+var paymentReferencePattern = regexp.MustCompile(`(?i)` + PaymentReferencePrefix + `(\d{1,9})\b`)
+
+// UPIPaymentRequest is a UPI collect request for the remaining balance of a payment
+type UPIPaymentRequest struct {
+	PaymentID int    `json:"payment_id"`
+	PayeeVPA  string `json:"payee_vpa"`  // The owner's UPI ID
+	PayeeName string `json:"payee_name"` // Shown to the tenant in their UPI app
+	Amount    int    `json:"amount"`     // Exact remaining balance in rupees
+	Reference string `json:"reference"`  // Encodes the payment ID (see PaymentReference)
+	Note      string `json:"note"`
+	IntentURL string `json:"intent_url"` // upi://pay link, also encoded in the QR code
+}
+
+// PaymentReference returns the UPI reference for a payment ID
+func PaymentReference(paymentID int) string {
+	return fmt.Sprintf("%s%d", PaymentReferencePrefix, paymentID)
+}
+
+// ParsePaymentReference finds a payment reference in free text such as a bank narration
+// Returns the payment ID and true if one is present
+func ParsePaymentReference(text string) (int, bool) {
+	match := paymentReferencePattern.FindStringSubmatch(text)
+	if match == nil {
+		return 0, false
+	}
+	paymentID, err := strconv.Atoi(match[1])
+	if err != nil || paymentID <= 0 {
+		return 0, false
+	}
+	return paymentID, true
+}
+
+// NewUPIPaymentRequest builds the UPI request for the remaining balance of a payment
+// The payment's own UPI ID is used as the payee, falling back to defaultVPA
+func NewUPIPaymentRequest(payment *Payment, defaultVPA, payeeName string) (*UPIPaymentRequest, error) {
+	vpa := strings.TrimSpace(payment.UPIID)
+	if vpa == "" {
+		vpa = strings.TrimSpace(defaultVPA)
+	}
+	if vpa == "" || !strings.Contains(vpa, "@") {
+		return nil, fmt.Errorf("no valid UPI ID configured for payment %d", payment.ID)
+	}
+	if payment.IsFullyPaid || payment.RemainingBalance <= 0 {
+		return nil, fmt.Errorf("payment is already fully paid")
+	}
+
+	req := &UPIPaymentRequest{
+		PaymentID: payment.ID,
+		PayeeVPA:  vpa,
+		PayeeName: strings.TrimSpace(payeeName),
+		Amount:    payment.RemainingBalance,
+		Reference: PaymentReference(payment.ID),
+	}
+	req.Note = fmt.Sprintf("%s %s due %s", req.Reference, payment.GetLabelDisplayName(), payment.DueDate.Format("Jan 2006"))
+	req.IntentURL = req.buildIntentURL()
+	return req, nil
+}
+
+// buildIntentURL returns the upi://pay deep link for the request
+func (r *UPIPaymentRequest) buildIntentURL() string {
+	params := []string{
+		"pa=" + url.QueryEscape(r.PayeeVPA),
+	}
+	if r.PayeeName != "" {
+		params = append(params, "pn="+url.QueryEscape(r.PayeeName))
+	}
+	params = append(params,
+		"am="+fmt.Sprintf("%d.00", r.Amount),
+		"cu=INR",
+		"tr="+url.QueryEscape(r.Reference),
+		"tn="+url.QueryEscape(r.Note),
+	)
+	// UPI apps expect %20 rather than + for spaces
+	return "upi://pay?" + strings.ReplaceAll(strings.Join(params, "&"), "+", "%20")
+}
+
+// GetFormattedAmount returns the amount formatted as currency
+func (r *UPIPaymentRequest) GetFormattedAmount() string {
+	return fmt.Sprintf("₹%d", r.Amount)
+}
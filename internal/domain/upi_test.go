@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewUPIPaymentRequest(t *testing.T) {
+	payment := &Payment{
+		ID:               42,
+		Amount:           15000,
+		AmountPaid:       5000,
+		RemainingBalance: 10000,
+		DueDate:          time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC),
+		UPIID:            "owner@ybl",
+		Label:            PaymentLabelRent,
+	}
+
+	req, err := NewUPIPaymentRequest(payment, "default@ybl", "Ravi Kumar")
+	if err != nil {
+		t.Fatalf("NewUPIPaymentRequest: %v", err)
+	}
+	if req.PayeeVPA != "owner@ybl" || req.Amount != 10000 || req.Reference != "RENTPAY42" {
+		t.Fatalf("unexpected request: %+v", req)
+	}
+
+	for _, want := range []string{"upi://pay?", "pa=owner%40ybl", "pn=Ravi%20Kumar", "am=10000.00", "cu=INR", "tr=RENTPAY42", "tn=RENTPAY42%20"} {
+		if !strings.Contains(req.IntentURL, want) {
+			t.Errorf("intent URL %q missing %q", req.IntentURL, want)
+		}
+	}
+	if strings.Contains(req.IntentURL, "+") {
+		t.Errorf("intent URL %q should encode spaces as %%20", req.IntentURL)
+	}
+
+	payment.UPIID = ""
+	req, err = NewUPIPaymentRequest(payment, "default@ybl", "")
+	if err != nil || req.PayeeVPA != "default@ybl" || strings.Contains(req.IntentURL, "pn=") {
+		t.Fatalf("expected default VPA without payee name, got %+v, %v", req, err)
+	}
+
+	payment.RemainingBalance = 0
+	payment.IsFullyPaid = true
+	if _, err := NewUPIPaymentRequest(payment, "default@ybl", ""); err == nil {
+		t.Fatal("expected error for a fully paid payment")
+	}
+}
+
+func TestParsePaymentReference(t *testing.T) {
+	cases := []struct {
+		text string
+		id   int
+		ok   bool
+	}{
+		{"UPI/512345678901/RENTPAY42/ravi@okaxis", 42, true},
+		{"upi-rentpay7 rent due mar 2025", 7, true},
+		{"RENTPAY42 Rent due Mar 2025", 42, true},
+		{"NEFT salary credit", 0, false},
+		{"RENTPAY", 0, false},
+		{"RENTPAY0", 0, false},
+	}
+	for _, c := range cases {
+		id, ok := ParsePaymentReference(c.text)
+		if id != c.id || ok != c.ok {
+			t.Errorf("ParsePaymentReference(%q) = %d, %v; want %d, %v", c.text, id, ok, c.id, c.ok)
+		}
+	}
+}
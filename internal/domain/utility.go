@@ -0,0 +1,194 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// UtilityTariff defines how a metered utility (water, electricity) is billed for a unit or a whole property
+// A unit tariff takes precedence over the tariff of its property
+type UtilityTariff struct {
+	ID            int          `json:"id" db:"id"`
+	PropertyID    *int         `json:"property_id,omitempty" db:"property_id"` // Set for property-wide tariffs
+	UnitID        *int         `json:"unit_id,omitempty" db:"unit_id"`         // Set for unit-specific tariffs
+	CategoryCode  string       `json:"category_code" db:"category_code"`       // Charge category of the bill (water_bill, current_bill)
+	RatePerUnit   float64      `json:"rate_per_unit" db:"rate_per_unit"`       // Flat rate, used when there are no slabs
+	Slabs         []TariffSlab `json:"slabs,omitempty" db:"slabs"`             // Tiered rates, applied in order
+	FixedCharge   int          `json:"fixed_charge" db:"fixed_charge"`         // Added to every bill
+	SplitByPeople bool         `json:"split_by_people" db:"split_by_people"`   // Shared meter: split by NumberOfPeople instead of equally
+	CreatedAt     time.Time    `json:"created_at" db:"created_at"`
+}
+
+// TariffSlab is one tier of a slab tariff
+// UpTo is the cumulative consumption the slab ends at (0 = no upper limit)
+type TariffSlab struct {
+	UpTo float64 `json:"up_to"`
+	Rate float64 `json:"rate"`
+}
+
+// MeterReading is a reading of a unit's meter for one utility
+type MeterReading struct {
+	ID              int       `json:"id" db:"id"`
+	UnitID          int       `json:"unit_id" db:"unit_id"`
+	CategoryCode    string    `json:"category_code" db:"category_code"`
+	PreviousReading float64   `json:"previous_reading" db:"previous_reading"`
+	CurrentReading  float64   `json:"current_reading" db:"current_reading"`
+	ReadingDate     time.Time `json:"reading_date" db:"reading_date"`
+	CreatedAt       time.Time `json:"created_at" db:"created_at"`
+}
+
+// UtilityBill is the breakdown behind a utility payment generated from a meter reading
+type UtilityBill struct {
+	ID          int                 `json:"id" db:"id"`
+	PaymentID   int                 `json:"payment_id" db:"payment_id"`
+	ReadingID   int                 `json:"reading_id" db:"reading_id"`
+	TenantID    int                 `json:"tenant_id" db:"tenant_id"`
+	Consumption float64             `json:"consumption" db:"consumption"`
+	UsageCharge int                 `json:"usage_charge" db:"usage_charge"` // Consumption charge for the whole meter
+	FixedCharge int                 `json:"fixed_charge" db:"fixed_charge"` // Fixed charge for the whole meter
+	MeterTotal  int                 `json:"meter_total" db:"meter_total"`   // UsageCharge + FixedCharge
+	ShareWeight int                 `json:"share_weight" db:"share_weight"` // This tenant's share (people, or 1 when split equally)
+	TotalWeight int                 `json:"total_weight" db:"total_weight"` // Sum of all shares on the meter
+	Amount      int                 `json:"amount" db:"amount"`             // This tenant's part of MeterTotal
+	SlabCharges []UtilitySlabCharge `json:"slab_charges,omitempty" db:"slab_charges"`
+	CreatedAt   time.Time           `json:"created_at" db:"created_at"`
+
+	// Related data (populated by joins)
+	Reading *MeterReading `json:"reading,omitempty"`
+}
+
+// UtilitySlabCharge is the charge for the consumption falling in one slab
+type UtilitySlabCharge struct {
+	Units  float64 `json:"units"`
+	Rate   float64 `json:"rate"`
+	Amount int     `json:"amount"`
+}
+
+// Validate validates the tariff data
+func (t *UtilityTariff) Validate() error {
+	if (t.PropertyID == nil) == (t.UnitID == nil) {
+		return fmt.Errorf("tariff must apply to either a property or a unit")
+	}
+	if t.CategoryCode == "" {
+		return fmt.Errorf("category is required")
+	}
+	if t.FixedCharge < 0 {
+		return fmt.Errorf("fixed charge cannot be negative")
+	}
+	if len(t.Slabs) == 0 {
+		if t.RatePerUnit <= 0 {
+			return fmt.Errorf("rate per unit must be greater than 0 when no slabs are set")
+		}
+		return nil
+	}
+
+	previous := 0.0
+	for i, slab := range t.Slabs {
+		if slab.Rate < 0 {
+			return fmt.Errorf("slab %d: rate cannot be negative", i+1)
+		}
+		last := i == len(t.Slabs)-1
+		if slab.UpTo == 0 && !last {
+			return fmt.Errorf("slab %d: only the last slab can be open-ended", i+1)
+		}
+		if slab.UpTo != 0 && slab.UpTo <= previous {
+			return fmt.Errorf("slab %d: limits must increase", i+1)
+		}
+		previous = slab.UpTo
+	}
+	return nil
+}
+
+// CalculateUsageCharge returns the consumption charge and its per-slab breakdown
+// Consumption beyond the last closed slab is billed at that slab's rate
+func (t *UtilityTariff) CalculateUsageCharge(consumption float64) (int, []UtilitySlabCharge) {
+	if consumption <= 0 {
+		return 0, nil
+	}
+	if len(t.Slabs) == 0 {
+		amount := int(math.Round(consumption * t.RatePerUnit))
+		return amount, []UtilitySlabCharge{{Units: consumption, Rate: t.RatePerUnit, Amount: amount}}
+	}
+
+	var charges []UtilitySlabCharge
+	total, billed := 0, 0.0
+	for i, slab := range t.Slabs {
+		units := consumption - billed
+		if slab.UpTo != 0 && i < len(t.Slabs)-1 && units > slab.UpTo-billed {
+			units = slab.UpTo - billed
+		}
+		if units <= 0 {
+			break
+		}
+		amount := int(math.Round(units * slab.Rate))
+		charges = append(charges, UtilitySlabCharge{Units: units, Rate: slab.Rate, Amount: amount})
+		total += amount
+		billed += units
+	}
+	return total, charges
+}
+
+// Consumption returns the units consumed between the two readings
+func (m *MeterReading) Consumption() float64 {
+	return m.CurrentReading - m.PreviousReading
+}
+
+// Validate validates the meter reading data
+func (m *MeterReading) Validate() error {
+	if m.UnitID <= 0 {
+		return fmt.Errorf("unit ID is required")
+	}
+	if m.CategoryCode == "" {
+		return fmt.Errorf("category is required")
+	}
+	if m.PreviousReading < 0 || m.CurrentReading < 0 {
+		return fmt.Errorf("readings cannot be negative")
+	}
+	if m.CurrentReading < m.PreviousReading {
+		return fmt.Errorf("current reading (%.2f) cannot be lower than previous reading (%.2f)", m.CurrentReading, m.PreviousReading)
+	}
+	if m.ReadingDate.IsZero() {
+		return fmt.Errorf("reading date is required")
+	}
+	return nil
+}
+
+// SplitAmount divides an amount by weight, giving any rounding remainder to the first shares
+// so the parts always add up to the total
+func SplitAmount(total int, weights []int) []int {
+	parts := make([]int, len(weights))
+	totalWeight := 0
+	for _, weight := range weights {
+		totalWeight += weight
+	}
+	if totalWeight <= 0 {
+		return parts
+	}
+
+	allocated := 0
+	for i, weight := range weights {
+		parts[i] = total * weight / totalWeight
+		allocated += parts[i]
+	}
+	for i := 0; allocated < total && len(parts) > 0; i = (i + 1) % len(parts) {
+		if weights[i] > 0 {
+			parts[i]++
+			allocated++
+		}
+	}
+	return parts
+}
+
+// GetFormattedConsumption returns the consumption with its meter readings
+func (b *UtilityBill) GetFormattedConsumption() string {
+	if b.Reading == nil {
+		return fmt.Sprintf("%.2f units", b.Consumption)
+	}
+	return fmt.Sprintf("%.2f → %.2f (%.2f units)", b.Reading.PreviousReading, b.Reading.CurrentReading, b.Consumption)
+}
+
+// IsShared returns true if the meter total was split between several tenants
+func (b *UtilityBill) IsShared() bool {
+	return b.TotalWeight > b.ShareWeight
+}
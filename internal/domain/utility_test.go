@@ -0,0 +1,65 @@
+package domain
+
+import "testing"
+
+func TestUtilityTariff_CalculateUsageCharge(t *testing.T) {
+	slabs := []TariffSlab{{UpTo: 100, Rate: 3}, {UpTo: 200, Rate: 5}, {Rate: 8}}
+
+	tests := []struct {
+		name        string
+		tariff      *UtilityTariff
+		consumption float64
+		want        int
+		wantSlabs   int
+	}{
+		{"flat rate", &UtilityTariff{RatePerUnit: 7.5}, 120, 900, 1},
+		{"within first slab", &UtilityTariff{Slabs: slabs}, 80, 240, 1},
+		{"across all slabs", &UtilityTariff{Slabs: slabs}, 250, 300 + 500 + 400, 3},
+		{"beyond last closed slab", &UtilityTariff{Slabs: []TariffSlab{{UpTo: 100, Rate: 3}, {UpTo: 200, Rate: 5}}}, 250, 300 + 750, 2},
+		{"no consumption", &UtilityTariff{Slabs: slabs}, 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, charges := tt.tariff.CalculateUsageCharge(tt.consumption)
+			if got != tt.want {
+				t.Errorf("CalculateUsageCharge() = %d, want %d", got, tt.want)
+			}
+			if len(charges) != tt.wantSlabs {
+				t.Errorf("CalculateUsageCharge() returned %d slab charges, want %d", len(charges), tt.wantSlabs)
+			}
+		})
+	}
+}
+
+func TestSplitAmount(t *testing.T) {
+	parts := SplitAmount(1000, []int{1, 2})
+	if parts[0]+parts[1] != 1000 {
+		t.Errorf("parts %v do not add up to 1000", parts)
+	}
+	if parts[0] != 334 || parts[1] != 666 {
+		t.Errorf("SplitAmount(1000, [1 2]) = %v, want [334 666]", parts)
+	}
+
+	if parts := SplitAmount(500, []int{3}); parts[0] != 500 {
+		t.Errorf("single share should get the whole amount, got %v", parts)
+	}
+}
+
+func TestUtilityTariff_Validate(t *testing.T) {
+	unitID := 1
+	valid := &UtilityTariff{UnitID: &unitID, CategoryCode: "current_bill", Slabs: []TariffSlab{{UpTo: 100, Rate: 3}, {Rate: 5}}}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("valid tariff returned error: %v", err)
+	}
+
+	openMiddle := &UtilityTariff{UnitID: &unitID, CategoryCode: "current_bill", Slabs: []TariffSlab{{Rate: 3}, {UpTo: 100, Rate: 5}}}
+	if err := openMiddle.Validate(); err == nil {
+		t.Errorf("open-ended slab before the last should be invalid")
+	}
+
+	noRate := &UtilityTariff{UnitID: &unitID, CategoryCode: "water_bill"}
+	if err := noRate.Validate(); err == nil {
+		t.Errorf("tariff without rate or slabs should be invalid")
+	}
+}
@@ -0,0 +1,99 @@
+package gateway
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Gateway names
+const (
+	GatewayMock     = "mock"
+	GatewayRazorpay = "razorpay"
+)
+
+// Webhook event status constants
+const (
+	EventStatusPaid   = "paid"
+	EventStatusFailed = "failed"
+)
+
+// ErrInvalidSignature is returned when a webhook signature does not match its body
+var ErrInvalidSignature = errors.New("invalid webhook signature")
+
+// PaymentGateway creates payment orders and verifies the webhook callbacks a gateway sends
+// Implementations must only return events whose signature has been verified
+type PaymentGateway interface {
+	// Name returns the gateway name stored with each order
+	Name() string
+	// CheckoutKey returns the public key the tenant's browser uses to open the gateway checkout ("" if none)
+	CheckoutKey() string
+	// CreateOrder creates an order for the given amount (in rupees)
+	CreateOrder(req OrderRequest) (*Order, error)
+	// ParseWebhook verifies the signature of a webhook callback and returns the event it carries
+	ParseWebhook(body []byte, headers http.Header) (*WebhookEvent, error)
+}
+
+// OrderRequest describes the order to create for a payment
+type OrderRequest struct {
+	Amount    int    // Amount in rupees
+	Currency  string // Defaults to INR
+	Receipt   string // Our reference for the order (shown in the gateway dashboard)
+	PaymentID int
+	TenantID  int
+}
+
+// Order is an order created on the gateway
+type Order struct {
+	OrderID     string // Gateway order ID
+	Amount      int    // Amount in rupees
+	Currency    string
+	CheckoutURL string // Where the tenant completes the payment (empty if the gateway uses a client-side checkout)
+}
+
+// WebhookEvent is a verified payment event sent by the gateway
+type WebhookEvent struct {
+	OrderID          string // Gateway order ID
+	GatewayPaymentID string // Gateway payment ID, used as the transaction ID
+	Amount           int    // Amount actually paid in rupees
+	Status           string // paid, failed
+	FailureReason    string
+}
+
+// New returns the gateway with the given name (nil if name is empty, i.e. gateway payments are disabled)
+func New(name, keyID, keySecret, webhookSecret string) (PaymentGateway, error) {
+	switch name {
+	case "":
+		return nil, nil
+	case GatewayMock:
+		return NewMockGateway(webhookSecret), nil
+	case GatewayRazorpay:
+		if keyID == "" || keySecret == "" || webhookSecret == "" {
+			return nil, fmt.Errorf("razorpay requires a key ID, key secret and webhook secret")
+		}
+		return NewRazorpayGateway(keyID, keySecret, webhookSecret), nil
+	default:
+		return nil, fmt.Errorf("unknown payment gateway: %s. Must be one of: mock, razorpay", name)
+	}
+}
+
+// Sign returns the hex-encoded HMAC-SHA256 of body with secret
+func Sign(body []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// verifySignature checks a hex-encoded HMAC-SHA256 signature in constant time
+func verifySignature(body []byte, signature, secret string) error {
+	if signature == "" || secret == "" {
+		return ErrInvalidSignature
+	}
+	if !hmac.Equal([]byte(Sign(body, secret)), []byte(signature)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package gateway
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// MockSignatureHeader carries the signature of mock webhook callbacks
+const MockSignatureHeader = "X-Mock-Signature"
+
+// defaultMockWebhookSecret is used when no webhook secret is configured for the mock gateway
+const defaultMockWebhookSecret = "mock-webhook-secret"
+
+// MockGateway is a local gateway for development and tests
+// Orders are created without any network call and payments are completed with SignedWebhook,
+// which produces the same signed callback a real gateway would post.
+type MockGateway struct {
+	webhookSecret string
+	sequence      int64
+}
+
+// mockWebhookPayload is the body of a mock webhook callback
+type mockWebhookPayload struct {
+	Event     string `json:"event"` // payment.paid, payment.failed
+	OrderID   string `json:"order_id"`
+	PaymentID string `json:"payment_id"`
+	Amount    int    `json:"amount"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// NewMockGateway creates a new MockGateway
+func NewMockGateway(webhookSecret string) *MockGateway {
+	if webhookSecret == "" {
+		webhookSecret = defaultMockWebhookSecret
+	}
+	return &MockGateway{webhookSecret: webhookSecret}
+}
+
+// Name returns the gateway name
+func (g *MockGateway) Name() string {
+	return GatewayMock
+}
+
+// CheckoutKey returns "" since mock orders are completed with SignedWebhook
+func (g *MockGateway) CheckoutKey() string {
+	return ""
+}
+
+// CreateOrder creates an order locally
+func (g *MockGateway) CreateOrder(req OrderRequest) (*Order, error) {
+	if req.Amount <= 0 {
+		return nil, fmt.Errorf("order amount must be greater than 0")
+	}
+	currency := req.Currency
+	if currency == "" {
+		currency = "INR"
+	}
+
+	n := atomic.AddInt64(&g.sequence, 1)
+	return &Order{
+		OrderID:  fmt.Sprintf("order_mock_%d_%d", time.Now().UnixNano(), n),
+		Amount:   req.Amount,
+		Currency: currency,
+	}, nil
+}
+
+// ParseWebhook verifies and decodes a mock webhook callback
+func (g *MockGateway) ParseWebhook(body []byte, headers http.Header) (*WebhookEvent, error) {
+	if err := verifySignature(body, headers.Get(MockSignatureHeader), g.webhookSecret); err != nil {
+		return nil, err
+	}
+
+	var payload mockWebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("invalid webhook payload: %w", err)
+	}
+	if payload.OrderID == "" || payload.PaymentID == "" {
+		return nil, fmt.Errorf("webhook payload is missing order_id or payment_id")
+	}
+
+	event := &WebhookEvent{
+		OrderID:          payload.OrderID,
+		GatewayPaymentID: payload.PaymentID,
+		Amount:           payload.Amount,
+		FailureReason:    payload.Reason,
+	}
+	switch payload.Event {
+	case "payment.paid":
+		event.Status = EventStatusPaid
+	case "payment.failed":
+		event.Status = EventStatusFailed
+	default:
+		return nil, fmt.Errorf("unsupported webhook event: %s", payload.Event)
+	}
+	return event, nil
+}
+
+// SignedWebhook builds a signed callback for an order, as the gateway would post it
+// Pass paid=false to simulate a failed payment
+func (g *MockGateway) SignedWebhook(orderID string, amount int, paid bool) ([]byte, http.Header, error) {
+	n := atomic.AddInt64(&g.sequence, 1)
+	payload := mockWebhookPayload{
+		Event:     "payment.paid",
+		OrderID:   orderID,
+		PaymentID: fmt.Sprintf("pay_mock_%d_%d", time.Now().UnixNano(), n),
+		Amount:    amount,
+	}
+	if !paid {
+		payload.Event = "payment.failed"
+		payload.Reason = "Payment declined (mock)"
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, nil, err
+	}
+	headers := http.Header{}
+	headers.Set(MockSignatureHeader, Sign(body, g.webhookSecret))
+	return body, headers, nil
+}
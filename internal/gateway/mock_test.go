@@ -0,0 +1,71 @@
+package gateway
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestMockGatewayWebhookRoundTrip(t *testing.T) {
+	g := NewMockGateway("secret")
+
+	order, err := g.CreateOrder(OrderRequest{Amount: 12000, PaymentID: 7, TenantID: 3})
+	if err != nil {
+		t.Fatalf("CreateOrder: %v", err)
+	}
+	if order.Amount != 12000 || order.Currency != "INR" {
+		t.Fatalf("unexpected order: %+v", order)
+	}
+
+	body, headers, err := g.SignedWebhook(order.OrderID, 12000, true)
+	if err != nil {
+		t.Fatalf("SignedWebhook: %v", err)
+	}
+	event, err := g.ParseWebhook(body, headers)
+	if err != nil {
+		t.Fatalf("ParseWebhook: %v", err)
+	}
+	if event.OrderID != order.OrderID || event.Amount != 12000 || event.Status != EventStatusPaid || event.GatewayPaymentID == "" {
+		t.Fatalf("unexpected event: %+v", event)
+	}
+
+	body, headers, _ = g.SignedWebhook(order.OrderID, 12000, false)
+	event, err = g.ParseWebhook(body, headers)
+	if err != nil || event.Status != EventStatusFailed {
+		t.Fatalf("expected failed event, got %+v, %v", event, err)
+	}
+}
+
+func TestMockGatewayRejectsBadSignature(t *testing.T) {
+	g := NewMockGateway("secret")
+	body, headers, _ := g.SignedWebhook("order_1", 500, true)
+
+	tampered := append([]byte{}, body...)
+	tampered[len(tampered)-2] = '9'
+	if _, err := g.ParseWebhook(tampered, headers); err != ErrInvalidSignature {
+		t.Fatalf("expected ErrInvalidSignature for tampered body, got %v", err)
+	}
+
+	if _, err := g.ParseWebhook(body, http.Header{}); err != ErrInvalidSignature {
+		t.Fatalf("expected ErrInvalidSignature for missing header, got %v", err)
+	}
+
+	other := NewMockGateway("other-secret")
+	if _, err := other.ParseWebhook(body, headers); err != ErrInvalidSignature {
+		t.Fatalf("expected ErrInvalidSignature for wrong secret, got %v", err)
+	}
+}
+
+func TestRazorpayWebhook(t *testing.T) {
+	g := NewRazorpayGateway("key", "secret", "whsec")
+	body := []byte(`{"event":"payment.captured","payload":{"payment":{"entity":{"id":"pay_1","order_id":"order_1","amount":1250000,"status":"captured"}}}}`)
+	headers := http.Header{}
+	headers.Set(RazorpaySignatureHeader, Sign(body, "whsec"))
+
+	event, err := g.ParseWebhook(body, headers)
+	if err != nil {
+		t.Fatalf("ParseWebhook: %v", err)
+	}
+	if event.OrderID != "order_1" || event.GatewayPaymentID != "pay_1" || event.Amount != 12500 || event.Status != EventStatusPaid {
+		t.Fatalf("unexpected event: %+v", event)
+	}
+}
@@ -0,0 +1,155 @@
+package gateway
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RazorpaySignatureHeader carries the signature of Razorpay webhook callbacks
+const RazorpaySignatureHeader = "X-Razorpay-Signature"
+
+const razorpayOrdersURL = "https://api.razorpay.com/v1/orders"
+
+// RazorpayGateway creates orders through the Razorpay Orders API
+// and verifies its payment.captured / payment.failed webhooks
+type RazorpayGateway struct {
+	keyID         string
+	keySecret     string
+	webhookSecret string
+	client        *http.Client
+}
+
+// razorpayWebhookPayload is the part of a Razorpay webhook body we use
+type razorpayWebhookPayload struct {
+	Event   string `json:"event"`
+	Payload struct {
+		Payment struct {
+			Entity struct {
+				ID               string `json:"id"`
+				OrderID          string `json:"order_id"`
+				Amount           int    `json:"amount"` // In paise
+				Status           string `json:"status"`
+				ErrorDescription string `json:"error_description"`
+			} `json:"entity"`
+		} `json:"payment"`
+	} `json:"payload"`
+}
+
+// NewRazorpayGateway creates a new RazorpayGateway
+func NewRazorpayGateway(keyID, keySecret, webhookSecret string) *RazorpayGateway {
+	return &RazorpayGateway{
+		keyID:         keyID,
+		keySecret:     keySecret,
+		webhookSecret: webhookSecret,
+		client:        &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+// Name returns the gateway name
+func (g *RazorpayGateway) Name() string {
+	return GatewayRazorpay
+}
+
+// CheckoutKey returns the key ID used by Razorpay Checkout in the browser
+func (g *RazorpayGateway) CheckoutKey() string {
+	return g.keyID
+}
+
+// CreateOrder creates an order on Razorpay
+// The tenant completes it with Razorpay Checkout using the returned order ID
+func (g *RazorpayGateway) CreateOrder(req OrderRequest) (*Order, error) {
+	if req.Amount <= 0 {
+		return nil, fmt.Errorf("order amount must be greater than 0")
+	}
+	currency := req.Currency
+	if currency == "" {
+		currency = "INR"
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"amount":   req.Amount * 100, // Razorpay amounts are in paise
+		"currency": currency,
+		"receipt":  req.Receipt,
+		"notes": map[string]string{
+			"payment_id": strconv.Itoa(req.PaymentID),
+			"tenant_id":  strconv.Itoa(req.TenantID),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequest(http.MethodPost, razorpayOrdersURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.SetBasicAuth(g.keyID, g.keySecret)
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := g.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("razorpay create order: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("razorpay create order: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("razorpay create order failed with status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	var created struct {
+		ID       string `json:"id"`
+		Amount   int    `json:"amount"`
+		Currency string `json:"currency"`
+	}
+	if err := json.Unmarshal(respBody, &created); err != nil {
+		return nil, fmt.Errorf("razorpay create order: invalid response: %w", err)
+	}
+
+	return &Order{
+		OrderID:  created.ID,
+		Amount:   created.Amount / 100,
+		Currency: created.Currency,
+	}, nil
+}
+
+// ParseWebhook verifies and decodes a Razorpay webhook callback
+func (g *RazorpayGateway) ParseWebhook(body []byte, headers http.Header) (*WebhookEvent, error) {
+	if err := verifySignature(body, headers.Get(RazorpaySignatureHeader), g.webhookSecret); err != nil {
+		return nil, err
+	}
+
+	var payload razorpayWebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("invalid webhook payload: %w", err)
+	}
+
+	entity := payload.Payload.Payment.Entity
+	if entity.OrderID == "" || entity.ID == "" {
+		return nil, fmt.Errorf("webhook payload is missing order or payment ID")
+	}
+
+	event := &WebhookEvent{
+		OrderID:          entity.OrderID,
+		GatewayPaymentID: entity.ID,
+		Amount:           entity.Amount / 100,
+		FailureReason:    entity.ErrorDescription,
+	}
+	switch payload.Event {
+	case "payment.captured":
+		event.Status = EventStatusPaid
+	case "payment.failed":
+		event.Status = EventStatusFailed
+	default:
+		return nil, fmt.Errorf("unsupported webhook event: %s", payload.Event)
+	}
+	return event, nil
+}
@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"backend-form/m/internal/domain"
+	"backend-form/m/internal/service"
+	"encoding/json"
+	"net/http"
+)
+
+// ChargeCategoryHandler handles owner-facing charge category (payment label) operations
+type ChargeCategoryHandler struct {
+	categoryService  *service.ChargeCategoryService
+	dashboardService *service.DashboardService
+}
+
+// NewChargeCategoryHandler creates a new ChargeCategoryHandler
+func NewChargeCategoryHandler(
+	categoryService *service.ChargeCategoryService,
+	dashboardService *service.DashboardService,
+) *ChargeCategoryHandler {
+	return &ChargeCategoryHandler{
+		categoryService:  categoryService,
+		dashboardService: dashboardService,
+	}
+}
+
+// chargeCategoryRequest is the JSON body accepted by create and update
+type chargeCategoryRequest struct {
+	Code          string `json:"code"` // Optional on create (derived from display name), required on update
+	DisplayName   string `json:"display_name"`
+	DefaultAmount int    `json:"default_amount"`
+	IsRecurring   bool   `json:"is_recurring"`
+	IsActive      *bool  `json:"is_active"` // Update only, defaults to true
+}
+
+// GetCategories returns charge categories as JSON (?all=true includes inactive ones)
+func (h *ChargeCategoryHandler) GetCategories(w http.ResponseWriter, r *http.Request) {
+	activeOnly := r.URL.Query().Get("all") != "true"
+
+	categories, err := h.categoryService.GetAllCategories(activeOnly)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(categories)
+}
+
+// CreateCategory creates a new charge category
+func (h *ChargeCategoryHandler) CreateCategory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Method not allowed",
+		})
+		return
+	}
+
+	var req chargeCategoryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Invalid JSON",
+		})
+		return
+	}
+
+	category := &domain.ChargeCategory{
+		Code:          req.Code,
+		DisplayName:   req.DisplayName,
+		DefaultAmount: req.DefaultAmount,
+		IsRecurring:   req.IsRecurring,
+	}
+
+	if err := h.categoryService.CreateCategory(category); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":  true,
+		"message":  "Charge category created successfully",
+		"category": category,
+	})
+}
+
+// UpdateCategory updates an existing charge category
+func (h *ChargeCategoryHandler) UpdateCategory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Method not allowed",
+		})
+		return
+	}
+
+	var req chargeCategoryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Invalid JSON",
+		})
+		return
+	}
+
+	if req.Code == "" {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "code is required",
+		})
+		return
+	}
+
+	category := &domain.ChargeCategory{
+		Code:          req.Code,
+		DisplayName:   req.DisplayName,
+		DefaultAmount: req.DefaultAmount,
+		IsRecurring:   req.IsRecurring,
+		IsActive:      req.IsActive == nil || *req.IsActive,
+	}
+
+	if err := h.categoryService.UpdateCategory(category); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	// Display names on the dashboard may have changed
+	h.dashboardService.InvalidateDashboardCache()
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":  true,
+		"message":  "Charge category updated successfully",
+		"category": category,
+	})
+}
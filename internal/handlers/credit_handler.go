@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"backend-form/m/internal/service"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// CreditHandler handles owner-facing tenant credit wallet operations
+type CreditHandler struct {
+	creditService *service.CreditService
+}
+
+// NewCreditHandler creates a new CreditHandler
+func NewCreditHandler(creditService *service.CreditService) *CreditHandler {
+	return &CreditHandler{
+		creditService: creditService,
+	}
+}
+
+// GetCredits returns a tenant's credit ledger and balance (?tenant_id=)
+func (h *CreditHandler) GetCredits(w http.ResponseWriter, r *http.Request) {
+	tenantID := 0
+	if tenantIDStr := r.URL.Query().Get("tenant_id"); tenantIDStr != "" {
+		fmt.Sscanf(tenantIDStr, "%d", &tenantID)
+	}
+
+	if tenantID <= 0 {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "tenant_id is required",
+		})
+		return
+	}
+
+	ledger, err := h.creditService.GetLedger(tenantID)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"ledger":  ledger,
+		"balance": ledger.GetBalance(),
+	})
+}
+
+// RefundCredit records credit paid back to a tenant
+func (h *CreditHandler) RefundCredit(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Method not allowed",
+		})
+		return
+	}
+
+	var req struct {
+		TenantID int    `json:"tenant_id"`
+		Amount   int    `json:"amount"`
+		Reason   string `json:"reason"`
+		Date     string `json:"date"` // Optional, defaults to today (YYYY-MM-DD)
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Invalid JSON",
+		})
+		return
+	}
+
+	refundDate := time.Now()
+	if req.Date != "" {
+		parsed, err := time.Parse("2006-01-02", req.Date)
+		if err != nil {
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"success": false,
+				"error":   "Invalid date format, expected YYYY-MM-DD",
+			})
+			return
+		}
+		refundDate = parsed
+	}
+
+	ledger, err := h.creditService.RefundCredit(req.TenantID, req.Amount, req.Reason, refundDate)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Credit refund recorded",
+		"ledger":  ledger,
+		"balance": ledger.GetBalance(),
+	})
+}
@@ -0,0 +1,187 @@
+package handlers
+
+import (
+	"backend-form/m/internal/service"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// DepositHandler handles owner-facing security deposit ledger operations
+type DepositHandler struct {
+	depositService *service.DepositService
+}
+
+// NewDepositHandler creates a new DepositHandler
+func NewDepositHandler(depositService *service.DepositService) *DepositHandler {
+	return &DepositHandler{
+		depositService: depositService,
+	}
+}
+
+// depositEntryRequest is the JSON body for collection and deduction entries
+type depositEntryRequest struct {
+	TenantID int    `json:"tenant_id"`
+	Amount   int    `json:"amount"`
+	Reason   string `json:"reason"`
+	Date     string `json:"date"` // Optional, defaults to today (YYYY-MM-DD)
+}
+
+// GetDeposit returns a tenant's deposit ledger (?tenant_id=)
+func (h *DepositHandler) GetDeposit(w http.ResponseWriter, r *http.Request) {
+	tenantID := 0
+	if tenantIDStr := r.URL.Query().Get("tenant_id"); tenantIDStr != "" {
+		fmt.Sscanf(tenantIDStr, "%d", &tenantID)
+	}
+
+	if tenantID <= 0 {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "tenant_id is required",
+		})
+		return
+	}
+
+	deposit, err := h.depositService.GetDepositByTenantID(tenantID)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+	if deposit == nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "No security deposit found for this tenant",
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":     true,
+		"deposit":     deposit,
+		"collected":   deposit.GetCollectedAmount(),
+		"balance":     deposit.GetBalance(),
+		"outstanding": deposit.GetOutstandingAmount(),
+	})
+}
+
+// RecordCollection records deposit money received from a tenant
+func (h *DepositHandler) RecordCollection(w http.ResponseWriter, r *http.Request) {
+	h.recordEntry(w, r, true)
+}
+
+// RecordDeduction records a deduction from a tenant's held deposit
+func (h *DepositHandler) RecordDeduction(w http.ResponseWriter, r *http.Request) {
+	h.recordEntry(w, r, false)
+}
+
+// recordEntry decodes a ledger entry request and records a collection or deduction
+func (h *DepositHandler) recordEntry(w http.ResponseWriter, r *http.Request, isCollection bool) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Method not allowed",
+		})
+		return
+	}
+
+	var req depositEntryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Invalid JSON",
+		})
+		return
+	}
+
+	entryDate := time.Now()
+	if req.Date != "" {
+		parsed, err := time.Parse("2006-01-02", req.Date)
+		if err != nil {
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"success": false,
+				"error":   "Invalid date format, expected YYYY-MM-DD",
+			})
+			return
+		}
+		entryDate = parsed
+	}
+
+	var err error
+	message := "Deposit collection recorded"
+	if isCollection {
+		_, err = h.depositService.RecordCollection(req.TenantID, req.Amount, entryDate, req.Reason)
+	} else {
+		_, err = h.depositService.RecordDeduction(req.TenantID, req.Amount, req.Reason, entryDate)
+		message = "Deposit deduction recorded"
+	}
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	deposit, _ := h.depositService.GetDepositByTenantID(req.TenantID)
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": message,
+		"deposit": deposit,
+	})
+}
+
+// GetSettlement returns the move-out settlement statement of a tenant's deposit (?tenant_id=)
+func (h *DepositHandler) GetSettlement(w http.ResponseWriter, r *http.Request) {
+	tenantID := 0
+	if tenantIDStr := r.URL.Query().Get("tenant_id"); tenantIDStr != "" {
+		fmt.Sscanf(tenantIDStr, "%d", &tenantID)
+	}
+
+	if tenantID <= 0 {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "tenant_id is required",
+		})
+		return
+	}
+
+	settlement, err := h.depositService.GetSettlement(tenantID)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":    true,
+		"settlement": settlement,
+	})
+}
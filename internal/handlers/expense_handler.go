@@ -0,0 +1,554 @@
+package handlers
+
+import (
+	"backend-form/m/internal/domain"
+	"backend-form/m/internal/repository/interfaces"
+	"backend-form/m/internal/service"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// maxAttachmentUploadBytes caps the multipart body of an attachment upload (file plus form overhead)
+const maxAttachmentUploadBytes = domain.MaxExpenseAttachmentSize + 1<<20
+
+// ExpenseHandler handles the owner's expense ledger and income/expense reports
+type ExpenseHandler struct {
+	expenseService *service.ExpenseService
+}
+
+// NewExpenseHandler creates a new ExpenseHandler
+func NewExpenseHandler(expenseService *service.ExpenseService) *ExpenseHandler {
+	return &ExpenseHandler{
+		expenseService: expenseService,
+	}
+}
+
+// expenseCategoryRequest is the JSON body accepted by category create and update
+type expenseCategoryRequest struct {
+	Code        string `json:"code"` // Optional on create (derived from display name), required on update
+	DisplayName string `json:"display_name"`
+	IsActive    *bool  `json:"is_active"` // Update only, defaults to true
+}
+
+// expenseRequest is the JSON body accepted by expense create and update
+type expenseRequest struct {
+	ID            int    `json:"id"` // Update only
+	Category      string `json:"category"`
+	PropertyID    *int   `json:"property_id"`  // Optional
+	UnitID        *int   `json:"unit_id"`      // Optional, implies the unit's property
+	Amount        int    `json:"amount"`       // In rupees
+	ExpenseDate   string `json:"expense_date"` // YYYY-MM-DD
+	Vendor        string `json:"vendor"`
+	Description   string `json:"description"`
+	PaymentMethod string `json:"payment_method"`
+	Reference     string `json:"reference"`
+}
+
+// ============================================
+// Categories
+// ============================================
+
+// GetCategories returns expense categories as JSON (?all=true includes inactive ones)
+func (h *ExpenseHandler) GetCategories(w http.ResponseWriter, r *http.Request) {
+	activeOnly := r.URL.Query().Get("all") != "true"
+
+	categories, err := h.expenseService.GetAllCategories(activeOnly)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(categories)
+}
+
+// CreateCategory creates a new expense category
+func (h *ExpenseHandler) CreateCategory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Method not allowed",
+		})
+		return
+	}
+
+	var req expenseCategoryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Invalid JSON",
+		})
+		return
+	}
+
+	category := &domain.ExpenseCategory{
+		Code:        req.Code,
+		DisplayName: req.DisplayName,
+	}
+
+	if err := h.expenseService.CreateCategory(category); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":  true,
+		"message":  "Expense category created successfully",
+		"category": category,
+	})
+}
+
+// UpdateCategory renames or (de)activates an expense category
+func (h *ExpenseHandler) UpdateCategory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Method not allowed",
+		})
+		return
+	}
+
+	var req expenseCategoryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Invalid JSON",
+		})
+		return
+	}
+
+	category := &domain.ExpenseCategory{
+		Code:        req.Code,
+		DisplayName: req.DisplayName,
+		IsActive:    req.IsActive == nil || *req.IsActive,
+	}
+
+	if err := h.expenseService.UpdateCategory(category); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":  true,
+		"message":  "Expense category updated successfully",
+		"category": category,
+	})
+}
+
+// ============================================
+// Expenses
+// ============================================
+
+// GetExpenses returns expenses as JSON (?property_id=&unit_id=&category=&from=YYYY-MM-DD&to=YYYY-MM-DD)
+func (h *ExpenseHandler) GetExpenses(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := interfaces.ExpenseFilter{Category: query.Get("category")}
+	if propertyIDStr := query.Get("property_id"); propertyIDStr != "" {
+		fmt.Sscanf(propertyIDStr, "%d", &filter.PropertyID)
+	}
+	if unitIDStr := query.Get("unit_id"); unitIDStr != "" {
+		fmt.Sscanf(unitIDStr, "%d", &filter.UnitID)
+	}
+
+	var err error
+	if fromStr := query.Get("from"); fromStr != "" {
+		if filter.From, err = time.Parse("2006-01-02", fromStr); err != nil {
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"success": false,
+				"error":   "Invalid from date, expected YYYY-MM-DD",
+			})
+			return
+		}
+	}
+	if toStr := query.Get("to"); toStr != "" {
+		if filter.To, err = time.Parse("2006-01-02", toStr); err != nil {
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"success": false,
+				"error":   "Invalid to date, expected YYYY-MM-DD",
+			})
+			return
+		}
+	}
+
+	expenses, err := h.expenseService.GetExpenses(filter)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	total := 0
+	for _, expense := range expenses {
+		total += expense.Amount
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":  true,
+		"expenses": expenses,
+		"total":    total,
+	})
+}
+
+// CreateExpense records a new expense
+func (h *ExpenseHandler) CreateExpense(w http.ResponseWriter, r *http.Request) {
+	h.saveExpense(w, r, false)
+}
+
+// UpdateExpense updates an existing expense
+func (h *ExpenseHandler) UpdateExpense(w http.ResponseWriter, r *http.Request) {
+	h.saveExpense(w, r, true)
+}
+
+// saveExpense decodes an expense request and creates or updates the expense
+func (h *ExpenseHandler) saveExpense(w http.ResponseWriter, r *http.Request, update bool) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Method not allowed",
+		})
+		return
+	}
+
+	user, ok := r.Context().Value("user").(*domain.User)
+	if !ok || user == nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Unauthorized",
+		})
+		return
+	}
+
+	var req expenseRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Invalid JSON",
+		})
+		return
+	}
+
+	expenseDate, err := time.Parse("2006-01-02", req.ExpenseDate)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Invalid expense date, expected YYYY-MM-DD",
+		})
+		return
+	}
+
+	expense := &domain.Expense{
+		ID:            req.ID,
+		Category:      req.Category,
+		PropertyID:    req.PropertyID,
+		UnitID:        req.UnitID,
+		Amount:        req.Amount,
+		ExpenseDate:   expenseDate,
+		Vendor:        req.Vendor,
+		Description:   req.Description,
+		PaymentMethod: req.PaymentMethod,
+		Reference:     req.Reference,
+	}
+
+	message := "Expense recorded successfully"
+	if update {
+		err = h.expenseService.UpdateExpense(expense)
+		message = "Expense updated successfully"
+	} else {
+		err = h.expenseService.CreateExpense(expense, user.ID)
+	}
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": message,
+		"expense": expense,
+	})
+}
+
+// DeleteExpense deletes an expense and its attachments
+func (h *ExpenseHandler) DeleteExpense(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Method not allowed",
+		})
+		return
+	}
+
+	var req struct {
+		ID int `json:"id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Invalid JSON",
+		})
+		return
+	}
+
+	if err := h.expenseService.DeleteExpense(req.ID); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Expense deleted successfully",
+	})
+}
+
+// ============================================
+// Attachments
+// ============================================
+
+// UploadAttachment attaches a bill or receipt to an expense (multipart: expense_id, file)
+func (h *ExpenseHandler) UploadAttachment(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Method not allowed",
+		})
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxAttachmentUploadBytes)
+	if err := r.ParseMultipartForm(maxAttachmentUploadBytes); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Invalid upload: " + err.Error(),
+		})
+		return
+	}
+
+	expenseID := 0
+	fmt.Sscanf(r.FormValue("expense_id"), "%d", &expenseID)
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "file is required",
+		})
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Failed to read file: " + err.Error(),
+		})
+		return
+	}
+
+	// Trust the file contents rather than the type claimed by the browser
+	attachment, err := h.expenseService.AddAttachment(expenseID, header.Filename, http.DetectContentType(data), data)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":    true,
+		"message":    "Attachment uploaded successfully",
+		"attachment": attachment,
+	})
+}
+
+// DownloadAttachment returns an expense attachment file (?id=)
+func (h *ExpenseHandler) DownloadAttachment(w http.ResponseWriter, r *http.Request) {
+	attachmentID := 0
+	if idStr := r.URL.Query().Get("id"); idStr != "" {
+		fmt.Sscanf(idStr, "%d", &attachmentID)
+	}
+
+	attachment, err := h.expenseService.GetAttachment(attachmentID)
+	if err != nil {
+		http.Error(w, "Attachment not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", attachment.FileName))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Write(attachment.Data)
+}
+
+// DeleteAttachment deletes an expense attachment
+func (h *ExpenseHandler) DeleteAttachment(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Method not allowed",
+		})
+		return
+	}
+
+	var req struct {
+		ID int `json:"id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Invalid JSON",
+		})
+		return
+	}
+
+	if err := h.expenseService.DeleteAttachment(req.ID); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Attachment deleted successfully",
+	})
+}
+
+// ============================================
+// Reports
+// ============================================
+
+// GetReport returns an income and expense report (?period=monthly|yearly&fy=2025-26&property_id=&format=json|csv)
+// Monthly reports cover one financial year (default: the current one); yearly reports cover all years.
+func (h *ExpenseHandler) GetReport(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	propertyID := 0
+	if propertyIDStr := query.Get("property_id"); propertyIDStr != "" {
+		fmt.Sscanf(propertyIDStr, "%d", &propertyID)
+	}
+
+	var report *domain.IncomeExpenseReport
+	var err error
+	filename := "income-expense"
+	switch query.Get("period") {
+	case "", domain.ReportMonthly:
+		financialYear := financialYearParam(r)
+		report, err = h.expenseService.GetMonthlyReport(financialYear, propertyID)
+		filename += "-" + financialYear
+	case domain.ReportYearly:
+		report, err = h.expenseService.GetYearlyReport(propertyID)
+		filename += "-yearly"
+	default:
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "period must be one of: monthly, yearly",
+		})
+		return
+	}
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	if query.Get("format") == "csv" {
+		var buf bytes.Buffer
+		if err := service.WriteIncomeExpenseReportCSV(report, &buf); err != nil {
+			http.Error(w, "Failed to render report: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename+".csv"))
+		w.Write(buf.Bytes())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"report":  report,
+	})
+}
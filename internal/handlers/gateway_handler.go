@@ -0,0 +1,245 @@
+package handlers
+
+import (
+	"backend-form/m/internal/domain"
+	"backend-form/m/internal/gateway"
+	"backend-form/m/internal/metrics"
+	"backend-form/m/internal/service"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// maxWebhookBodyBytes caps the size of a gateway webhook body
+const maxWebhookBodyBytes = 1 << 20 // 1 MB
+
+// GatewayHandler handles online payments through the configured payment gateway
+type GatewayHandler struct {
+	gatewayService   *service.GatewayService
+	dashboardService *service.DashboardService
+}
+
+// NewGatewayHandler creates a new GatewayHandler
+func NewGatewayHandler(gatewayService *service.GatewayService, dashboardService *service.DashboardService) *GatewayHandler {
+	return &GatewayHandler{
+		gatewayService:   gatewayService,
+		dashboardService: dashboardService,
+	}
+}
+
+// CreateOrder creates a gateway order for the logged-in tenant's payment
+// Body (optional): {"payment_id": 12}; defaults to the current unpaid payment
+func (h *GatewayHandler) CreateOrder(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Method not allowed",
+		})
+		return
+	}
+
+	user, ok := r.Context().Value("user").(*domain.User)
+	if !ok || user == nil || user.TenantID == nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Unauthorized",
+		})
+		return
+	}
+
+	var req struct {
+		PaymentID int `json:"payment_id"`
+	}
+	if r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"success": false,
+				"error":   "Invalid JSON",
+			})
+			return
+		}
+	}
+
+	order, err := h.gatewayService.CreateOrder(*user.TenantID, req.PaymentID)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"gateway": h.gatewayService.GetGatewayName(),
+		"order":   order,
+	})
+}
+
+// Webhook receives payment callbacks from the gateway
+// Public endpoint: requests are authenticated by the gateway's signature
+func (h *GatewayHandler) Webhook(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Method not allowed",
+		})
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBodyBytes))
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Failed to read body",
+		})
+		return
+	}
+
+	order, err := h.gatewayService.HandleWebhook(body, r.Header)
+	if errors.Is(err, gateway.ErrInvalidSignature) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		fmt.Printf("Warning: Gateway webhook failed: %v\n", err)
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	h.recordOrderUpdate(order)
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"status":  order.Status,
+	})
+}
+
+// MockCheckout completes a mock gateway order for the logged-in tenant
+// Body: {"order_id": "order_mock_...", "fail": false}
+func (h *GatewayHandler) MockCheckout(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Method not allowed",
+		})
+		return
+	}
+
+	user, ok := r.Context().Value("user").(*domain.User)
+	if !ok || user == nil || user.TenantID == nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Unauthorized",
+		})
+		return
+	}
+
+	var req struct {
+		OrderID string `json:"order_id"`
+		Fail    bool   `json:"fail"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Invalid JSON",
+		})
+		return
+	}
+
+	order, err := h.gatewayService.CompleteMockOrder(*user.TenantID, req.OrderID, !req.Fail)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	h.recordOrderUpdate(order)
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"order":   order,
+	})
+}
+
+// GetOrders returns a tenant's gateway orders (?tenant_id=)
+func (h *GatewayHandler) GetOrders(w http.ResponseWriter, r *http.Request) {
+	tenantID := 0
+	if tenantIDStr := r.URL.Query().Get("tenant_id"); tenantIDStr != "" {
+		fmt.Sscanf(tenantIDStr, "%d", &tenantID)
+	}
+
+	if tenantID <= 0 {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "tenant_id is required",
+		})
+		return
+	}
+
+	orders, err := h.gatewayService.GetOrdersByTenantID(tenantID)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"orders":  orders,
+	})
+}
+
+// recordOrderUpdate refreshes cached payment data once an order has been paid
+func (h *GatewayHandler) recordOrderUpdate(order *domain.GatewayOrder) {
+	if !order.IsPaid() {
+		return
+	}
+	// Invalidate dashboard cache since payment data changed
+	h.dashboardService.InvalidateDashboardCache()
+	metrics.GetMetrics().IncrementPaymentVerified()
+}
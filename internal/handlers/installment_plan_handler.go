@@ -0,0 +1,227 @@
+package handlers
+
+import (
+	"backend-form/m/internal/domain"
+	"backend-form/m/internal/service"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// InstallmentPlanHandler handles owner-facing installment plans for tenants in arrears
+type InstallmentPlanHandler struct {
+	planService      *service.InstallmentPlanService
+	dashboardService *service.DashboardService
+}
+
+// NewInstallmentPlanHandler creates a new InstallmentPlanHandler
+func NewInstallmentPlanHandler(
+	planService *service.InstallmentPlanService,
+	dashboardService *service.DashboardService,
+) *InstallmentPlanHandler {
+	return &InstallmentPlanHandler{
+		planService:      planService,
+		dashboardService: dashboardService,
+	}
+}
+
+// GetPlans returns a single plan (?id=) or all plans of a tenant (?tenant_id=)
+func (h *InstallmentPlanHandler) GetPlans(w http.ResponseWriter, r *http.Request) {
+	planID, tenantID := 0, 0
+	if idStr := r.URL.Query().Get("id"); idStr != "" {
+		fmt.Sscanf(idStr, "%d", &planID)
+	}
+	if tenantIDStr := r.URL.Query().Get("tenant_id"); tenantIDStr != "" {
+		fmt.Sscanf(tenantIDStr, "%d", &tenantID)
+	}
+
+	if planID > 0 {
+		plan, err := h.planService.GetPlanByID(planID)
+		if err != nil {
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"success": false,
+				"error":   err.Error(),
+			})
+			return
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		json.NewEncoder(w).Encode(plan)
+		return
+	}
+
+	if tenantID <= 0 {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "id or tenant_id is required",
+		})
+		return
+	}
+
+	plans, err := h.planService.GetPlansByTenantID(tenantID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(plans)
+}
+
+// CreatePlan restructures a tenant's arrears into an installment plan
+// Either pass an explicit schedule in installments, or installment_count and first_due_date
+// to split the arrears into equal monthly installments
+func (h *InstallmentPlanHandler) CreatePlan(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Method not allowed",
+		})
+		return
+	}
+
+	user, ok := r.Context().Value("user").(*domain.User)
+	if !ok || user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req struct {
+		TenantID         int    `json:"tenant_id"`
+		PaymentIDs       []int  `json:"payment_ids"`       // Optional, defaults to every overdue payment
+		InstallmentCount int    `json:"installment_count"` // Used when installments is empty
+		FirstDueDate     string `json:"first_due_date"`    // Format: "2006-01-02"
+		Installments     []struct {
+			Amount  int    `json:"amount"`
+			DueDate string `json:"due_date"` // Format: "2006-01-02"
+		} `json:"installments"`
+		Notes string `json:"notes"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Invalid JSON",
+		})
+		return
+	}
+
+	if req.TenantID <= 0 {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "tenant_id is required",
+		})
+		return
+	}
+
+	plan := &domain.InstallmentPlan{
+		TenantID: req.TenantID,
+		Notes:    req.Notes,
+	}
+	for _, item := range req.Installments {
+		dueDate, err := time.Parse("2006-01-02", item.DueDate)
+		if err != nil {
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"success": false,
+				"error":   "Invalid installment due_date format. Use YYYY-MM-DD",
+			})
+			return
+		}
+		plan.Installments = append(plan.Installments, &domain.Installment{Amount: item.Amount, DueDate: dueDate})
+	}
+
+	var firstDue time.Time
+	if len(plan.Installments) == 0 {
+		var err error
+		firstDue, err = time.Parse("2006-01-02", req.FirstDueDate)
+		if err != nil {
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"success": false,
+				"error":   "Invalid first_due_date format. Use YYYY-MM-DD",
+			})
+			return
+		}
+	}
+
+	plan, err := h.planService.CreatePlan(plan, req.PaymentIDs, req.InstallmentCount, firstDue, user.ID)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	// Invalidate dashboard cache since payment data changed
+	h.dashboardService.InvalidateDashboardCache()
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": fmt.Sprintf("Installment plan created with %d installments", len(plan.Installments)),
+		"plan":    plan,
+	})
+}
+
+// CancelPlan cancels an active plan and restores the unpaid balance to the original payments
+func (h *InstallmentPlanHandler) CancelPlan(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Method not allowed",
+		})
+		return
+	}
+
+	var req struct {
+		PlanID int    `json:"plan_id"`
+		Reason string `json:"reason"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Invalid JSON",
+		})
+		return
+	}
+
+	plan, err := h.planService.CancelPlan(req.PlanID, req.Reason)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	// Invalidate dashboard cache since payment data changed
+	h.dashboardService.InvalidateDashboardCache()
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Installment plan cancelled",
+		"plan":    plan,
+	})
+}
@@ -0,0 +1,334 @@
+package handlers
+
+import (
+	"backend-form/m/internal/domain"
+	"backend-form/m/internal/service"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// LateFeeHandler handles owner-facing late fee policies and actions
+type LateFeeHandler struct {
+	lateFeeService   *service.LateFeeService
+	dashboardService *service.DashboardService
+}
+
+// NewLateFeeHandler creates a new LateFeeHandler
+func NewLateFeeHandler(
+	lateFeeService *service.LateFeeService,
+	dashboardService *service.DashboardService,
+) *LateFeeHandler {
+	return &LateFeeHandler{
+		lateFeeService:   lateFeeService,
+		dashboardService: dashboardService,
+	}
+}
+
+// lateFeePolicyRequest is the JSON body accepted by create and update
+type lateFeePolicyRequest struct {
+	PolicyID   int     `json:"policy_id"` // Required for update only
+	PropertyID *int    `json:"property_id"`
+	UnitID     *int    `json:"unit_id"`
+	FeeType    string  `json:"fee_type"` // flat, per_day, percentage
+	Amount     int     `json:"amount"`
+	Percentage float64 `json:"percentage"`
+	GraceDays  int     `json:"grace_days"`
+	MaxFee     int     `json:"max_fee"` // 0 = no cap
+}
+
+// toPolicy converts the request into a domain policy
+func (req *lateFeePolicyRequest) toPolicy() *domain.LateFeePolicy {
+	return &domain.LateFeePolicy{
+		ID:         req.PolicyID,
+		PropertyID: req.PropertyID,
+		UnitID:     req.UnitID,
+		FeeType:    req.FeeType,
+		Amount:     req.Amount,
+		Percentage: req.Percentage,
+		GraceDays:  req.GraceDays,
+		MaxFee:     req.MaxFee,
+	}
+}
+
+// GetPolicies returns all late fee policies as JSON
+func (h *LateFeeHandler) GetPolicies(w http.ResponseWriter, r *http.Request) {
+	policies, err := h.lateFeeService.GetAllPolicies()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(policies)
+}
+
+// CreatePolicy creates a late fee policy for a unit or property
+func (h *LateFeeHandler) CreatePolicy(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Method not allowed",
+		})
+		return
+	}
+
+	var req lateFeePolicyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Invalid JSON",
+		})
+		return
+	}
+
+	policy := req.toPolicy()
+	if err := h.lateFeeService.CreatePolicy(policy); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Late fee policy created successfully",
+		"policy":  policy,
+	})
+}
+
+// UpdatePolicy updates an existing late fee policy
+func (h *LateFeeHandler) UpdatePolicy(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Method not allowed",
+		})
+		return
+	}
+
+	var req lateFeePolicyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Invalid JSON",
+		})
+		return
+	}
+
+	if req.PolicyID <= 0 {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "policy_id is required",
+		})
+		return
+	}
+
+	policy := req.toPolicy()
+	if err := h.lateFeeService.UpdatePolicy(policy); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Late fee policy updated successfully",
+		"policy":  policy,
+	})
+}
+
+// DeletePolicy deletes a late fee policy
+func (h *LateFeeHandler) DeletePolicy(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Method not allowed",
+		})
+		return
+	}
+
+	var req struct {
+		PolicyID int `json:"policy_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Invalid JSON",
+		})
+		return
+	}
+
+	if err := h.lateFeeService.DeletePolicy(req.PolicyID); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Late fee policy deleted successfully",
+	})
+}
+
+// GetLateFees returns the late fees charged to a tenant (?tenant_id=)
+func (h *LateFeeHandler) GetLateFees(w http.ResponseWriter, r *http.Request) {
+	tenantID := 0
+	if tenantIDStr := r.URL.Query().Get("tenant_id"); tenantIDStr != "" {
+		fmt.Sscanf(tenantIDStr, "%d", &tenantID)
+	}
+
+	if tenantID <= 0 {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "tenant_id is required",
+		})
+		return
+	}
+
+	fees, err := h.lateFeeService.GetLateFeesByTenantID(tenantID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(fees)
+}
+
+// WaiveLateFee forgives the unpaid part of a late fee
+func (h *LateFeeHandler) WaiveLateFee(w http.ResponseWriter, r *http.Request) {
+	h.closeLateFee(w, r, false)
+}
+
+// ReverseLateFee cancels a late fee charged in error
+func (h *LateFeeHandler) ReverseLateFee(w http.ResponseWriter, r *http.Request) {
+	h.closeLateFee(w, r, true)
+}
+
+// closeLateFee handles both waive and reverse requests
+func (h *LateFeeHandler) closeLateFee(w http.ResponseWriter, r *http.Request, reverse bool) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Method not allowed",
+		})
+		return
+	}
+
+	user, ok := r.Context().Value("user").(*domain.User)
+	if !ok || user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req struct {
+		LateFeeID int    `json:"late_fee_id"`
+		Reason    string `json:"reason"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Invalid JSON",
+		})
+		return
+	}
+
+	var fee *domain.LateFee
+	var err error
+	message := "Late fee waived successfully"
+	if reverse {
+		fee, err = h.lateFeeService.ReverseLateFee(req.LateFeeID, user.ID, req.Reason)
+		message = "Late fee reversed successfully"
+	} else {
+		fee, err = h.lateFeeService.WaiveLateFee(req.LateFeeID, user.ID, req.Reason)
+	}
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	// Invalidate dashboard cache since payment data changed
+	h.dashboardService.InvalidateDashboardCache()
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":  true,
+		"message":  message,
+		"late_fee": fee,
+	})
+}
+
+// RunLateFees applies late fees immediately instead of waiting for the daily job
+func (h *LateFeeHandler) RunLateFees(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Method not allowed",
+		})
+		return
+	}
+
+	changed, err := h.lateFeeService.ApplyLateFees(time.Now())
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	// Invalidate dashboard cache since payment data changed
+	h.dashboardService.InvalidateDashboardCache()
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":      true,
+		"message":      fmt.Sprintf("%d late fee(s) charged or updated", changed),
+		"fees_charged": changed,
+	})
+}
@@ -0,0 +1,247 @@
+package handlers
+
+import (
+	"backend-form/m/internal/domain"
+	"backend-form/m/internal/service"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// LeaseHandler handles owner-facing lease agreements
+type LeaseHandler struct {
+	leaseService *service.LeaseService
+}
+
+// NewLeaseHandler creates a new LeaseHandler
+func NewLeaseHandler(leaseService *service.LeaseService) *LeaseHandler {
+	return &LeaseHandler{
+		leaseService: leaseService,
+	}
+}
+
+// GetLeases returns a tenant's active lease and renewal history (?tenant_id=)
+func (h *LeaseHandler) GetLeases(w http.ResponseWriter, r *http.Request) {
+	tenantID := 0
+	if tenantIDStr := r.URL.Query().Get("tenant_id"); tenantIDStr != "" {
+		fmt.Sscanf(tenantIDStr, "%d", &tenantID)
+	}
+
+	if tenantID <= 0 {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "tenant_id is required",
+		})
+		return
+	}
+
+	leases, err := h.leaseService.GetLeaseHistory(tenantID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := map[string]interface{}{
+		"active":  nil,
+		"history": leases,
+	}
+	now := time.Now()
+	for _, lease := range leases {
+		if lease.IsActive() {
+			response["active"] = lease
+			response["current_rent"] = lease.GetRentFor(now)
+			response["next_escalation_date"] = lease.GetNextEscalationDate(now)
+			response["lock_in_end_date"] = lease.GetLockInEndDate()
+			response["notice_deadline"] = lease.GetNoticeDeadline()
+			break
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(response)
+}
+
+// CreateLease creates the lease of a tenant
+func (h *LeaseHandler) CreateLease(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Method not allowed",
+		})
+		return
+	}
+
+	var req struct {
+		TenantID                 int     `json:"tenant_id"`
+		StartDate                string  `json:"start_date"`   // Optional, defaults to move-in date. Format: "2006-01-02"
+		EndDate                  string  `json:"end_date"`     // Format: "2006-01-02"
+		MonthlyRent              int     `json:"monthly_rent"` // Optional, defaults to the unit's rent
+		EscalationPercent        float64 `json:"escalation_percent"`
+		EscalationIntervalMonths int     `json:"escalation_interval_months"` // Defaults to 12 when escalation is set
+		NoticePeriodDays         int     `json:"notice_period_days"`
+		LockInMonths             int     `json:"lock_in_months"`
+		Notes                    string  `json:"notes"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Invalid JSON",
+		})
+		return
+	}
+
+	if req.TenantID <= 0 {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "tenant_id is required",
+		})
+		return
+	}
+
+	endDate, err := time.Parse("2006-01-02", req.EndDate)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Invalid end_date format. Use YYYY-MM-DD",
+		})
+		return
+	}
+
+	lease := &domain.Lease{
+		TenantID:                 req.TenantID,
+		EndDate:                  endDate,
+		MonthlyRent:              req.MonthlyRent,
+		EscalationPercent:        req.EscalationPercent,
+		EscalationIntervalMonths: req.EscalationIntervalMonths,
+		NoticePeriodDays:         req.NoticePeriodDays,
+		LockInMonths:             req.LockInMonths,
+		Notes:                    req.Notes,
+	}
+	if req.StartDate != "" {
+		lease.StartDate, err = time.Parse("2006-01-02", req.StartDate)
+		if err != nil {
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"success": false,
+				"error":   "Invalid start_date format. Use YYYY-MM-DD",
+			})
+			return
+		}
+	}
+
+	if err := h.leaseService.CreateLease(lease); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Lease created successfully",
+		"lease":   lease,
+	})
+}
+
+// RenewLease renews a tenant's active lease until a new end date
+func (h *LeaseHandler) RenewLease(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Method not allowed",
+		})
+		return
+	}
+
+	var req struct {
+		TenantID          int      `json:"tenant_id"`
+		EndDate           string   `json:"end_date"`           // Format: "2006-01-02"
+		MonthlyRent       int      `json:"monthly_rent"`       // Optional, defaults to the rent in force at renewal
+		EscalationPercent *float64 `json:"escalation_percent"` // Optional, defaults to the current lease's
+		Notes             string   `json:"notes"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Invalid JSON",
+		})
+		return
+	}
+
+	if req.TenantID <= 0 {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "tenant_id is required",
+		})
+		return
+	}
+
+	endDate, err := time.Parse("2006-01-02", req.EndDate)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Invalid end_date format. Use YYYY-MM-DD",
+		})
+		return
+	}
+
+	lease, err := h.leaseService.RenewLease(req.TenantID, endDate, req.MonthlyRent, req.EscalationPercent, req.Notes)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Lease renewed successfully",
+		"lease":   lease,
+	})
+}
+
+// GetUpcomingLeaseEvents returns lease expiries and rent escalations coming up (?days=, default 60)
+func (h *LeaseHandler) GetUpcomingLeaseEvents(w http.ResponseWriter, r *http.Request) {
+	days := 60
+	if daysStr := r.URL.Query().Get("days"); daysStr != "" {
+		fmt.Sscanf(daysStr, "%d", &days)
+	}
+
+	now := time.Now()
+	events, err := h.leaseService.GetUpcomingEvents(time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC), days)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(events)
+}
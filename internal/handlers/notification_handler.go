@@ -0,0 +1,216 @@
+package handlers
+
+import (
+	"backend-form/m/internal/domain"
+	"backend-form/m/internal/service"
+	"encoding/json"
+	"net/http"
+)
+
+// NotificationHandler handles notification preferences of the owner and tenants, and failed notifications
+type NotificationHandler struct {
+	preferenceService   *service.NotificationPreferenceService
+	notificationService *service.NotificationService
+}
+
+// NewNotificationHandler creates a new NotificationHandler
+func NewNotificationHandler(preferenceService *service.NotificationPreferenceService, notificationService *service.NotificationService) *NotificationHandler {
+	return &NotificationHandler{
+		preferenceService:   preferenceService,
+		notificationService: notificationService,
+	}
+}
+
+// GetMyPreferences returns the logged-in tenant's notification preferences
+func (h *NotificationHandler) GetMyPreferences(w http.ResponseWriter, r *http.Request) {
+	user, ok := r.Context().Value("user").(*domain.User)
+	if !ok || user == nil || user.TenantID == nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Unauthorized",
+		})
+		return
+	}
+
+	prefs, err := h.preferenceService.GetTenantPreferences(*user.TenantID)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	h.writePreferences(w, prefs)
+}
+
+// UpdateMyPreferences saves the logged-in tenant's notification preferences
+func (h *NotificationHandler) UpdateMyPreferences(w http.ResponseWriter, r *http.Request) {
+	user, ok := r.Context().Value("user").(*domain.User)
+	if !ok || user == nil || user.TenantID == nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Unauthorized",
+		})
+		return
+	}
+
+	var prefs domain.NotificationPreferences
+	if err := json.NewDecoder(r.Body).Decode(&prefs); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Invalid JSON",
+		})
+		return
+	}
+
+	if err := h.preferenceService.UpdateTenantPreferences(*user.TenantID, &prefs); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	h.writePreferences(w, &prefs)
+}
+
+// GetOwnerPreferences returns the owner's notification preferences
+func (h *NotificationHandler) GetOwnerPreferences(w http.ResponseWriter, r *http.Request) {
+	prefs, err := h.preferenceService.GetOwnerPreferences()
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	h.writePreferences(w, prefs)
+}
+
+// UpdateOwnerPreferences saves the owner's notification preferences
+func (h *NotificationHandler) UpdateOwnerPreferences(w http.ResponseWriter, r *http.Request) {
+	var prefs domain.NotificationPreferences
+	if err := json.NewDecoder(r.Body).Decode(&prefs); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Invalid JSON",
+		})
+		return
+	}
+
+	if err := h.preferenceService.UpdateOwnerPreferences(&prefs); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	h.writePreferences(w, &prefs)
+}
+
+// writePreferences writes preferences along with the options they can be set to
+func (h *NotificationHandler) writePreferences(w http.ResponseWriter, prefs *domain.NotificationPreferences) {
+	var types []map[string]interface{}
+	for _, t := range domain.NotificationTypesFor(prefs.Recipient) {
+		types = append(types, map[string]interface{}{
+			"type": t,
+			"name": t.GetDisplayName(),
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":            true,
+		"preferences":        prefs,
+		"available_channels": h.preferenceService.GetAvailableChannels(),
+		"notification_types": types,
+		"languages":          domain.NotificationLanguages,
+		"max_reminder_days":  domain.MaxReminderLeadDays,
+	})
+}
+
+// GetFailedNotifications returns the notifications that could not be delivered (owner only)
+func (h *NotificationHandler) GetFailedNotifications(w http.ResponseWriter, r *http.Request) {
+	notifications, err := h.notificationService.GetFailedNotifications()
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+	if notifications == nil {
+		notifications = []*domain.Notification{}
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":       true,
+		"notifications": notifications,
+	})
+}
+
+// RetryNotification puts a failed notification back in the delivery queue (owner only)
+func (h *NotificationHandler) RetryNotification(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Method not allowed",
+		})
+		return
+	}
+
+	var req struct {
+		NotificationID int `json:"notification_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Invalid JSON",
+		})
+		return
+	}
+
+	notification, err := h.notificationService.RetryNotification(req.NotificationID)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":      true,
+		"message":      "Notification queued for delivery",
+		"notification": notification,
+	})
+}
@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"backend-form/m/internal/domain"
+	"backend-form/m/internal/service"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// PaymentAdjustmentHandler handles owner-facing discounts, waivers and write-offs
+type PaymentAdjustmentHandler struct {
+	adjustmentService *service.PaymentAdjustmentService
+	dashboardService  *service.DashboardService
+}
+
+// NewPaymentAdjustmentHandler creates a new PaymentAdjustmentHandler
+func NewPaymentAdjustmentHandler(
+	adjustmentService *service.PaymentAdjustmentService,
+	dashboardService *service.DashboardService,
+) *PaymentAdjustmentHandler {
+	return &PaymentAdjustmentHandler{
+		adjustmentService: adjustmentService,
+		dashboardService:  dashboardService,
+	}
+}
+
+// GetAdjustments returns the adjustments of a payment (?payment_id=) or a tenant (?tenant_id=)
+func (h *PaymentAdjustmentHandler) GetAdjustments(w http.ResponseWriter, r *http.Request) {
+	paymentID, tenantID := 0, 0
+	if paymentIDStr := r.URL.Query().Get("payment_id"); paymentIDStr != "" {
+		fmt.Sscanf(paymentIDStr, "%d", &paymentID)
+	}
+	if tenantIDStr := r.URL.Query().Get("tenant_id"); tenantIDStr != "" {
+		fmt.Sscanf(tenantIDStr, "%d", &tenantID)
+	}
+
+	var adjustments []*domain.PaymentAdjustment
+	var err error
+	switch {
+	case paymentID > 0:
+		adjustments, err = h.adjustmentService.GetAdjustmentsByPaymentID(paymentID)
+	case tenantID > 0:
+		adjustments, err = h.adjustmentService.GetAdjustmentsByTenantID(tenantID)
+	default:
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "payment_id or tenant_id is required",
+		})
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(adjustments)
+}
+
+// CreateAdjustment records a discount, waiver or write-off against a payment
+func (h *PaymentAdjustmentHandler) CreateAdjustment(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Method not allowed",
+		})
+		return
+	}
+
+	user, ok := r.Context().Value("user").(*domain.User)
+	if !ok || user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req struct {
+		PaymentID      int    `json:"payment_id"`
+		AdjustmentType string `json:"adjustment_type"` // discount, waiver, write_off
+		Amount         int    `json:"amount"`
+		Reason         string `json:"reason"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Invalid JSON",
+		})
+		return
+	}
+
+	adjustment := &domain.PaymentAdjustment{
+		PaymentID:      req.PaymentID,
+		AdjustmentType: req.AdjustmentType,
+		Amount:         req.Amount,
+		Reason:         req.Reason,
+	}
+	payment, err := h.adjustmentService.CreateAdjustment(adjustment, user.ID)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	// Invalidate dashboard cache since payment data changed
+	h.dashboardService.InvalidateDashboardCache()
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":    true,
+		"message":    fmt.Sprintf("%s of %s recorded", adjustment.GetTypeDisplayName(), adjustment.GetFormattedAmount()),
+		"adjustment": adjustment,
+		"payment":    payment,
+	})
+}
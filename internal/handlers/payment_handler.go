@@ -37,9 +37,15 @@ func NewPaymentHandler(
 	}
 }
 
-// GetPayments returns all payments as JSON
+// GetPayments returns all payments as JSON (optionally filtered by ?property_id=)
 func (h *PaymentHandler) GetPayments(w http.ResponseWriter, r *http.Request) {
-	payments, err := h.paymentQueryService.GetAllPayments()
+	propertyID, err := propertyIDParam(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	payments, err := h.paymentQueryService.GetPaymentsByPropertyID(propertyID)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -281,6 +287,7 @@ func (h *PaymentHandler) RejectTransaction(w http.ResponseWriter, r *http.Reques
 
 	var req struct {
 		TransactionID string `json:"transaction_id"`
+		Reason        string `json:"reason"` // Shown to the tenant so they can resubmit
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -293,8 +300,15 @@ func (h *PaymentHandler) RejectTransaction(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	// Get user from context (already validated by middleware)
+	user, ok := r.Context().Value("user").(*domain.User)
+	if !ok || user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
 	// Reject transaction
-	if err := h.paymentTransactionService.RejectTransaction(req.TransactionID); err != nil {
+	if err := h.paymentTransactionService.RejectTransaction(req.TransactionID, req.Reason, user.ID); err != nil {
 		w.Header().Set("Content-Type", "application/json; charset=utf-8")
 		w.WriteHeader(http.StatusBadRequest)
 		json.NewEncoder(w).Encode(map[string]interface{}{
@@ -317,6 +331,87 @@ func (h *PaymentHandler) RejectTransaction(w http.ResponseWriter, r *http.Reques
 	})
 }
 
+// GetDisputedSubmissions returns rejected transaction submissions with counts per tenant (?tenant_id=, optional)
+func (h *PaymentHandler) GetDisputedSubmissions(w http.ResponseWriter, r *http.Request) {
+	tenantID := 0
+	if tenantIDStr := r.URL.Query().Get("tenant_id"); tenantIDStr != "" {
+		fmt.Sscanf(tenantIDStr, "%d", &tenantID)
+	}
+
+	summary, err := h.paymentTransactionService.GetDisputedSubmissions(tenantID)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"summary": summary,
+	})
+}
+
+// upiQRCodeSize is the width and height in pixels of generated UPI QR codes
+const upiQRCodeSize = 256
+
+// GetUPIPaymentRequest returns the UPI intent link and reference for a payment (?payment_id=)
+// With ?format=png the QR code image is returned instead, e.g. to share with the tenant
+func (h *PaymentHandler) GetUPIPaymentRequest(w http.ResponseWriter, r *http.Request) {
+	paymentID := 0
+	if paymentIDStr := r.URL.Query().Get("payment_id"); paymentIDStr != "" {
+		fmt.Sscanf(paymentIDStr, "%d", &paymentID)
+	}
+
+	if paymentID <= 0 {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "payment_id is required",
+		})
+		return
+	}
+
+	if r.URL.Query().Get("format") == "png" {
+		png, err := h.paymentService.GetUPIQRCode(paymentID, upiQRCodeSize)
+		if err != nil {
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"success": false,
+				"error":   err.Error(),
+			})
+			return
+		}
+		w.Header().Set("Content-Type", "image/png")
+		w.Header().Set("Cache-Control", "no-store") // Amount changes as the payment is paid
+		w.Write(png)
+		return
+	}
+
+	upiRequest, err := h.paymentService.GetUPIPaymentRequest(paymentID)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"upi":     upiRequest,
+	})
+}
+
 // CreatePayment creates a new payment (owner only)
 func (h *PaymentHandler) CreatePayment(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
@@ -332,8 +427,8 @@ func (h *PaymentHandler) CreatePayment(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		TenantID int    `json:"tenant_id"`
 		UnitID   int    `json:"unit_id"`
-		Label    string `json:"label"` // rent, water_bill, current_bill, maintenance
-		Amount   int    `json:"amount"`
+		Label    string `json:"label"`    // Charge category code (rent, water_bill, ...)
+		Amount   int    `json:"amount"`   // 0 uses the category's default amount
 		DueDate  string `json:"due_date"` // Format: "2006-01-02"
 		Notes    string `json:"notes"`    // Optional
 	}
@@ -367,12 +462,12 @@ func (h *PaymentHandler) CreatePayment(w http.ResponseWriter, r *http.Request) {
 		})
 		return
 	}
-	if req.Amount <= 0 {
+	if req.Amount < 0 {
 		w.Header().Set("Content-Type", "application/json; charset=utf-8")
 		w.WriteHeader(http.StatusBadRequest)
 		json.NewEncoder(w).Encode(map[string]interface{}{
 			"success": false,
-			"error":   "amount must be greater than 0",
+			"error":   "amount cannot be negative",
 		})
 		return
 	}
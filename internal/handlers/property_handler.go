@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"backend-form/m/internal/domain"
+	"backend-form/m/internal/service"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// PropertyHandler handles owner-facing property (building) operations
+type PropertyHandler struct {
+	propertyService  *service.PropertyService
+	dashboardService *service.DashboardService
+}
+
+// NewPropertyHandler creates a new PropertyHandler
+func NewPropertyHandler(
+	propertyService *service.PropertyService,
+	dashboardService *service.DashboardService,
+) *PropertyHandler {
+	return &PropertyHandler{
+		propertyService:  propertyService,
+		dashboardService: dashboardService,
+	}
+}
+
+// GetProperties returns all properties as JSON
+func (h *PropertyHandler) GetProperties(w http.ResponseWriter, r *http.Request) {
+	properties, err := h.propertyService.GetAllProperties()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(properties)
+}
+
+// CreateProperty creates a new property
+func (h *PropertyHandler) CreateProperty(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Method not allowed",
+		})
+		return
+	}
+
+	var req struct {
+		Name    string `json:"name"`
+		Address string `json:"address"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Invalid JSON",
+		})
+		return
+	}
+
+	property := &domain.Property{
+		Name:    req.Name,
+		Address: req.Address,
+	}
+
+	if err := h.propertyService.CreateProperty(property); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	// Invalidate dashboard cache since the property list changed
+	h.dashboardService.InvalidateDashboardCache()
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":  true,
+		"message":  "Property created successfully",
+		"property": property,
+	})
+}
+
+// UpdateProperty updates a property's name and address
+func (h *PropertyHandler) UpdateProperty(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Method not allowed",
+		})
+		return
+	}
+
+	var req struct {
+		PropertyID int    `json:"property_id"`
+		Name       string `json:"name"`
+		Address    string `json:"address"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Invalid JSON",
+		})
+		return
+	}
+
+	if req.PropertyID <= 0 {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "property_id is required",
+		})
+		return
+	}
+
+	property := &domain.Property{
+		ID:      req.PropertyID,
+		Name:    req.Name,
+		Address: req.Address,
+	}
+
+	if err := h.propertyService.UpdateProperty(property); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	// Invalidate dashboard cache since the property list changed
+	h.dashboardService.InvalidateDashboardCache()
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":  true,
+		"message":  "Property updated successfully",
+		"property": property,
+	})
+}
+
+// propertyIDParam returns the optional ?property_id= filter (0 = all properties)
+func propertyIDParam(r *http.Request) (int, error) {
+	propertyIDStr := strings.TrimSpace(r.URL.Query().Get("property_id"))
+	if propertyIDStr == "" {
+		return 0, nil
+	}
+	propertyID, err := strconv.Atoi(propertyIDStr)
+	if err != nil || propertyID <= 0 {
+		return 0, fmt.Errorf("invalid property_id: %q", propertyIDStr)
+	}
+	return propertyID, nil
+}
@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"backend-form/m/internal/domain"
+	"backend-form/m/internal/service"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strings"
+)
+
+// ReceiptHandler handles owner-facing receipt and credit note operations
+type ReceiptHandler struct {
+	receiptService   *service.ReceiptService
+	dashboardService *service.DashboardService
+	templates        *template.Template
+}
+
+// NewReceiptHandler creates a new ReceiptHandler
+func NewReceiptHandler(receiptService *service.ReceiptService, dashboardService *service.DashboardService, templates *template.Template) *ReceiptHandler {
+	return &ReceiptHandler{
+		receiptService:   receiptService,
+		dashboardService: dashboardService,
+		templates:        templates,
+	}
+}
+
+// GetReceipts returns the receipts and credit notes of a payment (?payment_id=) or a tenant (?tenant_id=)
+func (h *ReceiptHandler) GetReceipts(w http.ResponseWriter, r *http.Request) {
+	paymentID, tenantID := 0, 0
+	if paymentIDStr := r.URL.Query().Get("payment_id"); paymentIDStr != "" {
+		fmt.Sscanf(paymentIDStr, "%d", &paymentID)
+	}
+	if tenantIDStr := r.URL.Query().Get("tenant_id"); tenantIDStr != "" {
+		fmt.Sscanf(tenantIDStr, "%d", &tenantID)
+	}
+
+	var receipts []*domain.Receipt
+	var err error
+	switch {
+	case paymentID > 0:
+		receipts, err = h.receiptService.GetReceiptsByPaymentID(paymentID)
+	case tenantID > 0:
+		receipts, err = h.receiptService.GetReceiptsByTenantID(tenantID)
+	default:
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "payment_id or tenant_id is required",
+		})
+		return
+	}
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":  true,
+		"receipts": receipts,
+	})
+}
+
+// DownloadReceipt returns a receipt or credit note as PDF or HTML (?id=&format=pdf|html)
+func (h *ReceiptHandler) DownloadReceipt(w http.ResponseWriter, r *http.Request) {
+	receiptID := 0
+	if idStr := r.URL.Query().Get("id"); idStr != "" {
+		fmt.Sscanf(idStr, "%d", &receiptID)
+	}
+
+	receipt, err := h.receiptService.GetReceiptByID(receiptID)
+	if err != nil {
+		http.Error(w, "Receipt not found", http.StatusNotFound)
+		return
+	}
+
+	writeReceipt(w, h.templates, receipt, r.URL.Query().Get("format"))
+}
+
+// IssueCreditNote corrects a receipt by issuing a credit note against it
+func (h *ReceiptHandler) IssueCreditNote(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Method not allowed",
+		})
+		return
+	}
+
+	user, ok := r.Context().Value("user").(*domain.User)
+	if !ok || user == nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Unauthorized",
+		})
+		return
+	}
+
+	var req struct {
+		ReceiptID int    `json:"receipt_id"`
+		Amount    int    `json:"amount"`
+		Reason    string `json:"reason"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Invalid JSON",
+		})
+		return
+	}
+
+	note, err := h.receiptService.IssueCreditNote(req.ReceiptID, req.Amount, req.Reason, user.ID)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	// Credit note reopens the payment balance
+	h.dashboardService.InvalidateDashboardCache()
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":     true,
+		"message":     fmt.Sprintf("Credit note %s issued", note.ReceiptNumber),
+		"credit_note": note,
+	})
+}
+
+// writeReceipt renders a receipt as a PDF download (default) or as an HTML page (format=html)
+func writeReceipt(w http.ResponseWriter, templates *template.Template, receipt *domain.Receipt, format string) {
+	if format == "html" {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if err := templates.ExecuteTemplate(w, "receipt.html", receipt); err != nil {
+			http.Error(w, "Failed to render receipt", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := service.RenderReceiptPDF(receipt, &buf); err != nil {
+		http.Error(w, "Failed to render receipt: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	filename := strings.ReplaceAll(receipt.ReceiptNumber, "/", "-") + ".pdf"
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.Write(buf.Bytes())
+}
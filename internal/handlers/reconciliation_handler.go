@@ -0,0 +1,247 @@
+package handlers
+
+import (
+	"backend-form/m/internal/domain"
+	"backend-form/m/internal/metrics"
+	"backend-form/m/internal/service"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// maxStatementUploadBytes caps the size of an uploaded bank statement
+const maxStatementUploadBytes = 10 << 20 // 10 MB
+
+// ReconciliationHandler handles bank statement imports and the reconciliation review queue
+type ReconciliationHandler struct {
+	reconciliationService *service.ReconciliationService
+	dashboardService      *service.DashboardService
+}
+
+// NewReconciliationHandler creates a new ReconciliationHandler
+func NewReconciliationHandler(reconciliationService *service.ReconciliationService, dashboardService *service.DashboardService) *ReconciliationHandler {
+	return &ReconciliationHandler{
+		reconciliationService: reconciliationService,
+		dashboardService:      dashboardService,
+	}
+}
+
+// ImportStatement uploads a bank statement (multipart field "statement", optional "format")
+// and verifies the pending transactions it matches
+func (h *ReconciliationHandler) ImportStatement(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Method not allowed",
+		})
+		return
+	}
+
+	user, ok := r.Context().Value("user").(*domain.User)
+	if !ok || user == nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Unauthorized",
+		})
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxStatementUploadBytes)
+	if err := r.ParseMultipartForm(maxStatementUploadBytes); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Invalid upload: " + err.Error(),
+		})
+		return
+	}
+
+	file, header, err := r.FormFile("statement")
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "statement file is required",
+		})
+		return
+	}
+	defer file.Close()
+
+	statementImport, err := h.reconciliationService.ImportStatement(header.Filename, r.FormValue("format"), file, user.ID)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	if statementImport.MatchedCount > 0 {
+		// Invalidate dashboard cache since payment data changed
+		h.dashboardService.InvalidateDashboardCache()
+		for i := 0; i < statementImport.MatchedCount; i++ {
+			metrics.GetMetrics().IncrementPaymentVerified()
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": fmt.Sprintf("Imported %d credit(s), verified %d, skipped %d already imported",
+			statementImport.LineCount, statementImport.MatchedCount, statementImport.DuplicateCount),
+		"import": statementImport,
+	})
+}
+
+// GetReviewQueue returns statement lines and submissions still to be reconciled
+func (h *ReconciliationHandler) GetReviewQueue(w http.ResponseWriter, r *http.Request) {
+	queue, err := h.reconciliationService.GetReviewQueue()
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"queue":   queue,
+	})
+}
+
+// GetImports returns the history of statement imports
+func (h *ReconciliationHandler) GetImports(w http.ResponseWriter, r *http.Request) {
+	imports, err := h.reconciliationService.GetImports()
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"imports": imports,
+	})
+}
+
+// MatchLine verifies a pending transaction against a statement line from the review queue
+func (h *ReconciliationHandler) MatchLine(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Method not allowed",
+		})
+		return
+	}
+
+	user, ok := r.Context().Value("user").(*domain.User)
+	if !ok || user == nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Unauthorized",
+		})
+		return
+	}
+
+	var req struct {
+		LineID        int    `json:"line_id"`
+		TransactionID string `json:"transaction_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Invalid JSON",
+		})
+		return
+	}
+
+	line, err := h.reconciliationService.MatchLine(req.LineID, req.TransactionID, user.ID)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	// Invalidate dashboard cache since payment data changed
+	h.dashboardService.InvalidateDashboardCache()
+	metrics.GetMetrics().IncrementPaymentVerified()
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Transaction verified from statement",
+		"line":    line,
+	})
+}
+
+// IgnoreLine dismisses a statement line that is not a tenant payment
+func (h *ReconciliationHandler) IgnoreLine(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Method not allowed",
+		})
+		return
+	}
+
+	var req struct {
+		LineID int    `json:"line_id"`
+		Reason string `json:"reason"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Invalid JSON",
+		})
+		return
+	}
+
+	line, err := h.reconciliationService.IgnoreLine(req.LineID, req.Reason)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Statement line ignored",
+		"line":    line,
+	})
+}
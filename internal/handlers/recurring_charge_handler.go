@@ -0,0 +1,398 @@
+package handlers
+
+import (
+	"backend-form/m/internal/domain"
+	"backend-form/m/internal/service"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// RecurringChargeHandler handles owner-facing recurring charge schedules
+type RecurringChargeHandler struct {
+	recurringService *service.RecurringChargeService
+	paymentService   *service.PaymentService
+	dashboardService *service.DashboardService
+}
+
+// NewRecurringChargeHandler creates a new RecurringChargeHandler
+func NewRecurringChargeHandler(
+	recurringService *service.RecurringChargeService,
+	paymentService *service.PaymentService,
+	dashboardService *service.DashboardService,
+) *RecurringChargeHandler {
+	return &RecurringChargeHandler{
+		recurringService: recurringService,
+		paymentService:   paymentService,
+		dashboardService: dashboardService,
+	}
+}
+
+// recurringChargeRequest is the JSON body accepted by create and update
+type recurringChargeRequest struct {
+	RecurringChargeID int    `json:"recurring_charge_id"` // Required for update only
+	TenantID          *int   `json:"tenant_id"`           // Set one of tenant_id or unit_id (create only)
+	UnitID            *int   `json:"unit_id"`
+	CategoryCode      string `json:"category_code"` // Create only
+	Amount            int    `json:"amount"`        // Create only, 0 uses the category's default amount
+	DueDay            int    `json:"due_day"`       // 0 = same day as rent
+	StartDate         string `json:"start_date"`    // Format: "2006-01-02"
+	EndDate           string `json:"end_date"`      // Optional, format: "2006-01-02"
+	IsActive          *bool  `json:"is_active"`     // Update only, defaults to true
+	Notes             string `json:"notes"`
+}
+
+// toRecurringCharge converts the request into a domain schedule
+func (req *recurringChargeRequest) toRecurringCharge() (*domain.RecurringCharge, error) {
+	charge := &domain.RecurringCharge{
+		ID:           req.RecurringChargeID,
+		TenantID:     req.TenantID,
+		UnitID:       req.UnitID,
+		CategoryCode: req.CategoryCode,
+		Amount:       req.Amount,
+		DueDay:       req.DueDay,
+		IsActive:     req.IsActive == nil || *req.IsActive,
+		Notes:        req.Notes,
+	}
+
+	if req.StartDate != "" {
+		startDate, err := time.Parse("2006-01-02", req.StartDate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid start_date format. Use YYYY-MM-DD")
+		}
+		charge.StartDate = startDate
+	}
+	if req.EndDate != "" {
+		endDate, err := time.Parse("2006-01-02", req.EndDate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid end_date format. Use YYYY-MM-DD")
+		}
+		charge.EndDate = &endDate
+	}
+
+	return charge, nil
+}
+
+// GetRecurringCharges returns schedules as JSON (?tenant_id= for those applying to one tenant)
+func (h *RecurringChargeHandler) GetRecurringCharges(w http.ResponseWriter, r *http.Request) {
+	tenantID := 0
+	if tenantIDStr := r.URL.Query().Get("tenant_id"); tenantIDStr != "" {
+		fmt.Sscanf(tenantIDStr, "%d", &tenantID)
+	}
+
+	var charges []*domain.RecurringCharge
+	var err error
+	if tenantID > 0 {
+		charges, err = h.recurringService.GetRecurringChargesForTenant(tenantID)
+	} else {
+		charges, err = h.recurringService.GetAllRecurringCharges()
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(charges)
+}
+
+// CreateRecurringCharge creates a schedule for a tenant or unit
+// If the schedule is due this month, the charge is raised right away
+func (h *RecurringChargeHandler) CreateRecurringCharge(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Method not allowed",
+		})
+		return
+	}
+
+	var req recurringChargeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Invalid JSON",
+		})
+		return
+	}
+
+	if req.StartDate == "" {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "start_date is required",
+		})
+		return
+	}
+
+	charge, err := req.toRecurringCharge()
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	if err := h.recurringService.CreateRecurringCharge(charge); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	now := time.Now()
+	generated, err := h.paymentService.GenerateRecurringChargesForMonth(now.Month(), now.Year())
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Schedule created but generating this month's charges failed: " + err.Error(),
+		})
+		return
+	}
+
+	// Invalidate dashboard cache since payment data may have changed
+	h.dashboardService.InvalidateDashboardCache()
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":          true,
+		"message":          "Recurring charge created successfully",
+		"recurring_charge": charge,
+		"generated":        generated,
+	})
+}
+
+// UpdateRecurringCharge updates the due day, dates, active flag and notes of a schedule
+func (h *RecurringChargeHandler) UpdateRecurringCharge(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Method not allowed",
+		})
+		return
+	}
+
+	var req recurringChargeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Invalid JSON",
+		})
+		return
+	}
+
+	if req.RecurringChargeID <= 0 {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "recurring_charge_id is required",
+		})
+		return
+	}
+
+	charge, err := req.toRecurringCharge()
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	if err := h.recurringService.UpdateRecurringCharge(charge); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":          true,
+		"message":          "Recurring charge updated successfully",
+		"recurring_charge": charge,
+	})
+}
+
+// ChangeRecurringChargeAmount sets a new amount for a schedule from a given month onwards
+func (h *RecurringChargeHandler) ChangeRecurringChargeAmount(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Method not allowed",
+		})
+		return
+	}
+
+	var req struct {
+		RecurringChargeID int    `json:"recurring_charge_id"`
+		Amount            int    `json:"amount"`
+		EffectiveFrom     string `json:"effective_from"` // Format: "2006-01-02", the month it applies from
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Invalid JSON",
+		})
+		return
+	}
+
+	if req.RecurringChargeID <= 0 {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "recurring_charge_id is required",
+		})
+		return
+	}
+
+	effectiveFrom, err := time.Parse("2006-01-02", req.EffectiveFrom)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Invalid effective_from format. Use YYYY-MM-DD",
+		})
+		return
+	}
+
+	charge, err := h.recurringService.ChangeAmount(req.RecurringChargeID, req.Amount, effectiveFrom)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":          true,
+		"message":          "Recurring charge amount changed successfully",
+		"recurring_charge": charge,
+	})
+}
+
+// DeleteRecurringCharge deletes a schedule (payments already generated are kept)
+func (h *RecurringChargeHandler) DeleteRecurringCharge(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Method not allowed",
+		})
+		return
+	}
+
+	var req struct {
+		RecurringChargeID int `json:"recurring_charge_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Invalid JSON",
+		})
+		return
+	}
+
+	if err := h.recurringService.DeleteRecurringCharge(req.RecurringChargeID); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Recurring charge deleted successfully",
+	})
+}
+
+// GenerateRecurringCharges raises due recurring charges for a month (?month=YYYY-MM, defaults to the current month)
+// Charges already raised for the month are skipped, so this is safe to run repeatedly
+func (h *RecurringChargeHandler) GenerateRecurringCharges(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Method not allowed",
+		})
+		return
+	}
+
+	month := time.Now()
+	if monthStr := r.URL.Query().Get("month"); monthStr != "" {
+		parsed, err := time.Parse("2006-01", monthStr)
+		if err != nil {
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"success": false,
+				"error":   "Invalid month format. Use YYYY-MM",
+			})
+			return
+		}
+		month = parsed
+	}
+
+	generated, err := h.paymentService.GenerateRecurringChargesForMonth(month.Month(), month.Year())
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	// Invalidate dashboard cache since payment data changed
+	h.dashboardService.InvalidateDashboardCache()
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":   true,
+		"message":   fmt.Sprintf("Generated %d recurring charge(s) for %s", generated, month.Format("January 2006")),
+		"generated": generated,
+	})
+}
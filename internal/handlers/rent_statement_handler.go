@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"backend-form/m/internal/domain"
+	"backend-form/m/internal/service"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// RentStatementHandler handles owner-facing annual rent statements
+type RentStatementHandler struct {
+	rentStatementService *service.RentStatementService
+}
+
+// NewRentStatementHandler creates a new RentStatementHandler
+func NewRentStatementHandler(rentStatementService *service.RentStatementService) *RentStatementHandler {
+	return &RentStatementHandler{
+		rentStatementService: rentStatementService,
+	}
+}
+
+// GetRentStatement returns a tenant's rent statement (?tenant_id=&fy=2025-26&format=pdf|csv|json)
+// The financial year defaults to the current one.
+func (h *RentStatementHandler) GetRentStatement(w http.ResponseWriter, r *http.Request) {
+	tenantID := 0
+	if tenantIDStr := r.URL.Query().Get("tenant_id"); tenantIDStr != "" {
+		fmt.Sscanf(tenantIDStr, "%d", &tenantID)
+	}
+
+	if tenantID <= 0 {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "tenant_id is required",
+		})
+		return
+	}
+
+	statement, err := h.rentStatementService.GetStatement(tenantID, financialYearParam(r))
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	writeRentStatement(w, statement, r.URL.Query().Get("format"))
+}
+
+// financialYearParam returns the ?fy= query parameter, defaulting to the current financial year
+func financialYearParam(r *http.Request) string {
+	if fy := strings.TrimSpace(r.URL.Query().Get("fy")); fy != "" {
+		return fy
+	}
+	return domain.FinancialYear(time.Now())
+}
+
+// writeRentStatement renders a rent statement as a PDF download (default), CSV download or JSON
+func writeRentStatement(w http.ResponseWriter, statement *domain.RentStatement, format string) {
+	filename := fmt.Sprintf("rent-statement-%s-%s", strings.ReplaceAll(strings.ToLower(statement.TenantName), " ", "-"), statement.FinancialYear)
+
+	var buf bytes.Buffer
+	switch format {
+	case "json":
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success":   true,
+			"statement": statement,
+		})
+		return
+	case "csv":
+		if err := service.WriteRentStatementCSV(statement, &buf); err != nil {
+			http.Error(w, "Failed to render statement: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename+".csv"))
+	default:
+		if err := service.RenderRentStatementPDF(statement, &buf); err != nil {
+			http.Error(w, "Failed to render statement: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename+".pdf"))
+	}
+	w.Write(buf.Bytes())
+}
@@ -17,6 +17,10 @@ type DashboardHandler struct {
 	paymentService            *service.PaymentService
 	paymentTransactionService *service.PaymentTransactionService
 	dashboardService          *service.DashboardService
+	receiptService            *service.ReceiptService
+	rentStatementService      *service.RentStatementService
+	installmentPlanService    *service.InstallmentPlanService
+	adjustmentService         *service.PaymentAdjustmentService
 	templates                 *template.Template
 }
 
@@ -27,6 +31,10 @@ func NewDashboardHandler(
 	paymentService *service.PaymentService,
 	paymentTransactionService *service.PaymentTransactionService,
 	dashboardService *service.DashboardService,
+	receiptService *service.ReceiptService,
+	rentStatementService *service.RentStatementService,
+	installmentPlanService *service.InstallmentPlanService,
+	adjustmentService *service.PaymentAdjustmentService,
 	templates *template.Template,
 ) *DashboardHandler {
 	return &DashboardHandler{
@@ -35,6 +43,10 @@ func NewDashboardHandler(
 		paymentService:            paymentService,
 		paymentTransactionService: paymentTransactionService,
 		dashboardService:          dashboardService,
+		receiptService:            receiptService,
+		rentStatementService:      rentStatementService,
+		installmentPlanService:    installmentPlanService,
+		adjustmentService:         adjustmentService,
 		templates:                 templates,
 	}
 }
@@ -45,12 +57,41 @@ type RentalHandler struct {
 	*DashboardHandler
 	paymentHandler          *PaymentHandler
 	tenantManagementHandler *TenantManagementHandler
+	unitManagementHandler   *UnitManagementHandler
+	propertyHandler         *PropertyHandler
+	depositHandler          *DepositHandler
+	lateFeeHandler          *LateFeeHandler
+	chargeCategoryHandler   *ChargeCategoryHandler
+	recurringChargeHandler  *RecurringChargeHandler
+	utilityHandler          *UtilityHandler
+	leaseHandler            *LeaseHandler
+	creditHandler           *CreditHandler
+	reconciliationHandler   *ReconciliationHandler
+	receiptHandler          *ReceiptHandler
+	rentStatementHandler    *RentStatementHandler
+	expenseHandler          *ExpenseHandler
+	installmentPlanHandler  *InstallmentPlanHandler
+	adjustmentHandler       *PaymentAdjustmentHandler
 }
 
 // NewRentalHandler creates a new RentalHandler (backward compatibility wrapper)
 func NewRentalHandler(
 	unitService *service.UnitService,
+	propertyService *service.PropertyService,
 	tenantService *service.TenantService,
+	depositService *service.DepositService,
+	lateFeeService *service.LateFeeService,
+	chargeCategoryService *service.ChargeCategoryService,
+	recurringChargeService *service.RecurringChargeService,
+	utilityService *service.UtilityService,
+	leaseService *service.LeaseService,
+	creditService *service.CreditService,
+	reconciliationService *service.ReconciliationService,
+	receiptService *service.ReceiptService,
+	rentStatementService *service.RentStatementService,
+	expenseService *service.ExpenseService,
+	installmentPlanService *service.InstallmentPlanService,
+	adjustmentService *service.PaymentAdjustmentService,
 	paymentService *service.PaymentService,
 	paymentQueryService *service.PaymentQueryService,
 	paymentTransactionService *service.PaymentTransactionService,
@@ -66,6 +107,10 @@ func NewRentalHandler(
 		paymentService,
 		paymentTransactionService,
 		dashboardService,
+		receiptService,
+		rentStatementService,
+		installmentPlanService,
+		adjustmentService,
 		templates,
 	)
 
@@ -81,12 +126,90 @@ func NewRentalHandler(
 		tenantService,
 		auth,
 		dashboardService,
+		notificationService,
+	)
+
+	unitManagementHandler := NewUnitManagementHandler(
+		unitService,
+		dashboardService,
+	)
+
+	propertyHandler := NewPropertyHandler(
+		propertyService,
+		dashboardService,
+	)
+
+	depositHandler := NewDepositHandler(depositService)
+
+	lateFeeHandler := NewLateFeeHandler(
+		lateFeeService,
+		dashboardService,
+	)
+
+	chargeCategoryHandler := NewChargeCategoryHandler(
+		chargeCategoryService,
+		dashboardService,
+	)
+
+	recurringChargeHandler := NewRecurringChargeHandler(
+		recurringChargeService,
+		paymentService,
+		dashboardService,
+	)
+
+	utilityHandler := NewUtilityHandler(
+		utilityService,
+		dashboardService,
+	)
+
+	leaseHandler := NewLeaseHandler(leaseService)
+
+	creditHandler := NewCreditHandler(creditService)
+
+	reconciliationHandler := NewReconciliationHandler(
+		reconciliationService,
+		dashboardService,
+	)
+
+	receiptHandler := NewReceiptHandler(
+		receiptService,
+		dashboardService,
+		templates,
+	)
+
+	rentStatementHandler := NewRentStatementHandler(rentStatementService)
+
+	expenseHandler := NewExpenseHandler(expenseService)
+
+	installmentPlanHandler := NewInstallmentPlanHandler(
+		installmentPlanService,
+		dashboardService,
+	)
+
+	adjustmentHandler := NewPaymentAdjustmentHandler(
+		adjustmentService,
+		dashboardService,
 	)
 
 	return &RentalHandler{
 		DashboardHandler:        dashboardHandler,
 		paymentHandler:          paymentHandler,
 		tenantManagementHandler: tenantManagementHandler,
+		unitManagementHandler:   unitManagementHandler,
+		propertyHandler:         propertyHandler,
+		depositHandler:          depositHandler,
+		lateFeeHandler:          lateFeeHandler,
+		chargeCategoryHandler:   chargeCategoryHandler,
+		recurringChargeHandler:  recurringChargeHandler,
+		utilityHandler:          utilityHandler,
+		leaseHandler:            leaseHandler,
+		creditHandler:           creditHandler,
+		reconciliationHandler:   reconciliationHandler,
+		receiptHandler:          receiptHandler,
+		rentStatementHandler:    rentStatementHandler,
+		expenseHandler:          expenseHandler,
+		installmentPlanHandler:  installmentPlanHandler,
+		adjustmentHandler:       adjustmentHandler,
 	}
 }
 
@@ -108,8 +231,15 @@ func (h *DashboardHandler) Dashboard(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Optional property filter (0 = all properties)
+	propertyID, err := propertyIDParam(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	// Get dashboard data using DashboardService
-	data, err := h.dashboardService.GetDashboardData()
+	data, err := h.dashboardService.GetDashboardData(propertyID)
 	if err != nil {
 		http.Error(w, "Failed to load dashboard data: "+err.Error(), http.StatusInternalServerError)
 		return
@@ -117,6 +247,8 @@ func (h *DashboardHandler) Dashboard(w http.ResponseWriter, r *http.Request) {
 
 	// Prepare dashboard data for template (convert to map)
 	dashboardData := map[string]interface{}{
+		"Properties":     data.Properties,
+		"PropertyID":     data.PropertyID,
 		"Units":          data.Units,
 		"Tenants":        data.Tenants,
 		"Payments":       data.Payments,
@@ -137,9 +269,15 @@ func (h *RentalHandler) GetUnits(w http.ResponseWriter, r *http.Request) {
 	h.DashboardHandler.GetUnits(w, r)
 }
 
-// GetUnits returns all units as JSON
+// GetUnits returns all units as JSON (optionally filtered by ?property_id=)
 func (h *DashboardHandler) GetUnits(w http.ResponseWriter, r *http.Request) {
-	units, err := h.unitService.GetAllUnits()
+	propertyID, err := propertyIDParam(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	units, err := h.unitService.GetUnitsByPropertyID(propertyID)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -162,10 +300,278 @@ func (h *RentalHandler) VacateTenant(w http.ResponseWriter, r *http.Request) {
 	h.tenantManagementHandler.VacateTenant(w, r)
 }
 
+func (h *RentalHandler) GetArchivedTenants(w http.ResponseWriter, r *http.Request) {
+	h.tenantManagementHandler.GetArchivedTenants(w, r)
+}
+
+func (h *RentalHandler) GetTenantHistory(w http.ResponseWriter, r *http.Request) {
+	h.tenantManagementHandler.GetTenantHistory(w, r)
+}
+
+func (h *RentalHandler) GetDeposit(w http.ResponseWriter, r *http.Request) {
+	h.depositHandler.GetDeposit(w, r)
+}
+
+func (h *RentalHandler) RecordDepositCollection(w http.ResponseWriter, r *http.Request) {
+	h.depositHandler.RecordCollection(w, r)
+}
+
+func (h *RentalHandler) RecordDepositDeduction(w http.ResponseWriter, r *http.Request) {
+	h.depositHandler.RecordDeduction(w, r)
+}
+
+func (h *RentalHandler) GetDepositSettlement(w http.ResponseWriter, r *http.Request) {
+	h.depositHandler.GetSettlement(w, r)
+}
+
+func (h *RentalHandler) GetLateFeePolicies(w http.ResponseWriter, r *http.Request) {
+	h.lateFeeHandler.GetPolicies(w, r)
+}
+
+func (h *RentalHandler) CreateLateFeePolicy(w http.ResponseWriter, r *http.Request) {
+	h.lateFeeHandler.CreatePolicy(w, r)
+}
+
+func (h *RentalHandler) UpdateLateFeePolicy(w http.ResponseWriter, r *http.Request) {
+	h.lateFeeHandler.UpdatePolicy(w, r)
+}
+
+func (h *RentalHandler) DeleteLateFeePolicy(w http.ResponseWriter, r *http.Request) {
+	h.lateFeeHandler.DeletePolicy(w, r)
+}
+
+func (h *RentalHandler) GetLateFees(w http.ResponseWriter, r *http.Request) {
+	h.lateFeeHandler.GetLateFees(w, r)
+}
+
+func (h *RentalHandler) WaiveLateFee(w http.ResponseWriter, r *http.Request) {
+	h.lateFeeHandler.WaiveLateFee(w, r)
+}
+
+func (h *RentalHandler) ReverseLateFee(w http.ResponseWriter, r *http.Request) {
+	h.lateFeeHandler.ReverseLateFee(w, r)
+}
+
+func (h *RentalHandler) RunLateFees(w http.ResponseWriter, r *http.Request) {
+	h.lateFeeHandler.RunLateFees(w, r)
+}
+
+func (h *RentalHandler) GetChargeCategories(w http.ResponseWriter, r *http.Request) {
+	h.chargeCategoryHandler.GetCategories(w, r)
+}
+
+func (h *RentalHandler) CreateChargeCategory(w http.ResponseWriter, r *http.Request) {
+	h.chargeCategoryHandler.CreateCategory(w, r)
+}
+
+func (h *RentalHandler) UpdateChargeCategory(w http.ResponseWriter, r *http.Request) {
+	h.chargeCategoryHandler.UpdateCategory(w, r)
+}
+
+func (h *RentalHandler) GetRecurringCharges(w http.ResponseWriter, r *http.Request) {
+	h.recurringChargeHandler.GetRecurringCharges(w, r)
+}
+
+func (h *RentalHandler) CreateRecurringCharge(w http.ResponseWriter, r *http.Request) {
+	h.recurringChargeHandler.CreateRecurringCharge(w, r)
+}
+
+func (h *RentalHandler) UpdateRecurringCharge(w http.ResponseWriter, r *http.Request) {
+	h.recurringChargeHandler.UpdateRecurringCharge(w, r)
+}
+
+func (h *RentalHandler) ChangeRecurringChargeAmount(w http.ResponseWriter, r *http.Request) {
+	h.recurringChargeHandler.ChangeRecurringChargeAmount(w, r)
+}
+
+func (h *RentalHandler) DeleteRecurringCharge(w http.ResponseWriter, r *http.Request) {
+	h.recurringChargeHandler.DeleteRecurringCharge(w, r)
+}
+
+func (h *RentalHandler) GenerateRecurringCharges(w http.ResponseWriter, r *http.Request) {
+	h.recurringChargeHandler.GenerateRecurringCharges(w, r)
+}
+
+func (h *RentalHandler) GetUtilityTariffs(w http.ResponseWriter, r *http.Request) {
+	h.utilityHandler.GetTariffs(w, r)
+}
+
+func (h *RentalHandler) CreateUtilityTariff(w http.ResponseWriter, r *http.Request) {
+	h.utilityHandler.CreateTariff(w, r)
+}
+
+func (h *RentalHandler) UpdateUtilityTariff(w http.ResponseWriter, r *http.Request) {
+	h.utilityHandler.UpdateTariff(w, r)
+}
+
+func (h *RentalHandler) DeleteUtilityTariff(w http.ResponseWriter, r *http.Request) {
+	h.utilityHandler.DeleteTariff(w, r)
+}
+
+func (h *RentalHandler) GetMeterReadings(w http.ResponseWriter, r *http.Request) {
+	h.utilityHandler.GetReadings(w, r)
+}
+
+func (h *RentalHandler) RecordMeterReading(w http.ResponseWriter, r *http.Request) {
+	h.utilityHandler.RecordReading(w, r)
+}
+
+func (h *RentalHandler) GetUtilityBills(w http.ResponseWriter, r *http.Request) {
+	h.utilityHandler.GetBills(w, r)
+}
+
+func (h *RentalHandler) GetLeases(w http.ResponseWriter, r *http.Request) {
+	h.leaseHandler.GetLeases(w, r)
+}
+
+func (h *RentalHandler) CreateLease(w http.ResponseWriter, r *http.Request) {
+	h.leaseHandler.CreateLease(w, r)
+}
+
+func (h *RentalHandler) RenewLease(w http.ResponseWriter, r *http.Request) {
+	h.leaseHandler.RenewLease(w, r)
+}
+
+func (h *RentalHandler) GetUpcomingLeaseEvents(w http.ResponseWriter, r *http.Request) {
+	h.leaseHandler.GetUpcomingLeaseEvents(w, r)
+}
+
+func (h *RentalHandler) GetCredits(w http.ResponseWriter, r *http.Request) {
+	h.creditHandler.GetCredits(w, r)
+}
+
+func (h *RentalHandler) RefundCredit(w http.ResponseWriter, r *http.Request) {
+	h.creditHandler.RefundCredit(w, r)
+}
+
+func (h *RentalHandler) ImportBankStatement(w http.ResponseWriter, r *http.Request) {
+	h.reconciliationHandler.ImportStatement(w, r)
+}
+
+func (h *RentalHandler) GetReconciliationQueue(w http.ResponseWriter, r *http.Request) {
+	h.reconciliationHandler.GetReviewQueue(w, r)
+}
+
+func (h *RentalHandler) GetStatementImports(w http.ResponseWriter, r *http.Request) {
+	h.reconciliationHandler.GetImports(w, r)
+}
+
+func (h *RentalHandler) MatchStatementLine(w http.ResponseWriter, r *http.Request) {
+	h.reconciliationHandler.MatchLine(w, r)
+}
+
+func (h *RentalHandler) IgnoreStatementLine(w http.ResponseWriter, r *http.Request) {
+	h.reconciliationHandler.IgnoreLine(w, r)
+}
+
+func (h *RentalHandler) GetReceipts(w http.ResponseWriter, r *http.Request) {
+	h.receiptHandler.GetReceipts(w, r)
+}
+
+func (h *RentalHandler) DownloadReceipt(w http.ResponseWriter, r *http.Request) {
+	h.receiptHandler.DownloadReceipt(w, r)
+}
+
+func (h *RentalHandler) IssueCreditNote(w http.ResponseWriter, r *http.Request) {
+	h.receiptHandler.IssueCreditNote(w, r)
+}
+
+func (h *RentalHandler) GetRentStatement(w http.ResponseWriter, r *http.Request) {
+	h.rentStatementHandler.GetRentStatement(w, r)
+}
+
+func (h *RentalHandler) GetExpenseCategories(w http.ResponseWriter, r *http.Request) {
+	h.expenseHandler.GetCategories(w, r)
+}
+
+func (h *RentalHandler) CreateExpenseCategory(w http.ResponseWriter, r *http.Request) {
+	h.expenseHandler.CreateCategory(w, r)
+}
+
+func (h *RentalHandler) UpdateExpenseCategory(w http.ResponseWriter, r *http.Request) {
+	h.expenseHandler.UpdateCategory(w, r)
+}
+
+func (h *RentalHandler) GetExpenses(w http.ResponseWriter, r *http.Request) {
+	h.expenseHandler.GetExpenses(w, r)
+}
+
+func (h *RentalHandler) CreateExpense(w http.ResponseWriter, r *http.Request) {
+	h.expenseHandler.CreateExpense(w, r)
+}
+
+func (h *RentalHandler) UpdateExpense(w http.ResponseWriter, r *http.Request) {
+	h.expenseHandler.UpdateExpense(w, r)
+}
+
+func (h *RentalHandler) DeleteExpense(w http.ResponseWriter, r *http.Request) {
+	h.expenseHandler.DeleteExpense(w, r)
+}
+
+func (h *RentalHandler) UploadExpenseAttachment(w http.ResponseWriter, r *http.Request) {
+	h.expenseHandler.UploadAttachment(w, r)
+}
+
+func (h *RentalHandler) DownloadExpenseAttachment(w http.ResponseWriter, r *http.Request) {
+	h.expenseHandler.DownloadAttachment(w, r)
+}
+
+func (h *RentalHandler) DeleteExpenseAttachment(w http.ResponseWriter, r *http.Request) {
+	h.expenseHandler.DeleteAttachment(w, r)
+}
+
+func (h *RentalHandler) GetIncomeExpenseReport(w http.ResponseWriter, r *http.Request) {
+	h.expenseHandler.GetReport(w, r)
+}
+
+func (h *RentalHandler) GetInstallmentPlans(w http.ResponseWriter, r *http.Request) {
+	h.installmentPlanHandler.GetPlans(w, r)
+}
+
+func (h *RentalHandler) CreateInstallmentPlan(w http.ResponseWriter, r *http.Request) {
+	h.installmentPlanHandler.CreatePlan(w, r)
+}
+
+func (h *RentalHandler) CancelInstallmentPlan(w http.ResponseWriter, r *http.Request) {
+	h.installmentPlanHandler.CancelPlan(w, r)
+}
+
+func (h *RentalHandler) GetPaymentAdjustments(w http.ResponseWriter, r *http.Request) {
+	h.adjustmentHandler.GetAdjustments(w, r)
+}
+
+func (h *RentalHandler) CreatePaymentAdjustment(w http.ResponseWriter, r *http.Request) {
+	h.adjustmentHandler.CreateAdjustment(w, r)
+}
+
 func (h *RentalHandler) RegenerateTenantPassword(w http.ResponseWriter, r *http.Request) {
 	h.tenantManagementHandler.RegenerateTenantPassword(w, r)
 }
 
+func (h *RentalHandler) CreateUnit(w http.ResponseWriter, r *http.Request) {
+	h.unitManagementHandler.CreateUnit(w, r)
+}
+
+func (h *RentalHandler) UpdateUnit(w http.ResponseWriter, r *http.Request) {
+	h.unitManagementHandler.UpdateUnit(w, r)
+}
+
+func (h *RentalHandler) RetireUnit(w http.ResponseWriter, r *http.Request) {
+	h.unitManagementHandler.RetireUnit(w, r)
+}
+
+func (h *RentalHandler) GetProperties(w http.ResponseWriter, r *http.Request) {
+	h.propertyHandler.GetProperties(w, r)
+}
+
+func (h *RentalHandler) CreateProperty(w http.ResponseWriter, r *http.Request) {
+	h.propertyHandler.CreateProperty(w, r)
+}
+
+func (h *RentalHandler) UpdateProperty(w http.ResponseWriter, r *http.Request) {
+	h.propertyHandler.UpdateProperty(w, r)
+}
+
 func (h *RentalHandler) GetPayments(w http.ResponseWriter, r *http.Request) {
 	h.paymentHandler.GetPayments(w, r)
 }
@@ -190,6 +596,14 @@ func (h *RentalHandler) RejectTransaction(w http.ResponseWriter, r *http.Request
 	h.paymentHandler.RejectTransaction(w, r)
 }
 
+func (h *RentalHandler) GetDisputedSubmissions(w http.ResponseWriter, r *http.Request) {
+	h.paymentHandler.GetDisputedSubmissions(w, r)
+}
+
+func (h *RentalHandler) GetUPIPaymentRequest(w http.ResponseWriter, r *http.Request) {
+	h.paymentHandler.GetUPIPaymentRequest(w, r)
+}
+
 func (h *RentalHandler) CreatePayment(w http.ResponseWriter, r *http.Request) {
 	h.paymentHandler.CreatePayment(w, r)
 }
@@ -199,9 +613,15 @@ func (h *RentalHandler) GetSummary(w http.ResponseWriter, r *http.Request) {
 	h.DashboardHandler.GetSummary(w, r)
 }
 
-// GetSummary returns dashboard summary
+// GetSummary returns dashboard summary (optionally filtered by ?property_id=)
 func (h *DashboardHandler) GetSummary(w http.ResponseWriter, r *http.Request) {
-	summary, err := h.dashboardService.GetDashboardSummary()
+	propertyID, err := propertyIDParam(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	summary, err := h.dashboardService.GetDashboardSummary(propertyID)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -269,6 +689,16 @@ func (h *DashboardHandler) UnitDetails(w http.ResponseWriter, r *http.Request) {
 		"Tenant":               tenant,
 		"Payments":             payments,
 		"PendingVerifications": pendingVerifications,
+		"ChargeCategories":     h.paymentService.GetChargeCategories(),
+	}
+	if tenant != nil {
+		unitData["CreditLedger"] = h.paymentService.GetCreditLedger(tenant.ID)
+		unitData["Receipts"] = h.receiptService.GetReceiptsByPayment(tenant.ID)
+		unitData["StatementYears"] = h.rentStatementService.GetFinancialYears(tenant)
+		unitData["Adjustments"] = h.adjustmentService.GetAdjustmentsByPayment(tenant.ID)
+		if plans, err := h.installmentPlanService.GetPlansByTenantID(tenant.ID); err == nil {
+			unitData["InstallmentPlans"] = plans
+		}
 	}
 
 	if err := h.templates.ExecuteTemplate(w, "unit-detail.html", unitData); err != nil {
@@ -14,17 +14,29 @@ type TenantHandler struct {
 	tenantService             *service.TenantService
 	paymentService            *service.PaymentService
 	paymentTransactionService *service.PaymentTransactionService
+	lateFeeService            *service.LateFeeService
+	utilityService            *service.UtilityService
+	gatewayService            *service.GatewayService
+	receiptService            *service.ReceiptService
+	rentStatementService      *service.RentStatementService
+	telegramLinkService       *service.TelegramLinkService
 	users                     interfaces.UserRepository
 	templates                 *template.Template
 	cookieName                string
 	auth                      *service.AuthService
 }
 
-func NewTenantHandler(tenant *service.TenantService, payment *service.PaymentService, paymentTransaction *service.PaymentTransactionService, users interfaces.UserRepository, templates *template.Template, cookieName string, auth *service.AuthService) *TenantHandler {
+func NewTenantHandler(tenant *service.TenantService, payment *service.PaymentService, paymentTransaction *service.PaymentTransactionService, lateFee *service.LateFeeService, utility *service.UtilityService, gateway *service.GatewayService, receipt *service.ReceiptService, rentStatement *service.RentStatementService, telegramLink *service.TelegramLinkService, users interfaces.UserRepository, templates *template.Template, cookieName string, auth *service.AuthService) *TenantHandler {
 	return &TenantHandler{
 		tenantService:             tenant,
 		paymentService:            payment,
 		paymentTransactionService: paymentTransaction,
+		lateFeeService:            lateFee,
+		utilityService:            utility,
+		gatewayService:            gateway,
+		receiptService:            receipt,
+		rentStatementService:      rentStatement,
+		telegramLinkService:       telegramLink,
 		users:                     users,
 		templates:                 templates,
 		cookieName:                cookieName,
@@ -58,6 +70,11 @@ func (h *TenantHandler) Me(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	payments, _ := h.paymentService.GetPaymentsByTenantID(tenant.ID)
+	lateFees := h.lateFeeService.GetActiveLateFeesByPayment(tenant.ID)
+	utilityBills := h.utilityService.GetBillsByPayment(tenant.ID)
+	rentProrations := h.paymentService.GetRentProrationsByPayment(tenant.ID)
+	receipts, _ := h.receiptService.GetReceiptsByTenantID(tenant.ID)
+	telegramLink, _ := h.telegramLinkService.GetLink(tenant.ID)
 
 	// Calculate family member limits for template
 	maxFamilyMembers := tenant.NumberOfPeople - 1
@@ -68,14 +85,36 @@ func (h *TenantHandler) Me(w http.ResponseWriter, r *http.Request) {
 	upiID := h.paymentService.GetDefaultUPIID()
 	paymentMethod := h.paymentService.GetDefaultPaymentMethod()
 
+	// Per-payment UPI QR codes and intent links; the first unpaid payment is shown by default
+	upiRequests := make(map[int]*domain.UPIPaymentRequest)
+	var currentUPIRequest *domain.UPIPaymentRequest
+	for _, req := range h.paymentService.GetUPIPaymentRequests(tenant.ID) {
+		upiRequests[req.PaymentID] = req
+		if currentUPIRequest == nil {
+			currentUPIRequest = req
+		}
+	}
+
 	data := map[string]interface{}{
 		"Tenant":               tenant,
 		"Payments":             payments,
+		"LateFees":             lateFees,
+		"UtilityBills":         utilityBills,
+		"RentProrations":       rentProrations,
+		"CreditLedger":         h.paymentService.GetCreditLedger(tenant.ID),
 		"MaxFamilyMembers":     maxFamilyMembers,
 		"CurrentFamilyCount":   currentFamilyCount,
 		"IsFamilyLimitReached": isAtLimit,
 		"UPIID":                upiID,
 		"PaymentMethod":        paymentMethod,
+		"ChargeCategories":     h.paymentService.GetChargeCategories(),
+		"PaymentGateway":       h.gatewayService.GetGatewayName(),
+		"UPIRequests":          upiRequests,
+		"CurrentUPIRequest":    currentUPIRequest,
+		"Receipts":             receipts,
+		"StatementYears":       h.rentStatementService.GetFinancialYears(tenant),
+		"TelegramEnabled":      h.telegramLinkService.IsEnabled(),
+		"TelegramLink":         telegramLink,
 	}
 	_ = h.templates.ExecuteTemplate(w, "tenant-dashboard.html", data)
 }
@@ -149,7 +188,13 @@ func (h *TenantHandler) SubmitPayment(w http.ResponseWriter, r *http.Request) {
 
 	suggestedAmount := &amount
 
-	if err := h.paymentTransactionService.SubmitPaymentIntent(*user.TenantID, txn, suggestedAmount); err != nil {
+	// Optional: the payment whose UPI QR/link was used
+	paymentID := 0
+	if paymentIDStr := r.FormValue("payment_id"); paymentIDStr != "" {
+		fmt.Sscanf(paymentIDStr, "%d", &paymentID)
+	}
+
+	if err := h.paymentTransactionService.SubmitPaymentIntent(*user.TenantID, txn, suggestedAmount, paymentID); err != nil {
 		w.Header().Set("Content-Type", "application/json; charset=utf-8")
 		w.WriteHeader(http.StatusBadRequest)
 		json.NewEncoder(w).Encode(map[string]interface{}{
@@ -161,6 +206,75 @@ func (h *TenantHandler) SubmitPayment(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// PaymentQR returns the UPI QR code for one of the logged-in tenant's payments (?payment_id=)
+func (h *TenantHandler) PaymentQR(w http.ResponseWriter, r *http.Request) {
+	user, ok := r.Context().Value("user").(*domain.User)
+	if !ok || user == nil || user.TenantID == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	paymentID := 0
+	if paymentIDStr := r.URL.Query().Get("payment_id"); paymentIDStr != "" {
+		fmt.Sscanf(paymentIDStr, "%d", &paymentID)
+	}
+
+	payment, err := h.paymentService.GetPaymentByID(paymentID)
+	if err != nil || payment.TenantID != *user.TenantID {
+		http.Error(w, "Payment not found", http.StatusNotFound)
+		return
+	}
+
+	png, err := h.paymentService.GetUPIQRCode(payment.ID, upiQRCodeSize)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/png")
+	w.Header().Set("Cache-Control", "no-store") // Amount changes as the payment is paid
+	w.Write(png)
+}
+
+// Receipt downloads one of the logged-in tenant's receipts or credit notes (?id=&format=pdf|html)
+func (h *TenantHandler) Receipt(w http.ResponseWriter, r *http.Request) {
+	user, ok := r.Context().Value("user").(*domain.User)
+	if !ok || user == nil || user.TenantID == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	receiptID := 0
+	if idStr := r.URL.Query().Get("id"); idStr != "" {
+		fmt.Sscanf(idStr, "%d", &receiptID)
+	}
+
+	receipt, err := h.receiptService.GetReceiptByID(receiptID)
+	if err != nil || receipt.TenantID != *user.TenantID {
+		http.Error(w, "Receipt not found", http.StatusNotFound)
+		return
+	}
+
+	writeReceipt(w, h.templates, receipt, r.URL.Query().Get("format"))
+}
+
+// RentStatement downloads the logged-in tenant's rent statement (?fy=2025-26&format=pdf|csv)
+func (h *TenantHandler) RentStatement(w http.ResponseWriter, r *http.Request) {
+	user, ok := r.Context().Value("user").(*domain.User)
+	if !ok || user == nil || user.TenantID == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	statement, err := h.rentStatementService.GetStatement(*user.TenantID, financialYearParam(r))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	writeRentStatement(w, statement, r.URL.Query().Get("format"))
+}
+
 func (h *TenantHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		w.Header().Set("Content-Type", "application/json; charset=utf-8")
@@ -288,6 +402,60 @@ func (h *TenantHandler) AddFamilyMember(w http.ResponseWriter, r *http.Request)
 	})
 }
 
+// UpdateEmail sets the email address the logged-in tenant receives notifications at
+// Body: {"email": "tenant@example.com"}; an empty email removes it
+func (h *TenantHandler) UpdateEmail(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Method not allowed",
+		})
+		return
+	}
+
+	user, ok := r.Context().Value("user").(*domain.User)
+	if !ok || user == nil || user.TenantID == nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Unauthorized",
+		})
+		return
+	}
+
+	var req struct {
+		Email string `json:"email"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Invalid JSON",
+		})
+		return
+	}
+
+	if err := h.tenantService.UpdateTenantEmail(*user.TenantID, req.Email); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Email updated",
+	})
+}
+
 // Helper function to parse age
 func parseAge(ageStr string) int {
 	var age int
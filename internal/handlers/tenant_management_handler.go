@@ -5,15 +5,18 @@ import (
 	"backend-form/m/internal/metrics"
 	"backend-form/m/internal/service"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strings"
 	"time"
 )
 
 // TenantManagementHandler handles owner-facing tenant management operations
 type TenantManagementHandler struct {
-	tenantService    *service.TenantService
-	authService      *service.AuthService
-	dashboardService *service.DashboardService
+	tenantService       *service.TenantService
+	authService         *service.AuthService
+	dashboardService    *service.DashboardService
+	notificationService *service.NotificationService
 }
 
 // NewTenantManagementHandler creates a new TenantManagementHandler
@@ -21,17 +24,25 @@ func NewTenantManagementHandler(
 	tenantService *service.TenantService,
 	authService *service.AuthService,
 	dashboardService *service.DashboardService,
+	notificationService *service.NotificationService,
 ) *TenantManagementHandler {
 	return &TenantManagementHandler{
-		tenantService:    tenantService,
-		authService:      authService,
-		dashboardService: dashboardService,
+		tenantService:       tenantService,
+		authService:         authService,
+		dashboardService:    dashboardService,
+		notificationService: notificationService,
 	}
 }
 
-// GetTenants returns all tenants as JSON
+// GetTenants returns all tenants as JSON (optionally filtered by ?property_id=)
 func (h *TenantManagementHandler) GetTenants(w http.ResponseWriter, r *http.Request) {
-	tenants, err := h.tenantService.GetAllTenants()
+	propertyID, err := propertyIDParam(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	tenants, err := h.tenantService.GetTenantsByPropertyID(propertyID)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -51,11 +62,13 @@ func (h *TenantManagementHandler) CreateTenant(w http.ResponseWriter, r *http.Re
 	var tenant struct {
 		Name             string `json:"name"`
 		Phone            string `json:"phone"`
+		Email            string `json:"email"` // Optional, for email notifications
 		AadharNumber     string `json:"aadhar_number"`
 		MoveInDate       string `json:"move_in_date"`
 		NumberOfPeople   int    `json:"number_of_people"`
 		UnitID           int    `json:"unit_id"`
 		IsExistingTenant bool   `json:"is_existing_tenant"` // If true, skip first payment creation
+		DepositCollected int    `json:"deposit_collected"`  // Security deposit received at move-in
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&tenant); err != nil {
@@ -73,13 +86,14 @@ func (h *TenantManagementHandler) CreateTenant(w http.ResponseWriter, r *http.Re
 	newTenant := &domain.Tenant{
 		Name:           tenant.Name,
 		Phone:          tenant.Phone,
+		Email:          strings.TrimSpace(tenant.Email),
 		AadharNumber:   tenant.AadharNumber,
 		MoveInDate:     moveInDate,
 		NumberOfPeople: tenant.NumberOfPeople,
 		UnitID:         tenant.UnitID,
 	}
 
-	if err := h.tenantService.CreateTenant(newTenant, tenant.IsExistingTenant); err != nil {
+	if err := h.tenantService.CreateTenant(newTenant, tenant.IsExistingTenant, tenant.DepositCollected); err != nil {
 		w.Header().Set("Content-Type", "application/json; charset=utf-8")
 		w.WriteHeader(http.StatusBadRequest)
 		if err := json.NewEncoder(w).Encode(map[string]interface{}{
@@ -140,7 +154,10 @@ func (h *TenantManagementHandler) VacateTenant(w http.ResponseWriter, r *http.Re
 	}
 
 	var req struct {
-		TenantID int `json:"tenant_id"`
+		TenantID     int                       `json:"tenant_id"`
+		MoveOutDate  string                    `json:"move_out_date"` // Optional, defaults to today
+		Deductions   []domain.DepositDeduction `json:"deductions"`    // Deposit deductions (damages, cleaning, ...)
+		OffsetUnpaid bool                      `json:"offset_unpaid"` // Pay unpaid balances from the deposit
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -153,7 +170,23 @@ func (h *TenantManagementHandler) VacateTenant(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	if err := h.tenantService.MoveOutTenant(req.TenantID); err != nil {
+	moveOutDate := time.Now()
+	if req.MoveOutDate != "" {
+		parsed, err := time.Parse("2006-01-02", req.MoveOutDate)
+		if err != nil {
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"success": false,
+				"error":   "Invalid move_out_date format, expected YYYY-MM-DD",
+			})
+			return
+		}
+		moveOutDate = parsed
+	}
+
+	settlement, err := h.tenantService.MoveOutTenant(req.TenantID, moveOutDate, req.Deductions, req.OffsetUnpaid)
+	if err != nil {
 		w.Header().Set("Content-Type", "application/json; charset=utf-8")
 		w.WriteHeader(http.StatusBadRequest)
 		json.NewEncoder(w).Encode(map[string]interface{}{
@@ -168,8 +201,64 @@ func (h *TenantManagementHandler) VacateTenant(w http.ResponseWriter, r *http.Re
 
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	json.NewEncoder(w).Encode(map[string]interface{}{
-		"success": true,
-		"message": "Tenant moved out successfully",
+		"success":    true,
+		"message":    "Tenant moved out successfully",
+		"settlement": settlement,
+	})
+}
+
+// GetArchivedTenants returns past tenants as JSON
+func (h *TenantManagementHandler) GetArchivedTenants(w http.ResponseWriter, r *http.Request) {
+	tenants, err := h.tenantService.GetArchivedTenants()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(tenants)
+}
+
+// GetTenantHistory returns a tenant's payments, transactions, notifications and family members
+// Works for both active and archived tenants (?tenant_id=)
+func (h *TenantManagementHandler) GetTenantHistory(w http.ResponseWriter, r *http.Request) {
+	tenantID := 0
+	if tenantIDStr := r.URL.Query().Get("tenant_id"); tenantIDStr != "" {
+		fmt.Sscanf(tenantIDStr, "%d", &tenantID)
+	}
+
+	if tenantID <= 0 {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "tenant_id is required",
+		})
+		return
+	}
+
+	tenant, payments, err := h.tenantService.GetTenantHistory(tenantID)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	notifications, err := h.notificationService.GetNotificationsByTenantID(tenantID)
+	if err != nil {
+		notifications = []*domain.Notification{}
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success":       true,
+		"tenant":        tenant,
+		"payments":      payments,
+		"notifications": notifications,
 	})
 }
 
@@ -223,6 +312,11 @@ func (h *TenantManagementHandler) RegenerateTenantPassword(w http.ResponseWriter
 		return
 	}
 
+	// Let the tenant know, so a reset they did not ask for does not go unnoticed
+	if err := h.notificationService.NotifyPasswordReset(tenant.ID); err != nil {
+		fmt.Printf("Warning: Failed to notify tenant %d of password reset: %v\n", tenant.ID, err)
+	}
+
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	json.NewEncoder(w).Encode(map[string]interface{}{
 		"success":       true,
@@ -0,0 +1,212 @@
+package handlers
+
+import (
+	"backend-form/m/internal/domain"
+	"backend-form/m/internal/service"
+	"encoding/json"
+	"net/http"
+)
+
+// UnitManagementHandler handles owner-facing unit management operations
+type UnitManagementHandler struct {
+	unitService      *service.UnitService
+	dashboardService *service.DashboardService
+}
+
+// NewUnitManagementHandler creates a new UnitManagementHandler
+func NewUnitManagementHandler(
+	unitService *service.UnitService,
+	dashboardService *service.DashboardService,
+) *UnitManagementHandler {
+	return &UnitManagementHandler{
+		unitService:      unitService,
+		dashboardService: dashboardService,
+	}
+}
+
+// unitRequest is the JSON body accepted by create and update
+type unitRequest struct {
+	UnitID          int    `json:"unit_id"`     // Required for update only
+	PropertyID      int    `json:"property_id"` // Required for create; optional for update (keeps current)
+	UnitCode        string `json:"unit_code"`
+	Floor           string `json:"floor"`
+	UnitType        string `json:"unit_type"`
+	MonthlyRent     int    `json:"monthly_rent"`
+	SecurityDeposit int    `json:"security_deposit"`
+	PaymentDueDay   int    `json:"payment_due_day"`
+}
+
+// CreateUnit creates a new unit
+func (h *UnitManagementHandler) CreateUnit(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Method not allowed",
+		})
+		return
+	}
+
+	var req unitRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Invalid JSON",
+		})
+		return
+	}
+
+	unit := &domain.Unit{
+		PropertyID:      req.PropertyID,
+		UnitCode:        req.UnitCode,
+		Floor:           req.Floor,
+		UnitType:        req.UnitType,
+		MonthlyRent:     req.MonthlyRent,
+		SecurityDeposit: req.SecurityDeposit,
+		PaymentDueDay:   req.PaymentDueDay,
+	}
+
+	if err := h.unitService.CreateUnit(unit); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	// Invalidate dashboard cache since unit data changed
+	h.dashboardService.InvalidateDashboardCache()
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Unit created successfully",
+		"unit":    unit,
+	})
+}
+
+// UpdateUnit updates an existing unit's rent, deposit, due day, floor and type
+func (h *UnitManagementHandler) UpdateUnit(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Method not allowed",
+		})
+		return
+	}
+
+	var req unitRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Invalid JSON",
+		})
+		return
+	}
+
+	if req.UnitID <= 0 {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "unit_id is required",
+		})
+		return
+	}
+
+	unit := &domain.Unit{
+		ID:              req.UnitID,
+		PropertyID:      req.PropertyID,
+		UnitCode:        req.UnitCode,
+		Floor:           req.Floor,
+		UnitType:        req.UnitType,
+		MonthlyRent:     req.MonthlyRent,
+		SecurityDeposit: req.SecurityDeposit,
+		PaymentDueDay:   req.PaymentDueDay,
+	}
+
+	if err := h.unitService.UpdateUnit(unit); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	// Invalidate dashboard cache since unit data changed
+	h.dashboardService.InvalidateDashboardCache()
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Unit updated successfully",
+		"unit":    unit,
+	})
+}
+
+// RetireUnit takes a vacant unit out of service
+func (h *UnitManagementHandler) RetireUnit(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Method not allowed",
+		})
+		return
+	}
+
+	var req struct {
+		UnitID int `json:"unit_id"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Invalid JSON",
+		})
+		return
+	}
+
+	if req.UnitID <= 0 {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "unit_id is required",
+		})
+		return
+	}
+
+	if err := h.unitService.RetireUnit(req.UnitID); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	// Invalidate dashboard cache since unit data changed
+	h.dashboardService.InvalidateDashboardCache()
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Unit retired successfully",
+	})
+}
@@ -0,0 +1,343 @@
+package handlers
+
+import (
+	"backend-form/m/internal/domain"
+	"backend-form/m/internal/service"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// UtilityHandler handles owner-facing metered utility tariffs and meter readings
+type UtilityHandler struct {
+	utilityService   *service.UtilityService
+	dashboardService *service.DashboardService
+}
+
+// NewUtilityHandler creates a new UtilityHandler
+func NewUtilityHandler(
+	utilityService *service.UtilityService,
+	dashboardService *service.DashboardService,
+) *UtilityHandler {
+	return &UtilityHandler{
+		utilityService:   utilityService,
+		dashboardService: dashboardService,
+	}
+}
+
+// utilityTariffRequest is the JSON body accepted by create and update
+type utilityTariffRequest struct {
+	TariffID      int                 `json:"tariff_id"` // Required for update only
+	PropertyID    *int                `json:"property_id"`
+	UnitID        *int                `json:"unit_id"`
+	CategoryCode  string              `json:"category_code"` // water_bill, current_bill, ...
+	RatePerUnit   float64             `json:"rate_per_unit"`
+	Slabs         []domain.TariffSlab `json:"slabs"` // Optional, overrides rate_per_unit
+	FixedCharge   int                 `json:"fixed_charge"`
+	SplitByPeople bool                `json:"split_by_people"`
+}
+
+// toTariff converts the request into a domain tariff
+func (req *utilityTariffRequest) toTariff() *domain.UtilityTariff {
+	return &domain.UtilityTariff{
+		ID:            req.TariffID,
+		PropertyID:    req.PropertyID,
+		UnitID:        req.UnitID,
+		CategoryCode:  req.CategoryCode,
+		RatePerUnit:   req.RatePerUnit,
+		Slabs:         req.Slabs,
+		FixedCharge:   req.FixedCharge,
+		SplitByPeople: req.SplitByPeople,
+	}
+}
+
+// GetTariffs returns all utility tariffs as JSON
+func (h *UtilityHandler) GetTariffs(w http.ResponseWriter, r *http.Request) {
+	tariffs, err := h.utilityService.GetAllTariffs()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(tariffs)
+}
+
+// CreateTariff creates a utility tariff for a unit or property
+func (h *UtilityHandler) CreateTariff(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Method not allowed",
+		})
+		return
+	}
+
+	var req utilityTariffRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Invalid JSON",
+		})
+		return
+	}
+
+	tariff := req.toTariff()
+	if err := h.utilityService.CreateTariff(tariff); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Utility tariff created successfully",
+		"tariff":  tariff,
+	})
+}
+
+// UpdateTariff updates an existing utility tariff
+func (h *UtilityHandler) UpdateTariff(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Method not allowed",
+		})
+		return
+	}
+
+	var req utilityTariffRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Invalid JSON",
+		})
+		return
+	}
+
+	if req.TariffID <= 0 {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "tariff_id is required",
+		})
+		return
+	}
+
+	tariff := req.toTariff()
+	if err := h.utilityService.UpdateTariff(tariff); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Utility tariff updated successfully",
+		"tariff":  tariff,
+	})
+}
+
+// DeleteTariff deletes a utility tariff
+func (h *UtilityHandler) DeleteTariff(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Method not allowed",
+		})
+		return
+	}
+
+	var req struct {
+		TariffID int `json:"tariff_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Invalid JSON",
+		})
+		return
+	}
+
+	if err := h.utilityService.DeleteTariff(req.TariffID); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": "Utility tariff deleted successfully",
+	})
+}
+
+// GetReadings returns the meter readings of a unit (?unit_id=)
+func (h *UtilityHandler) GetReadings(w http.ResponseWriter, r *http.Request) {
+	unitID := 0
+	if unitIDStr := r.URL.Query().Get("unit_id"); unitIDStr != "" {
+		fmt.Sscanf(unitIDStr, "%d", &unitID)
+	}
+
+	if unitID <= 0 {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "unit_id is required",
+		})
+		return
+	}
+
+	readings, err := h.utilityService.GetReadingsByUnitID(unitID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(readings)
+}
+
+// RecordReading records a meter reading and generates the utility payments for it
+func (h *UtilityHandler) RecordReading(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Method not allowed",
+		})
+		return
+	}
+
+	var req struct {
+		UnitID          int      `json:"unit_id"`
+		CategoryCode    string   `json:"category_code"`    // water_bill, current_bill, ...
+		PreviousReading *float64 `json:"previous_reading"` // Optional, defaults to the last reading
+		CurrentReading  float64  `json:"current_reading"`
+		ReadingDate     string   `json:"reading_date"` // Format: "2006-01-02"
+		DueDate         string   `json:"due_date"`     // Optional, defaults to the next rent due date
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Invalid JSON",
+		})
+		return
+	}
+
+	if req.UnitID <= 0 {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "unit_id is required",
+		})
+		return
+	}
+
+	readingDate, err := time.Parse("2006-01-02", req.ReadingDate)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "Invalid reading_date format. Use YYYY-MM-DD",
+		})
+		return
+	}
+
+	var dueDate time.Time
+	if req.DueDate != "" {
+		dueDate, err = time.Parse("2006-01-02", req.DueDate)
+		if err != nil {
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"success": false,
+				"error":   "Invalid due_date format. Use YYYY-MM-DD",
+			})
+			return
+		}
+	}
+
+	reading, bills, err := h.utilityService.RecordReading(req.UnitID, req.CategoryCode, req.PreviousReading, req.CurrentReading, readingDate, dueDate)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	// Invalidate dashboard cache since payment data changed
+	h.dashboardService.InvalidateDashboardCache()
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"message": fmt.Sprintf("Meter reading recorded, %d bill(s) generated", len(bills)),
+		"reading": reading,
+		"bills":   bills,
+	})
+}
+
+// GetBills returns the utility bills of a tenant (?tenant_id=)
+func (h *UtilityHandler) GetBills(w http.ResponseWriter, r *http.Request) {
+	tenantID := 0
+	if tenantIDStr := r.URL.Query().Get("tenant_id"); tenantIDStr != "" {
+		fmt.Sscanf(tenantIDStr, "%d", &tenantID)
+	}
+
+	if tenantID <= 0 {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": false,
+			"error":   "tenant_id is required",
+		})
+		return
+	}
+
+	bills, err := h.utilityService.GetBillsByTenantID(tenantID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(bills)
+}
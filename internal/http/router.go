@@ -12,13 +12,16 @@ import (
 
 // Router handles all HTTP routing
 type Router struct {
-	authHandler    *handlers.AuthHandler
-	rentalHandler  *handlers.RentalHandler
-	tenantHandler  *handlers.TenantHandler
-	metricsHandler *handlers.MetricsHandler
-	userRepo       interfaces.UserRepository
-	loginLimiter   *middleware.RateLimiter
-	dbHealthCheck  *middleware.DatabaseHealthCheck
+	authHandler         *handlers.AuthHandler
+	rentalHandler       *handlers.RentalHandler
+	tenantHandler       *handlers.TenantHandler
+	gatewayHandler      *handlers.GatewayHandler
+	telegramHandler     *handlers.TelegramHandler
+	notificationHandler *handlers.NotificationHandler
+	metricsHandler      *handlers.MetricsHandler
+	userRepo            interfaces.UserRepository
+	loginLimiter        *middleware.RateLimiter
+	dbHealthCheck       *middleware.DatabaseHealthCheck
 }
 
 // UserContextKey is the key for storing user in context
@@ -36,18 +39,24 @@ func NewRouter(
 	authHandler *handlers.AuthHandler,
 	rentalHandler *handlers.RentalHandler,
 	tenantHandler *handlers.TenantHandler,
+	gatewayHandler *handlers.GatewayHandler,
+	telegramHandler *handlers.TelegramHandler,
+	notificationHandler *handlers.NotificationHandler,
 	userRepo interfaces.UserRepository,
 	loginLimiter *middleware.RateLimiter,
 	dbHealthCheck *middleware.DatabaseHealthCheck,
 ) *Router {
 	return &Router{
-		authHandler:    authHandler,
-		rentalHandler:  rentalHandler,
-		tenantHandler:  tenantHandler,
-		metricsHandler: handlers.NewMetricsHandler(),
-		userRepo:       userRepo,
-		loginLimiter:   loginLimiter,
-		dbHealthCheck:  dbHealthCheck,
+		authHandler:         authHandler,
+		rentalHandler:       rentalHandler,
+		tenantHandler:       tenantHandler,
+		gatewayHandler:      gatewayHandler,
+		telegramHandler:     telegramHandler,
+		notificationHandler: notificationHandler,
+		metricsHandler:      handlers.NewMetricsHandler(),
+		userRepo:            userRepo,
+		loginLimiter:        loginLimiter,
+		dbHealthCheck:       dbHealthCheck,
 	}
 }
 
@@ -95,11 +104,6 @@ func (r *Router) SetupRoutes() {
 	// Metrics endpoint (owner only)
 	http.HandleFunc("/metrics", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireOwner(r.metricsHandler.GetMetrics))).ServeHTTP))))
 
-	// Static file: QR Code image
-	http.HandleFunc("/static/qrcode.png", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		http.ServeFile(w, r, "QRCode.png")
-	}))).ServeHTTP))))
-
 	// Redirect root to login
 	rootHandler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 		http.Redirect(w, req, "/login", http.StatusSeeOther)
@@ -115,9 +119,58 @@ func (r *Router) SetupRoutes() {
 
 	// API routes
 	http.HandleFunc("/api/units", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireOwner(r.rentalHandler.GetUnits))).ServeHTTP))))
+	propertiesHandler := r.requireOwner(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method == "GET" {
+			r.rentalHandler.GetProperties(w, req)
+		} else if req.Method == "POST" {
+			r.rentalHandler.CreateProperty(w, req)
+		}
+	})
+	http.HandleFunc("/api/properties", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(propertiesHandler)).ServeHTTP))))
+	http.HandleFunc("/api/properties/update", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireOwner(r.rentalHandler.UpdateProperty))).ServeHTTP))))
+	http.HandleFunc("/api/units/create", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireOwner(r.rentalHandler.CreateUnit))).ServeHTTP))))
+	http.HandleFunc("/api/units/update", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireOwner(r.rentalHandler.UpdateUnit))).ServeHTTP))))
+	http.HandleFunc("/api/units/retire", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireOwner(r.rentalHandler.RetireUnit))).ServeHTTP))))
 	http.HandleFunc("/api/payments/submit", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireTenant(r.tenantHandler.SubmitPayment))).ServeHTTP))))
 	http.HandleFunc("/api/me/change-password", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireTenant(r.tenantHandler.ChangePassword))).ServeHTTP))))
 	http.HandleFunc("/api/me/family-members", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireTenant(r.tenantHandler.AddFamilyMember))).ServeHTTP))))
+	http.HandleFunc("/api/me/payment-qr", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireTenant(r.tenantHandler.PaymentQR))).ServeHTTP))))
+	http.HandleFunc("/api/me/receipt", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireTenant(r.tenantHandler.Receipt))).ServeHTTP))))
+	http.HandleFunc("/api/me/email", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireTenant(r.tenantHandler.UpdateEmail))).ServeHTTP))))
+	http.HandleFunc("/api/me/rent-statement", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireTenant(r.tenantHandler.RentStatement))).ServeHTTP))))
+
+	// Tenant Telegram linking (webhook is authenticated by the secret token set when registering it)
+	http.HandleFunc("/api/me/telegram/link", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireTenant(r.telegramHandler.CreateLink))).ServeHTTP))))
+	http.HandleFunc("/api/me/telegram/unlink", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireTenant(r.telegramHandler.Unlink))).ServeHTTP))))
+	http.HandleFunc("/api/telegram/webhook", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(http.HandlerFunc(r.telegramHandler.Webhook))).ServeHTTP))))
+
+	// Notification preferences - GET returns them, POST saves them
+	myNotificationPreferencesHandler := r.requireTenant(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method == "GET" {
+			r.notificationHandler.GetMyPreferences(w, req)
+		} else if req.Method == "POST" {
+			r.notificationHandler.UpdateMyPreferences(w, req)
+		}
+	})
+	http.HandleFunc("/api/me/notification-preferences", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(myNotificationPreferencesHandler)).ServeHTTP))))
+	ownerNotificationPreferencesHandler := r.requireOwner(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method == "GET" {
+			r.notificationHandler.GetOwnerPreferences(w, req)
+		} else if req.Method == "POST" {
+			r.notificationHandler.UpdateOwnerPreferences(w, req)
+		}
+	})
+	http.HandleFunc("/api/notification-preferences", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(ownerNotificationPreferencesHandler)).ServeHTTP))))
+
+	// Notifications that could not be delivered, and putting them back in the queue
+	http.HandleFunc("/api/notifications/failed", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireOwner(r.notificationHandler.GetFailedNotifications))).ServeHTTP))))
+	http.HandleFunc("/api/notifications/retry", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireOwner(r.notificationHandler.RetryNotification))).ServeHTTP))))
+
+	// Online payments through the payment gateway (webhook is authenticated by the gateway signature)
+	http.HandleFunc("/api/payments/gateway/order", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireTenant(r.gatewayHandler.CreateOrder))).ServeHTTP))))
+	http.HandleFunc("/api/payments/gateway/mock-checkout", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireTenant(r.gatewayHandler.MockCheckout))).ServeHTTP))))
+	http.HandleFunc("/api/payments/gateway/webhook", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(http.HandlerFunc(r.gatewayHandler.Webhook))).ServeHTTP))))
+	http.HandleFunc("/api/payments/gateway/orders", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireOwner(r.gatewayHandler.GetOrders))).ServeHTTP))))
 	tenantsHandler := r.requireOwner(func(w http.ResponseWriter, req *http.Request) {
 		if req.Method == "GET" {
 			r.rentalHandler.GetTenants(w, req)
@@ -131,6 +184,144 @@ func (r *Router) SetupRoutes() {
 	http.HandleFunc("/api/payments/pending-verifications", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireOwner(r.rentalHandler.GetPendingVerifications))).ServeHTTP))))
 	http.HandleFunc("/api/tenants/vacate", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireOwner(r.rentalHandler.VacateTenant))).ServeHTTP))))
 	http.HandleFunc("/api/tenants/regenerate-password", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireOwner(r.rentalHandler.RegenerateTenantPassword))).ServeHTTP))))
+	http.HandleFunc("/api/tenants/archived", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireOwner(r.rentalHandler.GetArchivedTenants))).ServeHTTP))))
+	http.HandleFunc("/api/tenants/history", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireOwner(r.rentalHandler.GetTenantHistory))).ServeHTTP))))
+	// Security deposit ledger (owner only)
+	http.HandleFunc("/api/deposits", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireOwner(r.rentalHandler.GetDeposit))).ServeHTTP))))
+	http.HandleFunc("/api/deposits/collect", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireOwner(r.rentalHandler.RecordDepositCollection))).ServeHTTP))))
+	http.HandleFunc("/api/deposits/deduct", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireOwner(r.rentalHandler.RecordDepositDeduction))).ServeHTTP))))
+	http.HandleFunc("/api/deposits/settlement", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireOwner(r.rentalHandler.GetDepositSettlement))).ServeHTTP))))
+	// Late fees (owner only) - GET lists policies, POST creates one
+	lateFeePoliciesHandler := r.requireOwner(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method == "GET" {
+			r.rentalHandler.GetLateFeePolicies(w, req)
+		} else if req.Method == "POST" {
+			r.rentalHandler.CreateLateFeePolicy(w, req)
+		}
+	})
+	http.HandleFunc("/api/late-fees/policies", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(lateFeePoliciesHandler)).ServeHTTP))))
+	http.HandleFunc("/api/late-fees/policies/update", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireOwner(r.rentalHandler.UpdateLateFeePolicy))).ServeHTTP))))
+	http.HandleFunc("/api/late-fees/policies/delete", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireOwner(r.rentalHandler.DeleteLateFeePolicy))).ServeHTTP))))
+	http.HandleFunc("/api/late-fees", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireOwner(r.rentalHandler.GetLateFees))).ServeHTTP))))
+	http.HandleFunc("/api/late-fees/waive", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireOwner(r.rentalHandler.WaiveLateFee))).ServeHTTP))))
+	http.HandleFunc("/api/late-fees/reverse", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireOwner(r.rentalHandler.ReverseLateFee))).ServeHTTP))))
+	http.HandleFunc("/api/late-fees/run", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireOwner(r.rentalHandler.RunLateFees))).ServeHTTP))))
+	// Charge categories (owner only) - GET lists, POST creates
+	chargeCategoriesHandler := r.requireOwner(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method == "GET" {
+			r.rentalHandler.GetChargeCategories(w, req)
+		} else if req.Method == "POST" {
+			r.rentalHandler.CreateChargeCategory(w, req)
+		}
+	})
+	http.HandleFunc("/api/charge-categories", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(chargeCategoriesHandler)).ServeHTTP))))
+	http.HandleFunc("/api/charge-categories/update", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireOwner(r.rentalHandler.UpdateChargeCategory))).ServeHTTP))))
+
+	// Expense ledger (owner only) - GET lists, POST creates
+	expenseCategoriesHandler := r.requireOwner(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method == "GET" {
+			r.rentalHandler.GetExpenseCategories(w, req)
+		} else if req.Method == "POST" {
+			r.rentalHandler.CreateExpenseCategory(w, req)
+		}
+	})
+	http.HandleFunc("/api/expense-categories", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(expenseCategoriesHandler)).ServeHTTP))))
+	http.HandleFunc("/api/expense-categories/update", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireOwner(r.rentalHandler.UpdateExpenseCategory))).ServeHTTP))))
+	expensesHandler := r.requireOwner(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method == "GET" {
+			r.rentalHandler.GetExpenses(w, req)
+		} else if req.Method == "POST" {
+			r.rentalHandler.CreateExpense(w, req)
+		}
+	})
+	http.HandleFunc("/api/expenses", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(expensesHandler)).ServeHTTP))))
+	http.HandleFunc("/api/expenses/update", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireOwner(r.rentalHandler.UpdateExpense))).ServeHTTP))))
+	http.HandleFunc("/api/expenses/delete", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireOwner(r.rentalHandler.DeleteExpense))).ServeHTTP))))
+	http.HandleFunc("/api/expenses/attachments", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireOwner(r.rentalHandler.UploadExpenseAttachment))).ServeHTTP))))
+	http.HandleFunc("/api/expenses/attachments/download", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireOwner(r.rentalHandler.DownloadExpenseAttachment))).ServeHTTP))))
+	http.HandleFunc("/api/expenses/attachments/delete", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireOwner(r.rentalHandler.DeleteExpenseAttachment))).ServeHTTP))))
+	http.HandleFunc("/api/reports/income-expense", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireOwner(r.rentalHandler.GetIncomeExpenseReport))).ServeHTTP))))
+
+	// Recurring charge schedules (owner only) - GET lists, POST creates
+	recurringChargesHandler := r.requireOwner(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method == "GET" {
+			r.rentalHandler.GetRecurringCharges(w, req)
+		} else if req.Method == "POST" {
+			r.rentalHandler.CreateRecurringCharge(w, req)
+		}
+	})
+	http.HandleFunc("/api/recurring-charges", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(recurringChargesHandler)).ServeHTTP))))
+	http.HandleFunc("/api/recurring-charges/update", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireOwner(r.rentalHandler.UpdateRecurringCharge))).ServeHTTP))))
+	http.HandleFunc("/api/recurring-charges/amount", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireOwner(r.rentalHandler.ChangeRecurringChargeAmount))).ServeHTTP))))
+	http.HandleFunc("/api/recurring-charges/delete", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireOwner(r.rentalHandler.DeleteRecurringCharge))).ServeHTTP))))
+	http.HandleFunc("/api/recurring-charges/generate", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireOwner(r.rentalHandler.GenerateRecurringCharges))).ServeHTTP))))
+
+	// Metered utilities (owner only) - GET lists, POST creates
+	utilityTariffsHandler := r.requireOwner(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method == "GET" {
+			r.rentalHandler.GetUtilityTariffs(w, req)
+		} else if req.Method == "POST" {
+			r.rentalHandler.CreateUtilityTariff(w, req)
+		}
+	})
+	http.HandleFunc("/api/utilities/tariffs", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(utilityTariffsHandler)).ServeHTTP))))
+	http.HandleFunc("/api/utilities/tariffs/update", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireOwner(r.rentalHandler.UpdateUtilityTariff))).ServeHTTP))))
+	http.HandleFunc("/api/utilities/tariffs/delete", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireOwner(r.rentalHandler.DeleteUtilityTariff))).ServeHTTP))))
+	meterReadingsHandler := r.requireOwner(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method == "GET" {
+			r.rentalHandler.GetMeterReadings(w, req)
+		} else if req.Method == "POST" {
+			r.rentalHandler.RecordMeterReading(w, req)
+		}
+	})
+	http.HandleFunc("/api/utilities/readings", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(meterReadingsHandler)).ServeHTTP))))
+	http.HandleFunc("/api/utilities/bills", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireOwner(r.rentalHandler.GetUtilityBills))).ServeHTTP))))
+
+	// Lease agreements (owner only) - GET returns a tenant's leases, POST creates
+	leasesHandler := r.requireOwner(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method == "GET" {
+			r.rentalHandler.GetLeases(w, req)
+		} else if req.Method == "POST" {
+			r.rentalHandler.CreateLease(w, req)
+		}
+	})
+	http.HandleFunc("/api/leases", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(leasesHandler)).ServeHTTP))))
+	http.HandleFunc("/api/leases/renew", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireOwner(r.rentalHandler.RenewLease))).ServeHTTP))))
+	http.HandleFunc("/api/leases/upcoming", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireOwner(r.rentalHandler.GetUpcomingLeaseEvents))).ServeHTTP))))
+
+	// Discounts, waivers and write-offs (owner only) - GET lists, POST records an adjustment
+	paymentAdjustmentsHandler := r.requireOwner(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method == "GET" {
+			r.rentalHandler.GetPaymentAdjustments(w, req)
+		} else if req.Method == "POST" {
+			r.rentalHandler.CreatePaymentAdjustment(w, req)
+		}
+	})
+	http.HandleFunc("/api/payments/adjustments", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(paymentAdjustmentsHandler)).ServeHTTP))))
+
+	// Installment plans (owner only) - GET returns a tenant's plans, POST restructures arrears
+	installmentPlansHandler := r.requireOwner(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method == "GET" {
+			r.rentalHandler.GetInstallmentPlans(w, req)
+		} else if req.Method == "POST" {
+			r.rentalHandler.CreateInstallmentPlan(w, req)
+		}
+	})
+	http.HandleFunc("/api/installment-plans", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(installmentPlansHandler)).ServeHTTP))))
+	http.HandleFunc("/api/installment-plans/cancel", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireOwner(r.rentalHandler.CancelInstallmentPlan))).ServeHTTP))))
+	http.HandleFunc("/api/credits", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireOwner(r.rentalHandler.GetCredits))).ServeHTTP))))
+	http.HandleFunc("/api/credits/refund", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireOwner(r.rentalHandler.RefundCredit))).ServeHTTP))))
+	http.HandleFunc("/api/payments/disputed-submissions", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireOwner(r.rentalHandler.GetDisputedSubmissions))).ServeHTTP))))
+	http.HandleFunc("/api/payments/upi", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireOwner(r.rentalHandler.GetUPIPaymentRequest))).ServeHTTP))))
+	http.HandleFunc("/api/reconciliation/import", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireOwner(r.rentalHandler.ImportBankStatement))).ServeHTTP))))
+	http.HandleFunc("/api/reconciliation/queue", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireOwner(r.rentalHandler.GetReconciliationQueue))).ServeHTTP))))
+	http.HandleFunc("/api/reconciliation/imports", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireOwner(r.rentalHandler.GetStatementImports))).ServeHTTP))))
+	http.HandleFunc("/api/reconciliation/match", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireOwner(r.rentalHandler.MatchStatementLine))).ServeHTTP))))
+	http.HandleFunc("/api/reconciliation/ignore", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireOwner(r.rentalHandler.IgnoreStatementLine))).ServeHTTP))))
+	http.HandleFunc("/api/receipts", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireOwner(r.rentalHandler.GetReceipts))).ServeHTTP))))
+	http.HandleFunc("/api/receipts/download", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireOwner(r.rentalHandler.DownloadReceipt))).ServeHTTP))))
+	http.HandleFunc("/api/receipts/credit-note", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireOwner(r.rentalHandler.IssueCreditNote))).ServeHTTP))))
+	http.HandleFunc("/api/statements/rent", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireOwner(r.rentalHandler.GetRentStatement))).ServeHTTP))))
 	http.HandleFunc("/api/summary", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireOwner(r.rentalHandler.GetSummary))).ServeHTTP))))
 	http.HandleFunc("/api/payments/sync-history", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireOwner(r.rentalHandler.SyncPaymentHistory))).ServeHTTP))))
 	http.HandleFunc("/api/payments/adjust-due-date", compressionWrapper(metricsWrapper(http.HandlerFunc(recoveryWrapper(correlationWrapper(r.requireOwner(r.rentalHandler.AdjustPaymentDueDate))).ServeHTTP))))
@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"backend-form/m/internal/domain"
+	"time"
+)
+
+// BankStatementRepository defines the interface for imported bank statements and their lines
+type BankStatementRepository interface {
+	CreateImport(statementImport *domain.StatementImport) error
+	UpdateImportCounts(statementImport *domain.StatementImport) error
+	GetImports() ([]*domain.StatementImport, error) // Most recent first
+
+	CreateLine(line *domain.StatementLine) error
+	UpdateLine(line *domain.StatementLine) error
+	GetLineByID(id int) (*domain.StatementLine, error)
+	GetOpenLines() ([]*domain.StatementLine, error) // Lines awaiting review or unmatched
+	LineExists(txnDate time.Time, amount int, reference string, description string) (bool, error)
+}
@@ -0,0 +1,11 @@
+package interfaces
+
+import "backend-form/m/internal/domain"
+
+// ChargeCategoryRepository defines the interface for charge category data operations
+type ChargeCategoryRepository interface {
+	CreateCategory(category *domain.ChargeCategory) error
+	GetCategoryByCode(code string) (*domain.ChargeCategory, error)
+	GetAllCategories() ([]*domain.ChargeCategory, error) // Includes inactive categories
+	UpdateCategory(category *domain.ChargeCategory) error
+}
@@ -0,0 +1,10 @@
+package interfaces
+
+import "backend-form/m/internal/domain"
+
+// CreditRepository defines the interface for tenant credit ledger operations
+// Overpayments are credited by PaymentRepository.ApplySmartAllocation
+type CreditRepository interface {
+	CreateDebitEntries(tenantID int, entries []*domain.CreditEntry) error   // Applied and refund entries, checked against the balance in one transaction
+	GetCreditEntriesByTenantID(tenantID int) ([]*domain.CreditEntry, error) // Oldest first
+}
@@ -0,0 +1,14 @@
+package interfaces
+
+import "backend-form/m/internal/domain"
+
+// DepositRepository defines the interface for security deposit ledger operations
+type DepositRepository interface {
+	CreateDeposit(deposit *domain.SecurityDeposit) error
+	GetDepositByTenantID(tenantID int) (*domain.SecurityDeposit, error)
+	UpdateDepositStatus(deposit *domain.SecurityDeposit) error
+
+	// Ledger entries
+	CreateDepositEntry(entry *domain.DepositEntry) error
+	GetDepositEntriesByDepositID(depositID int) ([]*domain.DepositEntry, error)
+}
@@ -0,0 +1,37 @@
+package interfaces
+
+import (
+	"backend-form/m/internal/domain"
+	"time"
+)
+
+// ExpenseFilter narrows the expenses returned by GetExpenses (zero values match everything)
+type ExpenseFilter struct {
+	PropertyID int
+	UnitID     int
+	Category   string
+	From       time.Time // Inclusive
+	To         time.Time // Inclusive
+}
+
+// ExpenseRepository defines the interface for owner expenses and the income they are reported against
+type ExpenseRepository interface {
+	CreateCategory(category *domain.ExpenseCategory) error
+	GetCategoryByCode(code string) (*domain.ExpenseCategory, error)
+	GetAllCategories() ([]*domain.ExpenseCategory, error) // Includes inactive categories
+	UpdateCategory(category *domain.ExpenseCategory) error
+
+	CreateExpense(expense *domain.Expense) error
+	UpdateExpense(expense *domain.Expense) error
+	DeleteExpense(id int) error
+	GetExpenseByID(id int) (*domain.Expense, error)
+	GetExpenses(filter ExpenseFilter) ([]*domain.Expense, error) // Most recent first
+
+	CreateAttachment(attachment *domain.ExpenseAttachment) error
+	GetAttachmentByID(id int) (*domain.ExpenseAttachment, error) // Includes the file data
+	GetAttachmentsByExpenseID(expenseID int) ([]*domain.ExpenseAttachment, error)
+	DeleteAttachment(id int) error
+
+	// GetIncome returns verified payment transactions received between two dates (propertyID 0 = all)
+	GetIncome(from, to time.Time, propertyID int) ([]*domain.IncomeEntry, error)
+}
@@ -0,0 +1,12 @@
+package interfaces
+
+import "backend-form/m/internal/domain"
+
+// GatewayOrderRepository defines the interface for payment gateway order operations
+type GatewayOrderRepository interface {
+	CreateOrder(order *domain.GatewayOrder) error
+	UpdateOrder(order *domain.GatewayOrder) error
+	GetOrderByGatewayOrderID(gateway, gatewayOrderID string) (*domain.GatewayOrder, error) // nil if not found
+	GetOpenOrderForPayment(paymentID int, amount int) (*domain.GatewayOrder, error)        // nil if none
+	GetOrdersByTenantID(tenantID int) ([]*domain.GatewayOrder, error)                      // Newest first
+}
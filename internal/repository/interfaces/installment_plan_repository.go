@@ -0,0 +1,18 @@
+package interfaces
+
+import "backend-form/m/internal/domain"
+
+// InstallmentPlanRepository defines the interface for installment plan operations
+type InstallmentPlanRepository interface {
+	// Plans
+	CreatePlan(plan *domain.InstallmentPlan) error // With its arrears and installment payments, in one transaction
+	GetPlanByID(id int) (*domain.InstallmentPlan, error)
+	GetPlansByTenantID(tenantID int) ([]*domain.InstallmentPlan, error)
+	CancelPlan(plan *domain.InstallmentPlan) error // Closes installments and restores arrears, in one transaction
+
+	// Restructured arrears
+	GetArrearsByPlanID(planID int) ([]*domain.InstallmentPlanArrear, error)
+
+	// Installments
+	GetInstallmentsByPlanID(planID int) ([]*domain.Installment, error)
+}
@@ -0,0 +1,22 @@
+package interfaces
+
+import "backend-form/m/internal/domain"
+
+// LateFeeRepository defines the interface for late fee policy and charge operations
+type LateFeeRepository interface {
+	// Policies
+	CreatePolicy(policy *domain.LateFeePolicy) error
+	GetPolicyByID(id int) (*domain.LateFeePolicy, error)
+	GetAllPolicies() ([]*domain.LateFeePolicy, error)
+	GetPolicyForUnit(unitID int) (*domain.LateFeePolicy, error) // Unit policy, else property policy, else nil
+	UpdatePolicy(policy *domain.LateFeePolicy) error
+	DeletePolicy(id int) error
+
+	// Charged fees
+	CreateLateFee(fee *domain.LateFee, feePayment *domain.Payment) error // Also inserts the late_fee payment, in one transaction
+	GetLateFeeByID(id int) (*domain.LateFee, error)
+	GetLateFeeByPaymentID(paymentID int) (*domain.LateFee, error)
+	GetLateFeesByTenantID(tenantID int) ([]*domain.LateFee, error)
+	RaiseLateFee(fee *domain.LateFee, amount int) error                 // Also raises the late_fee payment, in one transaction
+	CloseLateFee(fee *domain.LateFee, feePayment *domain.Payment) error // Also closes or deletes the late_fee payment, in one transaction
+}
@@ -0,0 +1,13 @@
+package interfaces
+
+import "backend-form/m/internal/domain"
+
+// LeaseRepository defines the interface for lease agreement operations
+type LeaseRepository interface {
+	CreateLease(lease *domain.Lease) error
+	GetLeaseByID(id int) (*domain.Lease, error)
+	GetActiveLeaseByTenantID(tenantID int) (*domain.Lease, error) // nil if the tenant has no active lease
+	GetLeasesByTenantID(tenantID int) ([]*domain.Lease, error)    // Renewal history, most recent first
+	GetActiveLeases() ([]*domain.Lease, error)                    // Active leases of active tenants
+	UpdateLease(lease *domain.Lease) error
+}
@@ -0,0 +1,9 @@
+package interfaces
+
+import "backend-form/m/internal/domain"
+
+// NotificationPreferenceRepository defines the interface for owner and tenant notification preferences
+type NotificationPreferenceRepository interface {
+	GetPreferences(recipient domain.NotificationRecipient, tenantID *int) (*domain.NotificationPreferences, error) // nil if none were saved
+	SavePreferences(prefs *domain.NotificationPreferences) error                                                   // Creates or replaces the preferences
+}
@@ -1,11 +1,21 @@
 package interfaces
 
-import "backend-form/m/internal/domain"
+import (
+	"backend-form/m/internal/domain"
+	"time"
+)
 
 // NotificationRepository defines the interface for notification data operations
 type NotificationRepository interface {
-	CreateNotification(notification *domain.Notification) error
+	// CreateNotification queues a notification; returns false if one with the same dedupe key already exists
+	CreateNotification(notification *domain.Notification) (bool, error)
 	GetNotificationByID(id int) (*domain.Notification, error)
 	GetNotificationsByTenantID(tenantID int) ([]*domain.Notification, error)
 	UpdateNotification(notification *domain.Notification) error
+	// ClaimDueNotifications returns pending notifications due by now and holds them until claimUntil
+	ClaimDueNotifications(now time.Time, claimUntil time.Time, limit int) ([]*domain.Notification, error)
+	GetNotificationsByStatus(status domain.NotificationStatus, limit int) ([]*domain.Notification, error) // Most recent first
+	// HasNotificationSince reports whether a notification of the type was already recorded for the tenant
+	// (and payment, if given) since the given time; used to avoid repeating scheduled notifications
+	HasNotificationSince(notificationType domain.NotificationType, recipient domain.NotificationRecipient, tenantID int, paymentID *int, since time.Time) (bool, error)
 }
@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"backend-form/m/internal/domain"
+	"time"
+)
+
+// PaymentAdjustmentRepository defines the interface for discount, waiver and write-off records
+type PaymentAdjustmentRepository interface {
+	CreateAdjustment(adjustment *domain.PaymentAdjustment) error // Also reduces the payment's balance, in one transaction
+	GetAdjustmentsByPaymentID(paymentID int) ([]*domain.PaymentAdjustment, error)
+	GetAdjustmentsByTenantID(tenantID int) ([]*domain.PaymentAdjustment, error)
+	GetAdjustmentsBetween(from, to time.Time, propertyID int) ([]*domain.PaymentAdjustment, error) // Both dates inclusive, zero from = no lower bound, 0 = all properties
+}
@@ -14,6 +14,7 @@ type PaymentRepository interface {
 	UpdatePayment(payment *domain.Payment) error
 	DeletePayment(id int) error
 	GetPaymentsByTenantID(tenantID int) ([]*domain.Payment, error)
+	GetPaymentsByPropertyID(propertyID int) ([]*domain.Payment, error)
 	GetPaymentByTenantAndMonth(tenantID int, month time.Month, year int) (*domain.Payment, error)
 	DeletePaymentsByTenantID(tenantID int) error
 
@@ -26,8 +27,9 @@ type PaymentRepository interface {
 	VerifyTransaction(transactionID string, amount int, verifiedByUserID int) error
 	VerifyTransactionRecord(transactionID string, amount int, verifiedByUserID int, verifiedAt time.Time) error
 	ApplyPaymentAllocation(paymentID int, amount int, allocationTime time.Time) error
-	ApplySmartAllocation(transactionID string, amount int, verifiedByUserID int, allocations map[int]int, allocationTime time.Time) error
-	RejectTransaction(transactionID string) error
+	ApplySmartAllocation(transactionID string, amount int, verifiedByUserID int, allocations map[int]int, overpayment *domain.CreditEntry, receipts []*domain.Receipt, allocationTime time.Time) error
+	RejectTransaction(transactionID string, reason string, rejectedByUserID int, rejectedAt time.Time) error
+	GetRejectedTransactions(tenantID int) ([]*domain.PaymentTransaction, error) // 0 = all tenants, with Payment populated
 
 	// NEW: Auto-create helpers
 	GetLatestPaymentByTenantID(tenantID int) (*domain.Payment, error)
@@ -0,0 +1,11 @@
+package interfaces
+
+import "backend-form/m/internal/domain"
+
+// PropertyRepository defines the interface for property data operations
+type PropertyRepository interface {
+	CreateProperty(property *domain.Property) error
+	GetPropertyByID(id int) (*domain.Property, error)
+	GetAllProperties() ([]*domain.Property, error)
+	UpdateProperty(property *domain.Property) error
+}
@@ -0,0 +1,10 @@
+package interfaces
+
+import "backend-form/m/internal/domain"
+
+// ProrationRepository defines the interface for rent proration breakdowns
+type ProrationRepository interface {
+	CreateProratedPayment(payment *domain.Payment, proration *domain.RentProration) error // Inserts both in one transaction
+	GetProrationByPaymentID(paymentID int) (*domain.RentProration, error) // nil if the payment was not prorated
+	GetProrationsByTenantID(tenantID int) ([]*domain.RentProration, error)
+}
@@ -0,0 +1,15 @@
+package interfaces
+
+import "backend-form/m/internal/domain"
+
+// ReceiptRepository defines the interface for receipt and credit note operations
+// Receipts are immutable: there are no update or delete operations
+// Receipts are issued by PaymentRepository.ApplySmartAllocation in the transaction that verifies the payment;
+// every document gets the next number of its type and financial year
+type ReceiptRepository interface {
+	CreateCreditNote(note *domain.Receipt) error // Also reopens the payment's balance, in one transaction
+	GetReceiptByID(id int) (*domain.Receipt, error)
+	GetReceiptsByTenantID(tenantID int) ([]*domain.Receipt, error)   // Newest first
+	GetReceiptsByPaymentID(paymentID int) ([]*domain.Receipt, error) // Oldest first
+	GetCreditNotesByReceiptID(receiptID int) ([]*domain.Receipt, error)
+}
@@ -0,0 +1,18 @@
+package interfaces
+
+import "backend-form/m/internal/domain"
+
+// RecurringChargeRepository defines the interface for recurring charge schedule operations
+type RecurringChargeRepository interface {
+	// Schedules
+	CreateRecurringCharge(charge *domain.RecurringCharge) error
+	GetRecurringChargeByID(id int) (*domain.RecurringCharge, error)
+	GetRecurringChargesForTenant(tenantID, unitID int) ([]*domain.RecurringCharge, error) // Tenant schedules plus schedules of their unit
+	GetAllRecurringCharges() ([]*domain.RecurringCharge, error)
+	UpdateRecurringCharge(charge *domain.RecurringCharge) error
+	DeleteRecurringCharge(id int) error
+
+	// Amount changes
+	CreateAmountChange(change *domain.RecurringChargeAmountChange) error
+	GetAmountChangesByChargeID(chargeID int) ([]*domain.RecurringChargeAmountChange, error)
+}
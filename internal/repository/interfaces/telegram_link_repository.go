@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"backend-form/m/internal/domain"
+	"time"
+)
+
+// TelegramLinkRepository defines the interface for tenant Telegram chat links and their one-time tokens
+type TelegramLinkRepository interface {
+	CreateLinkToken(token *domain.TelegramLinkToken) error
+	ConsumeLinkToken(token string, now time.Time) (*domain.TelegramLinkToken, error) // nil if unknown, used or expired
+	SaveLink(link *domain.TelegramLink) error                                        // Creates or replaces the tenant's link
+	GetLinkByTenantID(tenantID int) (*domain.TelegramLink, error)                    // nil if the tenant has not linked a chat
+	GetLinksByChatID(chatID string) ([]*domain.TelegramLink, error)
+	UpdateLinkDelivery(link *domain.TelegramLink) error // Persists failure tracking
+	DeleteLink(tenantID int) error
+}
@@ -10,6 +10,11 @@ type TenantRepository interface {
 	UpdateTenant(tenant *domain.Tenant) error
 	DeleteTenant(id int) error
 	GetTenantsByUnitID(unitID int) ([]*domain.Tenant, error)
+	GetTenantsByPropertyID(propertyID int) ([]*domain.Tenant, error)
+
+	// Move-out history
+	GetArchivedTenants() ([]*domain.Tenant, error)
+	MoveOutTenant(moveOut *domain.MoveOut) error // Saves the whole move-out in one transaction
 
 	// Family member operations
 	CreateFamilyMember(familyMember *domain.FamilyMember) error
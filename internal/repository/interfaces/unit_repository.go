@@ -4,9 +4,15 @@ import "backend-form/m/internal/domain"
 
 // UnitRepository defines the interface for unit data operations
 type UnitRepository interface {
-	GetAllUnits() ([]*domain.Unit, error)
+	GetAllUnits() ([]*domain.Unit, error) // Excludes retired units
+	GetUnitsByPropertyID(propertyID int) ([]*domain.Unit, error)
 	GetUnitByID(id int) (*domain.Unit, error)
-	GetUnitByCode(code string) (*domain.Unit, error)
-	GetUnitsByIDs(ids []int) (map[int]*domain.Unit, error) // Bulk load units by IDs (fixes N+1)
+	GetUnitByCode(propertyID int, code string) (*domain.Unit, error) // Unit codes are unique within a property
+	GetUnitsByIDs(ids []int) (map[int]*domain.Unit, error)           // Bulk load units by IDs (fixes N+1)
 	UpdateUnitOccupancy(unitID int, isOccupied bool) error
+
+	// Unit management
+	CreateUnit(unit *domain.Unit) error
+	UpdateUnit(unit *domain.Unit) error
+	RetireUnit(unitID int) error
 }
@@ -0,0 +1,25 @@
+package interfaces
+
+import "backend-form/m/internal/domain"
+
+// UtilityRepository defines the interface for metered utility tariffs, readings and bills
+type UtilityRepository interface {
+	// Tariffs
+	CreateTariff(tariff *domain.UtilityTariff) error
+	GetTariffByID(id int) (*domain.UtilityTariff, error)
+	GetAllTariffs() ([]*domain.UtilityTariff, error)
+	GetTariffForUnit(unitID int, categoryCode string) (*domain.UtilityTariff, error) // Unit tariff, else property tariff, else nil
+	UpdateTariff(tariff *domain.UtilityTariff) error
+	DeleteTariff(id int) error
+
+	// Meter readings
+	CreateReading(reading *domain.MeterReading) error
+	GetReadingByID(id int) (*domain.MeterReading, error)
+	GetLatestReading(unitID int, categoryCode string) (*domain.MeterReading, error) // nil if the meter has no readings
+	GetReadingsByUnitID(unitID int) ([]*domain.MeterReading, error)
+
+	// Bills
+	CreateBill(bill *domain.UtilityBill) error
+	GetBillsByTenantID(tenantID int) ([]*domain.UtilityBill, error)
+	GetBillsByReadingID(readingID int) ([]*domain.UtilityBill, error)
+}
@@ -0,0 +1,245 @@
+package repository
+
+import (
+	domain "backend-form/m/internal/domain"
+	"backend-form/m/internal/repository/interfaces"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// PostgresBankStatementRepository implements BankStatementRepository interface
+type PostgresBankStatementRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresBankStatementRepository creates a new PostgresBankStatementRepository
+func NewPostgresBankStatementRepository(db *sql.DB) interfaces.BankStatementRepository {
+	return &PostgresBankStatementRepository{db: db}
+}
+
+// ============================================
+// Imports
+// ============================================
+
+// CreateImport records an uploaded statement file
+func (r *PostgresBankStatementRepository) CreateImport(statementImport *domain.StatementImport) error {
+	query := `
+		INSERT INTO bank_statement_imports (file_name, format, imported_by_user_id, line_count, matched_count, duplicate_count)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at`
+
+	err := r.db.QueryRow(query,
+		statementImport.FileName,
+		statementImport.Format,
+		statementImport.ImportedByUserID,
+		statementImport.LineCount,
+		statementImport.MatchedCount,
+		statementImport.DuplicateCount,
+	).Scan(&statementImport.ID, &statementImport.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create statement import: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateImportCounts updates the line counts of an import once reconciliation has run
+func (r *PostgresBankStatementRepository) UpdateImportCounts(statementImport *domain.StatementImport) error {
+	query := `
+		UPDATE bank_statement_imports
+		SET line_count = $1, matched_count = $2, duplicate_count = $3
+		WHERE id = $4`
+
+	_, err := r.db.Exec(query,
+		statementImport.LineCount,
+		statementImport.MatchedCount,
+		statementImport.DuplicateCount,
+		statementImport.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update statement import: %w", err)
+	}
+
+	return nil
+}
+
+// GetImports returns all statement imports, most recent first
+func (r *PostgresBankStatementRepository) GetImports() ([]*domain.StatementImport, error) {
+	query := `
+		SELECT id, file_name, format, imported_by_user_id, line_count, matched_count, duplicate_count, created_at
+		FROM bank_statement_imports
+		ORDER BY created_at DESC`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query statement imports: %w", err)
+	}
+	defer rows.Close()
+
+	var imports []*domain.StatementImport
+	for rows.Next() {
+		statementImport := &domain.StatementImport{}
+		err := rows.Scan(
+			&statementImport.ID,
+			&statementImport.FileName,
+			&statementImport.Format,
+			&statementImport.ImportedByUserID,
+			&statementImport.LineCount,
+			&statementImport.MatchedCount,
+			&statementImport.DuplicateCount,
+			&statementImport.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan statement import: %w", err)
+		}
+		imports = append(imports, statementImport)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating statement imports: %w", err)
+	}
+
+	return imports, nil
+}
+
+// ============================================
+// Lines
+// ============================================
+
+const statementLineColumns = `id, import_id, txn_date, amount, reference, description, status, matched_transaction_id, note, created_at`
+
+// scanStatementLine scans a statement line row
+func scanStatementLine(row rowScanner) (*domain.StatementLine, error) {
+	line := &domain.StatementLine{}
+	var matchedTransactionID sql.NullString
+	err := row.Scan(
+		&line.ID,
+		&line.ImportID,
+		&line.TxnDate,
+		&line.Amount,
+		&line.Reference,
+		&line.Description,
+		&line.Status,
+		&matchedTransactionID,
+		&line.Note,
+		&line.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if matchedTransactionID.Valid {
+		line.MatchedTransactionID = &matchedTransactionID.String
+	}
+	return line, nil
+}
+
+// CreateLine records a credit line of an imported statement
+func (r *PostgresBankStatementRepository) CreateLine(line *domain.StatementLine) error {
+	query := `
+		INSERT INTO bank_statement_lines (import_id, txn_date, amount, reference, description, status, matched_transaction_id, note)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, created_at`
+
+	err := r.db.QueryRow(query,
+		line.ImportID,
+		line.TxnDate,
+		line.Amount,
+		line.Reference,
+		line.Description,
+		line.Status,
+		line.MatchedTransactionID,
+		line.Note,
+	).Scan(&line.ID, &line.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create statement line: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateLine updates the reconciliation status of a statement line
+func (r *PostgresBankStatementRepository) UpdateLine(line *domain.StatementLine) error {
+	query := `
+		UPDATE bank_statement_lines
+		SET status = $1, matched_transaction_id = $2, note = $3
+		WHERE id = $4`
+
+	result, err := r.db.Exec(query, line.Status, line.MatchedTransactionID, line.Note, line.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update statement line: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("statement line with ID %d not found", line.ID)
+	}
+
+	return nil
+}
+
+// GetLineByID returns a statement line by ID
+func (r *PostgresBankStatementRepository) GetLineByID(id int) (*domain.StatementLine, error) {
+	query := `SELECT ` + statementLineColumns + ` FROM bank_statement_lines WHERE id = $1`
+
+	line, err := scanStatementLine(r.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("statement line with ID %d not found", id)
+		}
+		return nil, fmt.Errorf("failed to get statement line: %w", err)
+	}
+
+	return line, nil
+}
+
+// GetOpenLines returns lines awaiting review or unmatched, oldest first
+func (r *PostgresBankStatementRepository) GetOpenLines() ([]*domain.StatementLine, error) {
+	query := `
+		SELECT ` + statementLineColumns + `
+		FROM bank_statement_lines
+		WHERE status IN ('review', 'unmatched')
+		ORDER BY txn_date, id`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query statement lines: %w", err)
+	}
+	defer rows.Close()
+
+	var lines []*domain.StatementLine
+	for rows.Next() {
+		line, err := scanStatementLine(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan statement line: %w", err)
+		}
+		lines = append(lines, line)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating statement lines: %w", err)
+	}
+
+	return lines, nil
+}
+
+// LineExists returns true if the same credit was already imported from an earlier statement
+func (r *PostgresBankStatementRepository) LineExists(txnDate time.Time, amount int, reference string, description string) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM bank_statement_lines
+			WHERE txn_date = $1 AND amount = $2 AND reference = $3 AND description = $4
+		)`
+
+	var exists bool
+	if err := r.db.QueryRow(query, txnDate, amount, reference, description).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check statement line: %w", err)
+	}
+
+	return exists, nil
+}
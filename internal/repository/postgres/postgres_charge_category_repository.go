@@ -0,0 +1,140 @@
+package repository
+
+import (
+	domain "backend-form/m/internal/domain"
+	"backend-form/m/internal/repository/interfaces"
+	"database/sql"
+	"fmt"
+)
+
+// PostgresChargeCategoryRepository implements ChargeCategoryRepository interface
+type PostgresChargeCategoryRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresChargeCategoryRepository creates a new PostgresChargeCategoryRepository
+func NewPostgresChargeCategoryRepository(db *sql.DB) interfaces.ChargeCategoryRepository {
+	return &PostgresChargeCategoryRepository{db: db}
+}
+
+// CreateCategory creates a new charge category
+func (r *PostgresChargeCategoryRepository) CreateCategory(category *domain.ChargeCategory) error {
+	query := `
+		INSERT INTO charge_categories (code, display_name, default_amount, is_recurring, is_system, is_active)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at`
+
+	err := r.db.QueryRow(query,
+		category.Code,
+		category.DisplayName,
+		category.DefaultAmount,
+		category.IsRecurring,
+		category.IsSystem,
+		category.IsActive,
+	).Scan(&category.ID, &category.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create charge category: %w", err)
+	}
+
+	return nil
+}
+
+// GetCategoryByCode returns a charge category by its label code
+func (r *PostgresChargeCategoryRepository) GetCategoryByCode(code string) (*domain.ChargeCategory, error) {
+	query := `
+		SELECT id, code, display_name, default_amount, is_recurring, is_system, is_active, created_at
+		FROM charge_categories
+		WHERE code = $1`
+
+	category := &domain.ChargeCategory{}
+	err := r.db.QueryRow(query, code).Scan(
+		&category.ID,
+		&category.Code,
+		&category.DisplayName,
+		&category.DefaultAmount,
+		&category.IsRecurring,
+		&category.IsSystem,
+		&category.IsActive,
+		&category.CreatedAt,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("charge category '%s' not found", code)
+		}
+		return nil, fmt.Errorf("failed to get charge category: %w", err)
+	}
+
+	return category, nil
+}
+
+// GetAllCategories returns all charge categories, built-in ones first
+func (r *PostgresChargeCategoryRepository) GetAllCategories() ([]*domain.ChargeCategory, error) {
+	query := `
+		SELECT id, code, display_name, default_amount, is_recurring, is_system, is_active, created_at
+		FROM charge_categories
+		ORDER BY is_system DESC, display_name`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query charge categories: %w", err)
+	}
+	defer rows.Close()
+
+	var categories []*domain.ChargeCategory
+	for rows.Next() {
+		category := &domain.ChargeCategory{}
+		err := rows.Scan(
+			&category.ID,
+			&category.Code,
+			&category.DisplayName,
+			&category.DefaultAmount,
+			&category.IsRecurring,
+			&category.IsSystem,
+			&category.IsActive,
+			&category.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan charge category: %w", err)
+		}
+		categories = append(categories, category)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating charge categories: %w", err)
+	}
+
+	return categories, nil
+}
+
+// UpdateCategory updates display name, default amount, recurrence and active flag (the code never changes)
+func (r *PostgresChargeCategoryRepository) UpdateCategory(category *domain.ChargeCategory) error {
+	query := `
+		UPDATE charge_categories 
+		SET display_name = $1, default_amount = $2, is_recurring = $3, is_active = $4
+		WHERE id = $5`
+
+	result, err := r.db.Exec(query,
+		category.DisplayName,
+		category.DefaultAmount,
+		category.IsRecurring,
+		category.IsActive,
+		category.ID,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update charge category: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("charge category with ID %d not found", category.ID)
+	}
+
+	return nil
+}
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	domain "backend-form/m/internal/domain"
+	"backend-form/m/internal/repository/interfaces"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// PostgresCreditRepository implements CreditRepository interface
+type PostgresCreditRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresCreditRepository creates a new PostgresCreditRepository
+func NewPostgresCreditRepository(db *sql.DB) interfaces.CreditRepository {
+	return &PostgresCreditRepository{db: db}
+}
+
+// createCreditEntry records a movement on a tenant's credit ledger, inside a transaction when db is one
+func createCreditEntry(db execer, entry *domain.CreditEntry) error {
+	query := `
+		INSERT INTO tenant_credit_entries (tenant_id, entry_type, amount, reason, transaction_id, payment_id, entry_date)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at`
+
+	err := db.QueryRow(query,
+		entry.TenantID,
+		entry.EntryType,
+		entry.Amount,
+		entry.Reason,
+		entry.TransactionID,
+		entry.PaymentID,
+		entry.EntryDate,
+	).Scan(&entry.ID, &entry.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create credit entry: %w", err)
+	}
+
+	return nil
+}
+
+// CreateDebitEntries records credit applied to payments or refunded, in a single transaction
+// The tenant's ledger is locked while its balance is checked, so concurrent debits can never spend the same
+// credit twice. Applied entries are paid to their payments. Nothing is saved if the balance does not cover them all
+func (r *PostgresCreditRepository) CreateDebitEntries(tenantID int, entries []*domain.CreditEntry) error {
+	dbTx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback()
+
+	if _, err = dbTx.Exec(`SELECT pg_advisory_xact_lock(hashtext('tenant_credit_entries'), $1)`, tenantID); err != nil {
+		return fmt.Errorf("failed to lock credit ledger: %w", err)
+	}
+
+	var balance int
+	err = dbTx.QueryRow(`
+		SELECT COALESCE(SUM(CASE WHEN entry_type = $2 THEN amount ELSE -amount END), 0)
+		FROM tenant_credit_entries
+		WHERE tenant_id = $1`,
+		tenantID, domain.CreditEntryOverpayment,
+	).Scan(&balance)
+	if err != nil {
+		return fmt.Errorf("failed to get credit balance: %w", err)
+	}
+
+	var paidPaymentIDs []int
+	for _, entry := range entries {
+		if entry.Amount > balance {
+			return fmt.Errorf("₹%d exceeds credit balance of ₹%d", entry.Amount, balance)
+		}
+		balance -= entry.Amount
+
+		if entry.EntryType == domain.CreditEntryApplied {
+			if err := allocateToPayment(dbTx, *entry.PaymentID, entry.Amount, entry.EntryDate); err != nil {
+				return err
+			}
+			paidPaymentIDs = append(paidPaymentIDs, *entry.PaymentID)
+		}
+		if err := createCreditEntry(dbTx, entry); err != nil {
+			return err
+		}
+	}
+
+	if err = completeSettledInstallmentPlans(dbTx, paidPaymentIDs, time.Now()); err != nil {
+		return err
+	}
+
+	if err = dbTx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit credit entries: %w", err)
+	}
+
+	return nil
+}
+
+// GetCreditEntriesByTenantID returns all credit ledger entries of a tenant, oldest first
+func (r *PostgresCreditRepository) GetCreditEntriesByTenantID(tenantID int) ([]*domain.CreditEntry, error) {
+	query := `
+		SELECT id, tenant_id, entry_type, amount, reason, transaction_id, payment_id, entry_date, created_at
+		FROM tenant_credit_entries
+		WHERE tenant_id = $1
+		ORDER BY entry_date, id`
+
+	rows, err := r.db.Query(query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query credit entries: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*domain.CreditEntry
+	for rows.Next() {
+		entry := &domain.CreditEntry{}
+		var transactionID sql.NullString
+		var paymentID sql.NullInt64
+		err := rows.Scan(
+			&entry.ID,
+			&entry.TenantID,
+			&entry.EntryType,
+			&entry.Amount,
+			&entry.Reason,
+			&transactionID,
+			&paymentID,
+			&entry.EntryDate,
+			&entry.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan credit entry: %w", err)
+		}
+		if transactionID.Valid {
+			entry.TransactionID = &transactionID.String
+		}
+		if paymentID.Valid {
+			id := int(paymentID.Int64)
+			entry.PaymentID = &id
+		}
+		entries = append(entries, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating credit entries: %w", err)
+	}
+
+	return entries, nil
+}
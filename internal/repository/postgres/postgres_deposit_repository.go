@@ -0,0 +1,169 @@
+package repository
+
+import (
+	domain "backend-form/m/internal/domain"
+	"backend-form/m/internal/repository/interfaces"
+	"database/sql"
+	"fmt"
+)
+
+// PostgresDepositRepository implements DepositRepository interface
+type PostgresDepositRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresDepositRepository creates a new PostgresDepositRepository
+func NewPostgresDepositRepository(db *sql.DB) interfaces.DepositRepository {
+	return &PostgresDepositRepository{db: db}
+}
+
+// CreateDeposit creates the deposit ledger for a tenancy
+func (r *PostgresDepositRepository) CreateDeposit(deposit *domain.SecurityDeposit) error {
+	query := `
+		INSERT INTO security_deposits (tenant_id, unit_id, required_amount, status)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at`
+
+	err := r.db.QueryRow(query,
+		deposit.TenantID,
+		deposit.UnitID,
+		deposit.RequiredAmount,
+		deposit.Status,
+	).Scan(&deposit.ID, &deposit.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create security deposit: %w", err)
+	}
+
+	return nil
+}
+
+// GetDepositByTenantID returns the deposit ledger for a tenant, or nil if none exists
+func (r *PostgresDepositRepository) GetDepositByTenantID(tenantID int) (*domain.SecurityDeposit, error) {
+	query := `
+		SELECT id, tenant_id, unit_id, required_amount, status, settled_at, created_at
+		FROM security_deposits
+		WHERE tenant_id = $1`
+
+	deposit := &domain.SecurityDeposit{}
+	var settledAt sql.NullTime
+	err := r.db.QueryRow(query, tenantID).Scan(
+		&deposit.ID,
+		&deposit.TenantID,
+		&deposit.UnitID,
+		&deposit.RequiredAmount,
+		&deposit.Status,
+		&settledAt,
+		&deposit.CreatedAt,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // Tenant has no deposit ledger (e.g. created before ledgers existed)
+		}
+		return nil, fmt.Errorf("failed to get security deposit: %w", err)
+	}
+
+	if settledAt.Valid {
+		deposit.SettledAt = &settledAt.Time
+	}
+
+	return deposit, nil
+}
+
+// UpdateDepositStatus updates the status and settlement time of a deposit
+func (r *PostgresDepositRepository) UpdateDepositStatus(deposit *domain.SecurityDeposit) error {
+	query := `
+		UPDATE security_deposits 
+		SET status = $1, settled_at = $2
+		WHERE id = $3`
+
+	result, err := r.db.Exec(query,
+		deposit.Status,
+		deposit.SettledAt,
+		deposit.ID,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update security deposit: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("security deposit with ID %d not found", deposit.ID)
+	}
+
+	return nil
+}
+
+// CreateDepositEntry appends an entry to a deposit ledger
+func (r *PostgresDepositRepository) CreateDepositEntry(entry *domain.DepositEntry) error {
+	query := `
+		INSERT INTO deposit_entries (deposit_id, entry_type, amount, reason, payment_id, entry_date)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at`
+
+	err := r.db.QueryRow(query,
+		entry.DepositID,
+		entry.EntryType,
+		entry.Amount,
+		entry.Reason,
+		entry.PaymentID,
+		entry.EntryDate,
+	).Scan(&entry.ID, &entry.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create deposit entry: %w", err)
+	}
+
+	return nil
+}
+
+// GetDepositEntriesByDepositID returns all ledger entries for a deposit in chronological order
+func (r *PostgresDepositRepository) GetDepositEntriesByDepositID(depositID int) ([]*domain.DepositEntry, error) {
+	query := `
+		SELECT id, deposit_id, entry_type, amount, reason, payment_id, entry_date, created_at
+		FROM deposit_entries
+		WHERE deposit_id = $1
+		ORDER BY entry_date, id`
+
+	rows, err := r.db.Query(query, depositID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query deposit entries: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*domain.DepositEntry
+	for rows.Next() {
+		entry := &domain.DepositEntry{}
+		var paymentID sql.NullInt64
+		err := rows.Scan(
+			&entry.ID,
+			&entry.DepositID,
+			&entry.EntryType,
+			&entry.Amount,
+			&entry.Reason,
+			&paymentID,
+			&entry.EntryDate,
+			&entry.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan deposit entry: %w", err)
+		}
+		if paymentID.Valid {
+			id := int(paymentID.Int64)
+			entry.PaymentID = &id
+		}
+		entries = append(entries, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating deposit entries: %w", err)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,457 @@
+package repository
+
+import (
+	domain "backend-form/m/internal/domain"
+	"backend-form/m/internal/repository/interfaces"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// PostgresExpenseRepository implements ExpenseRepository interface
+type PostgresExpenseRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresExpenseRepository creates a new PostgresExpenseRepository
+func NewPostgresExpenseRepository(db *sql.DB) interfaces.ExpenseRepository {
+	return &PostgresExpenseRepository{db: db}
+}
+
+// ============================================
+// Categories
+// ============================================
+
+// CreateCategory creates a new expense category
+func (r *PostgresExpenseRepository) CreateCategory(category *domain.ExpenseCategory) error {
+	query := `
+		INSERT INTO expense_categories (code, display_name, is_active)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at`
+
+	err := r.db.QueryRow(query, category.Code, category.DisplayName, category.IsActive).Scan(&category.ID, &category.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create expense category: %w", err)
+	}
+
+	return nil
+}
+
+// GetCategoryByCode returns an expense category by its code
+func (r *PostgresExpenseRepository) GetCategoryByCode(code string) (*domain.ExpenseCategory, error) {
+	query := `SELECT id, code, display_name, is_active, created_at FROM expense_categories WHERE code = $1`
+
+	category := &domain.ExpenseCategory{}
+	err := r.db.QueryRow(query, code).Scan(
+		&category.ID,
+		&category.Code,
+		&category.DisplayName,
+		&category.IsActive,
+		&category.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("expense category '%s' not found", code)
+		}
+		return nil, fmt.Errorf("failed to get expense category: %w", err)
+	}
+
+	return category, nil
+}
+
+// GetAllCategories returns all expense categories, including inactive ones
+func (r *PostgresExpenseRepository) GetAllCategories() ([]*domain.ExpenseCategory, error) {
+	query := `SELECT id, code, display_name, is_active, created_at FROM expense_categories ORDER BY display_name`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query expense categories: %w", err)
+	}
+	defer rows.Close()
+
+	var categories []*domain.ExpenseCategory
+	for rows.Next() {
+		category := &domain.ExpenseCategory{}
+		err := rows.Scan(
+			&category.ID,
+			&category.Code,
+			&category.DisplayName,
+			&category.IsActive,
+			&category.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan expense category: %w", err)
+		}
+		categories = append(categories, category)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating expense categories: %w", err)
+	}
+
+	return categories, nil
+}
+
+// UpdateCategory updates the display name and active flag of an expense category
+func (r *PostgresExpenseRepository) UpdateCategory(category *domain.ExpenseCategory) error {
+	query := `UPDATE expense_categories SET display_name = $1, is_active = $2 WHERE code = $3`
+
+	result, err := r.db.Exec(query, category.DisplayName, category.IsActive, category.Code)
+	if err != nil {
+		return fmt.Errorf("failed to update expense category: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("expense category '%s' not found", category.Code)
+	}
+
+	return nil
+}
+
+// ============================================
+// Expenses
+// ============================================
+
+const expenseColumns = `id, category, property_id, unit_id, amount, expense_date, vendor, description, payment_method, reference, created_by_user_id, created_at, updated_at`
+
+// scanExpense scans an expense row
+func scanExpense(row rowScanner) (*domain.Expense, error) {
+	expense := &domain.Expense{}
+	var propertyID, unitID, createdByUserID sql.NullInt64
+	err := row.Scan(
+		&expense.ID,
+		&expense.Category,
+		&propertyID,
+		&unitID,
+		&expense.Amount,
+		&expense.ExpenseDate,
+		&expense.Vendor,
+		&expense.Description,
+		&expense.PaymentMethod,
+		&expense.Reference,
+		&createdByUserID,
+		&expense.CreatedAt,
+		&expense.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if propertyID.Valid {
+		id := int(propertyID.Int64)
+		expense.PropertyID = &id
+	}
+	if unitID.Valid {
+		id := int(unitID.Int64)
+		expense.UnitID = &id
+	}
+	if createdByUserID.Valid {
+		id := int(createdByUserID.Int64)
+		expense.CreatedByUserID = &id
+	}
+	return expense, nil
+}
+
+// CreateExpense records a new expense
+func (r *PostgresExpenseRepository) CreateExpense(expense *domain.Expense) error {
+	query := `
+		INSERT INTO expenses (category, property_id, unit_id, amount, expense_date, vendor, description, payment_method, reference, created_by_user_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id, created_at, updated_at`
+
+	err := r.db.QueryRow(query,
+		expense.Category,
+		expense.PropertyID,
+		expense.UnitID,
+		expense.Amount,
+		expense.ExpenseDate,
+		expense.Vendor,
+		expense.Description,
+		expense.PaymentMethod,
+		expense.Reference,
+		expense.CreatedByUserID,
+	).Scan(&expense.ID, &expense.CreatedAt, &expense.UpdatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create expense: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateExpense updates an existing expense
+func (r *PostgresExpenseRepository) UpdateExpense(expense *domain.Expense) error {
+	query := `
+		UPDATE expenses
+		SET category = $1, property_id = $2, unit_id = $3, amount = $4, expense_date = $5,
+		    vendor = $6, description = $7, payment_method = $8, reference = $9, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $10
+		RETURNING updated_at`
+
+	err := r.db.QueryRow(query,
+		expense.Category,
+		expense.PropertyID,
+		expense.UnitID,
+		expense.Amount,
+		expense.ExpenseDate,
+		expense.Vendor,
+		expense.Description,
+		expense.PaymentMethod,
+		expense.Reference,
+		expense.ID,
+	).Scan(&expense.UpdatedAt)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("expense with ID %d not found", expense.ID)
+		}
+		return fmt.Errorf("failed to update expense: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteExpense deletes an expense and its attachments
+func (r *PostgresExpenseRepository) DeleteExpense(id int) error {
+	result, err := r.db.Exec(`DELETE FROM expenses WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete expense: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("expense with ID %d not found", id)
+	}
+
+	return nil
+}
+
+// GetExpenseByID returns an expense by ID
+func (r *PostgresExpenseRepository) GetExpenseByID(id int) (*domain.Expense, error) {
+	query := `SELECT ` + expenseColumns + ` FROM expenses WHERE id = $1`
+
+	expense, err := scanExpense(r.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("expense with ID %d not found", id)
+		}
+		return nil, fmt.Errorf("failed to get expense: %w", err)
+	}
+
+	return expense, nil
+}
+
+// GetExpenses returns the expenses matching a filter, most recent first
+func (r *PostgresExpenseRepository) GetExpenses(filter interfaces.ExpenseFilter) ([]*domain.Expense, error) {
+	var conditions []string
+	var args []interface{}
+	addCondition := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.PropertyID > 0 {
+		addCondition("property_id = $%d", filter.PropertyID)
+	}
+	if filter.UnitID > 0 {
+		addCondition("unit_id = $%d", filter.UnitID)
+	}
+	if filter.Category != "" {
+		addCondition("category = $%d", filter.Category)
+	}
+	if !filter.From.IsZero() {
+		addCondition("expense_date >= $%d", filter.From)
+	}
+	if !filter.To.IsZero() {
+		addCondition("expense_date <= $%d", filter.To)
+	}
+
+	query := `SELECT ` + expenseColumns + ` FROM expenses`
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+	query += ` ORDER BY expense_date DESC, id DESC`
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query expenses: %w", err)
+	}
+	defer rows.Close()
+
+	var expenses []*domain.Expense
+	for rows.Next() {
+		expense, err := scanExpense(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan expense: %w", err)
+		}
+		expenses = append(expenses, expense)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating expenses: %w", err)
+	}
+
+	return expenses, nil
+}
+
+// ============================================
+// Attachments
+// ============================================
+
+// CreateAttachment stores a bill or receipt file for an expense
+func (r *PostgresExpenseRepository) CreateAttachment(attachment *domain.ExpenseAttachment) error {
+	query := `
+		INSERT INTO expense_attachments (expense_id, file_name, content_type, size_bytes, data)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, uploaded_at`
+
+	err := r.db.QueryRow(query,
+		attachment.ExpenseID,
+		attachment.FileName,
+		attachment.ContentType,
+		attachment.SizeBytes,
+		attachment.Data,
+	).Scan(&attachment.ID, &attachment.UploadedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create expense attachment: %w", err)
+	}
+
+	return nil
+}
+
+// GetAttachmentByID returns an attachment including its file data
+func (r *PostgresExpenseRepository) GetAttachmentByID(id int) (*domain.ExpenseAttachment, error) {
+	query := `
+		SELECT id, expense_id, file_name, content_type, size_bytes, data, uploaded_at
+		FROM expense_attachments
+		WHERE id = $1`
+
+	attachment := &domain.ExpenseAttachment{}
+	err := r.db.QueryRow(query, id).Scan(
+		&attachment.ID,
+		&attachment.ExpenseID,
+		&attachment.FileName,
+		&attachment.ContentType,
+		&attachment.SizeBytes,
+		&attachment.Data,
+		&attachment.UploadedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("expense attachment with ID %d not found", id)
+		}
+		return nil, fmt.Errorf("failed to get expense attachment: %w", err)
+	}
+
+	return attachment, nil
+}
+
+// GetAttachmentsByExpenseID returns the attachments of an expense without their file data
+func (r *PostgresExpenseRepository) GetAttachmentsByExpenseID(expenseID int) ([]*domain.ExpenseAttachment, error) {
+	query := `
+		SELECT id, expense_id, file_name, content_type, size_bytes, uploaded_at
+		FROM expense_attachments
+		WHERE expense_id = $1
+		ORDER BY uploaded_at, id`
+
+	rows, err := r.db.Query(query, expenseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query expense attachments: %w", err)
+	}
+	defer rows.Close()
+
+	var attachments []*domain.ExpenseAttachment
+	for rows.Next() {
+		attachment := &domain.ExpenseAttachment{}
+		err := rows.Scan(
+			&attachment.ID,
+			&attachment.ExpenseID,
+			&attachment.FileName,
+			&attachment.ContentType,
+			&attachment.SizeBytes,
+			&attachment.UploadedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan expense attachment: %w", err)
+		}
+		attachments = append(attachments, attachment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating expense attachments: %w", err)
+	}
+
+	return attachments, nil
+}
+
+// DeleteAttachment deletes an expense attachment
+func (r *PostgresExpenseRepository) DeleteAttachment(id int) error {
+	result, err := r.db.Exec(`DELETE FROM expense_attachments WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete expense attachment: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("expense attachment with ID %d not found", id)
+	}
+
+	return nil
+}
+
+// ============================================
+// Income
+// ============================================
+
+// GetIncome returns verified payment transactions received between two dates (both inclusive)
+// Each transaction is attributed to the unit and property of the payment it was submitted for.
+func (r *PostgresExpenseRepository) GetIncome(from, to time.Time, propertyID int) ([]*domain.IncomeEntry, error) {
+	query := `
+		SELECT pt.transaction_id, u.property_id, p.unit_id, pt.amount, pt.verified_at
+		FROM payment_transactions pt
+		JOIN payments p ON p.id = pt.payment_id
+		JOIN units u ON u.id = p.unit_id
+		WHERE pt.verified_at IS NOT NULL AND pt.amount IS NOT NULL
+		  AND pt.verified_at >= $1 AND pt.verified_at < $2
+		  AND ($3 = 0 OR u.property_id = $3)
+		ORDER BY pt.verified_at`
+
+	rows, err := r.db.Query(query, from, to.AddDate(0, 0, 1), propertyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query income: %w", err)
+	}
+	defer rows.Close()
+
+	var income []*domain.IncomeEntry
+	for rows.Next() {
+		entry := &domain.IncomeEntry{}
+		err := rows.Scan(
+			&entry.TransactionID,
+			&entry.PropertyID,
+			&entry.UnitID,
+			&entry.Amount,
+			&entry.ReceivedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan income: %w", err)
+		}
+		income = append(income, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating income: %w", err)
+	}
+
+	return income, nil
+}
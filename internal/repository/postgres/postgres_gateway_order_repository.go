@@ -0,0 +1,170 @@
+package repository
+
+import (
+	domain "backend-form/m/internal/domain"
+	"backend-form/m/internal/repository/interfaces"
+	"database/sql"
+	"fmt"
+)
+
+// PostgresGatewayOrderRepository implements GatewayOrderRepository interface
+type PostgresGatewayOrderRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresGatewayOrderRepository creates a new PostgresGatewayOrderRepository
+func NewPostgresGatewayOrderRepository(db *sql.DB) interfaces.GatewayOrderRepository {
+	return &PostgresGatewayOrderRepository{db: db}
+}
+
+const gatewayOrderColumns = `id, payment_id, tenant_id, gateway, gateway_order_id, amount, currency, status,
+	gateway_payment_id, amount_paid, failure_reason, paid_at, created_at, updated_at`
+
+// scanGatewayOrder scans a gateway order row selected with gatewayOrderColumns
+func scanGatewayOrder(row rowScanner) (*domain.GatewayOrder, error) {
+	order := &domain.GatewayOrder{}
+	var gatewayPaymentID sql.NullString
+	var paidAt sql.NullTime
+	err := row.Scan(
+		&order.ID,
+		&order.PaymentID,
+		&order.TenantID,
+		&order.Gateway,
+		&order.GatewayOrderID,
+		&order.Amount,
+		&order.Currency,
+		&order.Status,
+		&gatewayPaymentID,
+		&order.AmountPaid,
+		&order.FailureReason,
+		&paidAt,
+		&order.CreatedAt,
+		&order.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if gatewayPaymentID.Valid {
+		order.GatewayPaymentID = &gatewayPaymentID.String
+	}
+	if paidAt.Valid {
+		order.PaidAt = &paidAt.Time
+	}
+	return order, nil
+}
+
+// CreateOrder records an order created on a gateway
+func (r *PostgresGatewayOrderRepository) CreateOrder(order *domain.GatewayOrder) error {
+	query := `
+		INSERT INTO gateway_orders (payment_id, tenant_id, gateway, gateway_order_id, amount, currency, status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at, updated_at`
+
+	err := r.db.QueryRow(query,
+		order.PaymentID,
+		order.TenantID,
+		order.Gateway,
+		order.GatewayOrderID,
+		order.Amount,
+		order.Currency,
+		order.Status,
+	).Scan(&order.ID, &order.CreatedAt, &order.UpdatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create gateway order: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateOrder updates the status and payment details of a gateway order
+func (r *PostgresGatewayOrderRepository) UpdateOrder(order *domain.GatewayOrder) error {
+	query := `
+		UPDATE gateway_orders
+		SET status = $1, gateway_payment_id = $2, amount_paid = $3, failure_reason = $4, paid_at = $5, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $6
+		RETURNING updated_at`
+
+	err := r.db.QueryRow(query,
+		order.Status,
+		order.GatewayPaymentID,
+		order.AmountPaid,
+		order.FailureReason,
+		order.PaidAt,
+		order.ID,
+	).Scan(&order.UpdatedAt)
+
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("gateway order not found")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update gateway order: %w", err)
+	}
+
+	return nil
+}
+
+// GetOrderByGatewayOrderID returns the order with the given gateway order ID (nil if not found)
+func (r *PostgresGatewayOrderRepository) GetOrderByGatewayOrderID(gateway, gatewayOrderID string) (*domain.GatewayOrder, error) {
+	query := `SELECT ` + gatewayOrderColumns + `
+		FROM gateway_orders
+		WHERE gateway = $1 AND gateway_order_id = $2`
+
+	order, err := scanGatewayOrder(r.db.QueryRow(query, gateway, gatewayOrderID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gateway order: %w", err)
+	}
+
+	return order, nil
+}
+
+// GetOpenOrderForPayment returns the latest unpaid order for a payment and amount (nil if none)
+func (r *PostgresGatewayOrderRepository) GetOpenOrderForPayment(paymentID int, amount int) (*domain.GatewayOrder, error) {
+	query := `SELECT ` + gatewayOrderColumns + `
+		FROM gateway_orders
+		WHERE payment_id = $1 AND amount = $2 AND status = $3
+		ORDER BY created_at DESC
+		LIMIT 1`
+
+	order, err := scanGatewayOrder(r.db.QueryRow(query, paymentID, amount, domain.GatewayOrderStatusCreated))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get open gateway order: %w", err)
+	}
+
+	return order, nil
+}
+
+// GetOrdersByTenantID returns all gateway orders of a tenant, newest first
+func (r *PostgresGatewayOrderRepository) GetOrdersByTenantID(tenantID int) ([]*domain.GatewayOrder, error) {
+	query := `SELECT ` + gatewayOrderColumns + `
+		FROM gateway_orders
+		WHERE tenant_id = $1
+		ORDER BY created_at DESC`
+
+	rows, err := r.db.Query(query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query gateway orders: %w", err)
+	}
+	defer rows.Close()
+
+	var orders []*domain.GatewayOrder
+	for rows.Next() {
+		order, err := scanGatewayOrder(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan gateway order: %w", err)
+		}
+		orders = append(orders, order)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating gateway orders: %w", err)
+	}
+
+	return orders, nil
+}
@@ -0,0 +1,425 @@
+package repository
+
+import (
+	domain "backend-form/m/internal/domain"
+	"backend-form/m/internal/repository/interfaces"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// PostgresInstallmentPlanRepository implements InstallmentPlanRepository interface
+type PostgresInstallmentPlanRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresInstallmentPlanRepository creates a new PostgresInstallmentPlanRepository
+func NewPostgresInstallmentPlanRepository(db *sql.DB) interfaces.InstallmentPlanRepository {
+	return &PostgresInstallmentPlanRepository{db: db}
+}
+
+const installmentPlanColumns = `id, tenant_id, unit_id, total_amount, status, notes, cancel_reason, created_by_user_id, completed_at, cancelled_at, created_at, updated_at`
+
+// scanInstallmentPlan scans an installment plan row
+func scanInstallmentPlan(row rowScanner) (*domain.InstallmentPlan, error) {
+	plan := &domain.InstallmentPlan{}
+	var createdBy sql.NullInt64
+	var completedAt, cancelledAt sql.NullTime
+	err := row.Scan(
+		&plan.ID,
+		&plan.TenantID,
+		&plan.UnitID,
+		&plan.TotalAmount,
+		&plan.Status,
+		&plan.Notes,
+		&plan.CancelReason,
+		&createdBy,
+		&completedAt,
+		&cancelledAt,
+		&plan.CreatedAt,
+		&plan.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if createdBy.Valid {
+		id := int(createdBy.Int64)
+		plan.CreatedByUserID = &id
+	}
+	if completedAt.Valid {
+		plan.CompletedAt = &completedAt.Time
+	}
+	if cancelledAt.Valid {
+		plan.CancelledAt = &cancelledAt.Time
+	}
+	return plan, nil
+}
+
+// CreatePlan creates a plan, moves its arrears off the original payments and creates its installment payments
+// Everything runs in a single transaction; an original payment that changed since it was loaded fails the plan
+func (r *PostgresInstallmentPlanRepository) CreatePlan(plan *domain.InstallmentPlan) error {
+	dbTx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback()
+
+	err = dbTx.QueryRow(`
+		INSERT INTO installment_plans (tenant_id, unit_id, total_amount, status, notes, created_by_user_id)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at, updated_at`,
+		plan.TenantID,
+		plan.UnitID,
+		plan.TotalAmount,
+		plan.Status,
+		plan.Notes,
+		plan.CreatedByUserID,
+	).Scan(&plan.ID, &plan.CreatedAt, &plan.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create installment plan: %w", err)
+	}
+
+	// Settle the originals: the balance moved into the plan is recorded as adjusted, not paid
+	for _, arrear := range plan.Arrears {
+		arrear.PlanID = plan.ID
+		arrear.Payment.Notes = domain.MovedToPlanNote(arrear.Payment.Notes, arrear.Amount, plan.ID)
+		result, err := dbTx.Exec(`
+			UPDATE payments
+			SET amount_adjusted = amount_adjusted + $1,
+			    remaining_balance = remaining_balance - $1,
+			    is_fully_paid = TRUE,
+			    fully_paid_date = COALESCE(fully_paid_date, $2),
+			    notes = $3
+			WHERE id = $4 AND is_fully_paid = FALSE AND remaining_balance = $1
+			  AND NOT EXISTS (
+			      SELECT 1 FROM payment_transactions
+			      WHERE payment_id = $4 AND verified_at IS NULL AND rejected_at IS NULL)`,
+			arrear.Amount, plan.CreatedAt, arrear.Payment.Notes, arrear.PaymentID,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to settle payment %d: %w", arrear.PaymentID, err)
+		}
+		if err := expectOneRow(result, fmt.Sprintf("payment %d changed while the plan was being created; try again", arrear.PaymentID)); err != nil {
+			return err
+		}
+
+		err = dbTx.QueryRow(`
+			INSERT INTO installment_plan_arrears (plan_id, payment_id, amount)
+			VALUES ($1, $2, $3)
+			RETURNING id, created_at`,
+			arrear.PlanID, arrear.PaymentID, arrear.Amount,
+		).Scan(&arrear.ID, &arrear.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to create installment plan arrear: %w", err)
+		}
+	}
+
+	for _, installment := range plan.Installments {
+		payment := installment.Payment
+		payment.Notes = domain.InstallmentNote(installment.Sequence, len(plan.Installments), plan.ID)
+		err = dbTx.QueryRow(`
+			INSERT INTO payments (tenant_id, unit_id, amount, amount_paid, remaining_balance, due_date,
+			                      is_paid, is_fully_paid, payment_method, upi_id, notes, label)
+			VALUES ($1, $2, $3, 0, $3, $4, FALSE, FALSE, $5, $6, $7, $8)
+			RETURNING id, created_at`,
+			payment.TenantID,
+			payment.UnitID,
+			payment.Amount,
+			payment.DueDate,
+			payment.PaymentMethod,
+			payment.UPIID,
+			payment.Notes,
+			payment.Label,
+		).Scan(&payment.ID, &payment.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to create installment payment: %w", err)
+		}
+
+		paymentID := payment.ID
+		installment.PlanID = plan.ID
+		installment.PaymentID = &paymentID
+		err = dbTx.QueryRow(`
+			INSERT INTO installments (plan_id, payment_id, sequence, amount, due_date)
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING id, created_at`,
+			installment.PlanID,
+			installment.PaymentID,
+			installment.Sequence,
+			installment.Amount,
+			installment.DueDate,
+		).Scan(&installment.ID, &installment.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to create installment: %w", err)
+		}
+	}
+
+	if err = dbTx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit installment plan: %w", err)
+	}
+
+	return nil
+}
+
+// GetPlanByID returns an installment plan by ID
+func (r *PostgresInstallmentPlanRepository) GetPlanByID(id int) (*domain.InstallmentPlan, error) {
+	query := `SELECT ` + installmentPlanColumns + ` FROM installment_plans WHERE id = $1`
+
+	plan, err := scanInstallmentPlan(r.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("installment plan with ID %d not found", id)
+		}
+		return nil, fmt.Errorf("failed to get installment plan: %w", err)
+	}
+
+	return plan, nil
+}
+
+// GetPlansByTenantID returns all installment plans of a tenant, most recent first
+func (r *PostgresInstallmentPlanRepository) GetPlansByTenantID(tenantID int) ([]*domain.InstallmentPlan, error) {
+	query := `SELECT ` + installmentPlanColumns + ` FROM installment_plans WHERE tenant_id = $1 ORDER BY created_at DESC`
+
+	rows, err := r.db.Query(query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query installment plans: %w", err)
+	}
+	defer rows.Close()
+
+	var plans []*domain.InstallmentPlan
+	for rows.Next() {
+		plan, err := scanInstallmentPlan(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan installment plan: %w", err)
+		}
+		plans = append(plans, plan)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating installment plans: %w", err)
+	}
+
+	return plans, nil
+}
+
+// CancelPlan marks an active plan cancelled, closes its unpaid installments and restores balance to the originals
+// Installments with nothing paid are deleted; the unpaid part of the others is recorded as adjusted.
+// Each arrear's RestoredAmount is put back on its payment. Everything runs in a single transaction and
+// fails if the plan or one of its payments changed since it was loaded
+func (r *PostgresInstallmentPlanRepository) CancelPlan(plan *domain.InstallmentPlan) error {
+	dbTx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback()
+
+	result, err := dbTx.Exec(`
+		UPDATE installment_plans
+		SET status = $1, cancel_reason = $2, cancelled_at = $3, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $4 AND status = 'active'`,
+		plan.Status, plan.CancelReason, plan.CancelledAt, plan.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to cancel installment plan: %w", err)
+	}
+	if err := expectOneRow(result, "installment plan is no longer active"); err != nil {
+		return err
+	}
+
+	for _, installment := range plan.Installments {
+		payment := installment.Payment
+		if payment == nil || payment.IsFullyPaid {
+			continue
+		}
+		changed := fmt.Sprintf("installment %d was paid while the plan was being cancelled; try again", installment.Sequence)
+
+		if payment.AmountPaid > 0 {
+			result, err = dbTx.Exec(`
+				UPDATE payments
+				SET amount_adjusted = amount_adjusted + remaining_balance,
+				    remaining_balance = 0,
+				    is_fully_paid = TRUE,
+				    fully_paid_date = COALESCE(fully_paid_date, $1),
+				    notes = $2
+				WHERE id = $3 AND amount_paid = $4 AND is_fully_paid = FALSE`,
+				plan.CancelledAt, payment.Notes, payment.ID, payment.AmountPaid,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to close installment %d: %w", installment.Sequence, err)
+			}
+			if err := expectOneRow(result, changed); err != nil {
+				return err
+			}
+			continue
+		}
+
+		result, err = dbTx.Exec(`DELETE FROM payments WHERE id = $1 AND amount_paid = 0 AND is_fully_paid = FALSE`, payment.ID)
+		if err != nil {
+			return fmt.Errorf("failed to delete installment %d: %w", installment.Sequence, err)
+		}
+		if err := expectOneRow(result, changed); err != nil {
+			return err
+		}
+		if _, err = dbTx.Exec(`UPDATE installments SET payment_id = NULL WHERE id = $1`, installment.ID); err != nil {
+			return fmt.Errorf("failed to update installment: %w", err)
+		}
+	}
+
+	for _, arrear := range plan.Arrears {
+		if arrear.RestoredAmount <= 0 {
+			continue
+		}
+		result, err = dbTx.Exec(`
+			UPDATE payments
+			SET amount_adjusted = amount_adjusted - $1,
+			    remaining_balance = remaining_balance + $1,
+			    is_fully_paid = (remaining_balance + $1 <= 0),
+			    fully_paid_date = CASE WHEN remaining_balance + $1 > 0 THEN NULL ELSE fully_paid_date END,
+			    notes = $2
+			WHERE id = $3 AND amount_adjusted >= $1`,
+			arrear.RestoredAmount, arrear.Payment.Notes, arrear.PaymentID,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to restore payment %d: %w", arrear.PaymentID, err)
+		}
+		if err := expectOneRow(result, fmt.Sprintf("payment %d changed while the plan was being cancelled; try again", arrear.PaymentID)); err != nil {
+			return err
+		}
+
+		if _, err = dbTx.Exec(`UPDATE installment_plan_arrears SET restored_amount = $1 WHERE id = $2`, arrear.RestoredAmount, arrear.ID); err != nil {
+			return fmt.Errorf("failed to update installment plan arrear: %w", err)
+		}
+	}
+
+	if err = dbTx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit installment plan cancellation: %w", err)
+	}
+
+	return nil
+}
+
+// GetArrearsByPlanID returns the payments restructured by a plan, oldest first
+func (r *PostgresInstallmentPlanRepository) GetArrearsByPlanID(planID int) ([]*domain.InstallmentPlanArrear, error) {
+	query := `
+		SELECT a.id, a.plan_id, a.payment_id, a.amount, a.restored_amount, a.created_at
+		FROM installment_plan_arrears a
+		JOIN payments p ON a.payment_id = p.id
+		WHERE a.plan_id = $1
+		ORDER BY p.due_date ASC, a.id ASC`
+
+	rows, err := r.db.Query(query, planID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query installment plan arrears: %w", err)
+	}
+	defer rows.Close()
+
+	var arrears []*domain.InstallmentPlanArrear
+	for rows.Next() {
+		arrear := &domain.InstallmentPlanArrear{}
+		err := rows.Scan(
+			&arrear.ID,
+			&arrear.PlanID,
+			&arrear.PaymentID,
+			&arrear.Amount,
+			&arrear.RestoredAmount,
+			&arrear.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan installment plan arrear: %w", err)
+		}
+		arrears = append(arrears, arrear)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating installment plan arrears: %w", err)
+	}
+
+	return arrears, nil
+}
+
+// GetInstallmentsByPlanID returns the installments of a plan in schedule order
+func (r *PostgresInstallmentPlanRepository) GetInstallmentsByPlanID(planID int) ([]*domain.Installment, error) {
+	query := `
+		SELECT id, plan_id, payment_id, sequence, amount, due_date, created_at
+		FROM installments
+		WHERE plan_id = $1
+		ORDER BY sequence ASC`
+
+	rows, err := r.db.Query(query, planID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query installments: %w", err)
+	}
+	defer rows.Close()
+
+	var installments []*domain.Installment
+	for rows.Next() {
+		installment := &domain.Installment{}
+		var paymentID sql.NullInt64
+		err := rows.Scan(
+			&installment.ID,
+			&installment.PlanID,
+			&paymentID,
+			&installment.Sequence,
+			&installment.Amount,
+			&installment.DueDate,
+			&installment.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan installment: %w", err)
+		}
+		if paymentID.Valid {
+			id := int(paymentID.Int64)
+			installment.PaymentID = &id
+		}
+		installments = append(installments, installment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating installments: %w", err)
+	}
+
+	return installments, nil
+}
+
+// execer is satisfied by both *sql.DB and *sql.Tx
+type execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// expectOneRow returns an error with the given message unless the statement changed exactly one row
+func expectOneRow(result sql.Result, message string) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected != 1 {
+		return fmt.Errorf("%s", message)
+	}
+	return nil
+}
+
+// completeSettledInstallmentPlans marks completed the active plans of the given payments whose installments are all paid
+// Called in the same transaction that pays the payments, so a plan completes when its last installment is paid
+func completeSettledInstallmentPlans(db execer, paymentIDs []int, completedAt time.Time) error {
+	if len(paymentIDs) == 0 {
+		return nil
+	}
+
+	_, err := db.Exec(`
+		UPDATE installment_plans ip
+		SET status = 'completed', completed_at = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE ip.status = 'active'
+		  AND ip.id IN (SELECT plan_id FROM installments WHERE payment_id = ANY($1))
+		  AND NOT EXISTS (
+		      SELECT 1 FROM installments i
+		      LEFT JOIN payments p ON p.id = i.payment_id
+		      WHERE i.plan_id = ip.id AND (p.id IS NULL OR p.is_fully_paid = FALSE))`,
+		pq.Array(paymentIDs), completedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to complete installment plans: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,460 @@
+package repository
+
+import (
+	domain "backend-form/m/internal/domain"
+	"backend-form/m/internal/repository/interfaces"
+	"database/sql"
+	"fmt"
+)
+
+// PostgresLateFeeRepository implements LateFeeRepository interface
+type PostgresLateFeeRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresLateFeeRepository creates a new PostgresLateFeeRepository
+func NewPostgresLateFeeRepository(db *sql.DB) interfaces.LateFeeRepository {
+	return &PostgresLateFeeRepository{db: db}
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanLateFeePolicy scans a late fee policy row
+func scanLateFeePolicy(row rowScanner) (*domain.LateFeePolicy, error) {
+	policy := &domain.LateFeePolicy{}
+	var propertyID, unitID sql.NullInt64
+	err := row.Scan(
+		&policy.ID,
+		&propertyID,
+		&unitID,
+		&policy.FeeType,
+		&policy.Amount,
+		&policy.Percentage,
+		&policy.GraceDays,
+		&policy.MaxFee,
+		&policy.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if propertyID.Valid {
+		id := int(propertyID.Int64)
+		policy.PropertyID = &id
+	}
+	if unitID.Valid {
+		id := int(unitID.Int64)
+		policy.UnitID = &id
+	}
+	return policy, nil
+}
+
+// CreatePolicy creates a new late fee policy
+func (r *PostgresLateFeeRepository) CreatePolicy(policy *domain.LateFeePolicy) error {
+	query := `
+		INSERT INTO late_fee_policies (property_id, unit_id, fee_type, amount, percentage, grace_days, max_fee)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at`
+
+	err := r.db.QueryRow(query,
+		policy.PropertyID,
+		policy.UnitID,
+		policy.FeeType,
+		policy.Amount,
+		policy.Percentage,
+		policy.GraceDays,
+		policy.MaxFee,
+	).Scan(&policy.ID, &policy.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create late fee policy: %w", err)
+	}
+
+	return nil
+}
+
+// GetPolicyByID returns a late fee policy by ID
+func (r *PostgresLateFeeRepository) GetPolicyByID(id int) (*domain.LateFeePolicy, error) {
+	query := `
+		SELECT id, property_id, unit_id, fee_type, amount, percentage, grace_days, max_fee, created_at
+		FROM late_fee_policies
+		WHERE id = $1`
+
+	policy, err := scanLateFeePolicy(r.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("late fee policy with ID %d not found", id)
+		}
+		return nil, fmt.Errorf("failed to get late fee policy: %w", err)
+	}
+
+	return policy, nil
+}
+
+// GetAllPolicies returns all late fee policies
+func (r *PostgresLateFeeRepository) GetAllPolicies() ([]*domain.LateFeePolicy, error) {
+	query := `
+		SELECT id, property_id, unit_id, fee_type, amount, percentage, grace_days, max_fee, created_at
+		FROM late_fee_policies
+		ORDER BY property_id NULLS LAST, unit_id`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query late fee policies: %w", err)
+	}
+	defer rows.Close()
+
+	var policies []*domain.LateFeePolicy
+	for rows.Next() {
+		policy, err := scanLateFeePolicy(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan late fee policy: %w", err)
+		}
+		policies = append(policies, policy)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating late fee policies: %w", err)
+	}
+
+	return policies, nil
+}
+
+// GetPolicyForUnit returns the policy in force for a unit
+// A unit-specific policy wins over the property-wide one; returns nil if neither exists
+func (r *PostgresLateFeeRepository) GetPolicyForUnit(unitID int) (*domain.LateFeePolicy, error) {
+	query := `
+		SELECT p.id, p.property_id, p.unit_id, p.fee_type, p.amount, p.percentage, p.grace_days, p.max_fee, p.created_at
+		FROM late_fee_policies p
+		JOIN units u ON p.unit_id = u.id OR (p.unit_id IS NULL AND p.property_id = u.property_id)
+		WHERE u.id = $1
+		ORDER BY p.unit_id IS NULL, p.id
+		LIMIT 1`
+
+	policy, err := scanLateFeePolicy(r.db.QueryRow(query, unitID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // No late fee policy for this unit
+		}
+		return nil, fmt.Errorf("failed to get late fee policy for unit: %w", err)
+	}
+
+	return policy, nil
+}
+
+// UpdatePolicy updates a late fee policy
+func (r *PostgresLateFeeRepository) UpdatePolicy(policy *domain.LateFeePolicy) error {
+	query := `
+		UPDATE late_fee_policies 
+		SET property_id = $1, unit_id = $2, fee_type = $3, amount = $4, percentage = $5, grace_days = $6, max_fee = $7
+		WHERE id = $8`
+
+	result, err := r.db.Exec(query,
+		policy.PropertyID,
+		policy.UnitID,
+		policy.FeeType,
+		policy.Amount,
+		policy.Percentage,
+		policy.GraceDays,
+		policy.MaxFee,
+		policy.ID,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update late fee policy: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("late fee policy with ID %d not found", policy.ID)
+	}
+
+	return nil
+}
+
+// DeletePolicy deletes a late fee policy (fees already charged are kept)
+func (r *PostgresLateFeeRepository) DeletePolicy(id int) error {
+	result, err := r.db.Exec(`DELETE FROM late_fee_policies WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete late fee policy: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("late fee policy with ID %d not found", id)
+	}
+
+	return nil
+}
+
+// scanLateFee scans a late fee row
+func scanLateFee(row rowScanner) (*domain.LateFee, error) {
+	fee := &domain.LateFee{}
+	var feePaymentID, policyID, actionedBy sql.NullInt64
+	var actionedAt sql.NullTime
+	err := row.Scan(
+		&fee.ID,
+		&fee.PaymentID,
+		&feePaymentID,
+		&policyID,
+		&fee.TenantID,
+		&fee.Amount,
+		&fee.Status,
+		&fee.Reason,
+		&actionedBy,
+		&actionedAt,
+		&fee.CreatedAt,
+		&fee.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if feePaymentID.Valid {
+		id := int(feePaymentID.Int64)
+		fee.FeePaymentID = &id
+	}
+	if policyID.Valid {
+		fee.PolicyID = int(policyID.Int64)
+	}
+	if actionedBy.Valid {
+		id := int(actionedBy.Int64)
+		fee.ActionedByUserID = &id
+	}
+	if actionedAt.Valid {
+		fee.ActionedAt = &actionedAt.Time
+	}
+	return fee, nil
+}
+
+const lateFeeColumns = `id, payment_id, fee_payment_id, policy_id, tenant_id, amount, status, reason, actioned_by_user_id, actioned_at, created_at, updated_at`
+
+// CreateLateFee records a late fee charged for an overdue payment together with its late_fee payment
+// Both are inserted in a single transaction, so a fee is never billed without being recorded
+func (r *PostgresLateFeeRepository) CreateLateFee(fee *domain.LateFee, feePayment *domain.Payment) error {
+	dbTx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback()
+
+	err = dbTx.QueryRow(`
+		INSERT INTO payments (tenant_id, unit_id, amount, amount_paid, remaining_balance, due_date,
+		                      is_paid, is_fully_paid, payment_method, upi_id, notes, label)
+		VALUES ($1, $2, $3, 0, $3, $4, FALSE, FALSE, $5, $6, $7, $8)
+		RETURNING id, created_at`,
+		feePayment.TenantID,
+		feePayment.UnitID,
+		feePayment.Amount,
+		feePayment.DueDate,
+		feePayment.PaymentMethod,
+		feePayment.UPIID,
+		feePayment.Notes,
+		feePayment.Label,
+	).Scan(&feePayment.ID, &feePayment.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create late fee payment: %w", err)
+	}
+	feePayment.RemainingBalance = feePayment.Amount
+
+	fee.FeePaymentID = &feePayment.ID
+	err = dbTx.QueryRow(`
+		INSERT INTO late_fees (payment_id, fee_payment_id, policy_id, tenant_id, amount, status, reason)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at, updated_at`,
+		fee.PaymentID,
+		fee.FeePaymentID,
+		fee.PolicyID,
+		fee.TenantID,
+		fee.Amount,
+		fee.Status,
+		fee.Reason,
+	).Scan(&fee.ID, &fee.CreatedAt, &fee.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create late fee: %w", err)
+	}
+
+	if err = dbTx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit late fee: %w", err)
+	}
+
+	return nil
+}
+
+// GetLateFeeByID returns a late fee by ID
+func (r *PostgresLateFeeRepository) GetLateFeeByID(id int) (*domain.LateFee, error) {
+	query := `SELECT ` + lateFeeColumns + ` FROM late_fees WHERE id = $1`
+
+	fee, err := scanLateFee(r.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("late fee with ID %d not found", id)
+		}
+		return nil, fmt.Errorf("failed to get late fee: %w", err)
+	}
+
+	return fee, nil
+}
+
+// GetLateFeeByPaymentID returns the late fee charged for an overdue payment, or nil if none
+func (r *PostgresLateFeeRepository) GetLateFeeByPaymentID(paymentID int) (*domain.LateFee, error) {
+	query := `SELECT ` + lateFeeColumns + ` FROM late_fees WHERE payment_id = $1`
+
+	fee, err := scanLateFee(r.db.QueryRow(query, paymentID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // No late fee charged for this payment
+		}
+		return nil, fmt.Errorf("failed to get late fee: %w", err)
+	}
+
+	return fee, nil
+}
+
+// GetLateFeesByTenantID returns all late fees of a tenant, most recent first
+func (r *PostgresLateFeeRepository) GetLateFeesByTenantID(tenantID int) ([]*domain.LateFee, error) {
+	query := `SELECT ` + lateFeeColumns + ` FROM late_fees WHERE tenant_id = $1 ORDER BY created_at DESC`
+
+	rows, err := r.db.Query(query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query late fees: %w", err)
+	}
+	defer rows.Close()
+
+	var fees []*domain.LateFee
+	for rows.Next() {
+		fee, err := scanLateFee(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan late fee: %w", err)
+		}
+		fees = append(fees, fee)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating late fees: %w", err)
+	}
+
+	return fees, nil
+}
+
+// RaiseLateFee raises an applied late fee and its unpaid late_fee payment to a new amount in a single transaction
+func (r *PostgresLateFeeRepository) RaiseLateFee(fee *domain.LateFee, amount int) error {
+	dbTx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback()
+
+	result, err := dbTx.Exec(`
+		UPDATE late_fees
+		SET amount = $1, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $2 AND status = $3 AND amount = $4`,
+		amount, fee.ID, domain.LateFeeStatusApplied, fee.Amount,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update late fee: %w", err)
+	}
+	if err = expectOneRow(result, "late fee changed while it was being raised; try again"); err != nil {
+		return err
+	}
+
+	result, err = dbTx.Exec(`
+		UPDATE payments
+		SET amount = $1, remaining_balance = $1 - amount_paid - amount_adjusted
+		WHERE id = $2 AND is_fully_paid = FALSE`,
+		amount, fee.FeePaymentID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update late fee payment: %w", err)
+	}
+	if err = expectOneRow(result, "late fee payment changed while it was being raised; try again"); err != nil {
+		return err
+	}
+
+	if err = dbTx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit late fee: %w", err)
+	}
+
+	fee.Amount = amount
+	return nil
+}
+
+// CloseLateFee saves a waived or reversed late fee and its late_fee payment in a single transaction
+// If the fee no longer has a fee payment ID, feePayment is deleted; otherwise it is saved closed at what was paid.
+// feePayment is nil if the fee never had one. Fails if the fee or its payment changed since they were loaded.
+func (r *PostgresLateFeeRepository) CloseLateFee(fee *domain.LateFee, feePayment *domain.Payment) error {
+	dbTx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback()
+
+	if feePayment != nil {
+		var result sql.Result
+		if fee.FeePaymentID == nil {
+			result, err = dbTx.Exec(`
+				DELETE FROM payments
+				WHERE id = $1 AND amount_paid = 0
+				  AND NOT EXISTS (
+				      SELECT 1 FROM payment_transactions
+				      WHERE payment_id = $1 AND verified_at IS NULL AND rejected_at IS NULL)`,
+				feePayment.ID,
+			)
+		} else {
+			result, err = dbTx.Exec(`
+				UPDATE payments
+				SET amount = $1, remaining_balance = $2, is_fully_paid = $3, fully_paid_date = $4, notes = $5
+				WHERE id = $6 AND amount_paid = $7 AND amount_adjusted = $8`,
+				feePayment.Amount,
+				feePayment.RemainingBalance,
+				feePayment.IsFullyPaid,
+				feePayment.FullyPaidDate,
+				feePayment.Notes,
+				feePayment.ID,
+				feePayment.AmountPaid,
+				feePayment.AmountAdjusted,
+			)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to update late fee payment: %w", err)
+		}
+		if err = expectOneRow(result, "late fee payment changed while the fee was being closed; try again"); err != nil {
+			return err
+		}
+	}
+
+	result, err := dbTx.Exec(`
+		UPDATE late_fees 
+		SET fee_payment_id = $1, status = $2, reason = $3,
+		    actioned_by_user_id = $4, actioned_at = $5, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $6 AND status = $7`,
+		fee.FeePaymentID,
+		fee.Status,
+		fee.Reason,
+		fee.ActionedByUserID,
+		fee.ActionedAt,
+		fee.ID,
+		domain.LateFeeStatusApplied,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update late fee: %w", err)
+	}
+	if err = expectOneRow(result, "late fee has already been closed"); err != nil {
+		return err
+	}
+
+	if err = dbTx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit late fee: %w", err)
+	}
+
+	return nil
+}
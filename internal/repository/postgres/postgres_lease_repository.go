@@ -0,0 +1,190 @@
+package repository
+
+import (
+	domain "backend-form/m/internal/domain"
+	"backend-form/m/internal/repository/interfaces"
+	"database/sql"
+	"fmt"
+)
+
+// PostgresLeaseRepository implements LeaseRepository interface
+type PostgresLeaseRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresLeaseRepository creates a new PostgresLeaseRepository
+func NewPostgresLeaseRepository(db *sql.DB) interfaces.LeaseRepository {
+	return &PostgresLeaseRepository{db: db}
+}
+
+const leaseColumns = `l.id, l.tenant_id, l.unit_id, l.start_date, l.end_date, l.monthly_rent, l.escalation_percent,
+	l.escalation_interval_months, l.notice_period_days, l.lock_in_months, l.status, l.previous_lease_id, l.notes,
+	l.created_at, l.updated_at`
+
+// scanLease scans a lease row
+func scanLease(row rowScanner) (*domain.Lease, error) {
+	lease := &domain.Lease{}
+	var previousLeaseID sql.NullInt64
+	err := row.Scan(
+		&lease.ID,
+		&lease.TenantID,
+		&lease.UnitID,
+		&lease.StartDate,
+		&lease.EndDate,
+		&lease.MonthlyRent,
+		&lease.EscalationPercent,
+		&lease.EscalationIntervalMonths,
+		&lease.NoticePeriodDays,
+		&lease.LockInMonths,
+		&lease.Status,
+		&previousLeaseID,
+		&lease.Notes,
+		&lease.CreatedAt,
+		&lease.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if previousLeaseID.Valid {
+		id := int(previousLeaseID.Int64)
+		lease.PreviousLeaseID = &id
+	}
+	return lease, nil
+}
+
+// queryLeases runs a query returning lease rows
+func (r *PostgresLeaseRepository) queryLeases(query string, args ...interface{}) ([]*domain.Lease, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query leases: %w", err)
+	}
+	defer rows.Close()
+
+	var leases []*domain.Lease
+	for rows.Next() {
+		lease, err := scanLease(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan lease: %w", err)
+		}
+		leases = append(leases, lease)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating leases: %w", err)
+	}
+
+	return leases, nil
+}
+
+// CreateLease creates a new lease
+func (r *PostgresLeaseRepository) CreateLease(lease *domain.Lease) error {
+	query := `
+		INSERT INTO leases (tenant_id, unit_id, start_date, end_date, monthly_rent, escalation_percent,
+		                    escalation_interval_months, notice_period_days, lock_in_months, status, previous_lease_id, notes)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		RETURNING id, created_at, updated_at`
+
+	err := r.db.QueryRow(query,
+		lease.TenantID,
+		lease.UnitID,
+		lease.StartDate,
+		lease.EndDate,
+		lease.MonthlyRent,
+		lease.EscalationPercent,
+		lease.EscalationIntervalMonths,
+		lease.NoticePeriodDays,
+		lease.LockInMonths,
+		lease.Status,
+		lease.PreviousLeaseID,
+		lease.Notes,
+	).Scan(&lease.ID, &lease.CreatedAt, &lease.UpdatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create lease: %w", err)
+	}
+
+	return nil
+}
+
+// GetLeaseByID returns a lease by ID
+func (r *PostgresLeaseRepository) GetLeaseByID(id int) (*domain.Lease, error) {
+	query := `SELECT ` + leaseColumns + ` FROM leases l WHERE l.id = $1`
+
+	lease, err := scanLease(r.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("lease with ID %d not found", id)
+		}
+		return nil, fmt.Errorf("failed to get lease: %w", err)
+	}
+
+	return lease, nil
+}
+
+// GetActiveLeaseByTenantID returns the lease currently in force for a tenant, or nil if none
+func (r *PostgresLeaseRepository) GetActiveLeaseByTenantID(tenantID int) (*domain.Lease, error) {
+	query := `SELECT ` + leaseColumns + ` FROM leases l WHERE l.tenant_id = $1 AND l.status = 'active'`
+
+	lease, err := scanLease(r.db.QueryRow(query, tenantID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // Tenant has no active lease
+		}
+		return nil, fmt.Errorf("failed to get active lease: %w", err)
+	}
+
+	return lease, nil
+}
+
+// GetLeasesByTenantID returns all leases of a tenant, most recent first
+func (r *PostgresLeaseRepository) GetLeasesByTenantID(tenantID int) ([]*domain.Lease, error) {
+	query := `SELECT ` + leaseColumns + ` FROM leases l WHERE l.tenant_id = $1 ORDER BY l.start_date DESC, l.id DESC`
+
+	return r.queryLeases(query, tenantID)
+}
+
+// GetActiveLeases returns the active leases of tenants who have not moved out
+func (r *PostgresLeaseRepository) GetActiveLeases() ([]*domain.Lease, error) {
+	query := `SELECT ` + leaseColumns + ` FROM leases l
+		JOIN tenants t ON l.tenant_id = t.id
+		WHERE l.status = 'active' AND t.status = 'active'
+		ORDER BY l.end_date`
+
+	return r.queryLeases(query)
+}
+
+// UpdateLease updates a lease
+func (r *PostgresLeaseRepository) UpdateLease(lease *domain.Lease) error {
+	query := `
+		UPDATE leases 
+		SET end_date = $1, monthly_rent = $2, escalation_percent = $3, escalation_interval_months = $4,
+		    notice_period_days = $5, lock_in_months = $6, status = $7, notes = $8, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $9`
+
+	result, err := r.db.Exec(query,
+		lease.EndDate,
+		lease.MonthlyRent,
+		lease.EscalationPercent,
+		lease.EscalationIntervalMonths,
+		lease.NoticePeriodDays,
+		lease.LockInMonths,
+		lease.Status,
+		lease.Notes,
+		lease.ID,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update lease: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("lease with ID %d not found", lease.ID)
+	}
+
+	return nil
+}
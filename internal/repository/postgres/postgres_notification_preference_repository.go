@@ -0,0 +1,136 @@
+package repository
+
+import (
+	domain "backend-form/m/internal/domain"
+	"backend-form/m/internal/repository/interfaces"
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+// PostgresNotificationPreferenceRepository implements NotificationPreferenceRepository interface
+type PostgresNotificationPreferenceRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresNotificationPreferenceRepository creates a new PostgresNotificationPreferenceRepository
+func NewPostgresNotificationPreferenceRepository(db *sql.DB) interfaces.NotificationPreferenceRepository {
+	return &PostgresNotificationPreferenceRepository{db: db}
+}
+
+const notificationPreferenceColumns = `recipient, tenant_id, channels, muted_types, quiet_hours_start, quiet_hours_end, language, reminder_days, updated_at`
+
+// scanNotificationPreferences scans a notification preferences row
+func scanNotificationPreferences(row rowScanner) (*domain.NotificationPreferences, error) {
+	prefs := &domain.NotificationPreferences{}
+	var tenantID, quietStart, quietEnd sql.NullInt64
+	var channels, mutedTypes pq.StringArray
+	var reminderDays pq.Int64Array
+	err := row.Scan(
+		&prefs.Recipient,
+		&tenantID,
+		&channels,
+		&mutedTypes,
+		&quietStart,
+		&quietEnd,
+		&prefs.Language,
+		&reminderDays,
+		&prefs.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if tenantID.Valid {
+		id := int(tenantID.Int64)
+		prefs.TenantID = &id
+	}
+	if quietStart.Valid && quietEnd.Valid {
+		start, end := int(quietStart.Int64), int(quietEnd.Int64)
+		prefs.QuietHoursStart = &start
+		prefs.QuietHoursEnd = &end
+	}
+	prefs.Channels = []string(channels)
+	for _, t := range mutedTypes {
+		prefs.MutedTypes = append(prefs.MutedTypes, domain.NotificationType(t))
+	}
+	for _, days := range reminderDays {
+		prefs.ReminderDays = append(prefs.ReminderDays, int(days))
+	}
+	return prefs, nil
+}
+
+// GetPreferences returns the saved preferences of the owner (tenantID nil) or a tenant, or nil if there are none
+func (r *PostgresNotificationPreferenceRepository) GetPreferences(recipient domain.NotificationRecipient, tenantID *int) (*domain.NotificationPreferences, error) {
+	query := `SELECT ` + notificationPreferenceColumns + ` FROM notification_preferences
+		WHERE recipient = $1 AND COALESCE(tenant_id, 0) = $2`
+
+	id := 0
+	if tenantID != nil {
+		id = *tenantID
+	}
+
+	prefs, err := scanNotificationPreferences(r.db.QueryRow(query, recipient, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // Defaults apply
+		}
+		return nil, fmt.Errorf("failed to get notification preferences: %w", err)
+	}
+
+	return prefs, nil
+}
+
+// SavePreferences creates or replaces the preferences of the owner or a tenant
+func (r *PostgresNotificationPreferenceRepository) SavePreferences(prefs *domain.NotificationPreferences) error {
+	query := `
+		INSERT INTO notification_preferences (recipient, tenant_id, channels, muted_types, quiet_hours_start, quiet_hours_end, language, reminder_days, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (recipient, COALESCE(tenant_id, 0)) DO UPDATE SET
+			channels = EXCLUDED.channels,
+			muted_types = EXCLUDED.muted_types,
+			quiet_hours_start = EXCLUDED.quiet_hours_start,
+			quiet_hours_end = EXCLUDED.quiet_hours_end,
+			language = EXCLUDED.language,
+			reminder_days = EXCLUDED.reminder_days,
+			updated_at = EXCLUDED.updated_at`
+
+	var tenantID, quietStart, quietEnd sql.NullInt64
+	if prefs.TenantID != nil {
+		tenantID = sql.NullInt64{Int64: int64(*prefs.TenantID), Valid: true}
+	}
+	if prefs.QuietHoursStart != nil && prefs.QuietHoursEnd != nil {
+		quietStart = sql.NullInt64{Int64: int64(*prefs.QuietHoursStart), Valid: true}
+		quietEnd = sql.NullInt64{Int64: int64(*prefs.QuietHoursEnd), Valid: true}
+	}
+	mutedTypes := make([]string, 0, len(prefs.MutedTypes))
+	for _, t := range prefs.MutedTypes {
+		mutedTypes = append(mutedTypes, string(t))
+	}
+	reminderDays := make([]int64, 0, len(prefs.ReminderDays))
+	for _, days := range prefs.ReminderDays {
+		reminderDays = append(reminderDays, int64(days))
+	}
+	channels := prefs.Channels
+	if channels == nil {
+		channels = []string{}
+	}
+
+	_, err := r.db.Exec(query,
+		prefs.Recipient,
+		tenantID,
+		pq.StringArray(channels),
+		pq.StringArray(mutedTypes),
+		quietStart,
+		quietEnd,
+		prefs.Language,
+		pq.Int64Array(reminderDays),
+		prefs.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save notification preferences: %w", err)
+	}
+
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"backend-form/m/internal/repository/interfaces"
 	"database/sql"
 	"fmt"
+	"time"
 )
 
 // PostgresNotificationRepository implements NotificationRepository interface
@@ -17,16 +18,97 @@ func NewPostgresNotificationRepository(db *sql.DB) interfaces.NotificationReposi
 	return &PostgresNotificationRepository{db: db}
 }
 
-// CreateNotification creates a new notification
-func (r *PostgresNotificationRepository) CreateNotification(notification *domain.Notification) error {
+const notificationColumns = `id, type, recipient, tenant_id, payment_id, message, sent_at, sent_via, sent_to, error, created_at,
+	status, attempts, next_attempt_at, dedupe_key`
+
+// scanNotification scans a notification row
+func scanNotification(row rowScanner) (*domain.Notification, error) {
+	notification := &domain.Notification{}
+	var tenantID sql.NullInt64
+	var paymentID sql.NullInt64
+	var sentAt sql.NullTime
+	var nextAttemptAt sql.NullTime
+	var dedupeKey sql.NullString
+
+	err := row.Scan(
+		&notification.ID,
+		&notification.Type,
+		&notification.Recipient,
+		&tenantID,
+		&paymentID,
+		&notification.Message,
+		&sentAt,
+		&notification.SentVia,
+		&notification.SentTo,
+		&notification.Error,
+		&notification.CreatedAt,
+		&notification.Status,
+		&notification.Attempts,
+		&nextAttemptAt,
+		&dedupeKey,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if tenantID.Valid {
+		tenantIDInt := int(tenantID.Int64)
+		notification.TenantID = &tenantIDInt
+	}
+	if paymentID.Valid {
+		paymentIDInt := int(paymentID.Int64)
+		notification.PaymentID = &paymentIDInt
+	}
+	if sentAt.Valid {
+		notification.SentAt = &sentAt.Time
+	}
+	if nextAttemptAt.Valid {
+		notification.NextAttemptAt = &nextAttemptAt.Time
+	}
+	notification.DedupeKey = dedupeKey.String
+
+	return notification, nil
+}
+
+// queryNotifications runs a query returning notification rows
+func (r *PostgresNotificationRepository) queryNotifications(query string, args ...interface{}) ([]*domain.Notification, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query notifications: %w", err)
+	}
+	defer rows.Close()
+
+	var notifications []*domain.Notification
+	for rows.Next() {
+		notification, err := scanNotification(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan notification: %w", err)
+		}
+		notifications = append(notifications, notification)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating notifications: %w", err)
+	}
+
+	return notifications, nil
+}
+
+// CreateNotification queues a new notification
+// Returns false without creating anything if a notification with the same dedupe key already exists
+func (r *PostgresNotificationRepository) CreateNotification(notification *domain.Notification) (bool, error) {
 	query := `
-		INSERT INTO notifications (type, recipient, tenant_id, payment_id, message, sent_at, sent_via, sent_to, error, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		INSERT INTO notifications (type, recipient, tenant_id, payment_id, message, sent_at, sent_via, sent_to, error, created_at,
+			status, attempts, next_attempt_at, dedupe_key)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		ON CONFLICT (dedupe_key) DO NOTHING
 		RETURNING id`
 
 	var tenantID sql.NullInt64
 	var paymentID sql.NullInt64
 	var sentAt sql.NullTime
+	var nextAttemptAt sql.NullTime
+	var dedupeKey sql.NullString
 
 	if notification.TenantID != nil {
 		tenantID = sql.NullInt64{Int64: int64(*notification.TenantID), Valid: true}
@@ -37,6 +119,12 @@ func (r *PostgresNotificationRepository) CreateNotification(notification *domain
 	if notification.SentAt != nil {
 		sentAt = sql.NullTime{Time: *notification.SentAt, Valid: true}
 	}
+	if notification.NextAttemptAt != nil {
+		nextAttemptAt = sql.NullTime{Time: *notification.NextAttemptAt, Valid: true}
+	}
+	if notification.DedupeKey != "" {
+		dedupeKey = sql.NullString{String: notification.DedupeKey, Valid: true}
+	}
 
 	err := r.db.QueryRow(query,
 		notification.Type,
@@ -49,41 +137,27 @@ func (r *PostgresNotificationRepository) CreateNotification(notification *domain
 		notification.SentTo,
 		notification.Error,
 		notification.CreatedAt,
+		notification.Status,
+		notification.Attempts,
+		nextAttemptAt,
+		dedupeKey,
 	).Scan(&notification.ID)
 
 	if err != nil {
-		return fmt.Errorf("failed to create notification: %w", err)
+		if err == sql.ErrNoRows {
+			return false, nil // Already queued
+		}
+		return false, fmt.Errorf("failed to create notification: %w", err)
 	}
 
-	return nil
+	return true, nil
 }
 
 // GetNotificationByID returns a notification by ID
 func (r *PostgresNotificationRepository) GetNotificationByID(id int) (*domain.Notification, error) {
-	query := `
-		SELECT id, type, recipient, tenant_id, payment_id, message, sent_at, sent_via, sent_to, error, created_at
-		FROM notifications
-		WHERE id = $1`
-
-	notification := &domain.Notification{}
-	var tenantID sql.NullInt64
-	var paymentID sql.NullInt64
-	var sentAt sql.NullTime
-
-	err := r.db.QueryRow(query, id).Scan(
-		&notification.ID,
-		&notification.Type,
-		&notification.Recipient,
-		&tenantID,
-		&paymentID,
-		&notification.Message,
-		&sentAt,
-		&notification.SentVia,
-		&notification.SentTo,
-		&notification.Error,
-		&notification.CreatedAt,
-	)
+	query := `SELECT ` + notificationColumns + ` FROM notifications WHERE id = $1`
 
+	notification, err := scanNotification(r.db.QueryRow(query, id))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -91,98 +165,70 @@ func (r *PostgresNotificationRepository) GetNotificationByID(id int) (*domain.No
 		return nil, fmt.Errorf("failed to get notification: %w", err)
 	}
 
-	if tenantID.Valid {
-		tenantIDInt := int(tenantID.Int64)
-		notification.TenantID = &tenantIDInt
-	}
-	if paymentID.Valid {
-		paymentIDInt := int(paymentID.Int64)
-		notification.PaymentID = &paymentIDInt
-	}
-	if sentAt.Valid {
-		notification.SentAt = &sentAt.Time
-	}
-
 	return notification, nil
 }
 
 // GetNotificationsByTenantID returns all notifications for a tenant
 func (r *PostgresNotificationRepository) GetNotificationsByTenantID(tenantID int) ([]*domain.Notification, error) {
-	query := `
-		SELECT id, type, recipient, tenant_id, payment_id, message, sent_at, sent_via, sent_to, error, created_at
-		FROM notifications
+	query := `SELECT ` + notificationColumns + ` FROM notifications
 		WHERE tenant_id = $1
 		ORDER BY created_at DESC`
 
-	rows, err := r.db.Query(query, tenantID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to query notifications: %w", err)
-	}
-	defer rows.Close()
+	return r.queryNotifications(query, tenantID)
+}
 
-	var notifications []*domain.Notification
-	for rows.Next() {
-		notification := &domain.Notification{}
-		var tenantID sql.NullInt64
-		var paymentID sql.NullInt64
-		var sentAt sql.NullTime
-
-		err := rows.Scan(
-			&notification.ID,
-			&notification.Type,
-			&notification.Recipient,
-			&tenantID,
-			&paymentID,
-			&notification.Message,
-			&sentAt,
-			&notification.SentVia,
-			&notification.SentTo,
-			&notification.Error,
-			&notification.CreatedAt,
+// ClaimDueNotifications returns up to limit pending notifications due by now, oldest first,
+// and pushes their next attempt to claimUntil so no other worker picks them up meanwhile
+// If the worker stops before recording the attempt, the notification is retried after claimUntil
+func (r *PostgresNotificationRepository) ClaimDueNotifications(now time.Time, claimUntil time.Time, limit int) ([]*domain.Notification, error) {
+	query := `
+		UPDATE notifications SET next_attempt_at = $2
+		WHERE id IN (
+			SELECT id FROM notifications
+			WHERE status = 'pending' AND (next_attempt_at IS NULL OR next_attempt_at <= $1)
+			ORDER BY next_attempt_at NULLS FIRST, id
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
 		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan notification: %w", err)
-		}
-
-		if tenantID.Valid {
-			tenantIDInt := int(tenantID.Int64)
-			notification.TenantID = &tenantIDInt
-		}
-		if paymentID.Valid {
-			paymentIDInt := int(paymentID.Int64)
-			notification.PaymentID = &paymentIDInt
-		}
-		if sentAt.Valid {
-			notification.SentAt = &sentAt.Time
-		}
+		RETURNING ` + notificationColumns
 
-		notifications = append(notifications, notification)
-	}
+	return r.queryNotifications(query, now, claimUntil, limit)
+}
 
-	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating notifications: %w", err)
-	}
+// GetNotificationsByStatus returns the most recent notifications with the given status
+func (r *PostgresNotificationRepository) GetNotificationsByStatus(status domain.NotificationStatus, limit int) ([]*domain.Notification, error) {
+	query := `SELECT ` + notificationColumns + ` FROM notifications
+		WHERE status = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2`
 
-	return notifications, nil
+	return r.queryNotifications(query, status, limit)
 }
 
-// UpdateNotification updates a notification
+// UpdateNotification records the outcome of a delivery attempt
 func (r *PostgresNotificationRepository) UpdateNotification(notification *domain.Notification) error {
 	query := `
 		UPDATE notifications
-		SET sent_at = $1, sent_via = $2, sent_to = $3, error = $4
-		WHERE id = $5`
+		SET sent_at = $1, sent_via = $2, sent_to = $3, error = $4, status = $5, attempts = $6, next_attempt_at = $7
+		WHERE id = $8`
 
 	var sentAt sql.NullTime
+	var nextAttemptAt sql.NullTime
 	if notification.SentAt != nil {
 		sentAt = sql.NullTime{Time: *notification.SentAt, Valid: true}
 	}
+	if notification.NextAttemptAt != nil {
+		nextAttemptAt = sql.NullTime{Time: *notification.NextAttemptAt, Valid: true}
+	}
 
 	_, err := r.db.Exec(query,
 		sentAt,
 		notification.SentVia,
 		notification.SentTo,
 		notification.Error,
+		notification.Status,
+		notification.Attempts,
+		nextAttemptAt,
 		notification.ID,
 	)
 
@@ -192,3 +238,27 @@ func (r *PostgresNotificationRepository) UpdateNotification(notification *domain
 
 	return nil
 }
+
+// HasNotificationSince reports whether a matching notification was recorded since the given time
+// Failed deliveries count too, so a broken channel is not retried every hour
+func (r *PostgresNotificationRepository) HasNotificationSince(notificationType domain.NotificationType, recipient domain.NotificationRecipient, tenantID int, paymentID *int, since time.Time) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM notifications
+			WHERE type = $1 AND recipient = $2 AND tenant_id = $3
+				AND ($4::INTEGER IS NULL OR payment_id = $4)
+				AND created_at >= $5
+		)`
+
+	var payment sql.NullInt64
+	if paymentID != nil {
+		payment = sql.NullInt64{Int64: int64(*paymentID), Valid: true}
+	}
+
+	var exists bool
+	if err := r.db.QueryRow(query, notificationType, recipient, tenantID, payment, since).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check notifications: %w", err)
+	}
+
+	return exists, nil
+}
@@ -0,0 +1,178 @@
+package repository
+
+import (
+	domain "backend-form/m/internal/domain"
+	"backend-form/m/internal/repository/interfaces"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// PostgresPaymentAdjustmentRepository implements PaymentAdjustmentRepository interface
+type PostgresPaymentAdjustmentRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresPaymentAdjustmentRepository creates a new PostgresPaymentAdjustmentRepository
+func NewPostgresPaymentAdjustmentRepository(db *sql.DB) interfaces.PaymentAdjustmentRepository {
+	return &PostgresPaymentAdjustmentRepository{db: db}
+}
+
+const paymentAdjustmentColumns = `a.id, a.payment_id, a.tenant_id, a.unit_id, a.adjustment_type, a.amount, a.reason, a.created_by_user_id, a.created_at, u.property_id`
+
+// scanPaymentAdjustment scans a payment adjustment row
+func scanPaymentAdjustment(row rowScanner) (*domain.PaymentAdjustment, error) {
+	adjustment := &domain.PaymentAdjustment{}
+	var createdBy, propertyID sql.NullInt64
+	err := row.Scan(
+		&adjustment.ID,
+		&adjustment.PaymentID,
+		&adjustment.TenantID,
+		&adjustment.UnitID,
+		&adjustment.AdjustmentType,
+		&adjustment.Amount,
+		&adjustment.Reason,
+		&createdBy,
+		&adjustment.CreatedAt,
+		&propertyID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if createdBy.Valid {
+		id := int(createdBy.Int64)
+		adjustment.CreatedByUserID = &id
+	}
+	if propertyID.Valid {
+		adjustment.PropertyID = int(propertyID.Int64)
+	}
+	return adjustment, nil
+}
+
+// CreateAdjustment reduces the balance of the adjusted payment and records the adjustment in a single transaction
+// The balance is only reduced while it still covers the adjustment and no transaction awaits verification,
+// so concurrent adjustments or payments can never take a payment below zero.
+// Fills in the adjustment's tenant and unit from the payment
+func (r *PostgresPaymentAdjustmentRepository) CreateAdjustment(adjustment *domain.PaymentAdjustment) error {
+	dbTx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback()
+
+	now := time.Now()
+	var isFullyPaid bool
+	err = dbTx.QueryRow(`
+		UPDATE payments
+		SET amount_adjusted = amount_adjusted + $1,
+		    remaining_balance = remaining_balance - $1,
+		    is_fully_paid = (remaining_balance - $1 <= 0),
+		    fully_paid_date = CASE
+		        WHEN (remaining_balance - $1 <= 0) AND fully_paid_date IS NULL THEN $2
+		        ELSE fully_paid_date
+		    END
+		WHERE id = $3 AND remaining_balance >= $1
+		  AND NOT EXISTS (
+		      SELECT 1 FROM payment_transactions
+		      WHERE payment_id = $3 AND verified_at IS NULL AND rejected_at IS NULL)
+		RETURNING tenant_id, unit_id, is_fully_paid`,
+		adjustment.Amount, now, adjustment.PaymentID,
+	).Scan(&adjustment.TenantID, &adjustment.UnitID, &isFullyPaid)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("payment changed while the adjustment was being made; it may no longer owe ₹%d or has a transaction awaiting verification", adjustment.Amount)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to adjust payment: %w", err)
+	}
+
+	err = dbTx.QueryRow(`
+		INSERT INTO payment_adjustments (payment_id, tenant_id, unit_id, adjustment_type, amount, reason, created_by_user_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at`,
+		adjustment.PaymentID,
+		adjustment.TenantID,
+		adjustment.UnitID,
+		adjustment.AdjustmentType,
+		adjustment.Amount,
+		adjustment.Reason,
+		adjustment.CreatedByUserID,
+	).Scan(&adjustment.ID, &adjustment.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create payment adjustment: %w", err)
+	}
+
+	// An adjusted installment may be the last one of its plan
+	if isFullyPaid {
+		if err = completeSettledInstallmentPlans(dbTx, []int{adjustment.PaymentID}, now); err != nil {
+			return err
+		}
+	}
+
+	if err = dbTx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit payment adjustment: %w", err)
+	}
+
+	return nil
+}
+
+// GetAdjustmentsByPaymentID returns the adjustments made to a payment, oldest first
+func (r *PostgresPaymentAdjustmentRepository) GetAdjustmentsByPaymentID(paymentID int) ([]*domain.PaymentAdjustment, error) {
+	query := `
+		SELECT ` + paymentAdjustmentColumns + `
+		FROM payment_adjustments a
+		LEFT JOIN units u ON u.id = a.unit_id
+		WHERE a.payment_id = $1
+		ORDER BY a.created_at ASC, a.id ASC`
+
+	return r.queryAdjustments(query, paymentID)
+}
+
+// GetAdjustmentsByTenantID returns all adjustments of a tenant, most recent first
+func (r *PostgresPaymentAdjustmentRepository) GetAdjustmentsByTenantID(tenantID int) ([]*domain.PaymentAdjustment, error) {
+	query := `
+		SELECT ` + paymentAdjustmentColumns + `
+		FROM payment_adjustments a
+		LEFT JOIN units u ON u.id = a.unit_id
+		WHERE a.tenant_id = $1
+		ORDER BY a.created_at DESC, a.id DESC`
+
+	return r.queryAdjustments(query, tenantID)
+}
+
+// GetAdjustmentsBetween returns the adjustments made between two dates (both inclusive)
+// A zero from date means no lower bound; propertyID 0 means all properties
+func (r *PostgresPaymentAdjustmentRepository) GetAdjustmentsBetween(from, to time.Time, propertyID int) ([]*domain.PaymentAdjustment, error) {
+	query := `
+		SELECT ` + paymentAdjustmentColumns + `
+		FROM payment_adjustments a
+		LEFT JOIN units u ON u.id = a.unit_id
+		WHERE a.created_at >= $1 AND a.created_at < $2
+		  AND ($3 = 0 OR u.property_id = $3)
+		ORDER BY a.created_at ASC`
+
+	return r.queryAdjustments(query, from, to.AddDate(0, 0, 1), propertyID)
+}
+
+// queryAdjustments runs a query returning payment adjustment rows
+func (r *PostgresPaymentAdjustmentRepository) queryAdjustments(query string, args ...interface{}) ([]*domain.PaymentAdjustment, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query payment adjustments: %w", err)
+	}
+	defer rows.Close()
+
+	var adjustments []*domain.PaymentAdjustment
+	for rows.Next() {
+		adjustment, err := scanPaymentAdjustment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan payment adjustment: %w", err)
+		}
+		adjustments = append(adjustments, adjustment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating payment adjustments: %w", err)
+	}
+
+	return adjustments, nil
+}
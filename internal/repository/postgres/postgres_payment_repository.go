@@ -63,7 +63,7 @@ func (r *PostgresPaymentRepository) CreatePayment(payment *domain.Payment) error
 // GetPaymentByID returns a payment by ID
 func (r *PostgresPaymentRepository) GetPaymentByID(id int) (*domain.Payment, error) {
 	query := `
-		SELECT id, tenant_id, unit_id, amount, amount_paid, remaining_balance, payment_date, 
+		SELECT id, tenant_id, unit_id, amount, amount_paid, amount_adjusted, remaining_balance, payment_date, 
 		       due_date, is_paid, is_fully_paid, fully_paid_date, payment_method, upi_id, notes, label, created_at
 		FROM payments
 		WHERE id = $1`
@@ -78,6 +78,7 @@ func (r *PostgresPaymentRepository) GetPaymentByID(id int) (*domain.Payment, err
 		&payment.UnitID,
 		&payment.Amount,
 		&payment.AmountPaid,
+		&payment.AmountAdjusted,
 		&payment.RemainingBalance,
 		&paymentDate,
 		&payment.DueDate,
@@ -121,7 +122,7 @@ func (r *PostgresPaymentRepository) DeletePaymentsByTenantID(tenantID int) error
 // GetAllPayments returns all payments
 func (r *PostgresPaymentRepository) GetAllPayments() ([]*domain.Payment, error) {
 	query := `
-		SELECT id, tenant_id, unit_id, amount, amount_paid, remaining_balance, payment_date, 
+		SELECT id, tenant_id, unit_id, amount, amount_paid, amount_adjusted, remaining_balance, payment_date, 
 		       due_date, is_paid, is_fully_paid, fully_paid_date, payment_method, upi_id, notes, label, created_at
 		FROM payments
 		ORDER BY due_date DESC, created_at DESC`
@@ -144,6 +145,7 @@ func (r *PostgresPaymentRepository) GetAllPayments() ([]*domain.Payment, error)
 			&payment.UnitID,
 			&payment.Amount,
 			&payment.AmountPaid,
+			&payment.AmountAdjusted,
 			&payment.RemainingBalance,
 			&paymentDate,
 			&payment.DueDate,
@@ -183,8 +185,9 @@ func (r *PostgresPaymentRepository) UpdatePayment(payment *domain.Payment) error
 		UPDATE payments 
 		SET tenant_id = $1, unit_id = $2, amount = $3, amount_paid = $4, remaining_balance = $5,
 		    payment_date = $6, due_date = $7, is_paid = $8, is_fully_paid = $9, 
-		    fully_paid_date = $10, payment_method = $11, upi_id = $12, notes = $13, label = $14
-		WHERE id = $15`
+		    fully_paid_date = $10, payment_method = $11, upi_id = $12, notes = $13, label = $14,
+		    amount_adjusted = $15
+		WHERE id = $16`
 
 	result, err := r.db.Exec(query,
 		payment.TenantID,
@@ -201,6 +204,7 @@ func (r *PostgresPaymentRepository) UpdatePayment(payment *domain.Payment) error
 		payment.UPIID,
 		payment.Notes,
 		payment.Label,
+		payment.AmountAdjusted,
 		payment.ID,
 	)
 
@@ -244,7 +248,7 @@ func (r *PostgresPaymentRepository) DeletePayment(id int) error {
 // GetPaymentsByTenantID returns payments for a specific tenant
 func (r *PostgresPaymentRepository) GetPaymentsByTenantID(tenantID int) ([]*domain.Payment, error) {
 	query := `
-		SELECT id, tenant_id, unit_id, amount, amount_paid, remaining_balance, payment_date, 
+		SELECT id, tenant_id, unit_id, amount, amount_paid, amount_adjusted, remaining_balance, payment_date, 
 		       due_date, is_paid, is_fully_paid, fully_paid_date, payment_method, upi_id, notes, label, created_at
 		FROM payments
 		WHERE tenant_id = $1
@@ -268,6 +272,69 @@ func (r *PostgresPaymentRepository) GetPaymentsByTenantID(tenantID int) ([]*doma
 			&payment.UnitID,
 			&payment.Amount,
 			&payment.AmountPaid,
+			&payment.AmountAdjusted,
+			&payment.RemainingBalance,
+			&paymentDate,
+			&payment.DueDate,
+			&payment.IsPaid,
+			&payment.IsFullyPaid,
+			&fullyPaidDate,
+			&payment.PaymentMethod,
+			&payment.UPIID,
+			&payment.Notes,
+			&payment.Label,
+			&payment.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan payment: %w", err)
+		}
+
+		if paymentDate.Valid {
+			payment.PaymentDate = &paymentDate.Time
+		}
+		if fullyPaidDate.Valid {
+			payment.FullyPaidDate = &fullyPaidDate.Time
+		}
+
+		payments = append(payments, payment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating payments: %w", err)
+	}
+
+	return payments, nil
+}
+
+// GetPaymentsByPropertyID returns payments for all units of a specific property
+func (r *PostgresPaymentRepository) GetPaymentsByPropertyID(propertyID int) ([]*domain.Payment, error) {
+	query := `
+		SELECT p.id, p.tenant_id, p.unit_id, p.amount, p.amount_paid, p.amount_adjusted, p.remaining_balance, p.payment_date, 
+		       p.due_date, p.is_paid, p.is_fully_paid, p.fully_paid_date, p.payment_method, p.upi_id, p.notes, p.label, p.created_at
+		FROM payments p
+		INNER JOIN units u ON p.unit_id = u.id
+		WHERE u.property_id = $1
+		ORDER BY p.due_date DESC, p.created_at DESC`
+
+	rows, err := r.db.Query(query, propertyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query payments by property: %w", err)
+	}
+	defer rows.Close()
+
+	var payments []*domain.Payment
+	for rows.Next() {
+		payment := &domain.Payment{}
+		var paymentDate sql.NullTime
+		var fullyPaidDate sql.NullTime
+
+		err := rows.Scan(
+			&payment.ID,
+			&payment.TenantID,
+			&payment.UnitID,
+			&payment.Amount,
+			&payment.AmountPaid,
+			&payment.AmountAdjusted,
 			&payment.RemainingBalance,
 			&paymentDate,
 			&payment.DueDate,
@@ -304,7 +371,7 @@ func (r *PostgresPaymentRepository) GetPaymentsByTenantID(tenantID int) ([]*doma
 // GetPaymentByTenantAndMonth returns payment for a specific tenant and month
 func (r *PostgresPaymentRepository) GetPaymentByTenantAndMonth(tenantID int, month time.Month, year int) (*domain.Payment, error) {
 	query := `
-		SELECT id, tenant_id, unit_id, amount, amount_paid, remaining_balance, payment_date, 
+		SELECT id, tenant_id, unit_id, amount, amount_paid, amount_adjusted, remaining_balance, payment_date, 
 		       due_date, is_paid, is_fully_paid, fully_paid_date, payment_method, upi_id, notes, label, created_at
 		FROM payments
 		WHERE tenant_id = $1 AND EXTRACT(MONTH FROM due_date) = $2 AND EXTRACT(YEAR FROM due_date) = $3 AND label = 'rent'`
@@ -319,6 +386,7 @@ func (r *PostgresPaymentRepository) GetPaymentByTenantAndMonth(tenantID int, mon
 		&payment.UnitID,
 		&payment.Amount,
 		&payment.AmountPaid,
+		&payment.AmountAdjusted,
 		&payment.RemainingBalance,
 		&paymentDate,
 		&payment.DueDate,
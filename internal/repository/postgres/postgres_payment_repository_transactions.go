@@ -35,15 +35,65 @@ func (r *PostgresPaymentRepository) CreatePaymentTransaction(tx *domain.PaymentT
 	return nil
 }
 
-// GetPaymentTransactionsByPaymentID returns all transactions for a payment
-func (r *PostgresPaymentRepository) GetPaymentTransactionsByPaymentID(paymentID int) ([]*domain.PaymentTransaction, error) {
-	query := `
-		SELECT id, payment_id, transaction_id, amount, submitted_at, verified_at, verified_by_user_id, notes, created_at
-		FROM payment_transactions
-		WHERE payment_id = $1
-		ORDER BY submitted_at DESC`
+// paymentTransactionColumns is the column list read by scanPaymentTransaction
+const paymentTransactionColumns = `pt.id, pt.payment_id, pt.transaction_id, pt.amount, pt.submitted_at,
+	pt.verified_at, pt.verified_by_user_id, pt.rejected_at, pt.rejected_by_user_id, pt.rejection_reason,
+	pt.notes, pt.created_at`
 
-	rows, err := r.db.Query(query, paymentID)
+// scanPaymentTransaction scans a payment transaction row
+func scanPaymentTransaction(row rowScanner) (*domain.PaymentTransaction, error) {
+	tx := &domain.PaymentTransaction{}
+	var amount sql.NullInt64
+	var verifiedAt sql.NullTime
+	var verifiedByUserID sql.NullInt64
+	var rejectedAt sql.NullTime
+	var rejectedByUserID sql.NullInt64
+	var notes sql.NullString
+
+	err := row.Scan(
+		&tx.ID,
+		&tx.PaymentID,
+		&tx.TransactionID,
+		&amount,
+		&tx.SubmittedAt,
+		&verifiedAt,
+		&verifiedByUserID,
+		&rejectedAt,
+		&rejectedByUserID,
+		&tx.RejectionReason,
+		&notes,
+		&tx.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if amount.Valid {
+		amt := int(amount.Int64)
+		tx.Amount = &amt
+	}
+	if verifiedAt.Valid {
+		tx.VerifiedAt = &verifiedAt.Time
+	}
+	if verifiedByUserID.Valid {
+		uid := int(verifiedByUserID.Int64)
+		tx.VerifiedByUserID = &uid
+	}
+	if rejectedAt.Valid {
+		tx.RejectedAt = &rejectedAt.Time
+	}
+	if rejectedByUserID.Valid {
+		uid := int(rejectedByUserID.Int64)
+		tx.RejectedByUserID = &uid
+	}
+	tx.Notes = notes.String
+
+	return tx, nil
+}
+
+// queryPaymentTransactions runs a query returning payment transaction rows
+func (r *PostgresPaymentRepository) queryPaymentTransactions(query string, args ...interface{}) ([]*domain.PaymentTransaction, error) {
+	rows, err := r.db.Query(query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query payment transactions: %w", err)
 	}
@@ -51,38 +101,10 @@ func (r *PostgresPaymentRepository) GetPaymentTransactionsByPaymentID(paymentID
 
 	var transactions []*domain.PaymentTransaction
 	for rows.Next() {
-		tx := &domain.PaymentTransaction{}
-		var amount sql.NullInt64
-		var verifiedAt sql.NullTime
-		var verifiedByUserID sql.NullInt64
-
-		err := rows.Scan(
-			&tx.ID,
-			&tx.PaymentID,
-			&tx.TransactionID,
-			&amount,
-			&tx.SubmittedAt,
-			&verifiedAt,
-			&verifiedByUserID,
-			&tx.Notes,
-			&tx.CreatedAt,
-		)
+		tx, err := scanPaymentTransaction(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan payment transaction: %w", err)
 		}
-
-		if amount.Valid {
-			amt := int(amount.Int64)
-			tx.Amount = &amt
-		}
-		if verifiedAt.Valid {
-			tx.VerifiedAt = &verifiedAt.Time
-		}
-		if verifiedByUserID.Valid {
-			uid := int(verifiedByUserID.Int64)
-			tx.VerifiedByUserID = &uid
-		}
-
 		transactions = append(transactions, tx)
 	}
 
@@ -93,30 +115,28 @@ func (r *PostgresPaymentRepository) GetPaymentTransactionsByPaymentID(paymentID
 	return transactions, nil
 }
 
-// GetTransactionByPaymentAndID returns a specific transaction by payment ID and transaction ID
-func (r *PostgresPaymentRepository) GetTransactionByPaymentAndID(paymentID int, transactionID string) (*domain.PaymentTransaction, error) {
+// GetPaymentTransactionsByPaymentID returns all transactions for a payment, including rejected ones
+func (r *PostgresPaymentRepository) GetPaymentTransactionsByPaymentID(paymentID int) ([]*domain.PaymentTransaction, error) {
 	query := `
-		SELECT id, payment_id, transaction_id, amount, submitted_at, verified_at, verified_by_user_id, notes, created_at
-		FROM payment_transactions
-		WHERE payment_id = $1 AND transaction_id = $2`
+		SELECT ` + paymentTransactionColumns + `
+		FROM payment_transactions pt
+		WHERE pt.payment_id = $1
+		ORDER BY pt.submitted_at DESC`
 
-	tx := &domain.PaymentTransaction{}
-	var amount sql.NullInt64
-	var verifiedAt sql.NullTime
-	var verifiedByUserID sql.NullInt64
+	return r.queryPaymentTransactions(query, paymentID)
+}
 
-	err := r.db.QueryRow(query, paymentID, transactionID).Scan(
-		&tx.ID,
-		&tx.PaymentID,
-		&tx.TransactionID,
-		&amount,
-		&tx.SubmittedAt,
-		&verifiedAt,
-		&verifiedByUserID,
-		&tx.Notes,
-		&tx.CreatedAt,
-	)
+// GetTransactionByPaymentAndID returns a specific transaction by payment ID and transaction ID
+// A UTR rejected earlier and then submitted again resolves to the latest submission
+func (r *PostgresPaymentRepository) GetTransactionByPaymentAndID(paymentID int, transactionID string) (*domain.PaymentTransaction, error) {
+	query := `
+		SELECT ` + paymentTransactionColumns + `
+		FROM payment_transactions pt
+		WHERE pt.payment_id = $1 AND pt.transaction_id = $2
+		ORDER BY pt.submitted_at DESC
+		LIMIT 1`
 
+	tx, err := scanPaymentTransaction(r.db.QueryRow(query, paymentID, transactionID))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil // Transaction not found
@@ -124,45 +144,20 @@ func (r *PostgresPaymentRepository) GetTransactionByPaymentAndID(paymentID int,
 		return nil, fmt.Errorf("failed to get payment transaction: %w", err)
 	}
 
-	if amount.Valid {
-		amt := int(amount.Int64)
-		tx.Amount = &amt
-	}
-	if verifiedAt.Valid {
-		tx.VerifiedAt = &verifiedAt.Time
-	}
-	if verifiedByUserID.Valid {
-		uid := int(verifiedByUserID.Int64)
-		tx.VerifiedByUserID = &uid
-	}
-
 	return tx, nil
 }
 
 // GetTransactionByID returns a transaction by transaction ID (efficient lookup)
+// A UTR rejected earlier and then submitted again resolves to the latest submission
 func (r *PostgresPaymentRepository) GetTransactionByID(transactionID string) (*domain.PaymentTransaction, error) {
 	query := `
-		SELECT id, payment_id, transaction_id, amount, submitted_at, verified_at, verified_by_user_id, notes, created_at
-		FROM payment_transactions
-		WHERE transaction_id = $1`
-
-	tx := &domain.PaymentTransaction{}
-	var amount sql.NullInt64
-	var verifiedAt sql.NullTime
-	var verifiedByUserID sql.NullInt64
-
-	err := r.db.QueryRow(query, transactionID).Scan(
-		&tx.ID,
-		&tx.PaymentID,
-		&tx.TransactionID,
-		&amount,
-		&tx.SubmittedAt,
-		&verifiedAt,
-		&verifiedByUserID,
-		&tx.Notes,
-		&tx.CreatedAt,
-	)
+		SELECT ` + paymentTransactionColumns + `
+		FROM payment_transactions pt
+		WHERE pt.transaction_id = $1
+		ORDER BY pt.submitted_at DESC
+		LIMIT 1`
 
+	tx, err := scanPaymentTransaction(r.db.QueryRow(query, transactionID))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil // Transaction not found
@@ -170,43 +165,52 @@ func (r *PostgresPaymentRepository) GetTransactionByID(transactionID string) (*d
 		return nil, fmt.Errorf("failed to get transaction by ID: %w", err)
 	}
 
-	if amount.Valid {
-		amt := int(amount.Int64)
-		tx.Amount = &amt
-	}
-	if verifiedAt.Valid {
-		tx.VerifiedAt = &verifiedAt.Time
-	}
-	if verifiedByUserID.Valid {
-		uid := int(verifiedByUserID.Int64)
-		tx.VerifiedByUserID = &uid
-	}
-
 	return tx, nil
 }
 
-// GetPendingVerifications returns all pending (unverified) transactions for a tenant
+// GetPendingVerifications returns all pending (unverified, not rejected) transactions for a tenant (0 = all tenants)
 func (r *PostgresPaymentRepository) GetPendingVerifications(tenantID int) ([]*domain.PaymentTransaction, error) {
 	query := `
-		SELECT pt.id, pt.payment_id, pt.transaction_id, pt.amount, pt.submitted_at, 
-		       pt.verified_at, pt.verified_by_user_id, pt.notes, pt.created_at
+		SELECT ` + paymentTransactionColumns + `
 		FROM payment_transactions pt
 		INNER JOIN payments p ON pt.payment_id = p.id
-		WHERE p.tenant_id = $1 AND pt.verified_at IS NULL
+		WHERE ($1 = 0 OR p.tenant_id = $1) AND pt.verified_at IS NULL AND pt.rejected_at IS NULL
 		ORDER BY pt.submitted_at DESC`
 
-	rows, err := r.db.Query(query, tenantID)
+	transactions, err := r.queryPaymentTransactions(query, tenantID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query pending verifications: %w", err)
 	}
+
+	return transactions, nil
+}
+
+// GetRejectedTransactions returns rejected transactions with their payment, most recent first
+// tenantID 0 returns rejections across all tenants
+func (r *PostgresPaymentRepository) GetRejectedTransactions(tenantID int) ([]*domain.PaymentTransaction, error) {
+	query := `
+		SELECT ` + paymentTransactionColumns + `, p.tenant_id, p.unit_id, p.amount, p.due_date, p.label
+		FROM payment_transactions pt
+		INNER JOIN payments p ON pt.payment_id = p.id
+		WHERE pt.rejected_at IS NOT NULL AND ($1 = 0 OR p.tenant_id = $1)
+		ORDER BY pt.rejected_at DESC`
+
+	rows, err := r.db.Query(query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query rejected transactions: %w", err)
+	}
 	defer rows.Close()
 
 	var transactions []*domain.PaymentTransaction
 	for rows.Next() {
 		tx := &domain.PaymentTransaction{}
+		payment := &domain.Payment{}
 		var amount sql.NullInt64
 		var verifiedAt sql.NullTime
 		var verifiedByUserID sql.NullInt64
+		var rejectedAt sql.NullTime
+		var rejectedByUserID sql.NullInt64
+		var notes sql.NullString
 
 		err := rows.Scan(
 			&tx.ID,
@@ -216,30 +220,37 @@ func (r *PostgresPaymentRepository) GetPendingVerifications(tenantID int) ([]*do
 			&tx.SubmittedAt,
 			&verifiedAt,
 			&verifiedByUserID,
-			&tx.Notes,
+			&rejectedAt,
+			&rejectedByUserID,
+			&tx.RejectionReason,
+			&notes,
 			&tx.CreatedAt,
+			&payment.TenantID,
+			&payment.UnitID,
+			&payment.Amount,
+			&payment.DueDate,
+			&payment.Label,
 		)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan payment transaction: %w", err)
+			return nil, fmt.Errorf("failed to scan rejected transaction: %w", err)
 		}
 
-		if amount.Valid {
-			amt := int(amount.Int64)
-			tx.Amount = &amt
+		if rejectedAt.Valid {
+			tx.RejectedAt = &rejectedAt.Time
 		}
-		if verifiedAt.Valid {
-			tx.VerifiedAt = &verifiedAt.Time
-		}
-		if verifiedByUserID.Valid {
-			uid := int(verifiedByUserID.Int64)
-			tx.VerifiedByUserID = &uid
+		if rejectedByUserID.Valid {
+			uid := int(rejectedByUserID.Int64)
+			tx.RejectedByUserID = &uid
 		}
+		tx.Notes = notes.String
+		payment.ID = tx.PaymentID
+		tx.Payment = payment
 
 		transactions = append(transactions, tx)
 	}
 
 	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating pending verifications: %w", err)
+		return nil, fmt.Errorf("error iterating rejected transactions: %w", err)
 	}
 
 	return transactions, nil
@@ -255,15 +266,18 @@ func (r *PostgresPaymentRepository) VerifyTransaction(transactionID string, amou
 	}
 	defer dbTx.Rollback()
 
-	// Get the payment transaction
-	var paymentID int
+	// Get the payment transaction (the latest submission of the UTR that was not rejected)
+	var rowID, paymentID int
 	var currentAmount sql.NullInt64
 	err = dbTx.QueryRow(`
-		SELECT payment_id, amount 
+		SELECT id, payment_id, amount 
 		FROM payment_transactions 
-		WHERE transaction_id = $1`,
+		WHERE transaction_id = $1 AND rejected_at IS NULL
+		ORDER BY submitted_at DESC
+		LIMIT 1
+		FOR UPDATE`,
 		transactionID,
-	).Scan(&paymentID, &currentAmount)
+	).Scan(&rowID, &paymentID, &currentAmount)
 
 	if err != nil {
 		return fmt.Errorf("transaction not found: %w", err)
@@ -279,8 +293,8 @@ func (r *PostgresPaymentRepository) VerifyTransaction(transactionID string, amou
 	_, err = dbTx.Exec(`
 		UPDATE payment_transactions 
 		SET amount = $1, verified_at = $2, verified_by_user_id = $3
-		WHERE transaction_id = $4`,
-		amount, now, verifiedByUserID, transactionID,
+		WHERE id = $4`,
+		amount, now, verifiedByUserID, rowID,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to update transaction: %w", err)
@@ -290,10 +304,10 @@ func (r *PostgresPaymentRepository) VerifyTransaction(transactionID string, amou
 	_, err = dbTx.Exec(`
 		UPDATE payments 
 		SET amount_paid = amount_paid + $1,
-		    remaining_balance = amount - (amount_paid + $1),
-		    is_fully_paid = (amount - (amount_paid + $1) <= 0),
+		    remaining_balance = amount - amount_adjusted - (amount_paid + $1),
+		    is_fully_paid = (amount - amount_adjusted - (amount_paid + $1) <= 0),
 		    fully_paid_date = CASE 
-		        WHEN (amount - (amount_paid + $1) <= 0) AND fully_paid_date IS NULL THEN $2
+		        WHEN (amount - amount_adjusted - (amount_paid + $1) <= 0) AND fully_paid_date IS NULL THEN $2
 		        ELSE fully_paid_date
 		    END
 		WHERE id = $3`,
@@ -314,13 +328,16 @@ func (r *PostgresPaymentRepository) VerifyTransaction(transactionID string, amou
 // Used for smart allocation where payment updates are handled separately
 func (r *PostgresPaymentRepository) VerifyTransactionRecord(transactionID string, amount int, verifiedByUserID int, verifiedAt time.Time) error {
 	// Check if transaction exists and is not already verified
+	var rowID int
 	var currentAmount sql.NullInt64
 	err := r.db.QueryRow(`
-		SELECT amount 
+		SELECT id, amount 
 		FROM payment_transactions 
-		WHERE transaction_id = $1`,
+		WHERE transaction_id = $1 AND rejected_at IS NULL
+		ORDER BY submitted_at DESC
+		LIMIT 1`,
 		transactionID,
-	).Scan(&currentAmount)
+	).Scan(&rowID, &currentAmount)
 
 	if err != nil {
 		return fmt.Errorf("transaction not found: %w", err)
@@ -332,17 +349,17 @@ func (r *PostgresPaymentRepository) VerifyTransactionRecord(transactionID string
 	}
 
 	// Update transaction record only
-	_, err = r.db.Exec(`
+	result, err := r.db.Exec(`
 		UPDATE payment_transactions 
 		SET amount = $1, verified_at = $2, verified_by_user_id = $3
-		WHERE transaction_id = $4`,
-		amount, verifiedAt, verifiedByUserID, transactionID,
+		WHERE id = $4 AND verified_at IS NULL AND rejected_at IS NULL`,
+		amount, verifiedAt, verifiedByUserID, rowID,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to update transaction record: %w", err)
 	}
 
-	return nil
+	return expectOneRow(result, "transaction was verified or rejected meanwhile")
 }
 
 // ApplyPaymentAllocation applies a payment allocation to a specific payment
@@ -352,12 +369,13 @@ func (r *PostgresPaymentRepository) ApplyPaymentAllocation(paymentID int, amount
 	// Get current payment state
 	var currentAmountPaid int
 	var paymentAmount int
+	var amountAdjusted int
 	err := r.db.QueryRow(`
-		SELECT amount, amount_paid 
+		SELECT amount, amount_adjusted, amount_paid 
 		FROM payments 
 		WHERE id = $1`,
 		paymentID,
-	).Scan(&paymentAmount, &currentAmountPaid)
+	).Scan(&paymentAmount, &amountAdjusted, &currentAmountPaid)
 
 	if err != nil {
 		return fmt.Errorf("payment not found: %w", err)
@@ -365,7 +383,7 @@ func (r *PostgresPaymentRepository) ApplyPaymentAllocation(paymentID int, amount
 
 	// Calculate new values
 	newAmountPaid := currentAmountPaid + amount
-	newRemainingBalance := paymentAmount - newAmountPaid
+	newRemainingBalance := paymentAmount - amountAdjusted - newAmountPaid
 	isFullyPaid := newRemainingBalance <= 0
 
 	// Update payment
@@ -385,12 +403,17 @@ func (r *PostgresPaymentRepository) ApplyPaymentAllocation(paymentID int, amount
 		return fmt.Errorf("failed to update payment: %w", err)
 	}
 
+	if isFullyPaid {
+		return completeSettledInstallmentPlans(r.db, []int{paymentID}, allocationTime)
+	}
 	return nil
 }
 
 // ApplySmartAllocation applies payment allocations across multiple payments in a single transaction
 // allocations is a map of paymentID -> amount to allocate
-func (r *PostgresPaymentRepository) ApplySmartAllocation(transactionID string, amount int, verifiedByUserID int, allocations map[int]int, allocationTime time.Time) error {
+// overpayment is the credit entry for any amount left after all unpaid payments (nil if none)
+// receipts are issued for the allocations in the order given, with their balance after the allocation filled in
+func (r *PostgresPaymentRepository) ApplySmartAllocation(transactionID string, amount int, verifiedByUserID int, allocations map[int]int, overpayment *domain.CreditEntry, receipts []*domain.Receipt, allocationTime time.Time) error {
 	// Use a single transaction for all operations
 	dbTx, err := r.db.Begin()
 	if err != nil {
@@ -399,20 +422,29 @@ func (r *PostgresPaymentRepository) ApplySmartAllocation(transactionID string, a
 	defer dbTx.Rollback()
 
 	// First, verify the transaction record with row-level lock to prevent race conditions
+	// The latest submission of the UTR is the one verified; only that row is updated
+	var rowID int
 	var currentAmount sql.NullInt64
 	var verifiedAt sql.NullTime
+	var rejectedAt sql.NullTime
 	err = dbTx.QueryRow(`
-		SELECT amount, verified_at 
+		SELECT id, amount, verified_at, rejected_at 
 		FROM payment_transactions 
 		WHERE transaction_id = $1
+		ORDER BY submitted_at DESC
+		LIMIT 1
 		FOR UPDATE`,
 		transactionID,
-	).Scan(&currentAmount, &verifiedAt)
+	).Scan(&rowID, &currentAmount, &verifiedAt, &rejectedAt)
 
 	if err != nil {
 		return fmt.Errorf("transaction not found: %w", err)
 	}
 
+	if rejectedAt.Valid {
+		return fmt.Errorf("transaction was rejected on %s and cannot be verified", rejectedAt.Time.Format("Jan 2, 2006 3:04 PM"))
+	}
+
 	// Check if already verified (check both amount and verified_at for safety)
 	if currentAmount.Valid || verifiedAt.Valid {
 		amountStr := "not set"
@@ -426,28 +458,32 @@ func (r *PostgresPaymentRepository) ApplySmartAllocation(transactionID string, a
 		return fmt.Errorf("transaction already verified (amount: %s, verified_at: %s). Please refresh the page to see the updated status", amountStr, verifiedAtStr)
 	}
 
-	// Update transaction record
+	// Update transaction record (verifiedByUserID 0 = verified automatically, e.g. by a gateway webhook)
 	_, err = dbTx.Exec(`
 		UPDATE payment_transactions 
-		SET amount = $1, verified_at = $2, verified_by_user_id = $3
-		WHERE transaction_id = $4`,
-		amount, allocationTime, verifiedByUserID, transactionID,
+		SET amount = $1, verified_at = $2, verified_by_user_id = NULLIF($3, 0)
+		WHERE id = $4`,
+		amount, allocationTime, verifiedByUserID, rowID,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to update transaction record: %w", err)
 	}
 
 	// Apply allocations to each payment
+	balancesAfter := make(map[int]int, len(allocations))
+	paymentIDs := make([]int, 0, len(allocations))
 	for paymentID, allocAmount := range allocations {
+		paymentIDs = append(paymentIDs, paymentID)
 		// Get current payment state
 		var currentAmountPaid int
 		var paymentAmount int
+		var amountAdjusted int
 		err = dbTx.QueryRow(`
-			SELECT amount, amount_paid 
+			SELECT amount, amount_adjusted, amount_paid 
 			FROM payments 
 			WHERE id = $1`,
 			paymentID,
-		).Scan(&paymentAmount, &currentAmountPaid)
+		).Scan(&paymentAmount, &amountAdjusted, &currentAmountPaid)
 
 		if err != nil {
 			return fmt.Errorf("payment %d not found: %w", paymentID, err)
@@ -455,7 +491,7 @@ func (r *PostgresPaymentRepository) ApplySmartAllocation(transactionID string, a
 
 		// Calculate new values
 		newAmountPaid := currentAmountPaid + allocAmount
-		newRemainingBalance := paymentAmount - newAmountPaid
+		newRemainingBalance := paymentAmount - amountAdjusted - newAmountPaid
 		isFullyPaid := newRemainingBalance <= 0
 
 		// Update payment
@@ -474,6 +510,26 @@ func (r *PostgresPaymentRepository) ApplySmartAllocation(transactionID string, a
 		if err != nil {
 			return fmt.Errorf("failed to update payment %d: %w", paymentID, err)
 		}
+		balancesAfter[paymentID] = newRemainingBalance
+	}
+
+	// A paid installment may be the last one of its plan
+	if err = completeSettledInstallmentPlans(dbTx, paymentIDs, allocationTime); err != nil {
+		return err
+	}
+
+	// Credit the overpayment together with the verification, so one never happens without the other
+	if overpayment != nil {
+		if err = createCreditEntry(dbTx, overpayment); err != nil {
+			return err
+		}
+	}
+
+	for _, receipt := range receipts {
+		receipt.BalanceAfter = balancesAfter[receipt.PaymentID]
+		if err = createReceipt(dbTx, receipt); err != nil {
+			return err
+		}
 	}
 
 	// Commit all changes atomically
@@ -484,20 +540,44 @@ func (r *PostgresPaymentRepository) ApplySmartAllocation(transactionID string, a
 	return nil
 }
 
-// RejectTransaction deletes a pending transaction (rejects it)
-func (r *PostgresPaymentRepository) RejectTransaction(transactionID string) error {
-	// Check if transaction exists and is not verified
+// allocateToPayment pays an amount to a payment, inside a transaction when db is one
+// Fails if the payment no longer owes the amount
+func allocateToPayment(db execer, paymentID int, amount int, allocationTime time.Time) error {
+	result, err := db.Exec(`
+		UPDATE payments
+		SET amount_paid = amount_paid + $1,
+		    remaining_balance = remaining_balance - $1,
+		    is_fully_paid = (remaining_balance - $1 <= 0),
+		    fully_paid_date = CASE
+		        WHEN (remaining_balance - $1 <= 0) AND fully_paid_date IS NULL THEN $2
+		        ELSE fully_paid_date
+		    END
+		WHERE id = $3 AND remaining_balance >= $1`,
+		amount, allocationTime, paymentID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update payment %d: %w", paymentID, err)
+	}
+	return expectOneRow(result, fmt.Sprintf("payment %d no longer owes ₹%d; try again", paymentID, amount))
+}
+
+// RejectTransaction marks a pending transaction as rejected, keeping it with the reason
+func (r *PostgresPaymentRepository) RejectTransaction(transactionID string, reason string, rejectedByUserID int, rejectedAt time.Time) error {
+	// Check if transaction exists and is still pending (the latest submission of the UTR that was not rejected)
+	var rowID int
 	var verifiedAt sql.NullTime
 	err := r.db.QueryRow(`
-		SELECT verified_at 
+		SELECT id, verified_at
 		FROM payment_transactions 
-		WHERE transaction_id = $1`,
+		WHERE transaction_id = $1 AND rejected_at IS NULL
+		ORDER BY submitted_at DESC
+		LIMIT 1`,
 		transactionID,
-	).Scan(&verifiedAt)
+	).Scan(&rowID, &verifiedAt)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return fmt.Errorf("transaction not found")
+			return fmt.Errorf("transaction not found or already rejected")
 		}
 		return fmt.Errorf("failed to check transaction: %w", err)
 	}
@@ -507,17 +587,17 @@ func (r *PostgresPaymentRepository) RejectTransaction(transactionID string) erro
 		return fmt.Errorf("cannot reject already verified transaction")
 	}
 
-	// Delete the transaction
-	_, err = r.db.Exec(`
-		DELETE FROM payment_transactions 
-		WHERE transaction_id = $1`,
-		transactionID,
+	result, err := r.db.Exec(`
+		UPDATE payment_transactions 
+		SET rejected_at = $1, rejected_by_user_id = $2, rejection_reason = $3
+		WHERE id = $4 AND verified_at IS NULL AND rejected_at IS NULL`,
+		rejectedAt, rejectedByUserID, reason, rowID,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to reject transaction: %w", err)
 	}
 
-	return nil
+	return expectOneRow(result, "transaction was verified or rejected meanwhile")
 }
 
 // ============================================
@@ -527,7 +607,7 @@ func (r *PostgresPaymentRepository) RejectTransaction(transactionID string) erro
 // GetLatestPaymentByTenantID returns the latest payment for a tenant (by due date)
 func (r *PostgresPaymentRepository) GetLatestPaymentByTenantID(tenantID int) (*domain.Payment, error) {
 	query := `
-		SELECT id, tenant_id, unit_id, amount, amount_paid, remaining_balance, payment_date, 
+		SELECT id, tenant_id, unit_id, amount, amount_paid, amount_adjusted, remaining_balance, payment_date, 
 		       due_date, is_paid, is_fully_paid, fully_paid_date, payment_method, upi_id, notes, created_at
 		FROM payments
 		WHERE tenant_id = $1
@@ -544,6 +624,7 @@ func (r *PostgresPaymentRepository) GetLatestPaymentByTenantID(tenantID int) (*d
 		&payment.UnitID,
 		&payment.Amount,
 		&payment.AmountPaid,
+		&payment.AmountAdjusted,
 		&payment.RemainingBalance,
 		&paymentDate,
 		&payment.DueDate,
@@ -576,7 +657,7 @@ func (r *PostgresPaymentRepository) GetLatestPaymentByTenantID(tenantID int) (*d
 // GetUnpaidPaymentsByTenantID returns all unpaid payments for a tenant
 func (r *PostgresPaymentRepository) GetUnpaidPaymentsByTenantID(tenantID int) ([]*domain.Payment, error) {
 	query := `
-		SELECT id, tenant_id, unit_id, amount, amount_paid, remaining_balance, payment_date, 
+		SELECT id, tenant_id, unit_id, amount, amount_paid, amount_adjusted, remaining_balance, payment_date, 
 		       due_date, is_paid, is_fully_paid, fully_paid_date, payment_method, upi_id, notes, label, created_at
 		FROM payments
 		WHERE tenant_id = $1 AND is_fully_paid = FALSE
@@ -600,6 +681,7 @@ func (r *PostgresPaymentRepository) GetUnpaidPaymentsByTenantID(tenantID int) ([
 			&payment.UnitID,
 			&payment.Amount,
 			&payment.AmountPaid,
+			&payment.AmountAdjusted,
 			&payment.RemainingBalance,
 			&paymentDate,
 			&payment.DueDate,
@@ -633,19 +715,20 @@ func (r *PostgresPaymentRepository) GetUnpaidPaymentsByTenantID(tenantID int) ([
 	return payments, nil
 }
 
-// GetUnpaidPaymentsByDueDate returns all unpaid payments with a specific due date
+// GetUnpaidPaymentsByDueDate returns all unpaid payments of active tenants with a specific due date
 func (r *PostgresPaymentRepository) GetUnpaidPaymentsByDueDate(dueDate time.Time) ([]*domain.Payment, error) {
 	// Normalize due date to start of day for comparison
 	startOfDay := time.Date(dueDate.Year(), dueDate.Month(), dueDate.Day(), 0, 0, 0, 0, dueDate.Location())
 	endOfDay := startOfDay.Add(24 * time.Hour)
 
 	query := `
-		SELECT id, tenant_id, unit_id, amount, amount_paid, remaining_balance, payment_date, 
+		SELECT id, tenant_id, unit_id, amount, amount_paid, amount_adjusted, remaining_balance, payment_date, 
 		       due_date, is_paid, is_fully_paid, fully_paid_date, payment_method, upi_id, notes, label, created_at
 		FROM payments
 		WHERE is_fully_paid = FALSE 
 		  AND due_date >= $1 
 		  AND due_date < $2
+		  AND tenant_id IN (SELECT id FROM tenants WHERE status = 'active')
 		ORDER BY due_date ASC`
 
 	rows, err := r.db.Query(query, startOfDay, endOfDay)
@@ -666,6 +749,7 @@ func (r *PostgresPaymentRepository) GetUnpaidPaymentsByDueDate(dueDate time.Time
 			&payment.UnitID,
 			&payment.Amount,
 			&payment.AmountPaid,
+			&payment.AmountAdjusted,
 			&payment.RemainingBalance,
 			&paymentDate,
 			&payment.DueDate,
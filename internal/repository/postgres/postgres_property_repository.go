@@ -0,0 +1,126 @@
+package repository
+
+import (
+	domain "backend-form/m/internal/domain"
+	"backend-form/m/internal/repository/interfaces"
+	"database/sql"
+	"fmt"
+)
+
+// PostgresPropertyRepository implements PropertyRepository interface
+type PostgresPropertyRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresPropertyRepository creates a new PostgresPropertyRepository
+func NewPostgresPropertyRepository(db *sql.DB) interfaces.PropertyRepository {
+	return &PostgresPropertyRepository{db: db}
+}
+
+// CreateProperty creates a new property
+func (r *PostgresPropertyRepository) CreateProperty(property *domain.Property) error {
+	query := `
+		INSERT INTO properties (name, address)
+		VALUES ($1, $2)
+		RETURNING id, created_at`
+
+	err := r.db.QueryRow(query,
+		property.Name,
+		property.Address,
+	).Scan(&property.ID, &property.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create property: %w", err)
+	}
+
+	return nil
+}
+
+// GetPropertyByID returns a property by ID
+func (r *PostgresPropertyRepository) GetPropertyByID(id int) (*domain.Property, error) {
+	query := `
+		SELECT id, name, address, created_at
+		FROM properties
+		WHERE id = $1`
+
+	property := &domain.Property{}
+	err := r.db.QueryRow(query, id).Scan(
+		&property.ID,
+		&property.Name,
+		&property.Address,
+		&property.CreatedAt,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("property with ID %d not found", id)
+		}
+		return nil, fmt.Errorf("failed to get property: %w", err)
+	}
+
+	return property, nil
+}
+
+// GetAllProperties returns all properties
+func (r *PostgresPropertyRepository) GetAllProperties() ([]*domain.Property, error) {
+	query := `
+		SELECT id, name, address, created_at
+		FROM properties
+		ORDER BY name`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query properties: %w", err)
+	}
+	defer rows.Close()
+
+	var properties []*domain.Property
+	for rows.Next() {
+		property := &domain.Property{}
+		err := rows.Scan(
+			&property.ID,
+			&property.Name,
+			&property.Address,
+			&property.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan property: %w", err)
+		}
+		properties = append(properties, property)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating properties: %w", err)
+	}
+
+	return properties, nil
+}
+
+// UpdateProperty updates property information
+func (r *PostgresPropertyRepository) UpdateProperty(property *domain.Property) error {
+	query := `
+		UPDATE properties 
+		SET name = $1, address = $2
+		WHERE id = $3`
+
+	result, err := r.db.Exec(query,
+		property.Name,
+		property.Address,
+		property.ID,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update property: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("property with ID %d not found", property.ID)
+	}
+
+	return nil
+}
@@ -0,0 +1,142 @@
+package repository
+
+import (
+	domain "backend-form/m/internal/domain"
+	"backend-form/m/internal/repository/interfaces"
+	"database/sql"
+	"fmt"
+)
+
+// PostgresProrationRepository implements ProrationRepository interface
+type PostgresProrationRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresProrationRepository creates a new PostgresProrationRepository
+func NewPostgresProrationRepository(db *sql.DB) interfaces.ProrationRepository {
+	return &PostgresProrationRepository{db: db}
+}
+
+const prorationColumns = `id, payment_id, tenant_id, kind, policy, monthly_rent, period_start, period_end,
+	days_charged, days_in_month, prorated_amount, additional_amount, created_at`
+
+// scanProration scans a rent proration row
+func scanProration(row rowScanner) (*domain.RentProration, error) {
+	proration := &domain.RentProration{}
+	err := row.Scan(
+		&proration.ID,
+		&proration.PaymentID,
+		&proration.TenantID,
+		&proration.Kind,
+		&proration.Policy,
+		&proration.MonthlyRent,
+		&proration.PeriodStart,
+		&proration.PeriodEnd,
+		&proration.DaysCharged,
+		&proration.DaysInMonth,
+		&proration.ProratedAmount,
+		&proration.AdditionalAmount,
+		&proration.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return proration, nil
+}
+
+// CreateProratedPayment inserts a prorated rent payment and its proration breakdown in a single transaction
+func (r *PostgresProrationRepository) CreateProratedPayment(payment *domain.Payment, proration *domain.RentProration) error {
+	dbTx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback()
+
+	err = dbTx.QueryRow(`
+		INSERT INTO payments (tenant_id, unit_id, amount, amount_paid, remaining_balance, due_date,
+		                      is_paid, is_fully_paid, payment_method, upi_id, notes, label)
+		VALUES ($1, $2, $3, 0, $3, $4, FALSE, FALSE, $5, $6, $7, $8)
+		RETURNING id, created_at`,
+		payment.TenantID,
+		payment.UnitID,
+		payment.Amount,
+		payment.DueDate,
+		payment.PaymentMethod,
+		payment.UPIID,
+		payment.Notes,
+		payment.Label,
+	).Scan(&payment.ID, &payment.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create prorated payment: %w", err)
+	}
+	payment.RemainingBalance = payment.Amount
+
+	proration.PaymentID = payment.ID
+	err = dbTx.QueryRow(`
+		INSERT INTO rent_prorations (payment_id, tenant_id, kind, policy, monthly_rent, period_start, period_end,
+		                             days_charged, days_in_month, prorated_amount, additional_amount)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id, created_at`,
+		proration.PaymentID,
+		proration.TenantID,
+		proration.Kind,
+		proration.Policy,
+		proration.MonthlyRent,
+		proration.PeriodStart,
+		proration.PeriodEnd,
+		proration.DaysCharged,
+		proration.DaysInMonth,
+		proration.ProratedAmount,
+		proration.AdditionalAmount,
+	).Scan(&proration.ID, &proration.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create rent proration: %w", err)
+	}
+
+	if err = dbTx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit prorated payment: %w", err)
+	}
+
+	return nil
+}
+
+// GetProrationByPaymentID returns the proration breakdown of a payment, or nil if it was not prorated
+func (r *PostgresProrationRepository) GetProrationByPaymentID(paymentID int) (*domain.RentProration, error) {
+	query := `SELECT ` + prorationColumns + ` FROM rent_prorations WHERE payment_id = $1`
+
+	proration, err := scanProration(r.db.QueryRow(query, paymentID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // Payment charged a full month
+		}
+		return nil, fmt.Errorf("failed to get rent proration: %w", err)
+	}
+
+	return proration, nil
+}
+
+// GetProrationsByTenantID returns all proration breakdowns of a tenant
+func (r *PostgresProrationRepository) GetProrationsByTenantID(tenantID int) ([]*domain.RentProration, error) {
+	query := `SELECT ` + prorationColumns + ` FROM rent_prorations WHERE tenant_id = $1 ORDER BY period_start`
+
+	rows, err := r.db.Query(query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query rent prorations: %w", err)
+	}
+	defer rows.Close()
+
+	var prorations []*domain.RentProration
+	for rows.Next() {
+		proration, err := scanProration(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan rent proration: %w", err)
+		}
+		prorations = append(prorations, proration)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rent prorations: %w", err)
+	}
+
+	return prorations, nil
+}
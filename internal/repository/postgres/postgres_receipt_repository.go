@@ -0,0 +1,235 @@
+package repository
+
+import (
+	domain "backend-form/m/internal/domain"
+	"backend-form/m/internal/repository/interfaces"
+	"database/sql"
+	"fmt"
+)
+
+// PostgresReceiptRepository implements ReceiptRepository interface
+type PostgresReceiptRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresReceiptRepository creates a new PostgresReceiptRepository
+func NewPostgresReceiptRepository(db *sql.DB) interfaces.ReceiptRepository {
+	return &PostgresReceiptRepository{db: db}
+}
+
+const receiptColumns = `id, receipt_number, document_type, financial_year, transaction_id, payment_id, tenant_id,
+	tenant_name, unit_code, property_name, property_address, payee_name, payee_upi_id, label, label_name, period,
+	amount, balance_after, verified_at, original_receipt_id, original_receipt_number, reason, issued_by_user_id, issued_at`
+
+// scanReceipt scans a receipt row selected with receiptColumns
+func scanReceipt(row rowScanner) (*domain.Receipt, error) {
+	receipt := &domain.Receipt{}
+	var originalReceiptID sql.NullInt64
+	var issuedByUserID sql.NullInt64
+	err := row.Scan(
+		&receipt.ID,
+		&receipt.ReceiptNumber,
+		&receipt.DocumentType,
+		&receipt.FinancialYear,
+		&receipt.TransactionID,
+		&receipt.PaymentID,
+		&receipt.TenantID,
+		&receipt.TenantName,
+		&receipt.UnitCode,
+		&receipt.PropertyName,
+		&receipt.PropertyAddress,
+		&receipt.PayeeName,
+		&receipt.PayeeUPIID,
+		&receipt.Label,
+		&receipt.LabelName,
+		&receipt.Period,
+		&receipt.Amount,
+		&receipt.BalanceAfter,
+		&receipt.VerifiedAt,
+		&originalReceiptID,
+		&receipt.OriginalReceiptNumber,
+		&receipt.Reason,
+		&issuedByUserID,
+		&receipt.IssuedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if originalReceiptID.Valid {
+		id := int(originalReceiptID.Int64)
+		receipt.OriginalReceiptID = &id
+	}
+	if issuedByUserID.Valid {
+		id := int(issuedByUserID.Int64)
+		receipt.IssuedByUserID = &id
+	}
+	return receipt, nil
+}
+
+// queryReceipts runs a query selecting receiptColumns and scans all rows
+func (r *PostgresReceiptRepository) queryReceipts(query string, args ...interface{}) ([]*domain.Receipt, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query receipts: %w", err)
+	}
+	defer rows.Close()
+
+	var receipts []*domain.Receipt
+	for rows.Next() {
+		receipt, err := scanReceipt(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan receipt: %w", err)
+		}
+		receipts = append(receipts, receipt)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating receipts: %w", err)
+	}
+
+	return receipts, nil
+}
+
+// createReceipt issues a receipt or credit note with the next number in its series
+// It runs inside the caller's transaction, so numbers are never skipped or reused
+func createReceipt(db execer, receipt *domain.Receipt) error {
+	var sequence int
+	err := db.QueryRow(`
+		INSERT INTO receipt_sequences (document_type, financial_year, last_number)
+		VALUES ($1, $2, 1)
+		ON CONFLICT (document_type, financial_year)
+		DO UPDATE SET last_number = receipt_sequences.last_number + 1
+		RETURNING last_number`,
+		receipt.DocumentType, receipt.FinancialYear,
+	).Scan(&sequence)
+	if err != nil {
+		return fmt.Errorf("failed to allocate receipt number: %w", err)
+	}
+	receipt.ReceiptNumber = domain.FormatReceiptNumber(receipt.DocumentType, receipt.FinancialYear, sequence)
+
+	err = db.QueryRow(`
+		INSERT INTO receipts (receipt_number, document_type, financial_year, transaction_id, payment_id, tenant_id,
+			tenant_name, unit_code, property_name, property_address, payee_name, payee_upi_id, label, label_name, period,
+			amount, balance_after, verified_at, original_receipt_id, original_receipt_number, reason, issued_by_user_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22)
+		RETURNING id, issued_at`,
+		receipt.ReceiptNumber,
+		receipt.DocumentType,
+		receipt.FinancialYear,
+		receipt.TransactionID,
+		receipt.PaymentID,
+		receipt.TenantID,
+		receipt.TenantName,
+		receipt.UnitCode,
+		receipt.PropertyName,
+		receipt.PropertyAddress,
+		receipt.PayeeName,
+		receipt.PayeeUPIID,
+		receipt.Label,
+		receipt.LabelName,
+		receipt.Period,
+		receipt.Amount,
+		receipt.BalanceAfter,
+		receipt.VerifiedAt,
+		receipt.OriginalReceiptID,
+		receipt.OriginalReceiptNumber,
+		receipt.Reason,
+		receipt.IssuedByUserID,
+	).Scan(&receipt.ID, &receipt.IssuedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create receipt: %w", err)
+	}
+
+	return nil
+}
+
+// CreateCreditNote issues a credit note and takes its amount off what was paid towards the payment
+// In a single transaction: the original receipt is locked so concurrent credit notes cannot exceed it, and the
+// payment's balance is reopened, clearing its paid flags and fully paid date. BalanceAfter is filled in.
+func (r *PostgresReceiptRepository) CreateCreditNote(note *domain.Receipt) error {
+	dbTx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback()
+
+	var receiptAmount, alreadyCredited int
+	err = dbTx.QueryRow(`SELECT amount FROM receipts WHERE id = $1 FOR UPDATE`, note.OriginalReceiptID).Scan(&receiptAmount)
+	if err != nil {
+		return fmt.Errorf("failed to lock receipt %s: %w", note.OriginalReceiptNumber, err)
+	}
+	err = dbTx.QueryRow(`SELECT COALESCE(SUM(amount), 0) FROM receipts WHERE original_receipt_id = $1`, note.OriginalReceiptID).Scan(&alreadyCredited)
+	if err != nil {
+		return fmt.Errorf("failed to get credited amount: %w", err)
+	}
+	if remaining := receiptAmount - alreadyCredited; note.Amount > remaining {
+		return fmt.Errorf("credit of ₹%d exceeds the ₹%d left on receipt %s", note.Amount, remaining, note.OriginalReceiptNumber)
+	}
+
+	err = dbTx.QueryRow(`
+		UPDATE payments
+		SET amount_paid = amount_paid - $1,
+		    remaining_balance = remaining_balance + $1,
+		    is_fully_paid = (remaining_balance + $1 <= 0),
+		    is_paid = CASE WHEN remaining_balance + $1 > 0 THEN FALSE ELSE is_paid END,
+		    fully_paid_date = CASE WHEN remaining_balance + $1 > 0 THEN NULL ELSE fully_paid_date END
+		WHERE id = $2 AND amount_paid >= $1
+		RETURNING remaining_balance`,
+		note.Amount, note.PaymentID,
+	).Scan(&note.BalanceAfter)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("credit of ₹%d exceeds what was paid towards payment %d", note.Amount, note.PaymentID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update payment %d: %w", note.PaymentID, err)
+	}
+
+	if err = createReceipt(dbTx, note); err != nil {
+		return err
+	}
+
+	if err = dbTx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit credit note: %w", err)
+	}
+
+	return nil
+}
+
+// GetReceiptByID returns a receipt or credit note by ID
+func (r *PostgresReceiptRepository) GetReceiptByID(id int) (*domain.Receipt, error) {
+	query := `SELECT ` + receiptColumns + ` FROM receipts WHERE id = $1`
+
+	receipt, err := scanReceipt(r.db.QueryRow(query, id))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("receipt not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get receipt: %w", err)
+	}
+
+	return receipt, nil
+}
+
+// GetReceiptsByTenantID returns all receipts and credit notes of a tenant, newest first
+func (r *PostgresReceiptRepository) GetReceiptsByTenantID(tenantID int) ([]*domain.Receipt, error) {
+	return r.queryReceipts(`SELECT `+receiptColumns+`
+		FROM receipts
+		WHERE tenant_id = $1
+		ORDER BY issued_at DESC, id DESC`, tenantID)
+}
+
+// GetReceiptsByPaymentID returns all receipts and credit notes of a payment, oldest first
+func (r *PostgresReceiptRepository) GetReceiptsByPaymentID(paymentID int) ([]*domain.Receipt, error) {
+	return r.queryReceipts(`SELECT `+receiptColumns+`
+		FROM receipts
+		WHERE payment_id = $1
+		ORDER BY issued_at, id`, paymentID)
+}
+
+// GetCreditNotesByReceiptID returns the credit notes issued against a receipt
+func (r *PostgresReceiptRepository) GetCreditNotesByReceiptID(receiptID int) ([]*domain.Receipt, error) {
+	return r.queryReceipts(`SELECT `+receiptColumns+`
+		FROM receipts
+		WHERE original_receipt_id = $1
+		ORDER BY issued_at, id`, receiptID)
+}
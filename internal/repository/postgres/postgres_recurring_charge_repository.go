@@ -0,0 +1,238 @@
+package repository
+
+import (
+	domain "backend-form/m/internal/domain"
+	"backend-form/m/internal/repository/interfaces"
+	"database/sql"
+	"fmt"
+)
+
+// PostgresRecurringChargeRepository implements RecurringChargeRepository interface
+type PostgresRecurringChargeRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresRecurringChargeRepository creates a new PostgresRecurringChargeRepository
+func NewPostgresRecurringChargeRepository(db *sql.DB) interfaces.RecurringChargeRepository {
+	return &PostgresRecurringChargeRepository{db: db}
+}
+
+const recurringChargeColumns = `id, tenant_id, unit_id, category_code, amount, due_day, start_date, end_date, is_active, notes, created_at, updated_at`
+
+// scanRecurringCharge scans a recurring charge row
+func scanRecurringCharge(row rowScanner) (*domain.RecurringCharge, error) {
+	charge := &domain.RecurringCharge{}
+	var tenantID, unitID sql.NullInt64
+	var endDate sql.NullTime
+	err := row.Scan(
+		&charge.ID,
+		&tenantID,
+		&unitID,
+		&charge.CategoryCode,
+		&charge.Amount,
+		&charge.DueDay,
+		&charge.StartDate,
+		&endDate,
+		&charge.IsActive,
+		&charge.Notes,
+		&charge.CreatedAt,
+		&charge.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if tenantID.Valid {
+		id := int(tenantID.Int64)
+		charge.TenantID = &id
+	}
+	if unitID.Valid {
+		id := int(unitID.Int64)
+		charge.UnitID = &id
+	}
+	if endDate.Valid {
+		charge.EndDate = &endDate.Time
+	}
+	return charge, nil
+}
+
+// queryRecurringCharges runs a query returning recurring charge rows
+func (r *PostgresRecurringChargeRepository) queryRecurringCharges(query string, args ...interface{}) ([]*domain.RecurringCharge, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query recurring charges: %w", err)
+	}
+	defer rows.Close()
+
+	var charges []*domain.RecurringCharge
+	for rows.Next() {
+		charge, err := scanRecurringCharge(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan recurring charge: %w", err)
+		}
+		charges = append(charges, charge)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating recurring charges: %w", err)
+	}
+
+	return charges, nil
+}
+
+// CreateRecurringCharge creates a new recurring charge schedule
+func (r *PostgresRecurringChargeRepository) CreateRecurringCharge(charge *domain.RecurringCharge) error {
+	query := `
+		INSERT INTO recurring_charges (tenant_id, unit_id, category_code, amount, due_day, start_date, end_date, is_active, notes)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id, created_at, updated_at`
+
+	err := r.db.QueryRow(query,
+		charge.TenantID,
+		charge.UnitID,
+		charge.CategoryCode,
+		charge.Amount,
+		charge.DueDay,
+		charge.StartDate,
+		charge.EndDate,
+		charge.IsActive,
+		charge.Notes,
+	).Scan(&charge.ID, &charge.CreatedAt, &charge.UpdatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create recurring charge: %w", err)
+	}
+
+	return nil
+}
+
+// GetRecurringChargeByID returns a recurring charge schedule by ID
+func (r *PostgresRecurringChargeRepository) GetRecurringChargeByID(id int) (*domain.RecurringCharge, error) {
+	query := `SELECT ` + recurringChargeColumns + ` FROM recurring_charges WHERE id = $1`
+
+	charge, err := scanRecurringCharge(r.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("recurring charge with ID %d not found", id)
+		}
+		return nil, fmt.Errorf("failed to get recurring charge: %w", err)
+	}
+
+	return charge, nil
+}
+
+// GetRecurringChargesForTenant returns the tenant's own schedules and the schedules of their unit
+func (r *PostgresRecurringChargeRepository) GetRecurringChargesForTenant(tenantID, unitID int) ([]*domain.RecurringCharge, error) {
+	query := `SELECT ` + recurringChargeColumns + ` FROM recurring_charges
+		WHERE tenant_id = $1 OR unit_id = $2
+		ORDER BY category_code, tenant_id NULLS LAST, id`
+
+	return r.queryRecurringCharges(query, tenantID, unitID)
+}
+
+// GetAllRecurringCharges returns all recurring charge schedules
+func (r *PostgresRecurringChargeRepository) GetAllRecurringCharges() ([]*domain.RecurringCharge, error) {
+	query := `SELECT ` + recurringChargeColumns + ` FROM recurring_charges ORDER BY unit_id NULLS LAST, tenant_id, category_code`
+
+	return r.queryRecurringCharges(query)
+}
+
+// UpdateRecurringCharge updates a recurring charge schedule
+func (r *PostgresRecurringChargeRepository) UpdateRecurringCharge(charge *domain.RecurringCharge) error {
+	query := `
+		UPDATE recurring_charges 
+		SET due_day = $1, start_date = $2, end_date = $3, is_active = $4, notes = $5, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $6`
+
+	result, err := r.db.Exec(query,
+		charge.DueDay,
+		charge.StartDate,
+		charge.EndDate,
+		charge.IsActive,
+		charge.Notes,
+		charge.ID,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update recurring charge: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("recurring charge with ID %d not found", charge.ID)
+	}
+
+	return nil
+}
+
+// DeleteRecurringCharge deletes a recurring charge schedule (payments already generated are kept)
+func (r *PostgresRecurringChargeRepository) DeleteRecurringCharge(id int) error {
+	result, err := r.db.Exec(`DELETE FROM recurring_charges WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete recurring charge: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("recurring charge with ID %d not found", id)
+	}
+
+	return nil
+}
+
+// CreateAmountChange records a new amount for a schedule from a given month
+func (r *PostgresRecurringChargeRepository) CreateAmountChange(change *domain.RecurringChargeAmountChange) error {
+	query := `
+		INSERT INTO recurring_charge_amounts (recurring_charge_id, amount, effective_from)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at`
+
+	err := r.db.QueryRow(query,
+		change.RecurringChargeID,
+		change.Amount,
+		change.EffectiveFrom,
+	).Scan(&change.ID, &change.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create recurring charge amount change: %w", err)
+	}
+
+	return nil
+}
+
+// GetAmountChangesByChargeID returns the amount changes of a schedule in effective order
+func (r *PostgresRecurringChargeRepository) GetAmountChangesByChargeID(chargeID int) ([]*domain.RecurringChargeAmountChange, error) {
+	query := `
+		SELECT id, recurring_charge_id, amount, effective_from, created_at
+		FROM recurring_charge_amounts
+		WHERE recurring_charge_id = $1
+		ORDER BY effective_from, id`
+
+	rows, err := r.db.Query(query, chargeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query recurring charge amount changes: %w", err)
+	}
+	defer rows.Close()
+
+	var changes []*domain.RecurringChargeAmountChange
+	for rows.Next() {
+		change := &domain.RecurringChargeAmountChange{}
+		if err := rows.Scan(&change.ID, &change.RecurringChargeID, &change.Amount, &change.EffectiveFrom, &change.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan recurring charge amount change: %w", err)
+		}
+		changes = append(changes, change)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating recurring charge amount changes: %w", err)
+	}
+
+	return changes, nil
+}
@@ -0,0 +1,185 @@
+package repository
+
+import (
+	domain "backend-form/m/internal/domain"
+	"backend-form/m/internal/repository/interfaces"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// PostgresTelegramLinkRepository implements TelegramLinkRepository interface
+type PostgresTelegramLinkRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresTelegramLinkRepository creates a new PostgresTelegramLinkRepository
+func NewPostgresTelegramLinkRepository(db *sql.DB) interfaces.TelegramLinkRepository {
+	return &PostgresTelegramLinkRepository{db: db}
+}
+
+const telegramLinkColumns = `tenant_id, chat_id, telegram_username, linked_at, failure_count, last_error, last_failure_at`
+
+// scanTelegramLink scans a tenant Telegram link row
+func scanTelegramLink(row rowScanner) (*domain.TelegramLink, error) {
+	link := &domain.TelegramLink{}
+	var username, lastError sql.NullString
+	var lastFailureAt sql.NullTime
+	err := row.Scan(
+		&link.TenantID,
+		&link.ChatID,
+		&username,
+		&link.LinkedAt,
+		&link.FailureCount,
+		&lastError,
+		&lastFailureAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	link.TelegramUsername = username.String
+	link.LastError = lastError.String
+	if lastFailureAt.Valid {
+		link.LastFailureAt = &lastFailureAt.Time
+	}
+	return link, nil
+}
+
+// CreateLinkToken stores a new one-time link token
+func (r *PostgresTelegramLinkRepository) CreateLinkToken(token *domain.TelegramLinkToken) error {
+	query := `
+		INSERT INTO telegram_link_tokens (token, tenant_id, expires_at, created_at)
+		VALUES ($1, $2, $3, $4)`
+
+	if _, err := r.db.Exec(query, token.Token, token.TenantID, token.ExpiresAt, token.CreatedAt); err != nil {
+		return fmt.Errorf("failed to create telegram link token: %w", err)
+	}
+
+	return nil
+}
+
+// ConsumeLinkToken marks a token as used and returns it
+// The update is conditional so a token can only ever link one chat, even if the bot delivers the update twice
+func (r *PostgresTelegramLinkRepository) ConsumeLinkToken(token string, now time.Time) (*domain.TelegramLinkToken, error) {
+	query := `
+		UPDATE telegram_link_tokens
+		SET used_at = $2
+		WHERE token = $1 AND used_at IS NULL AND expires_at > $2
+		RETURNING token, tenant_id, expires_at, used_at, created_at`
+
+	linkToken := &domain.TelegramLinkToken{}
+	var usedAt sql.NullTime
+	err := r.db.QueryRow(query, token, now).Scan(
+		&linkToken.Token,
+		&linkToken.TenantID,
+		&linkToken.ExpiresAt,
+		&usedAt,
+		&linkToken.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // Unknown, already used or expired
+		}
+		return nil, fmt.Errorf("failed to consume telegram link token: %w", err)
+	}
+	if usedAt.Valid {
+		linkToken.UsedAt = &usedAt.Time
+	}
+
+	return linkToken, nil
+}
+
+// SaveLink creates the tenant's link or replaces the chat it points to
+// Relinking resets the failure tracking
+func (r *PostgresTelegramLinkRepository) SaveLink(link *domain.TelegramLink) error {
+	query := `
+		INSERT INTO tenant_telegram_links (tenant_id, chat_id, telegram_username, linked_at, failure_count, last_error, last_failure_at)
+		VALUES ($1, $2, NULLIF($3, ''), $4, 0, NULL, NULL)
+		ON CONFLICT (tenant_id) DO UPDATE SET
+			chat_id = EXCLUDED.chat_id,
+			telegram_username = EXCLUDED.telegram_username,
+			linked_at = EXCLUDED.linked_at,
+			failure_count = 0,
+			last_error = NULL,
+			last_failure_at = NULL`
+
+	_, err := r.db.Exec(query, link.TenantID, link.ChatID, link.TelegramUsername, link.LinkedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save telegram link: %w", err)
+	}
+
+	link.FailureCount = 0
+	link.LastError = ""
+	link.LastFailureAt = nil
+	return nil
+}
+
+// GetLinkByTenantID returns the tenant's linked chat, or nil if there is none
+func (r *PostgresTelegramLinkRepository) GetLinkByTenantID(tenantID int) (*domain.TelegramLink, error) {
+	query := `SELECT ` + telegramLinkColumns + ` FROM tenant_telegram_links WHERE tenant_id = $1`
+
+	link, err := scanTelegramLink(r.db.QueryRow(query, tenantID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // Tenant has not linked Telegram
+		}
+		return nil, fmt.Errorf("failed to get telegram link: %w", err)
+	}
+
+	return link, nil
+}
+
+// GetLinksByChatID returns the tenants linked to a chat (one person may rent several units)
+func (r *PostgresTelegramLinkRepository) GetLinksByChatID(chatID string) ([]*domain.TelegramLink, error) {
+	query := `SELECT ` + telegramLinkColumns + ` FROM tenant_telegram_links WHERE chat_id = $1 ORDER BY tenant_id`
+
+	rows, err := r.db.Query(query, chatID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query telegram links: %w", err)
+	}
+	defer rows.Close()
+
+	var links []*domain.TelegramLink
+	for rows.Next() {
+		link, err := scanTelegramLink(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan telegram link: %w", err)
+		}
+		links = append(links, link)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating telegram links: %w", err)
+	}
+
+	return links, nil
+}
+
+// UpdateLinkDelivery persists the failure tracking of a link
+func (r *PostgresTelegramLinkRepository) UpdateLinkDelivery(link *domain.TelegramLink) error {
+	query := `
+		UPDATE tenant_telegram_links
+		SET failure_count = $1, last_error = NULLIF($2, ''), last_failure_at = $3
+		WHERE tenant_id = $4`
+
+	var lastFailureAt sql.NullTime
+	if link.LastFailureAt != nil {
+		lastFailureAt = sql.NullTime{Time: *link.LastFailureAt, Valid: true}
+	}
+
+	_, err := r.db.Exec(query, link.FailureCount, link.LastError, lastFailureAt, link.TenantID)
+	if err != nil {
+		return fmt.Errorf("failed to update telegram link: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteLink removes the tenant's linked chat
+func (r *PostgresTelegramLinkRepository) DeleteLink(tenantID int) error {
+	if _, err := r.db.Exec(`DELETE FROM tenant_telegram_links WHERE tenant_id = $1`, tenantID); err != nil {
+		return fmt.Errorf("failed to delete telegram link: %w", err)
+	}
+
+	return nil
+}
@@ -20,17 +20,24 @@ func NewPostgresTenantRepository(db *sql.DB) interfaces.TenantRepository {
 // CreateTenant creates a new tenant
 func (r *PostgresTenantRepository) CreateTenant(tenant *domain.Tenant) error {
 	query := `
-		INSERT INTO tenants (name, phone, aadhar_number, move_in_date, number_of_people, unit_id)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO tenants (name, phone, email, aadhar_number, move_in_date, number_of_people, unit_id, status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 		RETURNING id, created_at`
 
+	// New tenants are always active
+	if tenant.Status == "" {
+		tenant.Status = domain.TenantStatusActive
+	}
+
 	err := r.db.QueryRow(query,
 		tenant.Name,
 		tenant.Phone,
+		tenant.Email,
 		tenant.AadharNumber,
 		tenant.MoveInDate,
 		tenant.NumberOfPeople,
 		tenant.UnitID,
+		tenant.Status,
 	).Scan(&tenant.ID, &tenant.CreatedAt)
 
 	if err != nil {
@@ -43,19 +50,23 @@ func (r *PostgresTenantRepository) CreateTenant(tenant *domain.Tenant) error {
 // GetTenantByID returns a tenant by ID
 func (r *PostgresTenantRepository) GetTenantByID(id int) (*domain.Tenant, error) {
 	query := `
-		SELECT id, name, phone, aadhar_number, move_in_date, number_of_people, unit_id, created_at
+		SELECT id, name, phone, email, aadhar_number, move_in_date, number_of_people, unit_id, status, move_out_date, created_at
 		FROM tenants
 		WHERE id = $1`
 
 	tenant := &domain.Tenant{}
+	var moveOutDate sql.NullTime
 	err := r.db.QueryRow(query, id).Scan(
 		&tenant.ID,
 		&tenant.Name,
 		&tenant.Phone,
+		&tenant.Email,
 		&tenant.AadharNumber,
 		&tenant.MoveInDate,
 		&tenant.NumberOfPeople,
 		&tenant.UnitID,
+		&tenant.Status,
+		&moveOutDate,
 		&tenant.CreatedAt,
 	)
 
@@ -66,14 +77,19 @@ func (r *PostgresTenantRepository) GetTenantByID(id int) (*domain.Tenant, error)
 		return nil, fmt.Errorf("failed to get tenant: %w", err)
 	}
 
+	if moveOutDate.Valid {
+		tenant.MoveOutDate = &moveOutDate.Time
+	}
+
 	return tenant, nil
 }
 
-// GetAllTenants returns all tenants
+// GetAllTenants returns all active tenants (archived tenants are excluded)
 func (r *PostgresTenantRepository) GetAllTenants() ([]*domain.Tenant, error) {
 	query := `
-		SELECT id, name, phone, aadhar_number, move_in_date, number_of_people, unit_id, created_at
+		SELECT id, name, phone, email, aadhar_number, move_in_date, number_of_people, unit_id, status, move_out_date, created_at
 		FROM tenants
+		WHERE status = 'active'
 		ORDER BY name`
 
 	rows, err := r.db.Query(query)
@@ -85,19 +101,26 @@ func (r *PostgresTenantRepository) GetAllTenants() ([]*domain.Tenant, error) {
 	var tenants []*domain.Tenant
 	for rows.Next() {
 		tenant := &domain.Tenant{}
+		var moveOutDate sql.NullTime
 		err := rows.Scan(
 			&tenant.ID,
 			&tenant.Name,
 			&tenant.Phone,
+			&tenant.Email,
 			&tenant.AadharNumber,
 			&tenant.MoveInDate,
 			&tenant.NumberOfPeople,
 			&tenant.UnitID,
+			&tenant.Status,
+			&moveOutDate,
 			&tenant.CreatedAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan tenant: %w", err)
 		}
+		if moveOutDate.Valid {
+			tenant.MoveOutDate = &moveOutDate.Time
+		}
 		tenants = append(tenants, tenant)
 	}
 
@@ -112,13 +135,14 @@ func (r *PostgresTenantRepository) GetAllTenants() ([]*domain.Tenant, error) {
 func (r *PostgresTenantRepository) UpdateTenant(tenant *domain.Tenant) error {
 	query := `
 		UPDATE tenants 
-		SET name = $1, phone = $2, aadhar_number = $3, move_in_date = $4, 
-		    number_of_people = $5, unit_id = $6
-		WHERE id = $7`
+		SET name = $1, phone = $2, email = $3, aadhar_number = $4, move_in_date = $5, 
+		    number_of_people = $6, unit_id = $7
+		WHERE id = $8`
 
 	result, err := r.db.Exec(query,
 		tenant.Name,
 		tenant.Phone,
+		tenant.Email,
 		tenant.AadharNumber,
 		tenant.MoveInDate,
 		tenant.NumberOfPeople,
@@ -163,12 +187,12 @@ func (r *PostgresTenantRepository) DeleteTenant(id int) error {
 	return nil
 }
 
-// GetTenantsByUnitID returns tenants for a specific unit
+// GetTenantsByUnitID returns active tenants for a specific unit
 func (r *PostgresTenantRepository) GetTenantsByUnitID(unitID int) ([]*domain.Tenant, error) {
 	query := `
-		SELECT id, name, phone, aadhar_number, move_in_date, number_of_people, unit_id, created_at
+		SELECT id, name, phone, email, aadhar_number, move_in_date, number_of_people, unit_id, status, move_out_date, created_at
 		FROM tenants
-		WHERE unit_id = $1
+		WHERE unit_id = $1 AND status = 'active'
 		ORDER BY name`
 
 	rows, err := r.db.Query(query, unitID)
@@ -180,19 +204,74 @@ func (r *PostgresTenantRepository) GetTenantsByUnitID(unitID int) ([]*domain.Ten
 	var tenants []*domain.Tenant
 	for rows.Next() {
 		tenant := &domain.Tenant{}
+		var moveOutDate sql.NullTime
+		err := rows.Scan(
+			&tenant.ID,
+			&tenant.Name,
+			&tenant.Phone,
+			&tenant.Email,
+			&tenant.AadharNumber,
+			&tenant.MoveInDate,
+			&tenant.NumberOfPeople,
+			&tenant.UnitID,
+			&tenant.Status,
+			&moveOutDate,
+			&tenant.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan tenant: %w", err)
+		}
+		if moveOutDate.Valid {
+			tenant.MoveOutDate = &moveOutDate.Time
+		}
+		tenants = append(tenants, tenant)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating tenants: %w", err)
+	}
+
+	return tenants, nil
+}
+
+// GetTenantsByPropertyID returns active tenants living in units of a specific property
+func (r *PostgresTenantRepository) GetTenantsByPropertyID(propertyID int) ([]*domain.Tenant, error) {
+	query := `
+		SELECT t.id, t.name, t.phone, t.email, t.aadhar_number, t.move_in_date, t.number_of_people, t.unit_id, t.status, t.move_out_date, t.created_at
+		FROM tenants t
+		INNER JOIN units u ON t.unit_id = u.id
+		WHERE u.property_id = $1 AND t.status = 'active'
+		ORDER BY t.name`
+
+	rows, err := r.db.Query(query, propertyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tenants by property: %w", err)
+	}
+	defer rows.Close()
+
+	var tenants []*domain.Tenant
+	for rows.Next() {
+		tenant := &domain.Tenant{}
+		var moveOutDate sql.NullTime
 		err := rows.Scan(
 			&tenant.ID,
 			&tenant.Name,
 			&tenant.Phone,
+			&tenant.Email,
 			&tenant.AadharNumber,
 			&tenant.MoveInDate,
 			&tenant.NumberOfPeople,
 			&tenant.UnitID,
+			&tenant.Status,
+			&moveOutDate,
 			&tenant.CreatedAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan tenant: %w", err)
 		}
+		if moveOutDate.Valid {
+			tenant.MoveOutDate = &moveOutDate.Time
+		}
 		tenants = append(tenants, tenant)
 	}
 
@@ -203,6 +282,184 @@ func (r *PostgresTenantRepository) GetTenantsByUnitID(unitID int) ([]*domain.Ten
 	return tenants, nil
 }
 
+// GetArchivedTenants returns all tenants that have moved out, most recent first
+func (r *PostgresTenantRepository) GetArchivedTenants() ([]*domain.Tenant, error) {
+	query := `
+		SELECT id, name, phone, email, aadhar_number, move_in_date, number_of_people, unit_id, status, move_out_date, created_at
+		FROM tenants
+		WHERE status = 'archived'
+		ORDER BY move_out_date DESC, name`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query archived tenants: %w", err)
+	}
+	defer rows.Close()
+
+	var tenants []*domain.Tenant
+	for rows.Next() {
+		tenant := &domain.Tenant{}
+		var moveOutDate sql.NullTime
+		err := rows.Scan(
+			&tenant.ID,
+			&tenant.Name,
+			&tenant.Phone,
+			&tenant.Email,
+			&tenant.AadharNumber,
+			&tenant.MoveInDate,
+			&tenant.NumberOfPeople,
+			&tenant.UnitID,
+			&tenant.Status,
+			&moveOutDate,
+			&tenant.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan tenant: %w", err)
+		}
+		if moveOutDate.Valid {
+			tenant.MoveOutDate = &moveOutDate.Time
+		}
+		tenants = append(tenants, tenant)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating archived tenants: %w", err)
+	}
+
+	return tenants, nil
+}
+
+// MoveOutTenant archives the tenant and saves the final proration, deposit settlement, lease end
+// and unit occupancy of a move-out in a single transaction
+// Payments, transactions, notifications and family members are left untouched
+func (r *PostgresTenantRepository) MoveOutTenant(moveOut *domain.MoveOut) error {
+	dbTx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback()
+
+	result, err := dbTx.Exec(`UPDATE tenants SET status = 'archived', move_out_date = $1 WHERE id = $2 AND status = 'active'`,
+		moveOut.MoveOutDate, moveOut.TenantID)
+	if err != nil {
+		return fmt.Errorf("failed to archive tenant: %w", err)
+	}
+	if err := expectOneRow(result, fmt.Sprintf("active tenant with ID %d not found", moveOut.TenantID)); err != nil {
+		return err
+	}
+
+	// Final-month proration; the payment must not have been paid or adjusted since it was prorated
+	if proration, payment := moveOut.FinalProration, moveOut.FinalPayment; proration != nil && payment != nil {
+		result, err = dbTx.Exec(`
+			UPDATE payments
+			SET amount = $1, remaining_balance = $2, is_fully_paid = $3, fully_paid_date = $4, notes = $5
+			WHERE id = $6 AND amount_paid = $7 AND amount_adjusted = $8`,
+			payment.Amount,
+			payment.RemainingBalance,
+			payment.IsFullyPaid,
+			payment.FullyPaidDate,
+			payment.Notes,
+			payment.ID,
+			payment.AmountPaid,
+			payment.AmountAdjusted,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to update prorated payment: %w", err)
+		}
+		if err := expectOneRow(result, "final rent payment changed while moving out; try again"); err != nil {
+			return err
+		}
+
+		err = dbTx.QueryRow(`
+			INSERT INTO rent_prorations (payment_id, tenant_id, kind, policy, monthly_rent, period_start, period_end,
+			                             days_charged, days_in_month, prorated_amount, additional_amount)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+			RETURNING id, created_at`,
+			proration.PaymentID,
+			proration.TenantID,
+			proration.Kind,
+			proration.Policy,
+			proration.MonthlyRent,
+			proration.PeriodStart,
+			proration.PeriodEnd,
+			proration.DaysCharged,
+			proration.DaysInMonth,
+			proration.ProratedAmount,
+			proration.AdditionalAmount,
+		).Scan(&proration.ID, &proration.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to create rent proration: %w", err)
+		}
+	}
+
+	// Deposit settlement: new ledger entries, arrears paid from the deposit and the settled status
+	if deposit := moveOut.Deposit; deposit != nil {
+		result, err = dbTx.Exec(`UPDATE security_deposits SET status = $1, settled_at = $2 WHERE id = $3 AND status <> 'settled'`,
+			deposit.Status, deposit.SettledAt, deposit.ID)
+		if err != nil {
+			return fmt.Errorf("failed to update security deposit: %w", err)
+		}
+		if err := expectOneRow(result, "security deposit has already been settled"); err != nil {
+			return err
+		}
+
+		var offsetPaymentIDs []int
+		for _, entry := range deposit.Entries {
+			if entry.ID != 0 {
+				continue // Already saved
+			}
+
+			if entry.EntryType == domain.DepositEntryArrearsOffset {
+				if err := allocateToPayment(dbTx, *entry.PaymentID, entry.Amount, moveOut.MoveOutDate); err != nil {
+					return err
+				}
+				offsetPaymentIDs = append(offsetPaymentIDs, *entry.PaymentID)
+			}
+
+			err = dbTx.QueryRow(`
+				INSERT INTO deposit_entries (deposit_id, entry_type, amount, reason, payment_id, entry_date)
+				VALUES ($1, $2, $3, $4, $5, $6)
+				RETURNING id, created_at`,
+				entry.DepositID,
+				entry.EntryType,
+				entry.Amount,
+				entry.Reason,
+				entry.PaymentID,
+				entry.EntryDate,
+			).Scan(&entry.ID, &entry.CreatedAt)
+			if err != nil {
+				return fmt.Errorf("failed to create deposit entry: %w", err)
+			}
+		}
+
+		if err = completeSettledInstallmentPlans(dbTx, offsetPaymentIDs, moveOut.MoveOutDate); err != nil {
+			return err
+		}
+	}
+
+	if lease := moveOut.Lease; lease != nil {
+		_, err = dbTx.Exec(`UPDATE leases SET end_date = $1, status = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $3`,
+			lease.EndDate, lease.Status, lease.ID)
+		if err != nil {
+			return fmt.Errorf("failed to end lease: %w", err)
+		}
+	}
+
+	result, err = dbTx.Exec(`UPDATE units SET is_occupied = FALSE WHERE id = $1`, moveOut.UnitID)
+	if err != nil {
+		return fmt.Errorf("failed to update unit occupancy: %w", err)
+	}
+	if err := expectOneRow(result, fmt.Sprintf("unit with ID %d not found", moveOut.UnitID)); err != nil {
+		return err
+	}
+
+	if err = dbTx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit move-out: %w", err)
+	}
+
+	return nil
+}
+
 // CreateFamilyMember creates a new family member
 func (r *PostgresTenantRepository) CreateFamilyMember(familyMember *domain.FamilyMember) error {
 	query := `
@@ -5,6 +5,7 @@ import (
 	"backend-form/m/internal/repository/interfaces"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/lib/pq"
 )
@@ -19,12 +20,13 @@ func NewPostgresUnitRepository(db *sql.DB) interfaces.UnitRepository {
 	return &PostgresUnitRepository{db: db}
 }
 
-// GetAllUnits returns all units
+// GetAllUnits returns all units that are still in service (retired units are excluded)
 func (r *PostgresUnitRepository) GetAllUnits() ([]*domain.Unit, error) {
 	query := `
-		SELECT id, unit_code, floor, unit_type, monthly_rent, security_deposit, 
-		       payment_due_day, is_occupied, created_at
+		SELECT id, property_id, unit_code, floor, unit_type, monthly_rent, security_deposit, 
+		       payment_due_day, is_occupied, retired_at, created_at
 		FROM units
+		WHERE retired_at IS NULL
 		ORDER BY floor, unit_code`
 
 	rows, err := r.db.Query(query)
@@ -36,8 +38,10 @@ func (r *PostgresUnitRepository) GetAllUnits() ([]*domain.Unit, error) {
 	var units []*domain.Unit
 	for rows.Next() {
 		unit := &domain.Unit{}
+		var retiredAt sql.NullTime
 		err := rows.Scan(
 			&unit.ID,
+			&unit.PropertyID,
 			&unit.UnitCode,
 			&unit.Floor,
 			&unit.UnitType,
@@ -45,11 +49,63 @@ func (r *PostgresUnitRepository) GetAllUnits() ([]*domain.Unit, error) {
 			&unit.SecurityDeposit,
 			&unit.PaymentDueDay,
 			&unit.IsOccupied,
+			&retiredAt,
 			&unit.CreatedAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan unit: %w", err)
 		}
+		if retiredAt.Valid {
+			unit.RetiredAt = &retiredAt.Time
+		}
+		units = append(units, unit)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating units: %w", err)
+	}
+
+	return units, nil
+}
+
+// GetUnitsByPropertyID returns all in-service units belonging to a property
+func (r *PostgresUnitRepository) GetUnitsByPropertyID(propertyID int) ([]*domain.Unit, error) {
+	query := `
+		SELECT id, property_id, unit_code, floor, unit_type, monthly_rent, security_deposit, 
+		       payment_due_day, is_occupied, retired_at, created_at
+		FROM units
+		WHERE property_id = $1 AND retired_at IS NULL
+		ORDER BY floor, unit_code`
+
+	rows, err := r.db.Query(query, propertyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query units by property: %w", err)
+	}
+	defer rows.Close()
+
+	var units []*domain.Unit
+	for rows.Next() {
+		unit := &domain.Unit{}
+		var retiredAt sql.NullTime
+		err := rows.Scan(
+			&unit.ID,
+			&unit.PropertyID,
+			&unit.UnitCode,
+			&unit.Floor,
+			&unit.UnitType,
+			&unit.MonthlyRent,
+			&unit.SecurityDeposit,
+			&unit.PaymentDueDay,
+			&unit.IsOccupied,
+			&retiredAt,
+			&unit.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan unit: %w", err)
+		}
+		if retiredAt.Valid {
+			unit.RetiredAt = &retiredAt.Time
+		}
 		units = append(units, unit)
 	}
 
@@ -60,17 +116,19 @@ func (r *PostgresUnitRepository) GetAllUnits() ([]*domain.Unit, error) {
 	return units, nil
 }
 
-// GetUnitByID returns a unit by ID
+// GetUnitByID returns a unit by ID (including retired units, so payment history still resolves)
 func (r *PostgresUnitRepository) GetUnitByID(id int) (*domain.Unit, error) {
 	query := `
-		SELECT id, unit_code, floor, unit_type, monthly_rent, security_deposit, 
-		       payment_due_day, is_occupied, created_at
+		SELECT id, property_id, unit_code, floor, unit_type, monthly_rent, security_deposit, 
+		       payment_due_day, is_occupied, retired_at, created_at
 		FROM units
 		WHERE id = $1`
 
 	unit := &domain.Unit{}
+	var retiredAt sql.NullTime
 	err := r.db.QueryRow(query, id).Scan(
 		&unit.ID,
+		&unit.PropertyID,
 		&unit.UnitCode,
 		&unit.Floor,
 		&unit.UnitType,
@@ -78,6 +136,7 @@ func (r *PostgresUnitRepository) GetUnitByID(id int) (*domain.Unit, error) {
 		&unit.SecurityDeposit,
 		&unit.PaymentDueDay,
 		&unit.IsOccupied,
+		&retiredAt,
 		&unit.CreatedAt,
 	)
 
@@ -88,20 +147,26 @@ func (r *PostgresUnitRepository) GetUnitByID(id int) (*domain.Unit, error) {
 		return nil, fmt.Errorf("failed to get unit: %w", err)
 	}
 
+	if retiredAt.Valid {
+		unit.RetiredAt = &retiredAt.Time
+	}
+
 	return unit, nil
 }
 
-// GetUnitByCode returns a unit by unit code
-func (r *PostgresUnitRepository) GetUnitByCode(code string) (*domain.Unit, error) {
+// GetUnitByCode returns a property's unit by unit code
+func (r *PostgresUnitRepository) GetUnitByCode(propertyID int, code string) (*domain.Unit, error) {
 	query := `
-		SELECT id, unit_code, floor, unit_type, monthly_rent, security_deposit, 
-		       payment_due_day, is_occupied, created_at
+		SELECT id, property_id, unit_code, floor, unit_type, monthly_rent, security_deposit, 
+		       payment_due_day, is_occupied, retired_at, created_at
 		FROM units
-		WHERE unit_code = $1`
+		WHERE property_id = $1 AND unit_code = $2`
 
 	unit := &domain.Unit{}
-	err := r.db.QueryRow(query, code).Scan(
+	var retiredAt sql.NullTime
+	err := r.db.QueryRow(query, propertyID, code).Scan(
 		&unit.ID,
+		&unit.PropertyID,
 		&unit.UnitCode,
 		&unit.Floor,
 		&unit.UnitType,
@@ -109,6 +174,7 @@ func (r *PostgresUnitRepository) GetUnitByCode(code string) (*domain.Unit, error
 		&unit.SecurityDeposit,
 		&unit.PaymentDueDay,
 		&unit.IsOccupied,
+		&retiredAt,
 		&unit.CreatedAt,
 	)
 
@@ -119,6 +185,10 @@ func (r *PostgresUnitRepository) GetUnitByCode(code string) (*domain.Unit, error
 		return nil, fmt.Errorf("failed to get unit: %w", err)
 	}
 
+	if retiredAt.Valid {
+		unit.RetiredAt = &retiredAt.Time
+	}
+
 	return unit, nil
 }
 
@@ -130,8 +200,8 @@ func (r *PostgresUnitRepository) GetUnitsByIDs(ids []int) (map[int]*domain.Unit,
 
 	// Build query with IN clause using PostgreSQL array
 	query := `
-		SELECT id, unit_code, floor, unit_type, monthly_rent, security_deposit, 
-		       payment_due_day, is_occupied, created_at
+		SELECT id, property_id, unit_code, floor, unit_type, monthly_rent, security_deposit, 
+		       payment_due_day, is_occupied, retired_at, created_at
 		FROM units
 		WHERE id = ANY($1)
 		ORDER BY id`
@@ -146,8 +216,10 @@ func (r *PostgresUnitRepository) GetUnitsByIDs(ids []int) (map[int]*domain.Unit,
 	units := make(map[int]*domain.Unit)
 	for rows.Next() {
 		unit := &domain.Unit{}
+		var retiredAt sql.NullTime
 		err := rows.Scan(
 			&unit.ID,
+			&unit.PropertyID,
 			&unit.UnitCode,
 			&unit.Floor,
 			&unit.UnitType,
@@ -155,11 +227,15 @@ func (r *PostgresUnitRepository) GetUnitsByIDs(ids []int) (map[int]*domain.Unit,
 			&unit.SecurityDeposit,
 			&unit.PaymentDueDay,
 			&unit.IsOccupied,
+			&retiredAt,
 			&unit.CreatedAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan unit: %w", err)
 		}
+		if retiredAt.Valid {
+			unit.RetiredAt = &retiredAt.Time
+		}
 		units[unit.ID] = unit
 	}
 
@@ -190,3 +266,85 @@ func (r *PostgresUnitRepository) UpdateUnitOccupancy(unitID int, isOccupied bool
 
 	return nil
 }
+
+// CreateUnit creates a new unit
+func (r *PostgresUnitRepository) CreateUnit(unit *domain.Unit) error {
+	query := `
+		INSERT INTO units (property_id, unit_code, floor, unit_type, monthly_rent, security_deposit, payment_due_day, is_occupied)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, created_at`
+
+	err := r.db.QueryRow(query,
+		unit.PropertyID,
+		unit.UnitCode,
+		unit.Floor,
+		unit.UnitType,
+		unit.MonthlyRent,
+		unit.SecurityDeposit,
+		unit.PaymentDueDay,
+		unit.IsOccupied,
+	).Scan(&unit.ID, &unit.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create unit: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateUnit updates unit details (occupancy is managed separately via UpdateUnitOccupancy)
+func (r *PostgresUnitRepository) UpdateUnit(unit *domain.Unit) error {
+	query := `
+		UPDATE units 
+		SET property_id = $1, unit_code = $2, floor = $3, unit_type = $4, monthly_rent = $5, 
+		    security_deposit = $6, payment_due_day = $7
+		WHERE id = $8 AND retired_at IS NULL`
+
+	result, err := r.db.Exec(query,
+		unit.PropertyID,
+		unit.UnitCode,
+		unit.Floor,
+		unit.UnitType,
+		unit.MonthlyRent,
+		unit.SecurityDeposit,
+		unit.PaymentDueDay,
+		unit.ID,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update unit: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("unit with ID %d not found or retired", unit.ID)
+	}
+
+	return nil
+}
+
+// RetireUnit marks a vacant unit as retired
+// The row is kept so that historical payments still reference a valid unit
+func (r *PostgresUnitRepository) RetireUnit(unitID int) error {
+	query := `UPDATE units SET retired_at = $1 WHERE id = $2 AND retired_at IS NULL AND is_occupied = FALSE`
+
+	result, err := r.db.Exec(query, time.Now(), unitID)
+	if err != nil {
+		return fmt.Errorf("failed to retire unit: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("unit with ID %d not found, occupied, or already retired", unitID)
+	}
+
+	return nil
+}
@@ -0,0 +1,422 @@
+package repository
+
+import (
+	domain "backend-form/m/internal/domain"
+	"backend-form/m/internal/repository/interfaces"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+)
+
+// PostgresUtilityRepository implements UtilityRepository interface
+type PostgresUtilityRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresUtilityRepository creates a new PostgresUtilityRepository
+func NewPostgresUtilityRepository(db *sql.DB) interfaces.UtilityRepository {
+	return &PostgresUtilityRepository{db: db}
+}
+
+// ============================================
+// Tariffs
+// ============================================
+
+const utilityTariffColumns = `id, property_id, unit_id, category_code, rate_per_unit, slabs, fixed_charge, split_by_people, created_at`
+
+// scanUtilityTariff scans a tariff row, decoding its slabs from JSON
+func scanUtilityTariff(row rowScanner) (*domain.UtilityTariff, error) {
+	tariff := &domain.UtilityTariff{}
+	var propertyID, unitID sql.NullInt64
+	var slabs []byte
+	err := row.Scan(
+		&tariff.ID,
+		&propertyID,
+		&unitID,
+		&tariff.CategoryCode,
+		&tariff.RatePerUnit,
+		&slabs,
+		&tariff.FixedCharge,
+		&tariff.SplitByPeople,
+		&tariff.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if propertyID.Valid {
+		id := int(propertyID.Int64)
+		tariff.PropertyID = &id
+	}
+	if unitID.Valid {
+		id := int(unitID.Int64)
+		tariff.UnitID = &id
+	}
+	if len(slabs) > 0 {
+		if err := json.Unmarshal(slabs, &tariff.Slabs); err != nil {
+			return nil, fmt.Errorf("failed to decode tariff slabs: %w", err)
+		}
+	}
+	return tariff, nil
+}
+
+// CreateTariff creates a new utility tariff
+func (r *PostgresUtilityRepository) CreateTariff(tariff *domain.UtilityTariff) error {
+	slabs, err := json.Marshal(tariff.Slabs)
+	if err != nil {
+		return fmt.Errorf("failed to encode tariff slabs: %w", err)
+	}
+
+	query := `
+		INSERT INTO utility_tariffs (property_id, unit_id, category_code, rate_per_unit, slabs, fixed_charge, split_by_people)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at`
+
+	err = r.db.QueryRow(query,
+		tariff.PropertyID,
+		tariff.UnitID,
+		tariff.CategoryCode,
+		tariff.RatePerUnit,
+		slabs,
+		tariff.FixedCharge,
+		tariff.SplitByPeople,
+	).Scan(&tariff.ID, &tariff.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create utility tariff: %w", err)
+	}
+
+	return nil
+}
+
+// GetTariffByID returns a utility tariff by ID
+func (r *PostgresUtilityRepository) GetTariffByID(id int) (*domain.UtilityTariff, error) {
+	query := `SELECT ` + utilityTariffColumns + ` FROM utility_tariffs WHERE id = $1`
+
+	tariff, err := scanUtilityTariff(r.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("utility tariff with ID %d not found", id)
+		}
+		return nil, fmt.Errorf("failed to get utility tariff: %w", err)
+	}
+
+	return tariff, nil
+}
+
+// GetAllTariffs returns all utility tariffs
+func (r *PostgresUtilityRepository) GetAllTariffs() ([]*domain.UtilityTariff, error) {
+	query := `SELECT ` + utilityTariffColumns + ` FROM utility_tariffs ORDER BY category_code, property_id NULLS LAST, unit_id`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query utility tariffs: %w", err)
+	}
+	defer rows.Close()
+
+	var tariffs []*domain.UtilityTariff
+	for rows.Next() {
+		tariff, err := scanUtilityTariff(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan utility tariff: %w", err)
+		}
+		tariffs = append(tariffs, tariff)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating utility tariffs: %w", err)
+	}
+
+	return tariffs, nil
+}
+
+// GetTariffForUnit returns the tariff in force for a unit's meter
+// A unit-specific tariff wins over the property-wide one; returns nil if neither exists
+func (r *PostgresUtilityRepository) GetTariffForUnit(unitID int, categoryCode string) (*domain.UtilityTariff, error) {
+	query := `
+		SELECT t.id, t.property_id, t.unit_id, t.category_code, t.rate_per_unit, t.slabs, t.fixed_charge, t.split_by_people, t.created_at
+		FROM utility_tariffs t
+		JOIN units u ON t.unit_id = u.id OR (t.unit_id IS NULL AND t.property_id = u.property_id)
+		WHERE u.id = $1 AND t.category_code = $2
+		ORDER BY t.unit_id IS NULL, t.id
+		LIMIT 1`
+
+	tariff, err := scanUtilityTariff(r.db.QueryRow(query, unitID, categoryCode))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // No tariff for this unit
+		}
+		return nil, fmt.Errorf("failed to get utility tariff for unit: %w", err)
+	}
+
+	return tariff, nil
+}
+
+// UpdateTariff updates a utility tariff
+func (r *PostgresUtilityRepository) UpdateTariff(tariff *domain.UtilityTariff) error {
+	slabs, err := json.Marshal(tariff.Slabs)
+	if err != nil {
+		return fmt.Errorf("failed to encode tariff slabs: %w", err)
+	}
+
+	query := `
+		UPDATE utility_tariffs 
+		SET property_id = $1, unit_id = $2, category_code = $3, rate_per_unit = $4, slabs = $5, fixed_charge = $6, split_by_people = $7
+		WHERE id = $8`
+
+	result, err := r.db.Exec(query,
+		tariff.PropertyID,
+		tariff.UnitID,
+		tariff.CategoryCode,
+		tariff.RatePerUnit,
+		slabs,
+		tariff.FixedCharge,
+		tariff.SplitByPeople,
+		tariff.ID,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update utility tariff: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("utility tariff with ID %d not found", tariff.ID)
+	}
+
+	return nil
+}
+
+// DeleteTariff deletes a utility tariff (bills already generated are kept)
+func (r *PostgresUtilityRepository) DeleteTariff(id int) error {
+	result, err := r.db.Exec(`DELETE FROM utility_tariffs WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete utility tariff: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("utility tariff with ID %d not found", id)
+	}
+
+	return nil
+}
+
+// ============================================
+// Meter readings
+// ============================================
+
+const meterReadingColumns = `id, unit_id, category_code, previous_reading, current_reading, reading_date, created_at`
+
+// scanMeterReading scans a meter reading row
+func scanMeterReading(row rowScanner) (*domain.MeterReading, error) {
+	reading := &domain.MeterReading{}
+	err := row.Scan(
+		&reading.ID,
+		&reading.UnitID,
+		&reading.CategoryCode,
+		&reading.PreviousReading,
+		&reading.CurrentReading,
+		&reading.ReadingDate,
+		&reading.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return reading, nil
+}
+
+// CreateReading records a new meter reading
+func (r *PostgresUtilityRepository) CreateReading(reading *domain.MeterReading) error {
+	query := `
+		INSERT INTO meter_readings (unit_id, category_code, previous_reading, current_reading, reading_date)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at`
+
+	err := r.db.QueryRow(query,
+		reading.UnitID,
+		reading.CategoryCode,
+		reading.PreviousReading,
+		reading.CurrentReading,
+		reading.ReadingDate,
+	).Scan(&reading.ID, &reading.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create meter reading: %w", err)
+	}
+
+	return nil
+}
+
+// GetReadingByID returns a meter reading by ID
+func (r *PostgresUtilityRepository) GetReadingByID(id int) (*domain.MeterReading, error) {
+	query := `SELECT ` + meterReadingColumns + ` FROM meter_readings WHERE id = $1`
+
+	reading, err := scanMeterReading(r.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("meter reading with ID %d not found", id)
+		}
+		return nil, fmt.Errorf("failed to get meter reading: %w", err)
+	}
+
+	return reading, nil
+}
+
+// GetLatestReading returns the most recent reading of a unit's meter, or nil if none
+func (r *PostgresUtilityRepository) GetLatestReading(unitID int, categoryCode string) (*domain.MeterReading, error) {
+	query := `SELECT ` + meterReadingColumns + ` FROM meter_readings
+		WHERE unit_id = $1 AND category_code = $2
+		ORDER BY reading_date DESC, id DESC
+		LIMIT 1`
+
+	reading, err := scanMeterReading(r.db.QueryRow(query, unitID, categoryCode))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // No readings yet
+		}
+		return nil, fmt.Errorf("failed to get latest meter reading: %w", err)
+	}
+
+	return reading, nil
+}
+
+// GetReadingsByUnitID returns all meter readings of a unit, most recent first
+func (r *PostgresUtilityRepository) GetReadingsByUnitID(unitID int) ([]*domain.MeterReading, error) {
+	query := `SELECT ` + meterReadingColumns + ` FROM meter_readings WHERE unit_id = $1 ORDER BY reading_date DESC, id DESC`
+
+	rows, err := r.db.Query(query, unitID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query meter readings: %w", err)
+	}
+	defer rows.Close()
+
+	var readings []*domain.MeterReading
+	for rows.Next() {
+		reading, err := scanMeterReading(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan meter reading: %w", err)
+		}
+		readings = append(readings, reading)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating meter readings: %w", err)
+	}
+
+	return readings, nil
+}
+
+// ============================================
+// Bills
+// ============================================
+
+// CreateBill records the breakdown of a utility payment
+func (r *PostgresUtilityRepository) CreateBill(bill *domain.UtilityBill) error {
+	slabCharges, err := json.Marshal(bill.SlabCharges)
+	if err != nil {
+		return fmt.Errorf("failed to encode slab charges: %w", err)
+	}
+
+	query := `
+		INSERT INTO utility_bills (payment_id, reading_id, tenant_id, consumption, usage_charge, fixed_charge,
+		                           meter_total, share_weight, total_weight, amount, slab_charges)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id, created_at`
+
+	err = r.db.QueryRow(query,
+		bill.PaymentID,
+		bill.ReadingID,
+		bill.TenantID,
+		bill.Consumption,
+		bill.UsageCharge,
+		bill.FixedCharge,
+		bill.MeterTotal,
+		bill.ShareWeight,
+		bill.TotalWeight,
+		bill.Amount,
+		slabCharges,
+	).Scan(&bill.ID, &bill.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create utility bill: %w", err)
+	}
+
+	return nil
+}
+
+// queryBills runs a query returning utility bills joined with their meter reading
+func (r *PostgresUtilityRepository) queryBills(query string, args ...interface{}) ([]*domain.UtilityBill, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query utility bills: %w", err)
+	}
+	defer rows.Close()
+
+	var bills []*domain.UtilityBill
+	for rows.Next() {
+		bill := &domain.UtilityBill{Reading: &domain.MeterReading{}}
+		var slabCharges []byte
+		err := rows.Scan(
+			&bill.ID,
+			&bill.PaymentID,
+			&bill.ReadingID,
+			&bill.TenantID,
+			&bill.Consumption,
+			&bill.UsageCharge,
+			&bill.FixedCharge,
+			&bill.MeterTotal,
+			&bill.ShareWeight,
+			&bill.TotalWeight,
+			&bill.Amount,
+			&slabCharges,
+			&bill.CreatedAt,
+			&bill.Reading.ID,
+			&bill.Reading.UnitID,
+			&bill.Reading.CategoryCode,
+			&bill.Reading.PreviousReading,
+			&bill.Reading.CurrentReading,
+			&bill.Reading.ReadingDate,
+			&bill.Reading.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan utility bill: %w", err)
+		}
+		if len(slabCharges) > 0 {
+			if err := json.Unmarshal(slabCharges, &bill.SlabCharges); err != nil {
+				return nil, fmt.Errorf("failed to decode slab charges: %w", err)
+			}
+		}
+		bills = append(bills, bill)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating utility bills: %w", err)
+	}
+
+	return bills, nil
+}
+
+const utilityBillSelect = `
+	SELECT b.id, b.payment_id, b.reading_id, b.tenant_id, b.consumption, b.usage_charge, b.fixed_charge,
+	       b.meter_total, b.share_weight, b.total_weight, b.amount, b.slab_charges, b.created_at,
+	       m.id, m.unit_id, m.category_code, m.previous_reading, m.current_reading, m.reading_date, m.created_at
+	FROM utility_bills b
+	JOIN meter_readings m ON b.reading_id = m.id`
+
+// GetBillsByTenantID returns all utility bills of a tenant, most recent first
+func (r *PostgresUtilityRepository) GetBillsByTenantID(tenantID int) ([]*domain.UtilityBill, error) {
+	return r.queryBills(utilityBillSelect+` WHERE b.tenant_id = $1 ORDER BY m.reading_date DESC, b.id DESC`, tenantID)
+}
+
+// GetBillsByReadingID returns the bills generated from one meter reading
+func (r *PostgresUtilityRepository) GetBillsByReadingID(readingID int) ([]*domain.UtilityBill, error) {
+	return r.queryBills(utilityBillSelect+` WHERE b.reading_id = $1 ORDER BY b.id`, readingID)
+}
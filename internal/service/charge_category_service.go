@@ -0,0 +1,106 @@
+package service
+
+import (
+	"backend-form/m/internal/domain"
+	interfaces "backend-form/m/internal/repository/interfaces"
+	"fmt"
+	"strings"
+)
+
+// ChargeCategoryService handles owner-managed charge categories (payment labels)
+type ChargeCategoryService struct {
+	categoryRepo interfaces.ChargeCategoryRepository
+}
+
+// NewChargeCategoryService creates a new ChargeCategoryService
+func NewChargeCategoryService(categoryRepo interfaces.ChargeCategoryRepository) *ChargeCategoryService {
+	return &ChargeCategoryService{
+		categoryRepo: categoryRepo,
+	}
+}
+
+// CreateCategory validates and creates a new charge category
+// The code is derived from the display name when not given
+func (s *ChargeCategoryService) CreateCategory(category *domain.ChargeCategory) error {
+	category.DisplayName = strings.TrimSpace(category.DisplayName)
+	if category.Code == "" {
+		category.Code = category.DisplayName
+	}
+	category.Code = domain.NormalizeChargeCategoryCode(category.Code)
+	category.IsSystem = false
+	category.IsActive = true
+
+	if err := category.Validate(); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	if existing, err := s.categoryRepo.GetCategoryByCode(category.Code); err == nil && existing != nil {
+		return fmt.Errorf("charge category '%s' already exists", category.Code)
+	}
+
+	return s.categoryRepo.CreateCategory(category)
+}
+
+// UpdateCategory updates an existing charge category identified by code
+// Built-in categories can be renamed but not deactivated
+func (s *ChargeCategoryService) UpdateCategory(category *domain.ChargeCategory) error {
+	existing, err := s.categoryRepo.GetCategoryByCode(category.Code)
+	if err != nil {
+		return err
+	}
+
+	if existing.IsSystem && !category.IsActive {
+		return fmt.Errorf("built-in charge category '%s' cannot be deactivated", existing.Code)
+	}
+
+	category.ID = existing.ID
+	category.IsSystem = existing.IsSystem
+	category.DisplayName = strings.TrimSpace(category.DisplayName)
+	if err := category.Validate(); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	return s.categoryRepo.UpdateCategory(category)
+}
+
+// GetCategoryByCode returns a charge category by its label code
+func (s *ChargeCategoryService) GetCategoryByCode(code string) (*domain.ChargeCategory, error) {
+	return s.categoryRepo.GetCategoryByCode(code)
+}
+
+// GetAllCategories returns all charge categories (activeOnly filters out deactivated ones)
+func (s *ChargeCategoryService) GetAllCategories(activeOnly bool) ([]*domain.ChargeCategory, error) {
+	categories, err := s.categoryRepo.GetAllCategories()
+	if err != nil {
+		return nil, err
+	}
+	if !activeOnly {
+		return categories, nil
+	}
+
+	active := make([]*domain.ChargeCategory, 0, len(categories))
+	for _, category := range categories {
+		if category.IsActive {
+			active = append(active, category)
+		}
+	}
+	return active, nil
+}
+
+// AttachCategories sets the category on each payment so display names come from the database
+func (s *ChargeCategoryService) AttachCategories(payments []*domain.Payment) {
+	categories, err := s.categoryRepo.GetAllCategories()
+	if err != nil {
+		return // Display names fall back to the label code
+	}
+
+	byCode := make(map[string]*domain.ChargeCategory, len(categories))
+	for _, category := range categories {
+		byCode[category.Code] = category
+	}
+	for _, payment := range payments {
+		if category, ok := byCode[payment.Label]; ok {
+			payment.Category = category
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"backend-form/m/internal/domain"
+	interfaces "backend-form/m/internal/repository/interfaces"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CreditService handles the tenant credit wallet
+// Overpayments are credited to the tenant and drawn down against payments as they are generated
+type CreditService struct {
+	creditRepo  interfaces.CreditRepository
+	paymentRepo interfaces.PaymentRepository
+}
+
+// NewCreditService creates a new CreditService
+func NewCreditService(creditRepo interfaces.CreditRepository, paymentRepo interfaces.PaymentRepository) *CreditService {
+	return &CreditService{
+		creditRepo:  creditRepo,
+		paymentRepo: paymentRepo,
+	}
+}
+
+// GetLedger returns a tenant's credit ledger with all entries
+func (s *CreditService) GetLedger(tenantID int) (*domain.CreditLedger, error) {
+	entries, err := s.creditRepo.GetCreditEntriesByTenantID(tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if entries == nil {
+		entries = []*domain.CreditEntry{}
+	}
+	return &domain.CreditLedger{TenantID: tenantID, Entries: entries}, nil
+}
+
+// PrepareOverpayment builds the credit entry for the part of a transaction left after all unpaid payments
+// The entry is saved with the transaction's verification (see PaymentRepository.ApplySmartAllocation)
+func (s *CreditService) PrepareOverpayment(tenantID int, transactionID string, amount int, entryDate time.Time) (*domain.CreditEntry, error) {
+	entry := &domain.CreditEntry{
+		TenantID:      tenantID,
+		EntryType:     domain.CreditEntryOverpayment,
+		Amount:        amount,
+		Reason:        fmt.Sprintf("Overpayment on transaction %s", transactionID),
+		TransactionID: &transactionID,
+		EntryDate:     entryDate,
+	}
+	if err := entry.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+	return entry, nil
+}
+
+// ApplyAvailableCredit pays a tenant's unpaid payments from their credit, most overdue first
+// The entries are saved in one transaction that locks the ledger, so credit is never applied twice
+// Returns the total amount of credit applied
+func (s *CreditService) ApplyAvailableCredit(tenantID int) (int, error) {
+	ledger, err := s.GetLedger(tenantID)
+	if err != nil {
+		return 0, err
+	}
+	balance := ledger.GetBalance()
+	if balance <= 0 {
+		return 0, nil
+	}
+
+	unpaid, err := s.paymentRepo.GetUnpaidPaymentsByTenantID(tenantID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load unpaid payments: %w", err)
+	}
+
+	applied := 0
+	now := time.Now()
+	var entries []*domain.CreditEntry
+	for _, payment := range unpaid {
+		if balance <= 0 {
+			break
+		}
+		amount := payment.RemainingBalance
+		if amount > balance {
+			amount = balance
+		}
+		if amount <= 0 {
+			continue
+		}
+
+		paymentID := payment.ID
+		entries = append(entries, &domain.CreditEntry{
+			TenantID:  tenantID,
+			EntryType: domain.CreditEntryApplied,
+			Amount:    amount,
+			Reason:    fmt.Sprintf("%s due %s", payment.GetLabelDisplayName(), payment.GetFormattedDueDate()),
+			PaymentID: &paymentID,
+			EntryDate: now,
+		})
+
+		balance -= amount
+		applied += amount
+	}
+	if len(entries) == 0 {
+		return 0, nil
+	}
+
+	if err := s.creditRepo.CreateDebitEntries(tenantID, entries); err != nil {
+		return 0, fmt.Errorf("failed to apply credit: %w", err)
+	}
+
+	return applied, nil
+}
+
+// RefundCredit records credit paid back to the tenant outside the app
+func (s *CreditService) RefundCredit(tenantID int, amount int, reason string, refundDate time.Time) (*domain.CreditLedger, error) {
+	ledger, err := s.GetLedger(tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &domain.CreditEntry{
+		TenantID:  tenantID,
+		EntryType: domain.CreditEntryRefund,
+		Amount:    amount,
+		Reason:    strings.TrimSpace(reason),
+		EntryDate: refundDate,
+	}
+	if err := entry.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	if balance := ledger.GetBalance(); amount > balance {
+		return nil, fmt.Errorf("refund of ₹%d exceeds credit balance of ₹%d", amount, balance)
+	}
+
+	if err := s.creditRepo.CreateDebitEntries(tenantID, []*domain.CreditEntry{entry}); err != nil {
+		return nil, err
+	}
+	ledger.Entries = append(ledger.Entries, entry)
+
+	return ledger, nil
+}
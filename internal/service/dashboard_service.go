@@ -13,6 +13,7 @@ type DashboardService struct {
 	unitService         *UnitService
 	tenantService       *TenantService
 	paymentQueryService *PaymentQueryService
+	propertyService     *PropertyService
 	cache               *cache.Cache
 }
 
@@ -21,62 +22,79 @@ func NewDashboardService(
 	unitService *UnitService,
 	tenantService *TenantService,
 	paymentQueryService *PaymentQueryService,
+	propertyService *PropertyService,
 ) *DashboardService {
 	// Cache dashboard data for 30 seconds
 	return &DashboardService{
 		unitService:         unitService,
 		tenantService:       tenantService,
 		paymentQueryService: paymentQueryService,
+		propertyService:     propertyService,
 		cache:               cache.NewCache(30 * time.Second),
 	}
 }
 
 // DashboardData represents all data needed for the dashboard view
 type DashboardData struct {
-	Units          []*domain.Unit    `json:"units"`
-	Tenants        []*domain.Tenant  `json:"tenants"`
-	Payments       []*domain.Payment `json:"payments"`
-	UnitSummary    *RentalSummary    `json:"unit_summary"`
-	PaymentSummary *PaymentSummary   `json:"payment_summary"`
+	Properties     []*domain.Property `json:"properties"`
+	PropertyID     int                `json:"property_id"` // 0 = all properties
+	Units          []*domain.Unit     `json:"units"`
+	Tenants        []*domain.Tenant   `json:"tenants"`
+	Payments       []*domain.Payment  `json:"payments"`
+	UnitSummary    *RentalSummary     `json:"unit_summary"`
+	PaymentSummary *PaymentSummary    `json:"payment_summary"`
 }
 
-// GetDashboardData returns all data needed for the dashboard page
+// dashboardCacheKey returns the cache key for a property-scoped dashboard
+func dashboardCacheKey(propertyID int) string {
+	return fmt.Sprintf("dashboard_data:%d", propertyID)
+}
+
+// GetDashboardData returns all data needed for the dashboard page (propertyID 0 = all properties)
 // Results are cached for 30 seconds to reduce database load
-func (s *DashboardService) GetDashboardData() (*DashboardData, error) {
+func (s *DashboardService) GetDashboardData(propertyID int) (*DashboardData, error) {
 	// Check cache first
-	if cached, found := s.cache.Get("dashboard_data"); found {
+	cacheKey := dashboardCacheKey(propertyID)
+	if cached, found := s.cache.Get(cacheKey); found {
 		if data, ok := cached.(*DashboardData); ok {
 			return data, nil
 		}
 	}
 
 	// Cache miss - load from database
-	units, err := s.unitService.GetAllUnits()
+	properties, err := s.propertyService.GetAllProperties()
+	if err != nil {
+		return nil, fmt.Errorf("get properties: %w", err)
+	}
+
+	units, err := s.unitService.GetUnitsByPropertyID(propertyID)
 	if err != nil {
 		return nil, fmt.Errorf("get units: %w", err)
 	}
 
-	tenants, err := s.tenantService.GetAllTenants()
+	tenants, err := s.tenantService.GetTenantsByPropertyID(propertyID)
 	if err != nil {
 		return nil, fmt.Errorf("get tenants: %w", err)
 	}
 
-	payments, err := s.paymentQueryService.GetAllPayments()
+	payments, err := s.paymentQueryService.GetPaymentsByPropertyID(propertyID)
 	if err != nil {
 		return nil, fmt.Errorf("get payments: %w", err)
 	}
 
-	unitSummary, err := s.unitService.GetRentalSummary()
+	unitSummary, err := s.unitService.GetRentalSummary(propertyID)
 	if err != nil {
 		return nil, fmt.Errorf("get unit summary: %w", err)
 	}
 
-	paymentSummary, err := s.paymentQueryService.GetPaymentSummary()
+	paymentSummary, err := s.paymentQueryService.GetPaymentSummary(propertyID)
 	if err != nil {
 		return nil, fmt.Errorf("get payment summary: %w", err)
 	}
 
 	data := &DashboardData{
+		Properties:     properties,
+		PropertyID:     propertyID,
 		Units:          units,
 		Tenants:        tenants,
 		Payments:       payments,
@@ -85,15 +103,15 @@ func (s *DashboardService) GetDashboardData() (*DashboardData, error) {
 	}
 
 	// Store in cache
-	s.cache.Set("dashboard_data", data)
+	s.cache.Set(cacheKey, data)
 
 	return data, nil
 }
 
-// InvalidateDashboardCache clears the dashboard cache
+// InvalidateDashboardCache clears the dashboard cache for every property
 // Call this when dashboard data changes (e.g., new tenant, payment update)
 func (s *DashboardService) InvalidateDashboardCache() {
-	s.cache.Delete("dashboard_data")
+	s.cache.Clear()
 }
 
 // DashboardSummary represents just the summary data (for JSON API)
@@ -103,13 +121,13 @@ type DashboardSummary struct {
 }
 
 // GetDashboardSummary returns only the summary data (for JSON API endpoint)
-func (s *DashboardService) GetDashboardSummary() (*DashboardSummary, error) {
-	unitSummary, err := s.unitService.GetRentalSummary()
+func (s *DashboardService) GetDashboardSummary(propertyID int) (*DashboardSummary, error) {
+	unitSummary, err := s.unitService.GetRentalSummary(propertyID)
 	if err != nil {
 		return nil, fmt.Errorf("get unit summary: %w", err)
 	}
 
-	paymentSummary, err := s.paymentQueryService.GetPaymentSummary()
+	paymentSummary, err := s.paymentQueryService.GetPaymentSummary(propertyID)
 	if err != nil {
 		return nil, fmt.Errorf("get payment summary: %w", err)
 	}
@@ -0,0 +1,273 @@
+package service
+
+import (
+	"backend-form/m/internal/domain"
+	interfaces "backend-form/m/internal/repository/interfaces"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DepositService handles security deposit ledger business logic
+type DepositService struct {
+	depositRepo interfaces.DepositRepository
+	paymentRepo interfaces.PaymentRepository
+}
+
+// NewDepositService creates a new DepositService
+func NewDepositService(depositRepo interfaces.DepositRepository, paymentRepo interfaces.PaymentRepository) *DepositService {
+	return &DepositService{
+		depositRepo: depositRepo,
+		paymentRepo: paymentRepo,
+	}
+}
+
+// CreateDepositForTenant opens the deposit ledger for a new tenancy
+// collectedAmount is what the tenant paid at move-in (0 if not yet collected)
+func (s *DepositService) CreateDepositForTenant(tenant *domain.Tenant, requiredAmount int, collectedAmount int) (*domain.SecurityDeposit, error) {
+	if collectedAmount < 0 {
+		return nil, fmt.Errorf("collected deposit cannot be negative")
+	}
+
+	deposit := &domain.SecurityDeposit{
+		TenantID:       tenant.ID,
+		UnitID:         tenant.UnitID,
+		RequiredAmount: requiredAmount,
+		Status:         domain.DepositStatusPending,
+	}
+	if collectedAmount > 0 {
+		deposit.Status = domain.DepositStatusHeld
+	}
+
+	if err := s.depositRepo.CreateDeposit(deposit); err != nil {
+		return nil, err
+	}
+
+	if collectedAmount > 0 {
+		entry := &domain.DepositEntry{
+			DepositID: deposit.ID,
+			EntryType: domain.DepositEntryCollection,
+			Amount:    collectedAmount,
+			Reason:    "Collected at move-in",
+			EntryDate: tenant.MoveInDate,
+		}
+		if err := s.depositRepo.CreateDepositEntry(entry); err != nil {
+			return nil, err
+		}
+		deposit.Entries = append(deposit.Entries, entry)
+	}
+
+	return deposit, nil
+}
+
+// GetDepositByTenantID returns a tenant's deposit ledger with all entries (nil if none exists)
+func (s *DepositService) GetDepositByTenantID(tenantID int) (*domain.SecurityDeposit, error) {
+	deposit, err := s.depositRepo.GetDepositByTenantID(tenantID)
+	if err != nil || deposit == nil {
+		return deposit, err
+	}
+
+	entries, err := s.depositRepo.GetDepositEntriesByDepositID(deposit.ID)
+	if err != nil {
+		return nil, err
+	}
+	deposit.Entries = entries
+
+	return deposit, nil
+}
+
+// getOpenDeposit loads a tenant's deposit and ensures it can still be changed
+func (s *DepositService) getOpenDeposit(tenantID int) (*domain.SecurityDeposit, error) {
+	deposit, err := s.GetDepositByTenantID(tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if deposit == nil {
+		return nil, fmt.Errorf("no security deposit found for tenant %d", tenantID)
+	}
+	if deposit.IsSettled() {
+		return nil, fmt.Errorf("security deposit has already been settled")
+	}
+	return deposit, nil
+}
+
+// RecordCollection records deposit money received from the tenant after move-in
+func (s *DepositService) RecordCollection(tenantID int, amount int, collectedDate time.Time, notes string) (*domain.SecurityDeposit, error) {
+	deposit, err := s.getOpenDeposit(tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &domain.DepositEntry{
+		DepositID: deposit.ID,
+		EntryType: domain.DepositEntryCollection,
+		Amount:    amount,
+		Reason:    strings.TrimSpace(notes),
+		EntryDate: collectedDate,
+	}
+	if err := entry.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	if err := s.depositRepo.CreateDepositEntry(entry); err != nil {
+		return nil, err
+	}
+	deposit.Entries = append(deposit.Entries, entry)
+
+	if deposit.Status == domain.DepositStatusPending {
+		deposit.Status = domain.DepositStatusHeld
+		if err := s.depositRepo.UpdateDepositStatus(deposit); err != nil {
+			return nil, err
+		}
+	}
+
+	return deposit, nil
+}
+
+// RecordDeduction deducts an amount from the held deposit with a reason
+func (s *DepositService) RecordDeduction(tenantID int, amount int, reason string, deductionDate time.Time) (*domain.SecurityDeposit, error) {
+	deposit, err := s.getOpenDeposit(tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := addDeduction(deposit, domain.DepositDeduction{Amount: amount, Reason: reason}, deductionDate)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.depositRepo.CreateDepositEntry(entry); err != nil {
+		return nil, err
+	}
+
+	return deposit, nil
+}
+
+// addDeduction validates a deduction against the held balance and adds it to the ledger (not saved)
+func addDeduction(deposit *domain.SecurityDeposit, deduction domain.DepositDeduction, deductionDate time.Time) (*domain.DepositEntry, error) {
+	entry := &domain.DepositEntry{
+		DepositID: deposit.ID,
+		EntryType: domain.DepositEntryDeduction,
+		Amount:    deduction.Amount,
+		Reason:    strings.TrimSpace(deduction.Reason),
+		EntryDate: deductionDate,
+	}
+	if err := entry.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	if balance := deposit.GetBalance(); entry.Amount > balance {
+		return nil, fmt.Errorf("deduction of ₹%d exceeds deposit balance of ₹%d", entry.Amount, balance)
+	}
+
+	deposit.Entries = append(deposit.Entries, entry)
+	return entry, nil
+}
+
+// PrepareSettlement works out the settlement of a tenant's deposit on move-out without saving anything
+// Deductions are applied first; if offsetUnpaid is set, unpaid balances due on or before the
+// move-out date are then paid from the remaining deposit; whatever is left is refunded.
+// The new entries are appended to the returned deposit, which is marked settled; the move-out saves them.
+// finalPayment is the prorated final rent payment (nil if none), used in place of its saved balance.
+// Returns a nil deposit if the tenant has no deposit ledger, and the unpaid balance the deposit does not cover.
+func (s *DepositService) PrepareSettlement(tenantID int, deductions []domain.DepositDeduction, offsetUnpaid bool, moveOutDate time.Time, finalPayment *domain.Payment) (*domain.SecurityDeposit, int, error) {
+	deposit, err := s.GetDepositByTenantID(tenantID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if deposit == nil {
+		if len(deductions) > 0 {
+			return nil, 0, fmt.Errorf("no security deposit found for tenant %d", tenantID)
+		}
+		return nil, 0, nil
+	}
+	if deposit.IsSettled() {
+		return nil, 0, fmt.Errorf("security deposit has already been settled")
+	}
+
+	for _, deduction := range deductions {
+		if _, err := addDeduction(deposit, deduction, moveOutDate); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	unpaid, err := s.paymentRepo.GetUnpaidPaymentsByTenantID(tenantID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to load unpaid payments: %w", err)
+	}
+
+	amountStillOwed := 0
+	for _, payment := range unpaid {
+		if finalPayment != nil && payment.ID == finalPayment.ID {
+			payment = finalPayment
+		}
+		// Future payments are not owed by a tenant who has moved out
+		if payment.DueDate.After(moveOutDate) || payment.RemainingBalance <= 0 {
+			continue
+		}
+
+		offset := 0
+		if offsetUnpaid {
+			offset = payment.RemainingBalance
+			if balance := deposit.GetBalance(); offset > balance {
+				offset = balance
+			}
+		}
+		amountStillOwed += payment.RemainingBalance - offset
+		if offset <= 0 {
+			continue
+		}
+
+		paymentID := payment.ID
+		deposit.Entries = append(deposit.Entries, &domain.DepositEntry{
+			DepositID: deposit.ID,
+			EntryType: domain.DepositEntryArrearsOffset,
+			Amount:    offset,
+			Reason:    fmt.Sprintf("%s due %s", payment.GetLabelDisplayName(), payment.DueDate.Format("Jan 2, 2006")),
+			PaymentID: &paymentID,
+			EntryDate: moveOutDate,
+		})
+	}
+
+	if refund := deposit.GetBalance(); refund > 0 {
+		deposit.Entries = append(deposit.Entries, &domain.DepositEntry{
+			DepositID: deposit.ID,
+			EntryType: domain.DepositEntryRefund,
+			Amount:    refund,
+			Reason:    "Refunded on move-out",
+			EntryDate: moveOutDate,
+		})
+	}
+
+	settledAt := time.Now()
+	deposit.Status = domain.DepositStatusSettled
+	deposit.SettledAt = &settledAt
+
+	return deposit, amountStillOwed, nil
+}
+
+// GetSettlement rebuilds the settlement statement of a settled deposit
+func (s *DepositService) GetSettlement(tenantID int) (*domain.DepositSettlement, error) {
+	deposit, err := s.GetDepositByTenantID(tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if deposit == nil {
+		return nil, fmt.Errorf("no security deposit found for tenant %d", tenantID)
+	}
+	if !deposit.IsSettled() {
+		return nil, fmt.Errorf("security deposit has not been settled yet")
+	}
+
+	// Whatever unpaid balance remains on or before settlement is still owed
+	amountStillOwed := 0
+	unpaid, err := s.paymentRepo.GetUnpaidPaymentsByTenantID(tenantID)
+	if err == nil {
+		for _, payment := range unpaid {
+			if !payment.DueDate.After(*deposit.SettledAt) {
+				amountStillOwed += payment.RemainingBalance
+			}
+		}
+	}
+
+	return deposit.BuildSettlement(amountStillOwed), nil
+}
@@ -0,0 +1,312 @@
+package service
+
+import (
+	"backend-form/m/internal/domain"
+	interfaces "backend-form/m/internal/repository/interfaces"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ExpenseService handles the owner's expense ledger and income/expense reports
+type ExpenseService struct {
+	expenseRepo    interfaces.ExpenseRepository
+	adjustmentRepo interfaces.PaymentAdjustmentRepository
+	unitRepo       interfaces.UnitRepository
+	propertyRepo   interfaces.PropertyRepository
+}
+
+// NewExpenseService creates a new ExpenseService
+func NewExpenseService(expenseRepo interfaces.ExpenseRepository, adjustmentRepo interfaces.PaymentAdjustmentRepository, unitRepo interfaces.UnitRepository, propertyRepo interfaces.PropertyRepository) *ExpenseService {
+	return &ExpenseService{
+		expenseRepo:    expenseRepo,
+		adjustmentRepo: adjustmentRepo,
+		unitRepo:       unitRepo,
+		propertyRepo:   propertyRepo,
+	}
+}
+
+// ============================================
+// Categories
+// ============================================
+
+// CreateCategory validates and creates a new expense category
+// The code is derived from the display name when not given
+func (s *ExpenseService) CreateCategory(category *domain.ExpenseCategory) error {
+	category.DisplayName = strings.TrimSpace(category.DisplayName)
+	if category.Code == "" {
+		category.Code = category.DisplayName
+	}
+	category.Code = domain.NormalizeChargeCategoryCode(category.Code)
+	category.IsActive = true
+
+	if err := category.Validate(); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	if existing, err := s.expenseRepo.GetCategoryByCode(category.Code); err == nil && existing != nil {
+		return fmt.Errorf("expense category '%s' already exists", category.Code)
+	}
+
+	return s.expenseRepo.CreateCategory(category)
+}
+
+// UpdateCategory renames or (de)activates an expense category identified by code
+// Deactivated categories keep their expenses but cannot be used for new ones
+func (s *ExpenseService) UpdateCategory(category *domain.ExpenseCategory) error {
+	existing, err := s.expenseRepo.GetCategoryByCode(category.Code)
+	if err != nil {
+		return err
+	}
+
+	category.ID = existing.ID
+	category.DisplayName = strings.TrimSpace(category.DisplayName)
+	if err := category.Validate(); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	return s.expenseRepo.UpdateCategory(category)
+}
+
+// GetAllCategories returns all expense categories (activeOnly filters out deactivated ones)
+func (s *ExpenseService) GetAllCategories(activeOnly bool) ([]*domain.ExpenseCategory, error) {
+	categories, err := s.expenseRepo.GetAllCategories()
+	if err != nil {
+		return nil, err
+	}
+	if !activeOnly {
+		return categories, nil
+	}
+
+	active := make([]*domain.ExpenseCategory, 0, len(categories))
+	for _, category := range categories {
+		if category.IsActive {
+			active = append(active, category)
+		}
+	}
+	return active, nil
+}
+
+// ============================================
+// Expenses
+// ============================================
+
+// CreateExpense validates and records a new expense
+func (s *ExpenseService) CreateExpense(expense *domain.Expense, createdByUserID int) error {
+	if err := s.prepareExpense(expense, true); err != nil {
+		return err
+	}
+	expense.CreatedByUserID = &createdByUserID
+
+	return s.expenseRepo.CreateExpense(expense)
+}
+
+// UpdateExpense validates and updates an existing expense
+func (s *ExpenseService) UpdateExpense(expense *domain.Expense) error {
+	existing, err := s.expenseRepo.GetExpenseByID(expense.ID)
+	if err != nil {
+		return err
+	}
+
+	// A deactivated category can still be kept on an expense that already uses it
+	if err := s.prepareExpense(expense, expense.Category != existing.Category); err != nil {
+		return err
+	}
+
+	expense.CreatedByUserID = existing.CreatedByUserID
+	expense.CreatedAt = existing.CreatedAt
+	return s.expenseRepo.UpdateExpense(expense)
+}
+
+// prepareExpense trims text fields, checks the category and allocation, and validates an expense
+func (s *ExpenseService) prepareExpense(expense *domain.Expense, checkCategory bool) error {
+	expense.Vendor = strings.TrimSpace(expense.Vendor)
+	expense.Description = strings.TrimSpace(expense.Description)
+	expense.PaymentMethod = strings.TrimSpace(expense.PaymentMethod)
+	expense.Reference = strings.TrimSpace(expense.Reference)
+
+	if checkCategory {
+		if err := s.checkCategory(expense.Category); err != nil {
+			return err
+		}
+	}
+	if err := s.resolveAllocation(expense); err != nil {
+		return err
+	}
+	if err := expense.Validate(); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+	return nil
+}
+
+// checkCategory ensures an expense category exists and is active
+func (s *ExpenseService) checkCategory(code string) error {
+	category, err := s.expenseRepo.GetCategoryByCode(code)
+	if err != nil {
+		return err
+	}
+	if !category.IsActive {
+		return fmt.Errorf("expense category '%s' is inactive", code)
+	}
+	return nil
+}
+
+// resolveAllocation checks the property and unit an expense is allocated to
+// A unit expense is always also recorded against the unit's property.
+func (s *ExpenseService) resolveAllocation(expense *domain.Expense) error {
+	if expense.UnitID != nil {
+		unit, err := s.unitRepo.GetUnitByID(*expense.UnitID)
+		if err != nil {
+			return err
+		}
+		if expense.PropertyID != nil && *expense.PropertyID != unit.PropertyID {
+			return fmt.Errorf("unit %s does not belong to property %d", unit.UnitCode, *expense.PropertyID)
+		}
+		propertyID := unit.PropertyID
+		expense.PropertyID = &propertyID
+		return nil
+	}
+
+	if expense.PropertyID != nil {
+		if _, err := s.propertyRepo.GetPropertyByID(*expense.PropertyID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteExpense deletes an expense and its attachments
+func (s *ExpenseService) DeleteExpense(id int) error {
+	return s.expenseRepo.DeleteExpense(id)
+}
+
+// GetExpenseByID returns an expense with its attachments
+func (s *ExpenseService) GetExpenseByID(id int) (*domain.Expense, error) {
+	expense, err := s.expenseRepo.GetExpenseByID(id)
+	if err != nil {
+		return nil, err
+	}
+	expense.Attachments, _ = s.expenseRepo.GetAttachmentsByExpenseID(expense.ID)
+	return expense, nil
+}
+
+// GetExpenses returns the expenses matching a filter with their attachments, most recent first
+func (s *ExpenseService) GetExpenses(filter interfaces.ExpenseFilter) ([]*domain.Expense, error) {
+	expenses, err := s.expenseRepo.GetExpenses(filter)
+	if err != nil {
+		return nil, err
+	}
+	for _, expense := range expenses {
+		expense.Attachments, _ = s.expenseRepo.GetAttachmentsByExpenseID(expense.ID)
+	}
+	return expenses, nil
+}
+
+// ============================================
+// Attachments
+// ============================================
+
+// AddAttachment stores a bill or receipt file with an expense
+func (s *ExpenseService) AddAttachment(expenseID int, fileName string, contentType string, data []byte) (*domain.ExpenseAttachment, error) {
+	if _, err := s.expenseRepo.GetExpenseByID(expenseID); err != nil {
+		return nil, err
+	}
+
+	attachment := &domain.ExpenseAttachment{
+		ExpenseID:   expenseID,
+		FileName:    strings.TrimSpace(fileName),
+		ContentType: contentType,
+		SizeBytes:   len(data),
+		Data:        data,
+	}
+	if err := attachment.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	if err := s.expenseRepo.CreateAttachment(attachment); err != nil {
+		return nil, err
+	}
+	return attachment, nil
+}
+
+// GetAttachment returns an attachment including its file data
+func (s *ExpenseService) GetAttachment(id int) (*domain.ExpenseAttachment, error) {
+	return s.expenseRepo.GetAttachmentByID(id)
+}
+
+// DeleteAttachment deletes an expense attachment
+func (s *ExpenseService) DeleteAttachment(id int) error {
+	return s.expenseRepo.DeleteAttachment(id)
+}
+
+// ============================================
+// Reports
+// ============================================
+
+// GetMonthlyReport returns income, expenses and net income per month of a financial year
+// With a propertyID, only that property's income and expenses are included (general expenses are left out).
+func (s *ExpenseService) GetMonthlyReport(financialYear string, propertyID int) (*domain.IncomeExpenseReport, error) {
+	first, last, err := domain.ParseFinancialYear(financialYear)
+	if err != nil {
+		return nil, err
+	}
+
+	income, expenses, adjustments, err := s.loadReportData(first, last, propertyID)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := domain.BuildMonthlyReport(financialYear, income, expenses, adjustments)
+	if err != nil {
+		return nil, err
+	}
+	s.finishReport(report, propertyID)
+	return report, nil
+}
+
+// GetYearlyReport returns income, expenses and net income per financial year
+func (s *ExpenseService) GetYearlyReport(propertyID int) (*domain.IncomeExpenseReport, error) {
+	income, expenses, adjustments, err := s.loadReportData(time.Time{}, time.Now(), propertyID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := domain.BuildYearlyReport(income, expenses, adjustments)
+	s.finishReport(report, propertyID)
+	return report, nil
+}
+
+// loadReportData loads the income, expenses and payment adjustments between two dates (both inclusive)
+func (s *ExpenseService) loadReportData(from, to time.Time, propertyID int) ([]*domain.IncomeEntry, []*domain.Expense, []*domain.PaymentAdjustment, error) {
+	income, err := s.expenseRepo.GetIncome(from, to, propertyID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	expenses, err := s.expenseRepo.GetExpenses(interfaces.ExpenseFilter{PropertyID: propertyID, From: from, To: to})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	adjustments, err := s.adjustmentRepo.GetAdjustmentsBetween(from, to, propertyID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return income, expenses, adjustments, nil
+}
+
+// finishReport sets the property filter and category display names on a report
+func (s *ExpenseService) finishReport(report *domain.IncomeExpenseReport, propertyID int) {
+	if propertyID > 0 {
+		report.PropertyID = &propertyID
+	}
+
+	categories, err := s.expenseRepo.GetAllCategories()
+	if err != nil {
+		return // Category columns fall back to the code
+	}
+	for _, category := range categories {
+		report.CategoryNames[category.Code] = category.DisplayName
+	}
+}
@@ -0,0 +1,226 @@
+package service
+
+import (
+	"backend-form/m/internal/domain"
+	"backend-form/m/internal/gateway"
+	interfaces "backend-form/m/internal/repository/interfaces"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// GatewayService creates payment gateway orders and verifies payments from gateway webhooks
+// Verification goes through PaymentTransactionService, so gateway payments are allocated
+// exactly like owner-verified UPI transactions.
+type GatewayService struct {
+	gateway                   gateway.PaymentGateway // nil when gateway payments are disabled
+	orderRepo                 interfaces.GatewayOrderRepository
+	paymentRepo               interfaces.PaymentRepository
+	paymentService            *PaymentService
+	paymentTransactionService *PaymentTransactionService
+}
+
+// NewGatewayService creates a new GatewayService
+func NewGatewayService(paymentGateway gateway.PaymentGateway, orderRepo interfaces.GatewayOrderRepository, paymentRepo interfaces.PaymentRepository, paymentService *PaymentService, paymentTransactionService *PaymentTransactionService) *GatewayService {
+	return &GatewayService{
+		gateway:                   paymentGateway,
+		orderRepo:                 orderRepo,
+		paymentRepo:               paymentRepo,
+		paymentService:            paymentService,
+		paymentTransactionService: paymentTransactionService,
+	}
+}
+
+// IsEnabled returns true if a payment gateway is configured
+func (s *GatewayService) IsEnabled() bool {
+	return s.gateway != nil
+}
+
+// GetGatewayName returns the configured gateway name ("" if disabled)
+func (s *GatewayService) GetGatewayName() string {
+	if s.gateway == nil {
+		return ""
+	}
+	return s.gateway.Name()
+}
+
+// CreateOrder creates a gateway order for the remaining balance of a tenant's payment
+// paymentID 0 uses the tenant's current unpaid payment. An open order for the same
+// payment and amount is reused so repeated clicks do not create duplicate orders.
+func (s *GatewayService) CreateOrder(tenantID int, paymentID int) (*domain.GatewayOrder, error) {
+	if s.gateway == nil {
+		return nil, fmt.Errorf("online payments are not enabled")
+	}
+
+	var payment *domain.Payment
+	var err error
+	if paymentID == 0 {
+		payment, err = s.paymentService.getOrCreateCurrentPayment(tenantID)
+	} else {
+		payment, err = s.paymentRepo.GetPaymentByID(paymentID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get payment: %w", err)
+	}
+	if payment.TenantID != tenantID {
+		return nil, fmt.Errorf("payment not found")
+	}
+	if payment.IsFullyPaid || payment.RemainingBalance <= 0 {
+		return nil, fmt.Errorf("payment is already fully paid")
+	}
+
+	existing, err := s.orderRepo.GetOpenOrderForPayment(payment.ID, payment.RemainingBalance)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil && existing.Gateway == s.gateway.Name() {
+		existing.CheckoutKey = s.gateway.CheckoutKey()
+		return existing, nil
+	}
+
+	created, err := s.gateway.CreateOrder(gateway.OrderRequest{
+		Amount:    payment.RemainingBalance,
+		Currency:  "INR",
+		Receipt:   fmt.Sprintf("payment-%d", payment.ID),
+		PaymentID: payment.ID,
+		TenantID:  tenantID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create gateway order: %w", err)
+	}
+
+	order := &domain.GatewayOrder{
+		PaymentID:      payment.ID,
+		TenantID:       tenantID,
+		Gateway:        s.gateway.Name(),
+		GatewayOrderID: created.OrderID,
+		Amount:         created.Amount,
+		Currency:       created.Currency,
+		Status:         domain.GatewayOrderStatusCreated,
+		CheckoutURL:    created.CheckoutURL,
+		CheckoutKey:    s.gateway.CheckoutKey(),
+	}
+	if err := s.orderRepo.CreateOrder(order); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// HandleWebhook verifies a gateway callback and applies it to its order
+// A paid event records the gateway payment as a transaction and verifies it automatically.
+// Gateways retry webhooks, so events for orders that are already paid are ignored.
+// Returns the updated order.
+func (s *GatewayService) HandleWebhook(body []byte, headers http.Header) (*domain.GatewayOrder, error) {
+	if s.gateway == nil {
+		return nil, fmt.Errorf("online payments are not enabled")
+	}
+
+	event, err := s.gateway.ParseWebhook(body, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := s.orderRepo.GetOrderByGatewayOrderID(s.gateway.Name(), event.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	if order == nil {
+		return nil, fmt.Errorf("gateway order %s not found", event.OrderID)
+	}
+	if order.IsPaid() {
+		return order, nil
+	}
+
+	if event.Status == gateway.EventStatusFailed {
+		order.Status = domain.GatewayOrderStatusFailed
+		order.FailureReason = event.FailureReason
+		if err := s.orderRepo.UpdateOrder(order); err != nil {
+			return nil, err
+		}
+		return order, nil
+	}
+
+	if event.Amount <= 0 {
+		return nil, fmt.Errorf("webhook reported an invalid amount for order %s", event.OrderID)
+	}
+
+	if err := s.recordGatewayTransaction(order, event); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	order.Status = domain.GatewayOrderStatusPaid
+	order.GatewayPaymentID = &event.GatewayPaymentID
+	order.AmountPaid = event.Amount
+	order.FailureReason = ""
+	order.PaidAt = &now
+	if err := s.orderRepo.UpdateOrder(order); err != nil {
+		return nil, fmt.Errorf("payment verified but failed to update gateway order: %w", err)
+	}
+
+	return order, nil
+}
+
+// recordGatewayTransaction creates the transaction for a paid order and verifies it
+// If a previous delivery of the webhook already created or verified it, only the missing steps run
+func (s *GatewayService) recordGatewayTransaction(order *domain.GatewayOrder, event *gateway.WebhookEvent) error {
+	tx, err := s.paymentRepo.GetTransactionByID(event.GatewayPaymentID)
+	if err != nil {
+		return fmt.Errorf("check existing transaction: %w", err)
+	}
+
+	if tx == nil {
+		tx = &domain.PaymentTransaction{
+			PaymentID:     order.PaymentID,
+			TransactionID: event.GatewayPaymentID,
+			Amount:        nil, // Set when verified below
+			SubmittedAt:   time.Now(),
+			Notes:         fmt.Sprintf("Paid online via %s (order %s)", order.Gateway, order.GatewayOrderID),
+		}
+		if err := s.paymentRepo.CreatePaymentTransaction(tx); err != nil {
+			return fmt.Errorf("create payment transaction: %w", err)
+		}
+	}
+	if tx.IsVerified() {
+		return nil
+	}
+
+	// 0 = verified by the gateway rather than a user
+	if err := s.paymentTransactionService.VerifyTransaction(event.GatewayPaymentID, event.Amount, 0); err != nil {
+		return fmt.Errorf("verify gateway transaction: %w", err)
+	}
+
+	return nil
+}
+
+// CompleteMockOrder pays or fails an order on the mock gateway by sending it a signed webhook
+// Only available when the mock gateway is configured, for development and testing
+func (s *GatewayService) CompleteMockOrder(tenantID int, gatewayOrderID string, paid bool) (*domain.GatewayOrder, error) {
+	mock, ok := s.gateway.(*gateway.MockGateway)
+	if !ok {
+		return nil, fmt.Errorf("mock checkout is only available with the mock gateway")
+	}
+
+	order, err := s.orderRepo.GetOrderByGatewayOrderID(mock.Name(), gatewayOrderID)
+	if err != nil {
+		return nil, err
+	}
+	if order == nil || order.TenantID != tenantID {
+		return nil, fmt.Errorf("gateway order %s not found", gatewayOrderID)
+	}
+	if !order.IsOpen() {
+		return nil, fmt.Errorf("gateway order is already %s", order.Status)
+	}
+
+	body, headers, err := mock.SignedWebhook(order.GatewayOrderID, order.Amount, paid)
+	if err != nil {
+		return nil, err
+	}
+	return s.HandleWebhook(body, headers)
+}
+
+// GetOrdersByTenantID returns all gateway orders of a tenant
+func (s *GatewayService) GetOrdersByTenantID(tenantID int) ([]*domain.GatewayOrder, error) {
+	return s.orderRepo.GetOrdersByTenantID(tenantID)
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"backend-form/m/internal/domain"
+	"encoding/csv"
+	"io"
+	"strconv"
+)
+
+// WriteIncomeExpenseReportCSV writes a report as CSV with one column per expense category
+// Adjustments get their own column so they are never mistaken for money received
+func WriteIncomeExpenseReportCSV(report *domain.IncomeExpenseReport, w io.Writer) error {
+	codes := report.GetCategoryCodes()
+
+	header := []string{"Period", "Income"}
+	for _, code := range codes {
+		name := report.CategoryNames[code]
+		if name == "" {
+			name = code
+		}
+		header = append(header, name)
+	}
+	header = append(header, "Total expenses", "Net", "Adjustments (not cash)")
+
+	rows := [][]string{header}
+	for _, row := range report.Rows {
+		rows = append(rows, reportCSVRow(row.Period, row.Income, row.ExpensesByCategory, row.Expenses, row.Net, row.Adjustments, codes))
+	}
+	rows = append(rows, reportCSVRow("Total", report.TotalIncome, report.ExpensesByCategory, report.TotalExpenses, report.Net, report.TotalAdjustments, codes))
+
+	cw := csv.NewWriter(w)
+	if err := cw.WriteAll(rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+// reportCSVRow formats one line of an income and expense report
+func reportCSVRow(period string, income int, byCategory map[string]int, expenses int, net int, adjustments int, codes []string) []string {
+	record := []string{period, strconv.Itoa(income)}
+	for _, code := range codes {
+		record = append(record, strconv.Itoa(byCategory[code]))
+	}
+	return append(record, strconv.Itoa(expenses), strconv.Itoa(net), strconv.Itoa(adjustments))
+}
@@ -0,0 +1,277 @@
+package service
+
+import (
+	"backend-form/m/internal/domain"
+	interfaces "backend-form/m/internal/repository/interfaces"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// InstallmentPlanService restructures tenant arrears into installment plans
+type InstallmentPlanService struct {
+	planRepo    interfaces.InstallmentPlanRepository
+	paymentRepo interfaces.PaymentRepository
+	tenantRepo  interfaces.TenantRepository
+}
+
+// NewInstallmentPlanService creates a new InstallmentPlanService
+func NewInstallmentPlanService(planRepo interfaces.InstallmentPlanRepository, paymentRepo interfaces.PaymentRepository, tenantRepo interfaces.TenantRepository) *InstallmentPlanService {
+	return &InstallmentPlanService{
+		planRepo:    planRepo,
+		paymentRepo: paymentRepo,
+		tenantRepo:  tenantRepo,
+	}
+}
+
+// CreatePlan settles the selected unpaid payments of a tenant and schedules installments for their balance
+// With no payment IDs, every overdue payment is restructured. The schedule is taken from
+// plan.Installments when given, otherwise installmentCount monthly installments starting at firstDue.
+func (s *InstallmentPlanService) CreatePlan(plan *domain.InstallmentPlan, paymentIDs []int, installmentCount int, firstDue time.Time, userID int) (*domain.InstallmentPlan, error) {
+	tenant, err := s.tenantRepo.GetTenantByID(plan.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if tenant.IsArchived() {
+		return nil, fmt.Errorf("tenant has moved out; settle arrears through the security deposit instead")
+	}
+
+	arrears, err := s.selectArrears(plan.TenantID, paymentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	total := 0
+	for _, payment := range arrears {
+		total += payment.RemainingBalance
+	}
+
+	installments := plan.Installments
+	if len(installments) == 0 {
+		installments, err = domain.BuildInstallmentSchedule(total, installmentCount, firstDue)
+		if err != nil {
+			return nil, fmt.Errorf("validation failed: %w", err)
+		}
+	}
+	if err := domain.ValidateInstallmentSchedule(total, installments); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	plan.UnitID = tenant.UnitID
+	plan.TotalAmount = total
+	plan.Status = domain.InstallmentPlanStatusActive
+	plan.Notes = strings.TrimSpace(plan.Notes)
+	plan.CreatedByUserID = &userID
+	plan.Arrears = nil
+	plan.Installments = nil
+
+	// Settle the originals: keep what was paid and move the rest into the plan
+	for _, payment := range arrears {
+		plan.Arrears = append(plan.Arrears, &domain.InstallmentPlanArrear{
+			PaymentID: payment.ID,
+			Amount:    payment.RemainingBalance,
+			Payment:   payment,
+		})
+	}
+
+	for _, installment := range installments {
+		installment.Payment = &domain.Payment{
+			TenantID:         plan.TenantID,
+			UnitID:           plan.UnitID,
+			Amount:           installment.Amount,
+			RemainingBalance: installment.Amount,
+			DueDate:          installment.DueDate,
+			PaymentMethod:    arrears[0].PaymentMethod,
+			UPIID:            arrears[0].UPIID,
+			Label:            domain.PaymentLabelInstallment,
+		}
+		if err := installment.Payment.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid installment payment: %w", err)
+		}
+		plan.Installments = append(plan.Installments, installment)
+	}
+
+	if err := s.planRepo.CreatePlan(plan); err != nil {
+		return nil, err
+	}
+
+	for _, arrear := range plan.Arrears {
+		arrear.Payment.AmountAdjusted += arrear.Amount
+		arrear.Payment.RecalculateBalance()
+	}
+	return plan, nil
+}
+
+// selectArrears loads the unpaid payments to restructure
+func (s *InstallmentPlanService) selectArrears(tenantID int, paymentIDs []int) ([]*domain.Payment, error) {
+	unpaid, err := s.paymentRepo.GetUnpaidPaymentsByTenantID(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load unpaid payments: %w", err)
+	}
+
+	selected := make(map[int]bool)
+	unpaidIDs := make(map[int]bool)
+	for _, id := range paymentIDs {
+		selected[id] = true
+	}
+	for _, payment := range unpaid {
+		unpaidIDs[payment.ID] = true
+	}
+	for id := range selected {
+		if !unpaidIDs[id] {
+			return nil, fmt.Errorf("payment %d is not an unpaid payment of this tenant", id)
+		}
+	}
+
+	now := time.Now()
+	var arrears []*domain.Payment
+	for _, payment := range unpaid {
+		if len(selected) > 0 && !selected[payment.ID] {
+			continue
+		}
+		if len(selected) == 0 && !now.After(payment.DueDate) {
+			continue // Only overdue payments by default
+		}
+
+		if payment.Label == domain.PaymentLabelInstallment {
+			return nil, fmt.Errorf("payment %d is already an installment; cancel its plan to restructure again", payment.ID)
+		}
+		payment.Transactions, err = s.paymentRepo.GetPaymentTransactionsByPaymentID(payment.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load transactions of payment %d: %w", payment.ID, err)
+		}
+		if payment.HasPendingVerification() {
+			return nil, fmt.Errorf("payment %d has a transaction awaiting verification; verify or reject it first", payment.ID)
+		}
+		arrears = append(arrears, payment)
+	}
+
+	if len(arrears) == 0 {
+		return nil, fmt.Errorf("tenant has no overdue payments to restructure")
+	}
+	return arrears, nil
+}
+
+// CancelPlan stops an active plan and puts the unpaid part back on the original payments
+// Paid installments are kept; unpaid balance is restored to the originals, oldest first
+func (s *InstallmentPlanService) CancelPlan(planID int, reason string) (*domain.InstallmentPlan, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, fmt.Errorf("reason is required")
+	}
+
+	plan, err := s.GetPlanByID(planID)
+	if err != nil {
+		return nil, err
+	}
+	if !plan.IsActive() {
+		return nil, fmt.Errorf("installment plan has already been %s", plan.Status)
+	}
+
+	for _, installment := range plan.Installments {
+		if installment.Payment != nil && installment.Payment.HasPendingVerification() {
+			return nil, fmt.Errorf("installment %d has a payment awaiting verification; verify or reject it first", installment.Sequence)
+		}
+	}
+
+	// Close the unpaid part of every installment
+	unpaid := 0
+	for _, installment := range plan.Installments {
+		payment := installment.Payment
+		if payment == nil || payment.IsFullyPaid {
+			continue
+		}
+		unpaid += payment.RemainingBalance
+		if payment.AmountPaid > 0 {
+			payment.Notes = strings.TrimSpace(payment.Notes + " (plan cancelled: " + reason + ")")
+		}
+	}
+
+	// Restore the unpaid balance to the original payments, oldest first
+	for _, arrear := range plan.Arrears {
+		if unpaid <= 0 {
+			break
+		}
+		restore := arrear.Amount
+		if restore > unpaid {
+			restore = unpaid
+		}
+
+		arrear.RestoredAmount = restore
+		arrear.Payment.Notes = strings.TrimSpace(fmt.Sprintf("%s (₹%d restored: installment plan #%d cancelled)", arrear.Payment.Notes, restore, plan.ID))
+		unpaid -= restore
+	}
+
+	now := time.Now()
+	plan.Status = domain.InstallmentPlanStatusCancelled
+	plan.CancelReason = reason
+	plan.CancelledAt = &now
+	if err := s.planRepo.CancelPlan(plan); err != nil {
+		return nil, err
+	}
+
+	return s.GetPlanByID(plan.ID)
+}
+
+// GetPlanByID returns a plan with its arrears and installments loaded
+func (s *InstallmentPlanService) GetPlanByID(id int) (*domain.InstallmentPlan, error) {
+	plan, err := s.planRepo.GetPlanByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.loadPlan(plan); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// GetPlansByTenantID returns all plans of a tenant with their arrears and installments loaded
+func (s *InstallmentPlanService) GetPlansByTenantID(tenantID int) ([]*domain.InstallmentPlan, error) {
+	plans, err := s.planRepo.GetPlansByTenantID(tenantID)
+	if err != nil {
+		return nil, err
+	}
+	for _, plan := range plans {
+		if err := s.loadPlan(plan); err != nil {
+			return nil, err
+		}
+	}
+	return plans, nil
+}
+
+// loadPlan populates a plan's arrears and installments
+func (s *InstallmentPlanService) loadPlan(plan *domain.InstallmentPlan) error {
+	arrears, err := s.planRepo.GetArrearsByPlanID(plan.ID)
+	if err != nil {
+		return err
+	}
+	for _, arrear := range arrears {
+		arrear.Payment, err = s.paymentRepo.GetPaymentByID(arrear.PaymentID)
+		if err != nil {
+			return err
+		}
+	}
+	plan.Arrears = arrears
+
+	installments, err := s.planRepo.GetInstallmentsByPlanID(plan.ID)
+	if err != nil {
+		return err
+	}
+	for _, installment := range installments {
+		if installment.PaymentID == nil {
+			continue
+		}
+		payment, err := s.paymentRepo.GetPaymentByID(*installment.PaymentID)
+		if err != nil {
+			return err
+		}
+		payment.Transactions, err = s.paymentRepo.GetPaymentTransactionsByPaymentID(payment.ID)
+		if err != nil {
+			return fmt.Errorf("failed to load transactions of payment %d: %w", payment.ID, err)
+		}
+		installment.Payment = payment
+	}
+	plan.Installments = installments
+
+	return nil
+}
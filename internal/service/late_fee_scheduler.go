@@ -0,0 +1,70 @@
+package service
+
+import (
+	"backend-form/m/internal/logger"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// LateFeeScheduler charges late fees on overdue payments once a day
+type LateFeeScheduler struct {
+	lateFeeService *LateFeeService
+	stopChan       chan bool
+}
+
+// NewLateFeeScheduler creates a new LateFeeScheduler
+func NewLateFeeScheduler(lateFeeService *LateFeeService) *LateFeeScheduler {
+	return &LateFeeScheduler{
+		lateFeeService: lateFeeService,
+		stopChan:       make(chan bool),
+	}
+}
+
+// Start starts the scheduler in the background
+func (s *LateFeeScheduler) Start() {
+	go s.run()
+}
+
+// Stop stops the scheduler (non-blocking)
+func (s *LateFeeScheduler) Stop() {
+	select {
+	case s.stopChan <- true:
+		// Stop signal sent
+	default:
+		// Channel full or already stopping, ignore
+	}
+}
+
+// run executes the scheduler loop: once on start, then every 24 hours
+func (s *LateFeeScheduler) run() {
+	s.applyLateFees()
+
+	ticker := time.NewTicker(24 * time.Hour)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.applyLateFees()
+		case <-s.stopChan:
+			logger.Info("Late fee scheduler stopped")
+			return
+		}
+	}
+}
+
+// applyLateFees runs the daily late fee job
+func (s *LateFeeScheduler) applyLateFees() {
+	logger.Info("Running daily late fee check...")
+	changed, err := s.lateFeeService.ApplyLateFees(time.Now())
+	if err != nil {
+		logger.Error("Error applying late fees",
+			zap.Error(err),
+		)
+		return
+	}
+	logger.Info("Late fee check completed successfully",
+		zap.Int("fees_charged", changed),
+	)
+}
@@ -0,0 +1,257 @@
+package service
+
+import (
+	"backend-form/m/internal/domain"
+	interfaces "backend-form/m/internal/repository/interfaces"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// LateFeeService handles late fee policies and charging late fees on overdue payments
+type LateFeeService struct {
+	lateFeeRepo interfaces.LateFeeRepository
+	paymentRepo interfaces.PaymentRepository
+	tenantRepo  interfaces.TenantRepository
+}
+
+// NewLateFeeService creates a new LateFeeService
+func NewLateFeeService(lateFeeRepo interfaces.LateFeeRepository, paymentRepo interfaces.PaymentRepository, tenantRepo interfaces.TenantRepository) *LateFeeService {
+	return &LateFeeService{
+		lateFeeRepo: lateFeeRepo,
+		paymentRepo: paymentRepo,
+		tenantRepo:  tenantRepo,
+	}
+}
+
+// ============================================
+// Policies
+// ============================================
+
+// CreatePolicy validates and creates a late fee policy
+func (s *LateFeeService) CreatePolicy(policy *domain.LateFeePolicy) error {
+	if err := policy.Validate(); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	return s.lateFeeRepo.CreatePolicy(policy)
+}
+
+// UpdatePolicy validates and updates a late fee policy
+// Fees already charged keep their amount; new charges use the updated policy
+func (s *LateFeeService) UpdatePolicy(policy *domain.LateFeePolicy) error {
+	if err := policy.Validate(); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	return s.lateFeeRepo.UpdatePolicy(policy)
+}
+
+// DeletePolicy deletes a late fee policy
+func (s *LateFeeService) DeletePolicy(id int) error {
+	return s.lateFeeRepo.DeletePolicy(id)
+}
+
+// GetAllPolicies returns all late fee policies
+func (s *LateFeeService) GetAllPolicies() ([]*domain.LateFeePolicy, error) {
+	return s.lateFeeRepo.GetAllPolicies()
+}
+
+// ============================================
+// Charging
+// ============================================
+
+// ApplyLateFees charges late fees on all overdue payments as of the given date
+// The first run creates a linked late_fee payment; later runs raise its amount
+// as per-day fees grow. Waived or reversed fees are never charged again.
+// Returns the number of fees created or adjusted.
+func (s *LateFeeService) ApplyLateFees(asOf time.Time) (int, error) {
+	payments, err := s.paymentRepo.GetAllPayments()
+	if err != nil {
+		return 0, fmt.Errorf("failed to load payments: %w", err)
+	}
+
+	policies := make(map[int]*domain.LateFeePolicy) // unitID -> policy (nil = none)
+	activeTenants := make(map[int]bool)
+	changed := 0
+
+	for _, payment := range payments {
+		if payment.IsFullyPaid || payment.Label == domain.PaymentLabelLateFee || !asOf.After(payment.DueDate) {
+			continue
+		}
+
+		// Tenants who have moved out are settled through their deposit, not charged late fees
+		active, seen := activeTenants[payment.TenantID]
+		if !seen {
+			tenant, err := s.tenantRepo.GetTenantByID(payment.TenantID)
+			active = err == nil && !tenant.IsArchived()
+			activeTenants[payment.TenantID] = active
+		}
+		if !active {
+			continue
+		}
+
+		policy, seen := policies[payment.UnitID]
+		if !seen {
+			policy, err = s.lateFeeRepo.GetPolicyForUnit(payment.UnitID)
+			if err != nil {
+				return changed, err
+			}
+			policies[payment.UnitID] = policy
+		}
+		if policy == nil {
+			continue
+		}
+
+		fee := policy.CalculateFee(payment, asOf)
+		if fee <= 0 {
+			continue
+		}
+
+		updated, err := s.chargeLateFee(payment, policy, fee, asOf)
+		if err != nil {
+			return changed, fmt.Errorf("failed to charge late fee for payment %d: %w", payment.ID, err)
+		}
+		if updated {
+			changed++
+		}
+	}
+
+	return changed, nil
+}
+
+// chargeLateFee creates or raises the late fee for a single overdue payment
+func (s *LateFeeService) chargeLateFee(payment *domain.Payment, policy *domain.LateFeePolicy, fee int, asOf time.Time) (bool, error) {
+	existing, err := s.lateFeeRepo.GetLateFeeByPaymentID(payment.ID)
+	if err != nil {
+		return false, err
+	}
+
+	if existing == nil {
+		feePayment := &domain.Payment{
+			TenantID:         payment.TenantID,
+			UnitID:           payment.UnitID,
+			Amount:           fee,
+			AmountPaid:       0,
+			RemainingBalance: fee,
+			DueDate:          time.Date(asOf.Year(), asOf.Month(), asOf.Day(), 0, 0, 0, 0, asOf.Location()),
+			PaymentMethod:    payment.PaymentMethod,
+			UPIID:            payment.UPIID,
+			Label:            domain.PaymentLabelLateFee,
+			Notes:            fmt.Sprintf("Late fee for %s due %s", payment.GetLabelDisplayName(), payment.GetFormattedDueDate()),
+		}
+		if err := feePayment.Validate(); err != nil {
+			return false, fmt.Errorf("invalid late fee payment: %w", err)
+		}
+
+		return true, s.lateFeeRepo.CreateLateFee(&domain.LateFee{
+			PaymentID: payment.ID,
+			PolicyID:  policy.ID,
+			TenantID:  payment.TenantID,
+			Amount:    fee,
+			Status:    domain.LateFeeStatusApplied,
+		}, feePayment)
+	}
+
+	// Only growing fees (per-day) need adjusting, and never after the owner has acted on them
+	if !existing.IsActive() || existing.FeePaymentID == nil || fee <= existing.Amount {
+		return false, nil
+	}
+
+	feePayment, err := s.paymentRepo.GetPaymentByID(*existing.FeePaymentID)
+	if err != nil {
+		return false, err
+	}
+	if feePayment.IsFullyPaid {
+		return false, nil // Tenant already paid the fee as it stood
+	}
+
+	return true, s.lateFeeRepo.RaiseLateFee(existing, fee)
+}
+
+// ============================================
+// Owner actions
+// ============================================
+
+// WaiveLateFee forgives the unpaid part of a late fee
+// Anything the tenant already paid towards the fee is kept
+func (s *LateFeeService) WaiveLateFee(lateFeeID int, userID int, reason string) (*domain.LateFee, error) {
+	return s.closeLateFee(lateFeeID, userID, reason, domain.LateFeeStatusWaived)
+}
+
+// ReverseLateFee cancels a late fee charged in error
+// Only possible while nothing has been paid towards it
+func (s *LateFeeService) ReverseLateFee(lateFeeID int, userID int, reason string) (*domain.LateFee, error) {
+	return s.closeLateFee(lateFeeID, userID, reason, domain.LateFeeStatusReversed)
+}
+
+// closeLateFee waives or reverses an applied late fee and updates its late_fee payment
+func (s *LateFeeService) closeLateFee(lateFeeID int, userID int, reason string, status string) (*domain.LateFee, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, fmt.Errorf("reason is required")
+	}
+
+	fee, err := s.lateFeeRepo.GetLateFeeByID(lateFeeID)
+	if err != nil {
+		return nil, err
+	}
+	if !fee.IsActive() {
+		return nil, fmt.Errorf("late fee has already been %s", fee.Status)
+	}
+
+	var feePayment *domain.Payment
+	if fee.FeePaymentID != nil {
+		feePayment, err = s.paymentRepo.GetPaymentByID(*fee.FeePaymentID)
+		if err != nil {
+			return nil, err
+		}
+
+		if feePayment.AmountPaid > 0 {
+			if status == domain.LateFeeStatusReversed {
+				return nil, fmt.Errorf("late fee has payments against it and cannot be reversed; waive the remainder instead")
+			}
+			// Keep what was paid and close the rest
+			feePayment.Amount = feePayment.AmountPaid + feePayment.AmountAdjusted
+			feePayment.Notes = strings.TrimSpace(feePayment.Notes + " (remainder waived: " + reason + ")")
+			feePayment.RecalculateBalance()
+		} else {
+			feePayment.Transactions, _ = s.paymentRepo.GetPaymentTransactionsByPaymentID(feePayment.ID)
+			if feePayment.HasPendingVerification() {
+				return nil, fmt.Errorf("late fee has a payment awaiting verification; verify or reject it first")
+			}
+			fee.FeePaymentID = nil // Nothing was paid: the fee payment is deleted
+		}
+	}
+
+	now := time.Now()
+	fee.Status = status
+	fee.Reason = reason
+	fee.ActionedByUserID = &userID
+	fee.ActionedAt = &now
+	if err := s.lateFeeRepo.CloseLateFee(fee, feePayment); err != nil {
+		return nil, err
+	}
+
+	return fee, nil
+}
+
+// GetLateFeesByTenantID returns all late fees charged to a tenant
+func (s *LateFeeService) GetLateFeesByTenantID(tenantID int) ([]*domain.LateFee, error) {
+	return s.lateFeeRepo.GetLateFeesByTenantID(tenantID)
+}
+
+// GetActiveLateFeesByPayment returns applied late fees of a tenant keyed by overdue payment ID
+func (s *LateFeeService) GetActiveLateFeesByPayment(tenantID int) map[int]*domain.LateFee {
+	result := make(map[int]*domain.LateFee)
+	fees, err := s.lateFeeRepo.GetLateFeesByTenantID(tenantID)
+	if err != nil {
+		return result
+	}
+	for _, fee := range fees {
+		if fee.IsActive() {
+			result[fee.PaymentID] = fee
+		}
+	}
+	return result
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"backend-form/m/internal/domain"
+	interfaces "backend-form/m/internal/repository/interfaces"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// LeaseService handles lease agreements: terms, renewals and the rent in force over time
+type LeaseService struct {
+	leaseRepo  interfaces.LeaseRepository
+	tenantRepo interfaces.TenantRepository
+	unitRepo   interfaces.UnitRepository
+}
+
+// NewLeaseService creates a new LeaseService
+func NewLeaseService(leaseRepo interfaces.LeaseRepository, tenantRepo interfaces.TenantRepository, unitRepo interfaces.UnitRepository) *LeaseService {
+	return &LeaseService{
+		leaseRepo:  leaseRepo,
+		tenantRepo: tenantRepo,
+		unitRepo:   unitRepo,
+	}
+}
+
+// CreateLease creates the lease of a tenant who has none in force
+// StartDate defaults to the move-in date and MonthlyRent to the unit's rent
+func (s *LeaseService) CreateLease(lease *domain.Lease) error {
+	tenant, err := s.tenantRepo.GetTenantByID(lease.TenantID)
+	if err != nil {
+		return fmt.Errorf("tenant not found: %w", err)
+	}
+	if tenant.IsArchived() {
+		return fmt.Errorf("tenant has moved out")
+	}
+
+	existing, err := s.leaseRepo.GetActiveLeaseByTenantID(tenant.ID)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return fmt.Errorf("tenant already has an active lease ending %s; renew it instead", existing.GetFormattedEndDate())
+	}
+
+	lease.UnitID = tenant.UnitID
+	if lease.StartDate.IsZero() {
+		lease.StartDate = tenant.MoveInDate
+	}
+	if lease.MonthlyRent <= 0 {
+		unit, err := s.unitRepo.GetUnitByID(tenant.UnitID)
+		if err != nil {
+			return fmt.Errorf("unit not found: %w", err)
+		}
+		lease.MonthlyRent = unit.MonthlyRent
+	}
+	if lease.EscalationPercent > 0 && lease.EscalationIntervalMonths == 0 {
+		lease.EscalationIntervalMonths = 12
+	}
+	lease.Status = domain.LeaseStatusActive
+	lease.PreviousLeaseID = nil
+	lease.Notes = strings.TrimSpace(lease.Notes)
+
+	if err := lease.Validate(); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	return s.leaseRepo.CreateLease(lease)
+}
+
+// RenewLease closes a tenant's active lease and starts a new one the day after it ends
+// monthlyRent 0 carries over the rent in force at the end of the old lease;
+// unset terms (escalation, notice, lock-in) are copied from the old lease.
+func (s *LeaseService) RenewLease(tenantID int, newEndDate time.Time, monthlyRent int, escalationPercent *float64, notes string) (*domain.Lease, error) {
+	current, err := s.leaseRepo.GetActiveLeaseByTenantID(tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil {
+		return nil, fmt.Errorf("tenant has no active lease to renew")
+	}
+
+	renewal := &domain.Lease{
+		TenantID:                 current.TenantID,
+		UnitID:                   current.UnitID,
+		StartDate:                current.EndDate.AddDate(0, 0, 1),
+		EndDate:                  newEndDate,
+		MonthlyRent:              monthlyRent,
+		EscalationPercent:        current.EscalationPercent,
+		EscalationIntervalMonths: current.EscalationIntervalMonths,
+		NoticePeriodDays:         current.NoticePeriodDays,
+		LockInMonths:             0, // Lock-in applies to the first term only
+		Status:                   domain.LeaseStatusActive,
+		PreviousLeaseID:          &current.ID,
+		Notes:                    strings.TrimSpace(notes),
+	}
+	if renewal.MonthlyRent <= 0 {
+		renewal.MonthlyRent = current.GetRentFor(current.EndDate)
+	}
+	if escalationPercent != nil {
+		renewal.EscalationPercent = *escalationPercent
+		if renewal.EscalationPercent > 0 && renewal.EscalationIntervalMonths == 0 {
+			renewal.EscalationIntervalMonths = 12
+		}
+	}
+
+	if err := renewal.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	current.Status = domain.LeaseStatusRenewed
+	if err := s.leaseRepo.UpdateLease(current); err != nil {
+		return nil, err
+	}
+	if err := s.leaseRepo.CreateLease(renewal); err != nil {
+		return nil, err
+	}
+
+	return renewal, nil
+}
+
+// PrepareEndLease returns a tenant's active lease ended early on endDate, e.g. on move-out (nil if there is none)
+// The lease is not saved; the move-out saves it
+func (s *LeaseService) PrepareEndLease(tenantID int, endDate time.Time) (*domain.Lease, error) {
+	lease, err := s.leaseRepo.GetActiveLeaseByTenantID(tenantID)
+	if err != nil || lease == nil {
+		return nil, err
+	}
+
+	if endDate.Before(lease.EndDate) && endDate.After(lease.StartDate) {
+		lease.EndDate = endDate
+	}
+	lease.Status = domain.LeaseStatusEnded
+	return lease, nil
+}
+
+// GetActiveLease returns the lease currently in force for a tenant (nil if none)
+func (s *LeaseService) GetActiveLease(tenantID int) (*domain.Lease, error) {
+	return s.leaseRepo.GetActiveLeaseByTenantID(tenantID)
+}
+
+// GetLeaseHistory returns all leases of a tenant including renewals, most recent first
+func (s *LeaseService) GetLeaseHistory(tenantID int) ([]*domain.Lease, error) {
+	return s.leaseRepo.GetLeasesByTenantID(tenantID)
+}
+
+// GetRentForDueDate returns the rent in force under the tenant's lease for a due date
+// Returns false if the tenant has no lease starting on or before the due date.
+// After a lease expires without renewal the last rent keeps applying (holdover).
+func (s *LeaseService) GetRentForDueDate(tenantID int, dueDate time.Time) (int, bool) {
+	leases, err := s.leaseRepo.GetLeasesByTenantID(tenantID)
+	if err != nil {
+		return 0, false
+	}
+
+	// Leases are ordered most recent first, so the first one started by the due date applies
+	for _, lease := range leases {
+		if dueDate.Before(lease.StartDate) {
+			continue
+		}
+		if lease.Covers(dueDate) {
+			return lease.GetRentFor(dueDate), true
+		}
+		return lease.GetRentFor(lease.EndDate), true
+	}
+	return 0, false
+}
+
+// GetUpcomingEvents returns lease expiries and rent escalations in the next days, soonest first
+func (s *LeaseService) GetUpcomingEvents(asOf time.Time, days int) ([]domain.LeaseEvent, error) {
+	leases, err := s.leaseRepo.GetActiveLeases()
+	if err != nil {
+		return nil, err
+	}
+
+	var events []domain.LeaseEvent
+	for _, lease := range leases {
+		events = append(events, lease.GetUpcomingEvents(asOf, days)...)
+	}
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Date.Before(events[j].Date)
+	})
+	return events, nil
+}
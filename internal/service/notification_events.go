@@ -0,0 +1,268 @@
+package service
+
+import (
+	"backend-form/m/internal/domain"
+	"fmt"
+	"time"
+)
+
+// Event notifications are queued by the services when something happens to a tenant's payments or account.
+// Each event is queued once per recipient (see domain.NotificationDedupeKey), so repeating an action does not
+// notify twice. Callers treat them as best effort: a failed notification never fails the action that raised it
+
+// NotifyPaymentSubmitted tells the owner a tenant submitted a UTR that needs verifying
+func (s *NotificationService) NotifyPaymentSubmitted(tx *domain.PaymentTransaction, payment *domain.Payment) error {
+	tenant, err := s.tenantRepo.GetTenantByID(payment.TenantID)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant: %w", err)
+	}
+	unit, err := s.unitRepo.GetUnitByID(payment.UnitID)
+	if err != nil {
+		return fmt.Errorf("failed to get unit: %w", err)
+	}
+
+	notification := &domain.Notification{
+		Type:      domain.NotificationTypePaymentSubmitted,
+		Recipient: domain.NotificationRecipientOwner,
+		TenantID:  &payment.TenantID,
+		PaymentID: &payment.ID,
+	}
+	notification.DedupeKey = domain.NotificationDedupeKey(notification.Type, notification.Recipient, &payment.TenantID, &payment.ID, tx.TransactionID)
+	return s.notifyOwner(notification, domain.MessagePaymentSubmitted,
+		tenant.Name,
+		unit.UnitCode,
+		tx.TransactionID,
+		payment.GetLabelDisplayName(),
+		payment.RemainingBalance,
+	)
+}
+
+// NotifyTransactionVerified tells the tenant a transaction was verified
+// If it left a balance on one of the payments it was allocated to, the tenant is told what is still due
+func (s *NotificationService) NotifyTransactionVerified(tenantID int, transactionID string, amount int, linkedPaymentID int, allocatedPaymentIDs []int) error {
+	paymentID := linkedPaymentID
+	var partial *domain.Payment
+	for _, id := range allocatedPaymentIDs {
+		payment, err := s.paymentRepo.GetPaymentByID(id)
+		if err != nil {
+			return fmt.Errorf("failed to get payment: %w", err)
+		}
+		if payment != nil && !payment.IsFullyPaid {
+			partial = payment
+			paymentID = payment.ID
+			break
+		}
+	}
+
+	notification := &domain.Notification{
+		Type:      domain.NotificationTypePaymentVerified,
+		Recipient: domain.NotificationRecipientTenant,
+		TenantID:  &tenantID,
+		PaymentID: &paymentID,
+	}
+	if partial == nil {
+		notification.DedupeKey = domain.NotificationDedupeKey(notification.Type, notification.Recipient, &tenantID, &paymentID, transactionID)
+		return s.notifyTenant(notification, domain.MessagePaymentVerified, amount, transactionID)
+	}
+
+	notification.Type = domain.NotificationTypePaymentPartial
+	notification.DedupeKey = domain.NotificationDedupeKey(notification.Type, notification.Recipient, &tenantID, &paymentID, transactionID)
+	return s.notifyTenant(notification, domain.MessagePaymentPartial,
+		amount,
+		transactionID,
+		partial.RemainingBalance,
+		partial.GetLabelDisplayName(),
+		partial.GetFormattedDueDate(),
+	)
+}
+
+// NotifyTransactionRejected tells the tenant a submitted transaction was rejected and why
+func (s *NotificationService) NotifyTransactionRejected(tenantID int, paymentID int, transactionID string, reason string) error {
+	notification := &domain.Notification{
+		Type:      domain.NotificationTypePaymentRejected,
+		Recipient: domain.NotificationRecipientTenant,
+		TenantID:  &tenantID,
+		PaymentID: &paymentID,
+	}
+	notification.DedupeKey = domain.NotificationDedupeKey(notification.Type, notification.Recipient, &tenantID, &paymentID, transactionID)
+	return s.notifyTenant(notification, domain.MessagePaymentRejected, transactionID, reason)
+}
+
+// NotifyChargeCreated tells the tenant a new charge was raised
+func (s *NotificationService) NotifyChargeCreated(payment *domain.Payment) error {
+	notification := &domain.Notification{
+		Type:      domain.NotificationTypeChargeCreated,
+		Recipient: domain.NotificationRecipientTenant,
+		TenantID:  &payment.TenantID,
+		PaymentID: &payment.ID,
+	}
+	notification.DedupeKey = domain.NotificationDedupeKey(notification.Type, notification.Recipient, &payment.TenantID, &payment.ID, "")
+	return s.notifyTenant(notification, domain.MessageChargeCreated,
+		payment.GetLabelDisplayName(),
+		payment.Amount,
+		payment.GetFormattedDueDate(),
+	)
+}
+
+// NotifyPasswordReset tells the tenant the owner regenerated their password
+// The new password is not included; the owner hands it over. Every reset is notified (no dedupe key).
+// Returns nil if the tenant cannot be reached
+func (s *NotificationService) NotifyPasswordReset(tenantID int) error {
+	notification := &domain.Notification{
+		Type:      domain.NotificationTypePasswordReset,
+		Recipient: domain.NotificationRecipientTenant,
+		TenantID:  &tenantID,
+	}
+	if err := s.notifyTenant(notification, domain.MessagePasswordReset); err != nil && !isSkipped(err) {
+		return err
+	}
+	return nil
+}
+
+// overdueEscalationDays are how many days after the due date overdue notices are sent
+// Tenants get every notice; the owner is only told from ownerOverdueEscalationDays on
+var overdueEscalationDays = []int{1, 7, 15, 30}
+
+const ownerOverdueEscalationDays = 7
+
+// CheckAndSendOverdueEscalations sends overdue notices for payments still unpaid after their due date
+// Each notice is queued once per payment, recipient and number of days overdue, on the first hourly run
+// outside the recipient's quiet hours
+func (s *NotificationService) CheckAndSendOverdueEscalations() error {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	ownerPrefs, err := s.preferences.GetOwnerPreferences()
+	if err != nil {
+		return fmt.Errorf("failed to get owner notification preferences: %w", err)
+	}
+
+	for _, daysOverdue := range overdueEscalationDays {
+		payments, err := s.paymentRepo.GetUnpaidPaymentsByDueDate(today.AddDate(0, 0, -daysOverdue))
+		if err != nil {
+			return fmt.Errorf("failed to get payments %d days overdue: %w", daysOverdue, err)
+		}
+
+		for _, payment := range payments {
+			if payment.IsFullyPaid {
+				continue
+			}
+
+			tenantPrefs, err := s.preferences.GetTenantPreferences(payment.TenantID)
+			if err != nil {
+				fmt.Printf("Warning: Failed to get notification preferences of tenant %d: %v\n", payment.TenantID, err)
+				continue
+			}
+			if s.isScheduledNotificationDue(tenantPrefs, domain.NotificationTypePaymentOverdue, payment.TenantID, &payment.ID, now) {
+				if err := s.SendOverdueNoticeToTenant(payment, daysOverdue, tenantPrefs); err != nil && !isSkipped(err) {
+					fmt.Printf("Warning: Failed to send overdue notice to tenant for payment %d: %v\n", payment.ID, err)
+					// Continue with other payments
+				}
+			}
+
+			if daysOverdue >= ownerOverdueEscalationDays && s.isScheduledNotificationDue(ownerPrefs, domain.NotificationTypePaymentOverdue, payment.TenantID, &payment.ID, now) {
+				if err := s.SendOverdueNoticeToOwner(payment, daysOverdue, ownerPrefs); err != nil && !isSkipped(err) {
+					fmt.Printf("Warning: Failed to send overdue notice to owner for payment %d: %v\n", payment.ID, err)
+					// Continue with other payments
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// SendOverdueNoticeToTenant queues a notice telling a tenant a payment is overdue
+func (s *NotificationService) SendOverdueNoticeToTenant(payment *domain.Payment, daysOverdue int, prefs *domain.NotificationPreferences) error {
+	tenant, err := s.tenantRepo.GetTenantByID(payment.TenantID)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant: %w", err)
+	}
+	to, err := s.tenantRecipient(tenant)
+	if err != nil {
+		return err
+	}
+
+	_, message := domain.FormatNotification(prefs.Language, domain.MessagePaymentOverdueTenant,
+		payment.GetLabelDisplayName(),
+		payment.RemainingBalance,
+		payment.GetFormattedDueDate(),
+		daysOverdue,
+	)
+
+	notification := &domain.Notification{
+		Type:      domain.NotificationTypePaymentOverdue,
+		Recipient: domain.NotificationRecipientTenant,
+		TenantID:  &payment.TenantID,
+		PaymentID: &payment.ID,
+		Message:   message,
+	}
+	notification.DedupeKey = domain.NotificationDedupeKey(notification.Type, notification.Recipient, &payment.TenantID, &payment.ID, fmt.Sprintf("%dd", daysOverdue))
+
+	return s.enqueue(notification, to, prefs)
+}
+
+// SendOverdueNoticeToOwner queues a notice telling the owner a tenant's payment is overdue
+func (s *NotificationService) SendOverdueNoticeToOwner(payment *domain.Payment, daysOverdue int, prefs *domain.NotificationPreferences) error {
+	tenant, err := s.tenantRepo.GetTenantByID(payment.TenantID)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant: %w", err)
+	}
+	unit, err := s.unitRepo.GetUnitByID(payment.UnitID)
+	if err != nil {
+		return fmt.Errorf("failed to get unit: %w", err)
+	}
+
+	_, message := domain.FormatNotification(prefs.Language, domain.MessagePaymentOverdueOwner,
+		tenant.Name,
+		unit.UnitCode,
+		payment.GetLabelDisplayName(),
+		payment.RemainingBalance,
+		payment.GetFormattedDueDate(),
+		daysOverdue,
+	)
+
+	notification := &domain.Notification{
+		Type:      domain.NotificationTypePaymentOverdue,
+		Recipient: domain.NotificationRecipientOwner,
+		TenantID:  &payment.TenantID,
+		PaymentID: &payment.ID,
+		Message:   message,
+	}
+	notification.DedupeKey = domain.NotificationDedupeKey(notification.Type, notification.Recipient, &payment.TenantID, &payment.ID, fmt.Sprintf("%dd", daysOverdue))
+
+	return s.enqueue(notification, s.owner, prefs)
+}
+
+// notifyOwner formats a notification in the owner's language and queues it
+func (s *NotificationService) notifyOwner(notification *domain.Notification, key domain.NotificationMessageKey, args ...interface{}) error {
+	prefs, err := s.preferences.GetOwnerPreferences()
+	if err != nil {
+		return fmt.Errorf("failed to get owner notification preferences: %w", err)
+	}
+
+	_, message := domain.FormatNotification(prefs.Language, key, args...)
+	notification.Message = message
+	return s.enqueue(notification, s.owner, prefs)
+}
+
+// notifyTenant formats a notification in the tenant's language and queues it
+func (s *NotificationService) notifyTenant(notification *domain.Notification, key domain.NotificationMessageKey, args ...interface{}) error {
+	tenantID := *notification.TenantID
+	prefs, err := s.preferences.GetTenantPreferences(tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to get notification preferences: %w", err)
+	}
+	tenant, err := s.tenantRepo.GetTenantByID(tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant: %w", err)
+	}
+	to, err := s.tenantRecipient(tenant)
+	if err != nil {
+		return err
+	}
+
+	_, message := domain.FormatNotification(prefs.Language, key, args...)
+	notification.Message = message
+	return s.enqueue(notification, to, prefs)
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"backend-form/m/internal/channel"
+	"backend-form/m/internal/domain"
+	interfaces "backend-form/m/internal/repository/interfaces"
+	"fmt"
+	"time"
+)
+
+// NotificationPreferenceService manages how the owner and tenants want to be notified
+type NotificationPreferenceService struct {
+	preferenceRepo interfaces.NotificationPreferenceRepository
+	channels       []string // Configured channels, in the order they are tried
+}
+
+// NewNotificationPreferenceService creates a new NotificationPreferenceService
+func NewNotificationPreferenceService(preferenceRepo interfaces.NotificationPreferenceRepository, dispatcher *channel.Dispatcher) *NotificationPreferenceService {
+	return &NotificationPreferenceService{
+		preferenceRepo: preferenceRepo,
+		channels:       dispatcher.Names(),
+	}
+}
+
+// GetAvailableChannels returns the channels users can choose from
+func (s *NotificationPreferenceService) GetAvailableChannels() []string {
+	return s.channels
+}
+
+// GetOwnerPreferences returns the owner's preferences, or the defaults if none were saved
+func (s *NotificationPreferenceService) GetOwnerPreferences() (*domain.NotificationPreferences, error) {
+	return s.getPreferences(domain.NotificationRecipientOwner, nil)
+}
+
+// GetTenantPreferences returns a tenant's preferences, or the defaults if none were saved
+func (s *NotificationPreferenceService) GetTenantPreferences(tenantID int) (*domain.NotificationPreferences, error) {
+	return s.getPreferences(domain.NotificationRecipientTenant, &tenantID)
+}
+
+// getPreferences loads saved preferences, falling back to the defaults
+func (s *NotificationPreferenceService) getPreferences(recipient domain.NotificationRecipient, tenantID *int) (*domain.NotificationPreferences, error) {
+	prefs, err := s.preferenceRepo.GetPreferences(recipient, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if prefs == nil {
+		prefs = domain.DefaultNotificationPreferences(recipient, tenantID)
+	}
+	return prefs, nil
+}
+
+// UpdateOwnerPreferences saves the owner's preferences
+func (s *NotificationPreferenceService) UpdateOwnerPreferences(prefs *domain.NotificationPreferences) error {
+	prefs.Recipient = domain.NotificationRecipientOwner
+	prefs.TenantID = nil
+	return s.savePreferences(prefs)
+}
+
+// UpdateTenantPreferences saves a tenant's preferences
+func (s *NotificationPreferenceService) UpdateTenantPreferences(tenantID int, prefs *domain.NotificationPreferences) error {
+	prefs.Recipient = domain.NotificationRecipientTenant
+	prefs.TenantID = &tenantID
+	return s.savePreferences(prefs)
+}
+
+// savePreferences validates and stores preferences
+func (s *NotificationPreferenceService) savePreferences(prefs *domain.NotificationPreferences) error {
+	if err := prefs.Validate(); err != nil {
+		return err
+	}
+	for _, name := range prefs.Channels {
+		if !s.isAvailable(name) {
+			return fmt.Errorf("notification channel %s is not available", name)
+		}
+	}
+
+	prefs.UpdatedAt = time.Now()
+	if err := s.preferenceRepo.SavePreferences(prefs); err != nil {
+		return fmt.Errorf("failed to save notification preferences: %w", err)
+	}
+	return nil
+}
+
+// isAvailable reports whether a channel is configured
+func (s *NotificationPreferenceService) isAvailable(name string) bool {
+	for _, ch := range s.channels {
+		if ch == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,189 @@
+package service
+
+import (
+	"backend-form/m/internal/channel"
+	"backend-form/m/internal/domain"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Notification queue (outbox): notifications are persisted as pending first and delivered by the
+// NotificationWorker. Failed deliveries are retried with exponential backoff and dead-lettered after
+// domain.NotificationMaxAttempts attempts; the owner can review dead letters and put them back in the queue
+
+const (
+	notificationBatchSize     = 50              // Notifications claimed per worker pass
+	notificationClaimDuration = 5 * time.Minute // How long a claimed notification is hidden from other workers
+	failedNotificationsLimit  = 100             // Dead letters shown to the owner
+)
+
+// enqueue queues a notification for delivery
+// Nothing is queued if the recipient muted the type, cannot be reached on any channel, or was already
+// queued the same notification (same dedupe key); during quiet hours the notification waits until they end
+func (s *NotificationService) enqueue(notification *domain.Notification, to channel.Recipient, prefs *domain.NotificationPreferences) error {
+	now := time.Now()
+	if !prefs.Wants(notification.Type) {
+		return errNotificationMuted
+	}
+	if len(prefs.Channels) > 0 {
+		to.Channels = prefs.Channels
+	}
+	if !s.dispatcher.CanReach(to) {
+		return channel.ErrNoChannel
+	}
+
+	nextAttemptAt := prefs.QuietHoursEndAfter(now)
+	notification.CreatedAt = now
+	notification.Status = domain.NotificationStatusPending
+	notification.NextAttemptAt = &nextAttemptAt
+
+	created, err := s.notificationRepo.CreateNotification(notification)
+	if err != nil {
+		return fmt.Errorf("failed to queue notification: %w", err)
+	}
+	if !created {
+		return errAlreadyQueued
+	}
+
+	s.wakeWorker()
+	return nil
+}
+
+// wakeWorker tells the worker there is something to send without waiting for its next tick
+func (s *NotificationService) wakeWorker() {
+	select {
+	case s.queued <- struct{}{}:
+	default:
+		// Worker already has a wake-up pending
+	}
+}
+
+// Queued returns a channel that receives a value whenever a notification is queued
+func (s *NotificationService) Queued() <-chan struct{} {
+	return s.queued
+}
+
+// DeliverDueNotifications attempts one batch of notifications that are due
+// Returns how many notifications were attempted, so the worker knows whether more are waiting
+func (s *NotificationService) DeliverDueNotifications() (int, error) {
+	now := time.Now()
+	due, err := s.notificationRepo.ClaimDueNotifications(now, now.Add(notificationClaimDuration), notificationBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim due notifications: %w", err)
+	}
+
+	for _, notification := range due {
+		if err := s.attempt(notification, now); err != nil {
+			// The claim expires and the notification is attempted again
+			fmt.Printf("Warning: Failed to deliver notification %d: %v\n", notification.ID, err)
+		}
+	}
+
+	return len(due), nil
+}
+
+// attempt delivers one claimed notification and records the outcome
+// The recipient's current preferences apply: a type muted since it was queued is cancelled,
+// and quiet hours postpone it without counting an attempt
+func (s *NotificationService) attempt(notification *domain.Notification, now time.Time) error {
+	prefs, to, err := s.recipientFor(notification)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case !prefs.Wants(notification.Type):
+		notification.Status = domain.NotificationStatusCancelled
+		notification.NextAttemptAt = nil
+		notification.Error = errNotificationMuted.Error()
+	case prefs.IsQuietAt(now):
+		nextAttemptAt := prefs.QuietHoursEndAfter(now)
+		notification.NextAttemptAt = &nextAttemptAt
+	default:
+		s.send(notification, to, prefs, now)
+	}
+
+	if err := s.notificationRepo.UpdateNotification(notification); err != nil {
+		return fmt.Errorf("failed to update notification record: %w", err)
+	}
+	return nil
+}
+
+// send dispatches a notification over the recipient's preferred channels and records the attempt
+// Falling back between channels counts as a single attempt
+func (s *NotificationService) send(notification *domain.Notification, to channel.Recipient, prefs *domain.NotificationPreferences, now time.Time) {
+	if len(prefs.Channels) > 0 {
+		to.Channels = prefs.Channels
+	}
+
+	message := channel.Message{Subject: domain.NotificationSubject(prefs.Language, notification.Type), Body: notification.Message}
+	delivery, err := s.dispatcher.Send(context.Background(), to, message)
+	s.recordTelegramAttempts(notification, delivery)
+	if errors.Is(err, channel.ErrNoChannel) {
+		// Retrying cannot help until the recipient's contact details change; the owner can retry it then
+		notification.MarkDead("no notification channel can reach the recipient")
+		return
+	}
+
+	via, address := delivery.Channel, delivery.Address
+	if err != nil {
+		if n := len(delivery.Attempts); n > 0 {
+			via = delivery.Attempts[n-1].Channel
+			address = delivery.Attempts[n-1].Address
+		}
+	}
+	notification.RecordAttempt(via, address, err, now)
+}
+
+// recipientFor returns the current preferences and contact details of a notification's recipient
+func (s *NotificationService) recipientFor(notification *domain.Notification) (*domain.NotificationPreferences, channel.Recipient, error) {
+	if notification.Recipient != domain.NotificationRecipientTenant || notification.TenantID == nil {
+		prefs, err := s.preferences.GetOwnerPreferences()
+		if err != nil {
+			return nil, s.owner, fmt.Errorf("failed to get owner notification preferences: %w", err)
+		}
+		return prefs, s.owner, nil
+	}
+
+	prefs, err := s.preferences.GetTenantPreferences(*notification.TenantID)
+	if err != nil {
+		return nil, channel.Recipient{}, fmt.Errorf("failed to get notification preferences: %w", err)
+	}
+	tenant, err := s.tenantRepo.GetTenantByID(*notification.TenantID)
+	if err != nil {
+		return nil, channel.Recipient{}, fmt.Errorf("failed to get tenant: %w", err)
+	}
+	to, err := s.tenantRecipient(tenant)
+	if err != nil {
+		return nil, channel.Recipient{}, err
+	}
+	return prefs, to, nil
+}
+
+// GetFailedNotifications returns the most recent notifications that were given up on
+func (s *NotificationService) GetFailedNotifications() ([]*domain.Notification, error) {
+	return s.notificationRepo.GetNotificationsByStatus(domain.NotificationStatusDead, failedNotificationsLimit)
+}
+
+// RetryNotification puts a failed notification back in the queue with a fresh set of attempts
+func (s *NotificationService) RetryNotification(id int) (*domain.Notification, error) {
+	notification, err := s.notificationRepo.GetNotificationByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if notification == nil {
+		return nil, fmt.Errorf("notification not found")
+	}
+
+	if err := notification.Requeue(time.Now()); err != nil {
+		return nil, err
+	}
+	if err := s.notificationRepo.UpdateNotification(notification); err != nil {
+		return nil, fmt.Errorf("failed to update notification record: %w", err)
+	}
+
+	s.wakeWorker()
+	return notification, nil
+}
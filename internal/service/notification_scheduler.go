@@ -21,7 +21,8 @@ func NewNotificationScheduler(notificationService *NotificationService) *Notific
 	}
 }
 
-// Start starts the scheduler to run daily at a specified time
+// Start starts the scheduler to run every hour
+// Each user's quiet hours and lead times decide when their reminders actually go out
 func (s *NotificationScheduler) Start() {
 	go s.run()
 }
@@ -36,59 +37,17 @@ func (s *NotificationScheduler) Stop() {
 	}
 }
 
+// notificationCheckInterval is how often reminders and alerts are checked
+const notificationCheckInterval = time.Hour
+
 // run executes the scheduler loop
 func (s *NotificationScheduler) run() {
-	// Run immediately on start (for testing/initial run)
+	// Run immediately on start
 	s.checkAndSendReminders()
 
-	// Calculate time until next 9 AM (or adjust to your preferred time)
-	now := time.Now()
-	nextRun := time.Date(now.Year(), now.Month(), now.Day(), 9, 0, 0, 0, now.Location())
-	if now.After(nextRun) {
-		// If it's already past 9 AM today, schedule for tomorrow
-		nextRun = nextRun.Add(24 * time.Hour)
-	}
-
-	ticker := time.NewTicker(24 * time.Hour)
+	ticker := time.NewTicker(notificationCheckInterval)
 	defer ticker.Stop()
 
-	// Wait until the scheduled time for first run, but check for stop signal periodically
-	waitDuration := time.Until(nextRun)
-	if waitDuration > 0 {
-		waitTimer := time.NewTimer(waitDuration)
-		defer waitTimer.Stop()
-
-		// Check for stop signal every 100ms while waiting
-		stopTicker := time.NewTicker(100 * time.Millisecond)
-		defer stopTicker.Stop()
-
-		for {
-			select {
-			case <-waitTimer.C:
-				// Time reached, proceed
-				goto runScheduled
-			case <-s.stopChan:
-				logger.Info("Notification scheduler stopped during initial wait")
-				return
-			case <-stopTicker.C:
-				// Check if stop was requested (non-blocking check)
-				select {
-				case <-s.stopChan:
-					logger.Info("Notification scheduler stopped during initial wait")
-					return
-				default:
-					// Continue waiting
-				}
-			}
-		}
-	}
-
-runScheduled:
-
-	// Run immediately at scheduled time
-	s.checkAndSendReminders()
-
-	// Then run daily
 	for {
 		select {
 		case <-ticker.C:
@@ -100,9 +59,10 @@ runScheduled:
 	}
 }
 
-// checkAndSendReminders checks and sends due date reminders
+// checkAndSendReminders queues due date reminders, lease alerts and overdue notices
+// The NotificationWorker delivers them
 func (s *NotificationScheduler) checkAndSendReminders() {
-	logger.Info("Running daily notification check...")
+	logger.Info("Running hourly notification check...")
 	if err := s.notificationService.CheckAndSendDueDateReminders(); err != nil {
 		logger.Error("Error checking and sending reminders",
 			zap.Error(err),
@@ -110,4 +70,16 @@ func (s *NotificationScheduler) checkAndSendReminders() {
 	} else {
 		logger.Info("Notification check completed successfully")
 	}
+
+	if err := s.notificationService.CheckAndSendLeaseAlerts(); err != nil {
+		logger.Error("Error checking and sending lease alerts",
+			zap.Error(err),
+		)
+	}
+
+	if err := s.notificationService.CheckAndSendOverdueEscalations(); err != nil {
+		logger.Error("Error checking and sending overdue notices",
+			zap.Error(err),
+		)
+	}
 }
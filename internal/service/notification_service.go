@@ -1,95 +1,123 @@
 package service
 
 import (
+	"backend-form/m/internal/channel"
 	"backend-form/m/internal/domain"
 	interfaces "backend-form/m/internal/repository/interfaces"
 	"context"
+	"errors"
 	"fmt"
-	"strconv"
 	"time"
-
-	"github.com/nikoksr/notify"
-	"github.com/nikoksr/notify/service/telegram"
 )
 
 // NotificationService handles notification-related business logic
+// Notifications are queued in the notifications table and delivered by the NotificationWorker
+// through the dispatcher, which picks the first channel that can reach the recipient
 type NotificationService struct {
 	notificationRepo interfaces.NotificationRepository
 	paymentRepo      interfaces.PaymentRepository
 	tenantRepo       interfaces.TenantRepository
 	unitRepo         interfaces.UnitRepository
-	notifier         *notify.Notify
-	telegramBotToken string
-	ownerChatID      string
+	telegramLinkRepo interfaces.TelegramLinkRepository
+	leaseService     *LeaseService
+	preferences      *NotificationPreferenceService
+	dispatcher       *channel.Dispatcher
+	owner            channel.Recipient
+	queued           chan struct{} // Wakes the worker when a notification is queued
 }
 
 // NewNotificationService creates a new NotificationService
+// owner holds the owner's contact details (Telegram chat ID, email, phone)
 func NewNotificationService(
 	notificationRepo interfaces.NotificationRepository,
 	paymentRepo interfaces.PaymentRepository,
 	tenantRepo interfaces.TenantRepository,
 	unitRepo interfaces.UnitRepository,
-	telegramBotToken string,
-	ownerChatID string,
+	telegramLinkRepo interfaces.TelegramLinkRepository,
+	leaseService *LeaseService,
+	preferences *NotificationPreferenceService,
+	dispatcher *channel.Dispatcher,
+	owner channel.Recipient,
 ) *NotificationService {
-	// Initialize notify library
-	notifier := notify.New()
-
-	// Initialize Telegram service if token is provided
-	if telegramBotToken != "" && ownerChatID != "" {
-		telegramService, err := telegram.New(telegramBotToken)
-		if err == nil {
-			// Convert owner chat ID to int64
-			if ownerChatIDInt, err := strconv.ParseInt(ownerChatID, 10, 64); err == nil {
-				telegramService.AddReceivers(ownerChatIDInt)
-				notifier.UseServices(telegramService)
-			}
-		}
-	}
-
 	return &NotificationService{
 		notificationRepo: notificationRepo,
 		paymentRepo:      paymentRepo,
 		tenantRepo:       tenantRepo,
 		unitRepo:         unitRepo,
-		notifier:         notifier,
-		telegramBotToken: telegramBotToken,
-		ownerChatID:      ownerChatID,
+		telegramLinkRepo: telegramLinkRepo,
+		leaseService:     leaseService,
+		preferences:      preferences,
+		dispatcher:       dispatcher,
+		owner:            owner,
+		queued:           make(chan struct{}, 1),
 	}
 }
 
-// SendTelegramMessage sends a message via Telegram using the notify library
+// IsEnabled reports whether any notification channel is configured
+func (s *NotificationService) IsEnabled() bool {
+	return len(s.dispatcher.Names()) > 0
+}
+
+// GetNotificationsByTenantID returns all notifications recorded for a tenant
+func (s *NotificationService) GetNotificationsByTenantID(tenantID int) ([]*domain.Notification, error) {
+	return s.notificationRepo.GetNotificationsByTenantID(tenantID)
+}
+
+// SendTelegramMessage sends a message to a Telegram chat directly, bypassing the other channels
 func (s *NotificationService) SendTelegramMessage(chatID string, message string) error {
-	if s.telegramBotToken == "" {
+	telegram := s.dispatcher.Channel(channel.ChannelTelegram)
+	if telegram == nil {
 		return fmt.Errorf("telegram bot token not configured")
 	}
+	return telegram.Send(context.Background(), chatID, channel.Message{Subject: "Rent Reminder", Body: message})
+}
 
-	// Convert chat ID string to int64
-	chatIDInt, err := strconv.ParseInt(chatID, 10, 64)
-	if err != nil {
-		return fmt.Errorf("invalid chat ID format: %w", err)
-	}
+// Errors returned when a notification is not queued on purpose
+var (
+	errNotificationMuted = errors.New("recipient does not want this notification type")
+	errAlreadyQueued     = errors.New("notification already queued")
+)
 
-	// Create a temporary notifier with the specific chat ID
-	tempNotifier := notify.New()
-	tempTelegramService, err := telegram.New(s.telegramBotToken)
+// isSkipped reports whether a notification was not queued on purpose rather than because of a failure
+func isSkipped(err error) bool {
+	return errors.Is(err, channel.ErrNoChannel) || errors.Is(err, errNotificationMuted) || errors.Is(err, errAlreadyQueued)
+}
+
+// tenantRecipient returns the contact details a tenant can be notified on
+// Telegram is only used while the tenant's link is active
+func (s *NotificationService) tenantRecipient(tenant *domain.Tenant) (channel.Recipient, error) {
+	to := channel.Recipient{Email: tenant.Email, Phone: tenant.Phone}
+
+	link, err := s.telegramLinkRepo.GetLinkByTenantID(tenant.ID)
 	if err != nil {
-		return fmt.Errorf("failed to create telegram service: %w", err)
+		return to, fmt.Errorf("failed to get telegram link: %w", err)
 	}
-	tempTelegramService.AddReceivers(chatIDInt)
-	tempNotifier.UseServices(tempTelegramService)
-
-	// Send the message with context
-	ctx := context.Background()
-	if err := tempNotifier.Send(ctx, "Rent Reminder", message); err != nil {
-		return fmt.Errorf("failed to send telegram message: %w", err)
+	if link != nil && link.IsActive() {
+		to.TelegramChatID = link.ChatID
 	}
+	return to, nil
+}
 
-	return nil
+// recordTelegramAttempts tracks Telegram deliveries to a tenant's linked chat
+func (s *NotificationService) recordTelegramAttempts(notification *domain.Notification, delivery *channel.Delivery) {
+	if notification.Recipient != domain.NotificationRecipientTenant || notification.TenantID == nil || delivery == nil {
+		return
+	}
+	for _, attempt := range delivery.Attempts {
+		if attempt.Channel != channel.ChannelTelegram {
+			continue
+		}
+		link, err := s.telegramLinkRepo.GetLinkByTenantID(*notification.TenantID)
+		if err != nil || link == nil || link.ChatID != attempt.Address {
+			return
+		}
+		s.recordTelegramDelivery(link, attempt.Err)
+		return
+	}
 }
 
-// SendDueDateReminderToOwner sends due date reminder to owner
-func (s *NotificationService) SendDueDateReminderToOwner(payment *domain.Payment) error {
+// SendDueDateReminderToOwner queues a due date reminder to the owner (at most one per payment a day)
+func (s *NotificationService) SendDueDateReminderToOwner(payment *domain.Payment, prefs *domain.NotificationPreferences) error {
 	// Load tenant and unit data
 	tenant, err := s.tenantRepo.GetTenantByID(payment.TenantID)
 	if err != nil {
@@ -101,56 +129,36 @@ func (s *NotificationService) SendDueDateReminderToOwner(payment *domain.Payment
 		return fmt.Errorf("failed to get unit: %w", err)
 	}
 
-	message := fmt.Sprintf(
-		"📅 Reminder: On %s, %s (%s) has to pay ₹%d",
+	_, message := domain.FormatNotification(prefs.Language, domain.MessageDueDateReminderOwner,
 		payment.DueDate.Format("Jan 2, 2006"),
 		tenant.Name,
 		unit.UnitCode,
 		payment.Amount,
 	)
 
-	// Create notification record
 	notification := &domain.Notification{
 		Type:      domain.NotificationTypeDueDateReminder,
 		Recipient: domain.NotificationRecipientOwner,
 		TenantID:  &payment.TenantID,
 		PaymentID: &payment.ID,
 		Message:   message,
-		SentVia:   "telegram",
-		SentTo:    s.ownerChatID,
-		CreatedAt: time.Now(),
 	}
+	notification.DedupeKey = domain.NotificationDedupeKey(notification.Type, notification.Recipient, &payment.TenantID, &payment.ID, time.Now().Format("2006-01-02"))
 
-	// Try to send via Telegram
-	err = s.SendTelegramMessage(s.ownerChatID, message)
-	if err != nil {
-		notification.Error = err.Error()
-		// Still save the notification record even if sending fails
-		if createErr := s.notificationRepo.CreateNotification(notification); createErr != nil {
-			return fmt.Errorf("failed to create notification record: %w", createErr)
-		}
-		return fmt.Errorf("failed to send telegram message: %w", err)
-	}
-
-	// Mark as sent
-	now := time.Now()
-	notification.SentAt = &now
-
-	// Save notification record
-	if err := s.notificationRepo.CreateNotification(notification); err != nil {
-		return fmt.Errorf("failed to create notification record: %w", err)
-	}
-
-	return nil
+	return s.enqueue(notification, s.owner, prefs)
 }
 
-// SendDueDateReminderToTenant sends due date reminder to tenant (5 days before)
-// Note: Tenant Telegram chat IDs need to be configured. For now, this will skip if chat ID is not available.
-// In future, you can add telegram_chat_id field to tenants or users table.
-func (s *NotificationService) SendDueDateReminderToTenant(payment *domain.Payment, tenantChatID string) error {
-	if tenantChatID == "" {
-		// Skip if tenant chat ID not configured
-		return fmt.Errorf("tenant chat ID not configured")
+// SendDueDateReminderToTenant queues a due date reminder to the tenant (at most one per payment a day)
+// The reminder goes to the tenant's linked Telegram chat, falling back to WhatsApp, SMS and email;
+// channel.ErrNoChannel is returned when the tenant cannot be reached at all
+func (s *NotificationService) SendDueDateReminderToTenant(payment *domain.Payment, prefs *domain.NotificationPreferences) error {
+	tenant, err := s.tenantRepo.GetTenantByID(payment.TenantID)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant: %w", err)
+	}
+	to, err := s.tenantRecipient(tenant)
+	if err != nil {
+		return err
 	}
 
 	// Load unit data
@@ -159,122 +167,200 @@ func (s *NotificationService) SendDueDateReminderToTenant(payment *domain.Paymen
 		return fmt.Errorf("failed to get unit: %w", err)
 	}
 
-	message := fmt.Sprintf(
-		"📅 Reminder: Your rent payment of ₹%d for %s is due on %s. Please make the payment to %s",
+	_, message := domain.FormatNotification(prefs.Language, domain.MessageDueDateReminderTenant,
 		payment.Amount,
 		unit.UnitCode,
 		payment.DueDate.Format("Jan 2, 2006"),
 		payment.UPIID,
 	)
 
-	// Create notification record
 	notification := &domain.Notification{
 		Type:      domain.NotificationTypeDueDateReminder,
 		Recipient: domain.NotificationRecipientTenant,
 		TenantID:  &payment.TenantID,
 		PaymentID: &payment.ID,
 		Message:   message,
-		SentVia:   "telegram",
-		SentTo:    tenantChatID,
-		CreatedAt: time.Now(),
-	}
-
-	// Try to send via Telegram
-	err = s.SendTelegramMessage(tenantChatID, message)
-	if err != nil {
-		notification.Error = err.Error()
-		// Still save the notification record even if sending fails
-		if createErr := s.notificationRepo.CreateNotification(notification); createErr != nil {
-			return fmt.Errorf("failed to create notification record: %w", createErr)
-		}
-		return fmt.Errorf("failed to send telegram message: %w", err)
 	}
+	notification.DedupeKey = domain.NotificationDedupeKey(notification.Type, notification.Recipient, &payment.TenantID, &payment.ID, time.Now().Format("2006-01-02"))
 
-	// Mark as sent
-	now := time.Now()
-	notification.SentAt = &now
+	return s.enqueue(notification, to, prefs)
+}
 
-	// Save notification record
-	if err := s.notificationRepo.CreateNotification(notification); err != nil {
-		return fmt.Errorf("failed to create notification record: %w", err)
+// recordTelegramDelivery tracks consecutive failed deliveries on a tenant's link
+// After domain.TelegramLinkMaxFailures the link is no longer used until the tenant links again
+func (s *NotificationService) recordTelegramDelivery(link *domain.TelegramLink, sendErr error) {
+	if sendErr == nil && link.FailureCount == 0 {
+		return
+	}
+	link.RecordDelivery(sendErr, time.Now())
+	if err := s.telegramLinkRepo.UpdateLinkDelivery(link); err != nil {
+		fmt.Printf("Warning: Failed to update telegram link of tenant %d: %v\n", link.TenantID, err)
 	}
-
-	return nil
 }
 
-// CheckAndSendDueDateReminders checks for payments due today (for owner) and due in 5 days (for tenants)
+// CheckAndSendDueDateReminders queues due date reminders as far ahead as the owner and each tenant asked for
+// It runs every hour: reminders held back by quiet hours go out on the first run after them,
+// and a reminder already recorded today is not queued again
 func (s *NotificationService) CheckAndSendDueDateReminders() error {
 	now := time.Now()
 	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
 
-	// 1. Send reminders to owner for payments due today
-	paymentsDueToday, err := s.paymentRepo.GetUnpaidPaymentsByDueDate(today)
+	ownerPrefs, err := s.preferences.GetOwnerPreferences()
 	if err != nil {
-		return fmt.Errorf("failed to get payments due today: %w", err)
+		return fmt.Errorf("failed to get owner notification preferences: %w", err)
 	}
 
-	for _, payment := range paymentsDueToday {
-		// Double-check payment is not fully paid (race condition protection)
-		if payment.IsFullyPaid {
-			continue
-		}
-
-		// Reload payment to ensure we have latest status
-		latestPayment, err := s.paymentRepo.GetPaymentByID(payment.ID)
+	for daysAhead := 0; daysAhead <= domain.MaxReminderLeadDays; daysAhead++ {
+		payments, err := s.paymentRepo.GetUnpaidPaymentsByDueDate(today.AddDate(0, 0, daysAhead))
 		if err != nil {
-			fmt.Printf("Warning: Failed to reload payment %d: %v\n", payment.ID, err)
-			continue
+			return fmt.Errorf("failed to get payments due in %d days: %w", daysAhead, err)
 		}
 
-		if latestPayment.IsFullyPaid {
-			continue
-		}
+		for _, payment := range payments {
+			// Double-check payment is not fully paid (race condition protection)
+			if payment.IsFullyPaid {
+				continue
+			}
+
+			// Reload payment to ensure we have latest status
+			latestPayment, err := s.paymentRepo.GetPaymentByID(payment.ID)
+			if err != nil {
+				fmt.Printf("Warning: Failed to reload payment %d: %v\n", payment.ID, err)
+				continue
+			}
+
+			if latestPayment.IsFullyPaid {
+				continue
+			}
+
+			if ownerPrefs.WantsReminderDaysBefore(daysAhead) && s.isScheduledNotificationDue(ownerPrefs, domain.NotificationTypeDueDateReminder, latestPayment.TenantID, &latestPayment.ID, now) {
+				if err := s.SendDueDateReminderToOwner(latestPayment, ownerPrefs); err != nil && !isSkipped(err) {
+					fmt.Printf("Warning: Failed to send reminder to owner for payment %d: %v\n", latestPayment.ID, err)
+					// Continue with other payments
+				}
+			}
 
-		if err := s.SendDueDateReminderToOwner(latestPayment); err != nil {
-			fmt.Printf("Warning: Failed to send reminder to owner for payment %d: %v\n", latestPayment.ID, err)
-			// Continue with other payments
+			tenantPrefs, err := s.preferences.GetTenantPreferences(latestPayment.TenantID)
+			if err != nil {
+				fmt.Printf("Warning: Failed to get notification preferences of tenant %d: %v\n", latestPayment.TenantID, err)
+				continue
+			}
+			if tenantPrefs.WantsReminderDaysBefore(daysAhead) && s.isScheduledNotificationDue(tenantPrefs, domain.NotificationTypeDueDateReminder, latestPayment.TenantID, &latestPayment.ID, now) {
+				if err := s.SendDueDateReminderToTenant(latestPayment, tenantPrefs); err != nil && !isSkipped(err) {
+					fmt.Printf("Warning: Failed to send reminder to tenant for payment %d: %v\n", latestPayment.ID, err)
+					// Continue with other payments
+				}
+			}
 		}
 	}
 
-	// 2. Send reminders to tenants for payments due in 5 days
-	// Logic: Tenants get reminders exactly 5 days before their due date
-	// Example: If today is July 31st, we check for payments due on Aug 5th (today + 5 days)
-	//          So payment due Aug 5th → Tenant notified on July 31st (5 days before)
-	fiveDaysFromNow := today.AddDate(0, 0, 5)
-	paymentsDueIn5Days, err := s.paymentRepo.GetUnpaidPaymentsByDueDate(fiveDaysFromNow)
+	return nil
+}
+
+// isScheduledNotificationDue reports whether a scheduled notification should be sent now:
+// the recipient wants it, is not in quiet hours and has not been sent it today
+func (s *NotificationService) isScheduledNotificationDue(prefs *domain.NotificationPreferences, notificationType domain.NotificationType, tenantID int, paymentID *int, now time.Time) bool {
+	if !prefs.Wants(notificationType) || prefs.IsQuietAt(now) {
+		return false
+	}
+
+	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	sent, err := s.notificationRepo.HasNotificationSince(notificationType, prefs.Recipient, tenantID, paymentID, startOfDay)
 	if err != nil {
-		return fmt.Errorf("failed to get payments due in 5 days: %w", err)
+		// Better to skip a reminder than to send it every hour
+		fmt.Printf("Warning: Failed to check earlier notifications for tenant %d: %v\n", tenantID, err)
+		return false
 	}
+	return !sent
+}
 
-	for _, payment := range paymentsDueIn5Days {
-		// Double-check payment is not fully paid
-		if payment.IsFullyPaid {
-			continue
-		}
+// leaseAlertDays are how many days ahead of a lease expiry or rent escalation the owner is alerted
+var leaseAlertDays = []int{30, 7}
 
-		// Reload payment to ensure we have latest status
-		latestPayment, err := s.paymentRepo.GetPaymentByID(payment.ID)
-		if err != nil {
-			fmt.Printf("Warning: Failed to reload payment %d: %v\n", payment.ID, err)
-			continue
-		}
+// CheckAndSendLeaseAlerts alerts the owner about lease expiries and rent escalations coming up
+// Alerts respect the owner's preferences and, like reminders, are sent once a day
+func (s *NotificationService) CheckAndSendLeaseAlerts() error {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
 
-		if latestPayment.IsFullyPaid {
-			continue
-		}
+	ownerPrefs, err := s.preferences.GetOwnerPreferences()
+	if err != nil {
+		return fmt.Errorf("failed to get owner notification preferences: %w", err)
+	}
 
-		// TODO: Get tenant's Telegram chat ID from database (you may want to add this field to tenants/users table)
-		// For now, using owner chat ID for testing tenant notifications
-		// In production, this should be retrieved from database per tenant
-		tenantChatID := s.ownerChatID // TEMPORARY: Using owner chat ID for testing
+	events, err := s.leaseService.GetUpcomingEvents(today, leaseAlertDays[0])
+	if err != nil {
+		return fmt.Errorf("failed to get upcoming lease events: %w", err)
+	}
 
-		if tenantChatID != "" {
-			if err := s.SendDueDateReminderToTenant(latestPayment, tenantChatID); err != nil {
-				fmt.Printf("Warning: Failed to send reminder to tenant for payment %d: %v\n", latestPayment.ID, err)
-				// Continue with other payments
+	for _, event := range events {
+		daysAhead := int(event.Date.Sub(today).Hours() / 24)
+		for _, alertDays := range leaseAlertDays {
+			if daysAhead != alertDays {
+				continue
+			}
+			if !s.isScheduledNotificationDue(ownerPrefs, leaseNotificationType(event), event.Lease.TenantID, nil, now) {
+				continue
+			}
+			if err := s.SendLeaseAlertToOwner(event, daysAhead, ownerPrefs); err != nil && !isSkipped(err) {
+				fmt.Printf("Warning: Failed to send lease alert for lease %d: %v\n", event.Lease.ID, err)
+				// Continue with other leases
 			}
 		}
 	}
 
 	return nil
 }
+
+// leaseNotificationType returns the notification type of a lease event
+func leaseNotificationType(event domain.LeaseEvent) domain.NotificationType {
+	if event.Type == domain.LeaseEventEscalation {
+		return domain.NotificationTypeLeaseEscalation
+	}
+	return domain.NotificationTypeLeaseExpiry
+}
+
+// SendLeaseAlertToOwner queues a lease expiry or rent escalation alert to the owner
+func (s *NotificationService) SendLeaseAlertToOwner(event domain.LeaseEvent, daysAhead int, prefs *domain.NotificationPreferences) error {
+	lease := event.Lease
+
+	tenant, err := s.tenantRepo.GetTenantByID(lease.TenantID)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	unit, err := s.unitRepo.GetUnitByID(lease.UnitID)
+	if err != nil {
+		return fmt.Errorf("failed to get unit: %w", err)
+	}
+
+	notificationType := leaseNotificationType(event)
+	_, message := domain.FormatNotification(prefs.Language, domain.MessageLeaseExpiry,
+		tenant.Name,
+		unit.UnitCode,
+		event.Date.Format("Jan 2, 2006"),
+		daysAhead,
+		lease.GetNoticeDeadline().Format("Jan 2, 2006"),
+	)
+	if notificationType == domain.NotificationTypeLeaseEscalation {
+		_, message = domain.FormatNotification(prefs.Language, domain.MessageLeaseEscalation,
+			tenant.Name,
+			unit.UnitCode,
+			lease.GetRentFor(event.Date.AddDate(0, 0, -1)),
+			event.NewRent,
+			event.Date.Format("Jan 2, 2006"),
+			daysAhead,
+		)
+	}
+
+	notification := &domain.Notification{
+		Type:      notificationType,
+		Recipient: domain.NotificationRecipientOwner,
+		TenantID:  &lease.TenantID,
+		Message:   message,
+	}
+	notification.DedupeKey = domain.NotificationDedupeKey(notificationType, notification.Recipient, &lease.TenantID, nil,
+		fmt.Sprintf("lease%d:%s:%dd", lease.ID, event.Date.Format("2006-01-02"), daysAhead))
+
+	return s.enqueue(notification, s.owner, prefs)
+}
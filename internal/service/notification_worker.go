@@ -0,0 +1,78 @@
+package service
+
+import (
+	"backend-form/m/internal/logger"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// NotificationWorker delivers queued notifications
+// It runs whenever a notification is queued and every notificationWorkerInterval for retries
+type NotificationWorker struct {
+	notificationService *NotificationService
+	stopChan            chan bool
+}
+
+// NewNotificationWorker creates a new NotificationWorker
+func NewNotificationWorker(notificationService *NotificationService) *NotificationWorker {
+	return &NotificationWorker{
+		notificationService: notificationService,
+		stopChan:            make(chan bool),
+	}
+}
+
+// Start starts the worker
+func (w *NotificationWorker) Start() {
+	go w.run()
+}
+
+// Stop stops the worker (non-blocking)
+func (w *NotificationWorker) Stop() {
+	select {
+	case w.stopChan <- true:
+		// Stop signal sent
+	default:
+		// Channel full or already stopping, ignore
+	}
+}
+
+// notificationWorkerInterval is how often the queue is checked for retries and notifications leaving quiet hours
+const notificationWorkerInterval = 30 * time.Second
+
+// run executes the worker loop
+func (w *NotificationWorker) run() {
+	// Deliver anything left in the queue by a previous run
+	w.deliverDueNotifications()
+
+	ticker := time.NewTicker(notificationWorkerInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.deliverDueNotifications()
+		case <-w.notificationService.Queued():
+			w.deliverDueNotifications()
+		case <-w.stopChan:
+			logger.Info("Notification worker stopped")
+			return
+		}
+	}
+}
+
+// deliverDueNotifications delivers due notifications batch by batch until none are left
+func (w *NotificationWorker) deliverDueNotifications() {
+	for {
+		attempted, err := w.notificationService.DeliverDueNotifications()
+		if err != nil {
+			logger.Error("Error delivering queued notifications",
+				zap.Error(err),
+			)
+			return
+		}
+		if attempted < notificationBatchSize {
+			return
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"backend-form/m/internal/domain"
+	interfaces "backend-form/m/internal/repository/interfaces"
+	"fmt"
+	"strings"
+)
+
+// PaymentAdjustmentService records discounts, waivers and write-offs against payments
+type PaymentAdjustmentService struct {
+	adjustmentRepo interfaces.PaymentAdjustmentRepository
+	paymentRepo    interfaces.PaymentRepository
+	paymentService *PaymentService
+}
+
+// NewPaymentAdjustmentService creates a new PaymentAdjustmentService
+func NewPaymentAdjustmentService(adjustmentRepo interfaces.PaymentAdjustmentRepository, paymentRepo interfaces.PaymentRepository, paymentService *PaymentService) *PaymentAdjustmentService {
+	return &PaymentAdjustmentService{
+		adjustmentRepo: adjustmentRepo,
+		paymentRepo:    paymentRepo,
+		paymentService: paymentService,
+	}
+}
+
+// CreateAdjustment reduces the balance of a payment and records who did it and why
+// Like a verified payment, an adjustment that settles a rent payment generates the next month's rent
+func (s *PaymentAdjustmentService) CreateAdjustment(adjustment *domain.PaymentAdjustment, userID int) (*domain.Payment, error) {
+	adjustment.Reason = strings.TrimSpace(adjustment.Reason)
+	if err := adjustment.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	payment, err := s.paymentRepo.GetPaymentByID(adjustment.PaymentID)
+	if err != nil {
+		return nil, err
+	}
+	payment.Transactions, err = s.paymentRepo.GetPaymentTransactionsByPaymentID(payment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load payment transactions: %w", err)
+	}
+	if payment.HasPendingVerification() {
+		return nil, fmt.Errorf("payment has a transaction awaiting verification; verify or reject it first")
+	}
+	if err := payment.ApplyAdjustment(adjustment.Amount); err != nil {
+		return nil, err
+	}
+
+	adjustment.CreatedByUserID = &userID
+	if err := s.adjustmentRepo.CreateAdjustment(adjustment); err != nil {
+		return nil, err
+	}
+
+	payment, err = s.paymentRepo.GetPaymentByID(adjustment.PaymentID)
+	if err != nil {
+		return nil, err
+	}
+	if payment.IsFullyPaid && payment.Label == domain.PaymentLabelRent {
+		if err := s.paymentService.AutoCreateNextPayment(payment); err != nil {
+			// Log error but don't fail - next payment creation is best effort
+			fmt.Printf("Warning: Failed to auto-create next payment for payment %d: %v\n", payment.ID, err)
+		}
+	}
+
+	return payment, nil
+}
+
+// GetAdjustmentsByPaymentID returns the adjustments made to a payment
+func (s *PaymentAdjustmentService) GetAdjustmentsByPaymentID(paymentID int) ([]*domain.PaymentAdjustment, error) {
+	return s.adjustmentRepo.GetAdjustmentsByPaymentID(paymentID)
+}
+
+// GetAdjustmentsByTenantID returns all adjustments of a tenant
+func (s *PaymentAdjustmentService) GetAdjustmentsByTenantID(tenantID int) ([]*domain.PaymentAdjustment, error) {
+	return s.adjustmentRepo.GetAdjustmentsByTenantID(tenantID)
+}
+
+// GetAdjustmentsByPayment returns a tenant's adjustments keyed by payment ID, oldest first
+func (s *PaymentAdjustmentService) GetAdjustmentsByPayment(tenantID int) map[int][]*domain.PaymentAdjustment {
+	result := make(map[int][]*domain.PaymentAdjustment)
+	adjustments, err := s.adjustmentRepo.GetAdjustmentsByTenantID(tenantID)
+	if err != nil {
+		return result
+	}
+	for i := len(adjustments) - 1; i >= 0; i-- {
+		adjustment := adjustments[i]
+		result[adjustment.PaymentID] = append(result[adjustment.PaymentID], adjustment)
+	}
+	return result
+}
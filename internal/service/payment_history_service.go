@@ -88,7 +88,7 @@ func (s *PaymentHistoryService) CreateHistoricalPaidPayment(
 		if !existingPayment.IsFullyPaid {
 			existingPayment.IsPaid = true
 			existingPayment.IsFullyPaid = true
-			existingPayment.AmountPaid = existingPayment.Amount
+			existingPayment.AmountPaid = existingPayment.Amount - existingPayment.AmountAdjusted
 			existingPayment.RemainingBalance = 0
 			existingPayment.PaymentDate = &paymentDate
 			existingPayment.FullyPaidDate = &paymentDate
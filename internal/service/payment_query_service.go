@@ -25,6 +25,14 @@ func (s *PaymentQueryService) GetAllPayments() ([]*domain.Payment, error) {
 	return s.paymentRepo.GetAllPayments()
 }
 
+// GetPaymentsByPropertyID returns payments for a property (0 = all properties)
+func (s *PaymentQueryService) GetPaymentsByPropertyID(propertyID int) ([]*domain.Payment, error) {
+	if propertyID == 0 {
+		return s.paymentRepo.GetAllPayments()
+	}
+	return s.paymentRepo.GetPaymentsByPropertyID(propertyID)
+}
+
 // GetUnpaidPaymentsByTenantID returns unpaid payments for a tenant
 func (s *PaymentQueryService) GetUnpaidPaymentsByTenantID(tenantID int) ([]*domain.Payment, error) {
 	return s.paymentRepo.GetUnpaidPaymentsByTenantID(tenantID)
@@ -68,9 +76,9 @@ func (s *PaymentQueryService) GetPendingPayments() ([]*domain.Payment, error) {
 	return pending, nil
 }
 
-// GetPaymentSummary returns a summary of payments
-func (s *PaymentQueryService) GetPaymentSummary() (*PaymentSummary, error) {
-	payments, err := s.paymentRepo.GetAllPayments()
+// GetPaymentSummary returns a summary of payments for a property (0 = all properties)
+func (s *PaymentQueryService) GetPaymentSummary(propertyID int) (*PaymentSummary, error) {
+	payments, err := s.GetPaymentsByPropertyID(propertyID)
 	if err != nil {
 		return nil, err
 	}
@@ -84,16 +92,18 @@ func (s *PaymentQueryService) GetPaymentSummary() (*PaymentSummary, error) {
 		PaidAmount:      0,
 		PendingAmount:   0,
 		OverdueAmount:   0,
+		AdjustedAmount:  0,
 	}
 
 	now := time.Now()
 
 	for _, payment := range payments {
 		summary.TotalAmount += payment.Amount
+		summary.AdjustedAmount += payment.AmountAdjusted // Forgiven, kept apart from money received
 
 		if payment.IsFullyPaid {
 			summary.PaidPayments++
-			summary.PaidAmount += payment.Amount
+			summary.PaidAmount += payment.AmountPaid
 		} else if now.After(payment.DueDate) {
 			summary.OverduePayments++
 			summary.OverdueAmount += payment.RemainingBalance // Use remaining balance for overdue
@@ -117,6 +127,7 @@ type PaymentSummary struct {
 	PaidAmount      int `json:"paid_amount"`
 	PendingAmount   int `json:"pending_amount"`
 	OverdueAmount   int `json:"overdue_amount"`
+	AdjustedAmount  int `json:"adjusted_amount"` // Discounts, waivers and write-offs
 }
 
 // GetFormattedTotalAmount returns formatted total amount
@@ -138,3 +149,8 @@ func (ps *PaymentSummary) GetFormattedPendingAmount() string {
 func (ps *PaymentSummary) GetFormattedOverdueAmount() string {
 	return fmt.Sprintf("₹%d", ps.OverdueAmount)
 }
+
+// GetFormattedAdjustedAmount returns formatted adjusted amount
+func (ps *PaymentSummary) GetFormattedAdjustedAmount() string {
+	return fmt.Sprintf("₹%d", ps.AdjustedAmount)
+}
@@ -5,6 +5,8 @@ import (
 	interfaces "backend-form/m/internal/repository/interfaces"
 	"fmt"
 	"time"
+
+	qrcode "github.com/skip2/go-qrcode"
 )
 
 // PaymentService handles payment-related business logic
@@ -12,18 +14,32 @@ type PaymentService struct {
 	paymentRepo          interfaces.PaymentRepository
 	tenantRepo           interfaces.TenantRepository
 	unitRepo             interfaces.UnitRepository
+	categoryService      *ChargeCategoryService
+	recurringService     *RecurringChargeService
+	leaseService         *LeaseService
+	prorationService     *ProrationService
+	creditService        *CreditService
+	notificationService  *NotificationService // Tells tenants about new charges
 	defaultPaymentMethod string
 	defaultUPIID         string
+	upiPayeeName         string // Payee name shown in the tenant's UPI app
 }
 
 // NewPaymentService creates a new PaymentService
-func NewPaymentService(paymentRepo interfaces.PaymentRepository, tenantRepo interfaces.TenantRepository, unitRepo interfaces.UnitRepository, defaultPaymentMethod, defaultUPIID string) *PaymentService {
+func NewPaymentService(paymentRepo interfaces.PaymentRepository, tenantRepo interfaces.TenantRepository, unitRepo interfaces.UnitRepository, categoryService *ChargeCategoryService, recurringService *RecurringChargeService, leaseService *LeaseService, prorationService *ProrationService, creditService *CreditService, notificationService *NotificationService, defaultPaymentMethod, defaultUPIID, upiPayeeName string) *PaymentService {
 	return &PaymentService{
 		paymentRepo:          paymentRepo,
 		tenantRepo:           tenantRepo,
 		unitRepo:             unitRepo,
+		categoryService:      categoryService,
+		recurringService:     recurringService,
+		leaseService:         leaseService,
+		prorationService:     prorationService,
+		creditService:        creditService,
+		notificationService:  notificationService,
 		defaultPaymentMethod: defaultPaymentMethod,
 		defaultUPIID:         defaultUPIID,
+		upiPayeeName:         upiPayeeName,
 	}
 }
 
@@ -64,7 +80,7 @@ func (s *PaymentService) CreateMonthlyPayment(tenantID int, month time.Month, ye
 		return nil, fmt.Errorf("failed to create payment: %w", err)
 	}
 
-	return payment, nil
+	return s.applyCredit(payment), nil
 }
 
 // MarkPaymentAsPaid marks a payment as paid (legacy method - kept for backward compatibility)
@@ -82,7 +98,7 @@ func (s *PaymentService) MarkPaymentAsPaid(paymentID int, paymentDate time.Time,
 	// Update to fully paid
 	payment.IsPaid = true
 	payment.IsFullyPaid = true
-	payment.AmountPaid = payment.Amount
+	payment.AmountPaid = payment.Amount - payment.AmountAdjusted
 	payment.RemainingBalance = 0
 	payment.PaymentDate = &paymentDate
 	payment.FullyPaidDate = &paymentDate
@@ -120,6 +136,7 @@ func (s *PaymentService) GetPaymentByID(id int) (*domain.Payment, error) {
 
 	// Load transactions for status calculation
 	s.loadPaymentTransactions(payment)
+	s.categoryService.AttachCategories([]*domain.Payment{payment})
 
 	return payment, nil
 }
@@ -140,6 +157,7 @@ func (s *PaymentService) GetPaymentsByTenantID(tenantID int) ([]*domain.Payment,
 	for _, payment := range payments {
 		s.loadPaymentTransactions(payment)
 	}
+	s.categoryService.AttachCategories(payments)
 	return payments, nil
 }
 
@@ -205,15 +223,47 @@ func (s *PaymentService) getOrCreateCurrentPayment(tenantID int) (*domain.Paymen
 // Payment Lifecycle & Helpers
 // ============================================
 
-// CreatePaymentForTenant creates a payment with explicit parameters
-// Used by both TenantService (first payment) and PaymentService (auto-create)
+// CreatePaymentForTenant creates a rent payment with explicit parameters
+// Used by PaymentService (auto-create) and PaymentHistoryService
+// The rent in force under the tenant's lease replaces amount when there is one.
+// Recurring charges due in the same month are generated alongside the rent,
+// then any credit the tenant holds is drawn down against them
 func (s *PaymentService) CreatePaymentForTenant(
 	tenantID int,
 	unitID int,
 	dueDate time.Time,
 	amount int,
 ) (*domain.Payment, error) {
-	payment := &domain.Payment{
+	payment := s.newRentPayment(tenantID, unitID, dueDate, amount)
+	if err := s.paymentRepo.CreatePayment(payment); err != nil {
+		return nil, fmt.Errorf("create payment for tenant: %w", err)
+	}
+
+	return s.completeRentPayment(payment), nil
+}
+
+// CreateFirstPaymentForTenant creates a new tenant's first rent payment, prorated for a partial move-in month
+// The payment is prorated before it is created, so credit is drawn down against the prorated amount
+func (s *PaymentService) CreateFirstPaymentForTenant(tenant *domain.Tenant, dueDate time.Time, amount int) (*domain.Payment, error) {
+	payment := s.newRentPayment(tenant.ID, tenant.UnitID, dueDate, amount)
+	if proration := s.prorationService.PrepareFirstPaymentProration(tenant, payment); proration != nil {
+		if err := s.prorationService.CreateProratedPayment(payment, proration); err != nil {
+			return nil, fmt.Errorf("create first payment for tenant: %w", err)
+		}
+	} else if err := s.paymentRepo.CreatePayment(payment); err != nil {
+		return nil, fmt.Errorf("create first payment for tenant: %w", err)
+	}
+
+	return s.completeRentPayment(payment), nil
+}
+
+// newRentPayment builds an unsaved rent payment, charging the lease rent when there is one
+func (s *PaymentService) newRentPayment(tenantID int, unitID int, dueDate time.Time, amount int) *domain.Payment {
+	if leaseRent, ok := s.leaseService.GetRentForDueDate(tenantID, dueDate); ok {
+		amount = leaseRent
+	}
+
+	return &domain.Payment{
 		TenantID:         tenantID,
 		UnitID:           unitID,
 		Amount:           amount,
@@ -226,15 +276,117 @@ func (s *PaymentService) CreatePaymentForTenant(
 		UPIID:            s.defaultUPIID,
 		Label:            domain.PaymentLabelRent, // Auto-created payments are always rent
 	}
+}
 
-	if err := s.paymentRepo.CreatePayment(payment); err != nil {
-		return nil, fmt.Errorf("create payment for tenant: %w", err)
+// completeRentPayment generates the recurring charges due with a new rent payment and draws down credit
+// Returns the payment reloaded if credit was applied
+func (s *PaymentService) completeRentPayment(payment *domain.Payment) *domain.Payment {
+	if _, err := s.GenerateRecurringCharges(payment.TenantID, payment.UnitID, payment.DueDate); err != nil {
+		// Log error but don't fail - rent was created and charges can be generated again
+		fmt.Printf("Warning: Failed to generate recurring charges for tenant %d: %v\n", payment.TenantID, err)
 	}
 
-	return payment, nil
+	return s.applyCredit(payment)
+}
+
+// applyCredit draws down the tenant's credit against their unpaid payments
+// Returns the payment reloaded if credit was applied
+func (s *PaymentService) applyCredit(payment *domain.Payment) *domain.Payment {
+	applied, err := s.creditService.ApplyAvailableCredit(payment.TenantID)
+	if err != nil {
+		// Log error but don't fail - credit stays on the ledger and is applied next time
+		fmt.Printf("Warning: Failed to apply credit for tenant %d: %v\n", payment.TenantID, err)
+	}
+	if applied == 0 {
+		return payment
+	}
+
+	if updated, err := s.paymentRepo.GetPaymentByID(payment.ID); err == nil {
+		return updated
+	}
+	return payment
+}
+
+// GenerateRecurringCharges creates the payments for recurring charge schedules due in the month of rentDueDate
+// Charges already raised for that month (by a previous run or by hand) are skipped.
+// Returns the number of payments created.
+func (s *PaymentService) GenerateRecurringCharges(tenantID int, unitID int, rentDueDate time.Time) (int, error) {
+	month, year := rentDueDate.Month(), rentDueDate.Year()
+	due, err := s.recurringService.GetDueCharges(tenantID, unitID, month, year)
+	if err != nil || len(due) == 0 {
+		return 0, err
+	}
+
+	existingPayments, err := s.paymentRepo.GetPaymentsByTenantID(tenantID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load payments: %w", err)
+	}
+	raised := make(map[string]bool)
+	for _, existing := range existingPayments {
+		if existing.DueDate.Month() == month && existing.DueDate.Year() == year {
+			raised[existing.Label] = true
+		}
+	}
+
+	created := 0
+	for _, charge := range due {
+		if raised[charge.CategoryCode] {
+			continue
+		}
+
+		amount := charge.GetAmountFor(month, year)
+		payment := &domain.Payment{
+			TenantID:         tenantID,
+			UnitID:           unitID,
+			Amount:           amount,
+			AmountPaid:       0,
+			RemainingBalance: amount,
+			DueDate:          charge.GetDueDate(month, year, rentDueDate.Day()),
+			IsPaid:           false,
+			IsFullyPaid:      false,
+			PaymentMethod:    s.defaultPaymentMethod,
+			UPIID:            s.defaultUPIID,
+			Label:            charge.CategoryCode,
+			Notes:            charge.Notes,
+		}
+		if err := payment.Validate(); err != nil {
+			return created, fmt.Errorf("invalid recurring charge %d: %w", charge.ID, err)
+		}
+		if err := s.paymentRepo.CreatePayment(payment); err != nil {
+			return created, fmt.Errorf("failed to create recurring charge %d: %w", charge.ID, err)
+		}
+		created++
+	}
+
+	return created, nil
+}
+
+// GenerateRecurringChargesForMonth generates recurring charges for every active tenant for a month
+// Used to backfill a month after a schedule is added or changed
+func (s *PaymentService) GenerateRecurringChargesForMonth(month time.Month, year int) (int, error) {
+	tenants, err := s.tenantRepo.GetAllTenants()
+	if err != nil {
+		return 0, fmt.Errorf("failed to load tenants: %w", err)
+	}
+
+	created := 0
+	for _, tenant := range tenants {
+		unit, err := s.unitRepo.GetUnitByID(tenant.UnitID)
+		if err != nil {
+			continue
+		}
+		count, err := s.GenerateRecurringCharges(tenant.ID, tenant.UnitID, time.Date(year, month, unit.PaymentDueDay, 0, 0, 0, 0, time.UTC))
+		created += count
+		if err != nil {
+			return created, err
+		}
+	}
+
+	return created, nil
 }
 
-// CreateCustomPayment creates a payment with custom label (for water bills, current bills, maintenance, etc.)
+// CreateCustomPayment creates a payment for an owner-managed charge category (water bill, parking, internet, etc.)
+// amount 0 uses the category's default amount
 func (s *PaymentService) CreateCustomPayment(
 	tenantID int,
 	unitID int,
@@ -265,9 +417,21 @@ func (s *PaymentService) CreateCustomPayment(
 		label = domain.PaymentLabelRent
 	}
 
-	// Check if payment of this type already exists for this month
-	// Only check for non-rent payments (rent is handled by auto-creation logic)
-	if label != domain.PaymentLabelRent {
+	category, err := s.categoryService.GetCategoryByCode(label)
+	if err != nil {
+		return nil, fmt.Errorf("unknown payment type '%s'", label)
+	}
+	if !category.IsActive {
+		return nil, fmt.Errorf("payment type '%s' is no longer in use", category.DisplayName)
+	}
+
+	if amount <= 0 {
+		amount = category.DefaultAmount
+	}
+
+	// Recurring charges can only be raised once per month
+	// Rent is handled by auto-creation logic, one-off charges can repeat
+	if label != domain.PaymentLabelRent && category.IsRecurring {
 		existingPayments, err := s.paymentRepo.GetPaymentsByTenantID(tenantID)
 		if err == nil {
 			paymentMonth := dueDate.Month()
@@ -276,7 +440,7 @@ func (s *PaymentService) CreateCustomPayment(
 				if existing.Label == label &&
 					existing.DueDate.Month() == paymentMonth &&
 					existing.DueDate.Year() == paymentYear {
-					return nil, fmt.Errorf("payment of type '%s' already exists for %s %d", label, paymentMonth.String(), paymentYear)
+					return nil, fmt.Errorf("payment of type '%s' already exists for %s %d", category.DisplayName, paymentMonth.String(), paymentYear)
 				}
 			}
 		}
@@ -295,6 +459,7 @@ func (s *PaymentService) CreateCustomPayment(
 		UPIID:            s.defaultUPIID,
 		Label:            label,
 		Notes:            notes,
+		Category:         category,
 	}
 
 	// Validate payment
@@ -306,6 +471,11 @@ func (s *PaymentService) CreateCustomPayment(
 		return nil, fmt.Errorf("failed to create payment: %w", err)
 	}
 
+	if err := s.notificationService.NotifyChargeCreated(payment); err != nil && !isSkipped(err) {
+		// Log error but don't fail - the charge is created and shows on the tenant's dashboard
+		fmt.Printf("Warning: Failed to notify tenant %d of payment %d: %v\n", tenantID, payment.ID, err)
+	}
+
 	return payment, nil
 }
 
@@ -314,22 +484,95 @@ func (s *PaymentService) GetDefaultPaymentMethod() string {
 	return s.defaultPaymentMethod
 }
 
+// GetChargeCategories returns the active charge categories for payment forms and filters
+func (s *PaymentService) GetChargeCategories() []*domain.ChargeCategory {
+	categories, err := s.categoryService.GetAllCategories(true)
+	if err != nil {
+		return []*domain.ChargeCategory{}
+	}
+	return categories
+}
+
 // GetDefaultUPIID returns the default UPI ID
 func (s *PaymentService) GetDefaultUPIID() string {
 	return s.defaultUPIID
 }
 
+// GetUPIPayeeName returns the payee name used in UPI requests
+func (s *PaymentService) GetUPIPayeeName() string {
+	return s.upiPayeeName
+}
+
+// GetUPIPaymentRequest returns the UPI request (intent link and reference) for a payment's remaining balance
+func (s *PaymentService) GetUPIPaymentRequest(paymentID int) (*domain.UPIPaymentRequest, error) {
+	payment, err := s.paymentRepo.GetPaymentByID(paymentID)
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewUPIPaymentRequest(payment, s.defaultUPIID, s.upiPayeeName)
+}
+
+// GetUPIPaymentRequests returns UPI requests for all unpaid payments of a tenant, most overdue first
+func (s *PaymentService) GetUPIPaymentRequests(tenantID int) []*domain.UPIPaymentRequest {
+	requests := []*domain.UPIPaymentRequest{}
+	unpaid, err := s.paymentRepo.GetUnpaidPaymentsByTenantID(tenantID)
+	if err != nil {
+		return requests
+	}
+	for _, payment := range unpaid {
+		req, err := domain.NewUPIPaymentRequest(payment, s.defaultUPIID, s.upiPayeeName)
+		if err != nil {
+			continue
+		}
+		requests = append(requests, req)
+	}
+	return requests
+}
+
+// GetUPIQRCode returns a PNG QR code of the UPI intent link for a payment's remaining balance
+func (s *PaymentService) GetUPIQRCode(paymentID int, size int) ([]byte, error) {
+	req, err := s.GetUPIPaymentRequest(paymentID)
+	if err != nil {
+		return nil, err
+	}
+	png, err := qrcode.Encode(req.IntentURL, qrcode.Medium, size)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate QR code: %w", err)
+	}
+	return png, nil
+}
+
+// GetCreditLedger returns a tenant's credit ledger (nil if it cannot be loaded)
+func (s *PaymentService) GetCreditLedger(tenantID int) *domain.CreditLedger {
+	ledger, err := s.creditService.GetLedger(tenantID)
+	if err != nil {
+		return nil
+	}
+	return ledger
+}
+
+// GetRentProrationsByPayment returns a tenant's prorated rent breakdowns keyed by payment ID
+func (s *PaymentService) GetRentProrationsByPayment(tenantID int) map[int]*domain.RentProration {
+	return s.prorationService.GetProrationsByPayment(tenantID)
+}
+
 // CreateNextPayment creates the next payment for a tenant after current payment is fully paid
 func (s *PaymentService) CreateNextPayment(currentPayment *domain.Payment) (*domain.Payment, error) {
 	// Calculate next due date: currentPayment.DueDate + 1 month
 	nextDueDate := currentPayment.DueDate.AddDate(0, 1, 0)
 
+	// A prorated first month does not set the rent for the months after it
+	amount := currentPayment.Amount
+	if proration, err := s.prorationService.GetProrationByPaymentID(currentPayment.ID); err == nil && proration != nil {
+		amount = proration.MonthlyRent
+	}
+
 	// Use shared helper method
 	return s.CreatePaymentForTenant(
 		currentPayment.TenantID,
 		currentPayment.UnitID,
 		nextDueDate,
-		currentPayment.Amount,
+		amount,
 	)
 }
 
@@ -340,6 +583,12 @@ func (s *PaymentService) AutoCreateNextPayment(payment *domain.Payment) error {
 		return nil // Not fully paid, no need to create next
 	}
 
+	// Archived tenants have moved out, so no further rent is generated
+	tenant, err := s.tenantRepo.GetTenantByID(payment.TenantID)
+	if err == nil && tenant.IsArchived() {
+		return nil
+	}
+
 	// Check if next payment already exists
 	nextDueDate := payment.DueDate.AddDate(0, 1, 0)
 	existing, err := s.paymentRepo.GetPaymentByTenantAndMonth(payment.TenantID, nextDueDate.Month(), nextDueDate.Year())
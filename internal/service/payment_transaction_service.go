@@ -4,6 +4,7 @@ import (
 	"backend-form/m/internal/domain"
 	interfaces "backend-form/m/internal/repository/interfaces"
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -12,13 +13,20 @@ import (
 type PaymentTransactionService struct {
 	paymentRepo    interfaces.PaymentRepository
 	paymentService *PaymentService // For getOrCreateCurrentPayment and autoCreateNextPayment
+	creditService  *CreditService  // Holds overpayments until the next payment is generated
+	receiptService *ReceiptService // Prepares the receipts issued when a transaction is verified
+
+	notificationService *NotificationService // Tells the owner about submissions and the tenant about verifications
 }
 
 // NewPaymentTransactionService creates a new PaymentTransactionService
-func NewPaymentTransactionService(paymentRepo interfaces.PaymentRepository, paymentService *PaymentService) *PaymentTransactionService {
+func NewPaymentTransactionService(paymentRepo interfaces.PaymentRepository, paymentService *PaymentService, creditService *CreditService, receiptService *ReceiptService, notificationService *NotificationService) *PaymentTransactionService {
 	return &PaymentTransactionService{
-		paymentRepo:    paymentRepo,
-		paymentService: paymentService,
+		paymentRepo:         paymentRepo,
+		paymentService:      paymentService,
+		creditService:       creditService,
+		receiptService:      receiptService,
+		notificationService: notificationService,
 	}
 }
 
@@ -30,19 +38,20 @@ func (s *PaymentTransactionService) GetPendingVerifications(tenantID int) ([]*do
 // SubmitPaymentIntent creates a payment transaction record for a tenant
 // This is called when a tenant submits a UPI transaction ID
 // suggestedAmount is optional - if provided, it's stored in notes for owner reference
-func (s *PaymentTransactionService) SubmitPaymentIntent(tenantID int, txnID string, suggestedAmount *int) error {
-	// Get or create current unpaid payment using PaymentService
-	payment, err := s.paymentService.getOrCreateCurrentPayment(tenantID)
+// paymentID links the transaction to the payment the tenant paid with its UPI QR/link (0 = current payment)
+func (s *PaymentTransactionService) SubmitPaymentIntent(tenantID int, txnID string, suggestedAmount *int, paymentID int) error {
+	payment, err := s.getSubmissionPayment(tenantID, paymentID)
 	if err != nil {
-		return fmt.Errorf("get or create payment: %w", err)
+		return err
 	}
 
 	// Check if transaction already exists
+	// A rejected UTR can be submitted again: it is recorded as a new submission and the rejection is kept
 	existing, err := s.paymentRepo.GetTransactionByPaymentAndID(payment.ID, txnID)
 	if err != nil {
 		return fmt.Errorf("check existing transaction: %w", err)
 	}
-	if existing != nil {
+	if existing != nil && !existing.IsRejected() {
 		return nil // Already exists, no error
 	}
 
@@ -65,12 +74,40 @@ func (s *PaymentTransactionService) SubmitPaymentIntent(tenantID int, txnID stri
 		return fmt.Errorf("create payment transaction: %w", err)
 	}
 
+	if err := s.notificationService.NotifyPaymentSubmitted(tx, payment); err != nil && !isSkipped(err) {
+		// Log error but don't fail - the submission is recorded and shows up for verification
+		fmt.Printf("Warning: Failed to notify owner of transaction %s: %v\n", txnID, err)
+	}
+
 	return nil
 }
 
+// getSubmissionPayment returns the payment a tenant's submission is for
+// Falls back to the current unpaid payment if paymentID is 0 or already paid
+func (s *PaymentTransactionService) getSubmissionPayment(tenantID int, paymentID int) (*domain.Payment, error) {
+	if paymentID > 0 {
+		payment, err := s.paymentRepo.GetPaymentByID(paymentID)
+		if err != nil || payment.TenantID != tenantID {
+			return nil, fmt.Errorf("payment not found")
+		}
+		if !payment.IsFullyPaid {
+			return payment, nil
+		}
+	}
+
+	// Get or create current unpaid payment using PaymentService
+	payment, err := s.paymentService.getOrCreateCurrentPayment(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("get or create payment: %w", err)
+	}
+	return payment, nil
+}
+
 // VerifyTransaction verifies a transaction by setting its amount and updating the payment
 // This implements smart allocation: if amount exceeds the linked payment, excess is allocated to next payments
-// This is called when an owner verifies a transaction submitted by a tenant
+// Anything left after all unpaid payments is credited to the tenant's wallet
+// This is called when an owner verifies a transaction submitted by a tenant,
+// or by GatewayService with verifiedByUserID 0 when a gateway webhook confirms a payment
 func (s *PaymentTransactionService) VerifyTransaction(transactionID string, amount int, verifiedByUserID int) error {
 	// Get transaction directly by ID (efficient - O(1))
 	tx, err := s.paymentRepo.GetTransactionByID(transactionID)
@@ -89,6 +126,9 @@ func (s *PaymentTransactionService) VerifyTransaction(transactionID string, amou
 		}
 		return fmt.Errorf("transaction already verified with amount %s on %s", verifiedAmount, tx.GetFormattedVerifiedAt())
 	}
+	if tx.IsRejected() {
+		return fmt.Errorf("transaction was rejected on %s and cannot be verified", tx.GetFormattedRejectedAt())
+	}
 
 	// Get the linked payment to get tenant ID
 	linkedPayment, err := s.paymentRepo.GetPaymentByID(tx.PaymentID)
@@ -104,10 +144,6 @@ func (s *PaymentTransactionService) VerifyTransaction(transactionID string, amou
 		return fmt.Errorf("get unpaid payments: %w", err)
 	}
 
-	if len(unpaidPayments) == 0 {
-		return fmt.Errorf("no unpaid payments found for tenant")
-	}
-
 	// Find the linked payment in the unpaid list (should be first or near first)
 	// Allocate starting from linked payment, then continue to next payments
 	remainingAmount := amount
@@ -144,15 +180,24 @@ func (s *PaymentTransactionService) VerifyTransaction(transactionID string, amou
 	}
 
 	// If there's still remaining amount after all unpaid payments, that's an overpayment
-	// We'll allocate it to the last payment (or could reject - but allocating is more user-friendly)
-	if remainingAmount > 0 && len(unpaidPayments) > 0 {
-		lastPayment := unpaidPayments[len(unpaidPayments)-1]
-		allocations[lastPayment.ID] += remainingAmount
+	// Credit it so it is drawn down when the next payment is generated
+	now := time.Now()
+	var overpayment *domain.CreditEntry
+	if remainingAmount > 0 {
+		overpayment, err = s.creditService.PrepareOverpayment(tenantID, transactionID, remainingAmount, now)
+		if err != nil {
+			return fmt.Errorf("credit overpayment of ₹%d: %w", remainingAmount, err)
+		}
 	}
 
-	// Apply all allocations in a single database transaction for atomicity
-	now := time.Now()
-	if err := s.paymentRepo.ApplySmartAllocation(transactionID, amount, verifiedByUserID, allocations, now); err != nil {
+	// Receipt for every payment the transaction is allocated to
+	receipts, err := s.receiptService.PrepareReceipts(transactionID, allocations, now)
+	if err != nil {
+		return fmt.Errorf("prepare receipts: %w", err)
+	}
+
+	// Apply all allocations, the overpayment credit and the receipts in a single database transaction for atomicity
+	if err := s.paymentRepo.ApplySmartAllocation(transactionID, amount, verifiedByUserID, allocations, overpayment, receipts, now); err != nil {
 		return fmt.Errorf("apply smart allocation: %w", err)
 	}
 
@@ -167,12 +212,26 @@ func (s *PaymentTransactionService) VerifyTransaction(transactionID string, amou
 		}
 	}
 
+	allocatedPaymentIDs := make([]int, 0, len(allocations))
+	for paymentID := range allocations {
+		allocatedPaymentIDs = append(allocatedPaymentIDs, paymentID)
+	}
+	if err := s.notificationService.NotifyTransactionVerified(tenantID, transactionID, amount, tx.PaymentID, allocatedPaymentIDs); err != nil && !isSkipped(err) {
+		fmt.Printf("Warning: Failed to notify tenant of verified transaction %s: %v\n", transactionID, err)
+	}
+
 	return nil
 }
 
-// RejectTransaction rejects a pending transaction (deletes it)
+// RejectTransaction rejects a pending transaction with a reason
+// The transaction is kept so the tenant can see why and resubmit
 // This is called when an owner rejects an invalid transaction
-func (s *PaymentTransactionService) RejectTransaction(transactionID string) error {
+func (s *PaymentTransactionService) RejectTransaction(transactionID string, reason string, rejectedByUserID int) error {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return fmt.Errorf("reason is required")
+	}
+
 	// Check if transaction exists
 	tx, err := s.paymentRepo.GetTransactionByID(transactionID)
 	if err != nil {
@@ -181,7 +240,51 @@ func (s *PaymentTransactionService) RejectTransaction(transactionID string) erro
 	if tx == nil {
 		return fmt.Errorf("transaction not found")
 	}
+	if tx.IsRejected() {
+		return fmt.Errorf("transaction was already rejected on %s", tx.GetFormattedRejectedAt())
+	}
+
+	if err := s.paymentRepo.RejectTransaction(transactionID, reason, rejectedByUserID, time.Now()); err != nil {
+		return err
+	}
+
+	payment, err := s.paymentRepo.GetPaymentByID(tx.PaymentID)
+	if err != nil || payment == nil {
+		fmt.Printf("Warning: Failed to notify tenant of rejected transaction %s: payment %d not found\n", transactionID, tx.PaymentID)
+		return nil
+	}
+	if err := s.notificationService.NotifyTransactionRejected(payment.TenantID, payment.ID, transactionID, reason); err != nil && !isSkipped(err) {
+		fmt.Printf("Warning: Failed to notify tenant of rejected transaction %s: %v\n", transactionID, err)
+	}
+
+	return nil
+}
+
+// DisputedSubmissionSummary counts rejected transaction submissions
+type DisputedSubmissionSummary struct {
+	TotalRejected    int                          `json:"total_rejected"`
+	RejectedByTenant map[int]int                  `json:"rejected_by_tenant"` // tenantID -> rejected submissions
+	Transactions     []*domain.PaymentTransaction `json:"transactions"`
+}
+
+// GetDisputedSubmissions returns rejected submissions with counts per tenant (0 = all tenants)
+func (s *PaymentTransactionService) GetDisputedSubmissions(tenantID int) (*DisputedSubmissionSummary, error) {
+	transactions, err := s.paymentRepo.GetRejectedTransactions(tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &DisputedSubmissionSummary{
+		RejectedByTenant: make(map[int]int),
+		Transactions:     []*domain.PaymentTransaction{},
+	}
+	for _, tx := range transactions {
+		summary.TotalRejected++
+		if tx.Payment != nil {
+			summary.RejectedByTenant[tx.Payment.TenantID]++
+		}
+		summary.Transactions = append(summary.Transactions, tx)
+	}
 
-	// Reject (delete) the transaction
-	return s.paymentRepo.RejectTransaction(transactionID)
+	return summary, nil
 }
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"backend-form/m/internal/domain"
+	interfaces "backend-form/m/internal/repository/interfaces"
+	"fmt"
+	"strings"
+)
+
+// PropertyService handles property (building) related business logic
+type PropertyService struct {
+	propertyRepo interfaces.PropertyRepository
+	unitRepo     interfaces.UnitRepository
+}
+
+// NewPropertyService creates a new PropertyService
+func NewPropertyService(propertyRepo interfaces.PropertyRepository, unitRepo interfaces.UnitRepository) *PropertyService {
+	return &PropertyService{
+		propertyRepo: propertyRepo,
+		unitRepo:     unitRepo,
+	}
+}
+
+// CreateProperty validates and creates a new property
+func (s *PropertyService) CreateProperty(property *domain.Property) error {
+	property.Name = strings.TrimSpace(property.Name)
+	property.Address = strings.TrimSpace(property.Address)
+
+	if err := property.Validate(); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	return s.propertyRepo.CreateProperty(property)
+}
+
+// UpdateProperty updates property name and address
+func (s *PropertyService) UpdateProperty(property *domain.Property) error {
+	property.Name = strings.TrimSpace(property.Name)
+	property.Address = strings.TrimSpace(property.Address)
+
+	if err := property.Validate(); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	return s.propertyRepo.UpdateProperty(property)
+}
+
+// GetPropertyByID returns a property with its units
+func (s *PropertyService) GetPropertyByID(id int) (*domain.Property, error) {
+	property, err := s.propertyRepo.GetPropertyByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	units, err := s.unitRepo.GetUnitsByPropertyID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get units of property %d: %w", id, err)
+	}
+	property.Units = units
+
+	return property, nil
+}
+
+// GetAllProperties returns all properties
+func (s *PropertyService) GetAllProperties() ([]*domain.Property, error) {
+	return s.propertyRepo.GetAllProperties()
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"backend-form/m/internal/domain"
+	interfaces "backend-form/m/internal/repository/interfaces"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ProrationService charges rent for the partial first and final months of a tenancy
+type ProrationService struct {
+	prorationRepo interfaces.ProrationRepository
+	paymentRepo   interfaces.PaymentRepository
+	policy        string
+}
+
+// NewProrationService creates a new ProrationService
+// policy is one of domain.ProrationPolicyNone, ProrationPolicyActualDays or ProrationPolicyThirtyDay
+func NewProrationService(prorationRepo interfaces.ProrationRepository, paymentRepo interfaces.PaymentRepository, policy string) *ProrationService {
+	return &ProrationService{
+		prorationRepo: prorationRepo,
+		paymentRepo:   paymentRepo,
+		policy:        policy,
+	}
+}
+
+// GetPolicy returns the proration policy in use
+func (s *ProrationService) GetPolicy() string {
+	return s.policy
+}
+
+// PrepareFirstPaymentProration prorates a tenant's first rent payment, before it is created, for the days from
+// move-in to month end. If the first payment falls due in the move-in month it is reduced to the prorated rent;
+// if move-in is after the due day (first payment due next month) the prorated days are added on top of that
+// month's full rent. Prorating before the payment exists means credit is drawn down against the prorated amount.
+// Returns nil, leaving the payment alone, when the tenant moves in on the 1st or proration is disabled.
+func (s *ProrationService) PrepareFirstPaymentProration(tenant *domain.Tenant, payment *domain.Payment) *domain.RentProration {
+	moveIn := tenant.MoveInDate
+	if s.policy == domain.ProrationPolicyNone || moveIn.Day() == 1 {
+		return nil
+	}
+
+	monthlyRent := payment.Amount
+	periodStart := time.Date(moveIn.Year(), moveIn.Month(), moveIn.Day(), 0, 0, 0, 0, moveIn.Location())
+	periodEnd := time.Date(moveIn.Year(), moveIn.Month()+1, 0, 0, 0, 0, 0, moveIn.Location())
+
+	amount, daysCharged, monthDays := domain.ProrateRent(s.policy, monthlyRent, periodStart, periodEnd)
+	if daysCharged >= monthDays {
+		return nil
+	}
+
+	proration := &domain.RentProration{
+		TenantID:       tenant.ID,
+		Kind:           domain.ProrationKindFirst,
+		Policy:         s.policy,
+		MonthlyRent:    monthlyRent,
+		PeriodStart:    periodStart,
+		PeriodEnd:      periodEnd,
+		DaysCharged:    daysCharged,
+		DaysInMonth:    monthDays,
+		ProratedAmount: amount,
+	}
+	// First payment is due next month, so it also carries that month's full rent
+	if payment.DueDate.Month() != moveIn.Month() || payment.DueDate.Year() != moveIn.Year() {
+		proration.AdditionalAmount = monthlyRent
+	}
+
+	prorate(payment, proration)
+	return proration
+}
+
+// CreateProratedPayment saves a payment prorated by PrepareFirstPaymentProration together with its breakdown
+func (s *ProrationService) CreateProratedPayment(payment *domain.Payment, proration *domain.RentProration) error {
+	return s.prorationRepo.CreateProratedPayment(payment, proration)
+}
+
+// PrepareMoveOutProration prorates the rent payment due in the move-out month for the days up to move-out
+// Payments already prorated or fully paid are left alone, and the amount never drops below what was paid.
+// Nothing is saved: the prorated payment is returned with the breakdown for the move-out to save.
+// Returns nil if no payment needed prorating.
+func (s *ProrationService) PrepareMoveOutProration(tenantID int, moveOutDate time.Time) (*domain.RentProration, *domain.Payment, error) {
+	if s.policy == domain.ProrationPolicyNone {
+		return nil, nil, nil
+	}
+
+	payments, err := s.paymentRepo.GetPaymentsByTenantID(tenantID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load payments: %w", err)
+	}
+
+	for _, payment := range payments {
+		if payment.Label != domain.PaymentLabelRent || payment.IsFullyPaid ||
+			payment.DueDate.Month() != moveOutDate.Month() || payment.DueDate.Year() != moveOutDate.Year() {
+			continue
+		}
+
+		existing, err := s.prorationRepo.GetProrationByPaymentID(payment.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if existing != nil {
+			continue // Tenant moved in and out in the same month; first-month proration stands
+		}
+
+		periodStart := time.Date(moveOutDate.Year(), moveOutDate.Month(), 1, 0, 0, 0, 0, moveOutDate.Location())
+		periodEnd := time.Date(moveOutDate.Year(), moveOutDate.Month(), moveOutDate.Day(), 0, 0, 0, 0, moveOutDate.Location())
+
+		amount, daysCharged, monthDays := domain.ProrateRent(s.policy, payment.Amount, periodStart, periodEnd)
+		if daysCharged >= monthDays {
+			return nil, nil, nil // Moved out on the last day, full month is owed
+		}
+
+		proration := &domain.RentProration{
+			PaymentID:      payment.ID,
+			TenantID:       tenantID,
+			Kind:           domain.ProrationKindFinal,
+			Policy:         s.policy,
+			MonthlyRent:    payment.Amount,
+			PeriodStart:    periodStart,
+			PeriodEnd:      periodEnd,
+			DaysCharged:    daysCharged,
+			DaysInMonth:    monthDays,
+			ProratedAmount: amount,
+		}
+		prorate(payment, proration)
+		return proration, payment, nil
+	}
+
+	return nil, nil, nil
+}
+
+// prorate updates the payment amount and notes for a proration
+func prorate(payment *domain.Payment, proration *domain.RentProration) {
+	payment.Amount = proration.GetTotalAmount()
+	if settled := payment.AmountPaid + payment.AmountAdjusted; payment.Amount < settled {
+		payment.Amount = settled // Never owe less than already paid or adjusted
+	}
+	payment.Notes = strings.TrimSpace(payment.Notes + " " + proration.GetDescription())
+	payment.RecalculateBalance()
+}
+
+// GetProrationByPaymentID returns the proration breakdown of a payment (nil if it charged a full month)
+func (s *ProrationService) GetProrationByPaymentID(paymentID int) (*domain.RentProration, error) {
+	return s.prorationRepo.GetProrationByPaymentID(paymentID)
+}
+
+// GetProrationsByPayment returns a tenant's proration breakdowns keyed by payment ID
+func (s *ProrationService) GetProrationsByPayment(tenantID int) map[int]*domain.RentProration {
+	result := make(map[int]*domain.RentProration)
+	prorations, err := s.prorationRepo.GetProrationsByTenantID(tenantID)
+	if err != nil {
+		return result
+	}
+	for _, proration := range prorations {
+		result[proration.PaymentID] = proration
+	}
+	return result
+}
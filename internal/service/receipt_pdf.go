@@ -0,0 +1,80 @@
+package service
+
+import (
+	"backend-form/m/internal/domain"
+	"fmt"
+	"io"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// RenderReceiptPDF writes a receipt or credit note as a single-page A4 PDF
+// The built-in PDF fonts have no rupee sign, so amounts are written as "Rs."
+func RenderReceiptPDF(receipt *domain.Receipt, w io.Writer) error {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	tr := pdf.UnicodeTranslatorFromDescriptor("") // Core fonts use cp1252
+	pdf.SetTitle(receipt.GetTitle()+" "+receipt.ReceiptNumber, true)
+	pdf.SetMargins(20, 20, 20)
+	pdf.AddPage()
+
+	// Header
+	pdf.SetFont("Helvetica", "B", 18)
+	pdf.CellFormat(0, 10, tr(receipt.GetTitle()), "", 1, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.CellFormat(0, 6, tr("No. "+receipt.ReceiptNumber), "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, 6, tr("Issued on "+receipt.GetFormattedIssuedAt()+"  |  FY "+receipt.FinancialYear), "", 1, "L", false, 0, "")
+	pdf.Ln(4)
+	pdf.SetDrawColor(200, 200, 200)
+	pdf.Line(20, pdf.GetY(), 190, pdf.GetY())
+	pdf.Ln(6)
+
+	// Details
+	rows := [][2]string{
+		{"Received from", receipt.TenantName},
+		{"Unit", receipt.UnitCode},
+		{"Property", receipt.PropertyName},
+		{"Address", receipt.PropertyAddress},
+		{"Towards", receipt.LabelName},
+		{"Period", receipt.Period},
+		{"Transaction ID (UTR)", receipt.TransactionID},
+		{"Verified on", receipt.GetFormattedVerifiedAt()},
+		{"Paid to", receipt.PayeeName},
+		{"Payee UPI ID", receipt.PayeeUPIID},
+	}
+	if receipt.IsCreditNote() {
+		rows = append(rows,
+			[2]string{"Against receipt", receipt.OriginalReceiptNumber},
+			[2]string{"Reason", receipt.Reason},
+		)
+	}
+	for _, row := range rows {
+		if row[1] == "" {
+			continue
+		}
+		pdf.SetFont("Helvetica", "B", 10)
+		pdf.CellFormat(55, 7, tr(row[0]), "", 0, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "", 10)
+		pdf.MultiCell(0, 7, tr(row[1]), "", "L", false)
+	}
+	pdf.Ln(6)
+
+	// Amounts
+	amountLabel, balanceLabel := "Amount received", "Balance due after this payment"
+	if receipt.IsCreditNote() {
+		amountLabel, balanceLabel = "Amount credited", "Balance due after this credit"
+	}
+	pdf.SetFillColor(240, 240, 240)
+	pdf.SetFont("Helvetica", "B", 12)
+	pdf.CellFormat(85, 10, tr(amountLabel), "1", 0, "L", true, 0, "")
+	pdf.CellFormat(85, 10, fmt.Sprintf("Rs. %d", receipt.Amount), "1", 1, "R", true, 0, "")
+	pdf.SetFont("Helvetica", "", 11)
+	pdf.CellFormat(85, 9, balanceLabel, "1", 0, "L", false, 0, "")
+	pdf.CellFormat(85, 9, fmt.Sprintf("Rs. %d", receipt.BalanceAfter), "1", 1, "R", false, 0, "")
+	pdf.Ln(10)
+
+	pdf.SetFont("Helvetica", "I", 8)
+	pdf.SetTextColor(120, 120, 120)
+	pdf.MultiCell(0, 5, "This is a computer-generated document and does not require a signature.", "", "L", false)
+
+	return pdf.Output(w)
+}
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"backend-form/m/internal/domain"
+	interfaces "backend-form/m/internal/repository/interfaces"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ReceiptService issues numbered receipts for verified transactions and credit notes for corrections
+// Receipts are never changed once issued.
+type ReceiptService struct {
+	receiptRepo  interfaces.ReceiptRepository
+	paymentRepo  interfaces.PaymentRepository
+	tenantRepo   interfaces.TenantRepository
+	unitRepo     interfaces.UnitRepository
+	propertyRepo interfaces.PropertyRepository
+	payeeName    string
+	defaultUPIID string
+}
+
+// NewReceiptService creates a new ReceiptService
+func NewReceiptService(receiptRepo interfaces.ReceiptRepository, paymentRepo interfaces.PaymentRepository, tenantRepo interfaces.TenantRepository, unitRepo interfaces.UnitRepository, propertyRepo interfaces.PropertyRepository, payeeName, defaultUPIID string) *ReceiptService {
+	return &ReceiptService{
+		receiptRepo:  receiptRepo,
+		paymentRepo:  paymentRepo,
+		tenantRepo:   tenantRepo,
+		unitRepo:     unitRepo,
+		propertyRepo: propertyRepo,
+		payeeName:    payeeName,
+		defaultUPIID: defaultUPIID,
+	}
+}
+
+// PrepareReceipts builds one receipt per payment a transaction is being allocated to, in payment ID order
+// Nothing is saved: the receipts are issued in the transaction that verifies it (see PaymentRepository.ApplySmartAllocation)
+func (s *ReceiptService) PrepareReceipts(transactionID string, allocations map[int]int, verifiedAt time.Time) ([]*domain.Receipt, error) {
+	paymentIDs := make([]int, 0, len(allocations))
+	for paymentID := range allocations {
+		paymentIDs = append(paymentIDs, paymentID)
+	}
+	sort.Ints(paymentIDs) // Deterministic numbering for a multi-payment allocation
+
+	var receipts []*domain.Receipt
+	for _, paymentID := range paymentIDs {
+		amount := allocations[paymentID]
+		if amount <= 0 {
+			continue
+		}
+
+		receipt, err := s.buildReceipt(transactionID, paymentID, amount, verifiedAt)
+		if err != nil {
+			return nil, fmt.Errorf("payment %d: %w", paymentID, err)
+		}
+		receipts = append(receipts, receipt)
+	}
+
+	return receipts, nil
+}
+
+// buildReceipt copies the payment, tenant, unit and payee details into a new receipt
+func (s *ReceiptService) buildReceipt(transactionID string, paymentID int, amount int, verifiedAt time.Time) (*domain.Receipt, error) {
+	payment, err := s.paymentRepo.GetPaymentByID(paymentID)
+	if err != nil {
+		return nil, err
+	}
+	tenant, err := s.tenantRepo.GetTenantByID(payment.TenantID)
+	if err != nil {
+		return nil, fmt.Errorf("tenant not found: %w", err)
+	}
+
+	receipt := &domain.Receipt{
+		DocumentType:  domain.ReceiptTypeReceipt,
+		FinancialYear: domain.FinancialYear(verifiedAt),
+		TransactionID: transactionID,
+		PaymentID:     payment.ID,
+		TenantID:      tenant.ID,
+		TenantName:    tenant.Name,
+		PayeeName:     s.payeeName,
+		PayeeUPIID:    payment.UPIID,
+		Label:         payment.Label,
+		LabelName:     payment.GetLabelDisplayName(),
+		Period:        payment.DueDate.Format("January 2006"),
+		Amount:        amount,
+		BalanceAfter:  payment.RemainingBalance - amount, // Set again from the balance the allocation leaves
+		VerifiedAt:    verifiedAt,
+	}
+	if receipt.PayeeUPIID == "" {
+		receipt.PayeeUPIID = s.defaultUPIID
+	}
+
+	if unit, err := s.unitRepo.GetUnitByID(payment.UnitID); err == nil {
+		receipt.UnitCode = unit.UnitCode
+		if property, err := s.propertyRepo.GetPropertyByID(unit.PropertyID); err == nil {
+			receipt.PropertyName = property.Name
+			receipt.PropertyAddress = property.Address
+		}
+	}
+
+	if err := receipt.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid receipt: %w", err)
+	}
+	return receipt, nil
+}
+
+// IssueCreditNote corrects a receipt by crediting back part or all of its amount
+// The credited amount is taken off what was paid towards the payment, which reopens its balance;
+// the note and the payment are saved together.
+func (s *ReceiptService) IssueCreditNote(receiptID int, amount int, reason string, issuedByUserID int) (*domain.Receipt, error) {
+	original, err := s.receiptRepo.GetReceiptByID(receiptID)
+	if err != nil {
+		return nil, err
+	}
+
+	creditNotes, err := s.receiptRepo.GetCreditNotesByReceiptID(original.ID)
+	if err != nil {
+		return nil, err
+	}
+	alreadyCredited := 0
+	for _, note := range creditNotes {
+		alreadyCredited += note.Amount
+	}
+
+	note, err := domain.NewCreditNote(original, amount, alreadyCredited, reason)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	payment, err := s.paymentRepo.GetPaymentByID(original.PaymentID)
+	if err != nil {
+		return nil, fmt.Errorf("payment %d for receipt %s: %w", original.PaymentID, original.ReceiptNumber, err)
+	}
+	if payment.AmountPaid < amount {
+		return nil, fmt.Errorf("credit of ₹%d exceeds the ₹%d paid towards payment %d", amount, payment.AmountPaid, payment.ID)
+	}
+
+	now := time.Now()
+	note.FinancialYear = domain.FinancialYear(now)
+	note.VerifiedAt = now
+	note.IssuedByUserID = &issuedByUserID
+	if err := s.receiptRepo.CreateCreditNote(note); err != nil {
+		return nil, err
+	}
+
+	return note, nil
+}
+
+// GetReceiptByID returns a receipt or credit note
+func (s *ReceiptService) GetReceiptByID(id int) (*domain.Receipt, error) {
+	return s.receiptRepo.GetReceiptByID(id)
+}
+
+// GetReceiptsByTenantID returns all receipts and credit notes of a tenant, newest first
+func (s *ReceiptService) GetReceiptsByTenantID(tenantID int) ([]*domain.Receipt, error) {
+	return s.receiptRepo.GetReceiptsByTenantID(tenantID)
+}
+
+// GetReceiptsByPaymentID returns all receipts and credit notes of a payment, oldest first
+func (s *ReceiptService) GetReceiptsByPaymentID(paymentID int) ([]*domain.Receipt, error) {
+	return s.receiptRepo.GetReceiptsByPaymentID(paymentID)
+}
+
+// GetReceiptsByPayment returns a tenant's receipts and credit notes keyed by payment ID
+func (s *ReceiptService) GetReceiptsByPayment(tenantID int) map[int][]*domain.Receipt {
+	result := make(map[int][]*domain.Receipt)
+	receipts, err := s.receiptRepo.GetReceiptsByTenantID(tenantID)
+	if err != nil {
+		return result
+	}
+	// Oldest first within each payment
+	for i := len(receipts) - 1; i >= 0; i-- {
+		result[receipts[i].PaymentID] = append(result[receipts[i].PaymentID], receipts[i])
+	}
+	return result
+}
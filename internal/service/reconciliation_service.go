@@ -0,0 +1,212 @@
+package service
+
+import (
+	"backend-form/m/internal/domain"
+	interfaces "backend-form/m/internal/repository/interfaces"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ReconciliationService imports bank statements and verifies submitted transactions against them
+// Credits whose reference carries a pending UTR are verified automatically; everything else
+// is left in a review queue for the owner.
+type ReconciliationService struct {
+	statementRepo             interfaces.BankStatementRepository
+	paymentTransactionService *PaymentTransactionService
+}
+
+// NewReconciliationService creates a new ReconciliationService
+func NewReconciliationService(statementRepo interfaces.BankStatementRepository, paymentTransactionService *PaymentTransactionService) *ReconciliationService {
+	return &ReconciliationService{
+		statementRepo:             statementRepo,
+		paymentTransactionService: paymentTransactionService,
+	}
+}
+
+// ReconciliationQueue is what the owner still has to reconcile by hand
+type ReconciliationQueue struct {
+	Lines              []*domain.StatementLine      `json:"lines"`               // Statement credits in review or unmatched
+	PendingSubmissions []*domain.PaymentTransaction `json:"pending_submissions"` // Tenant submissions not found on any statement
+}
+
+// ImportStatement parses a statement, stores its credit lines and verifies the ones that match
+// format may be empty to detect it from the file name. Credits already imported from an earlier
+// statement are skipped.
+func (s *ReconciliationService) ImportStatement(fileName string, format string, r io.Reader, importedByUserID int) (*domain.StatementImport, error) {
+	if format == "" {
+		format = domain.DetectStatementFormat(fileName)
+	}
+
+	lines, err := domain.ParseStatement(format, r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse statement: %w", err)
+	}
+
+	pending, err := s.paymentTransactionService.GetPendingVerifications(0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load pending transactions: %w", err)
+	}
+
+	statementImport := &domain.StatementImport{
+		FileName:         fileName,
+		Format:           format,
+		ImportedByUserID: importedByUserID,
+	}
+	if err := s.statementRepo.CreateImport(statementImport); err != nil {
+		return nil, err
+	}
+
+	for _, line := range lines {
+		exists, err := s.statementRepo.LineExists(line.TxnDate, line.Amount, line.Reference, line.Description)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			statementImport.DuplicateCount++
+			continue
+		}
+
+		line.ImportID = statementImport.ID
+		line.Status = domain.StatementLineUnmatched
+
+		tx, note := domain.MatchStatementLine(line, pending)
+		autoVerify := false
+		if tx != nil {
+			transactionID := tx.TransactionID
+			line.MatchedTransactionID = &transactionID
+			line.Status = domain.StatementLineReview
+			line.Note = note
+
+			if note == "" {
+				autoVerify = true
+				line.Note = "automatic verification did not complete"
+			}
+			pending = removeTransaction(pending, tx)
+		}
+
+		// Saved before verifying: if anything fails afterwards the line is already on record,
+		// so importing the statement again skips it instead of verifying the credit twice
+		if err := s.statementRepo.CreateLine(line); err != nil {
+			return nil, err
+		}
+		statementImport.LineCount++
+		statementImport.Lines = append(statementImport.Lines, line)
+
+		if autoVerify {
+			if err := s.paymentTransactionService.VerifyTransaction(tx.TransactionID, line.Amount, importedByUserID); err != nil {
+				line.Note = fmt.Sprintf("automatic verification failed: %v", err)
+			} else {
+				line.Status = domain.StatementLineMatched
+				line.Note = ""
+				statementImport.MatchedCount++
+			}
+			if err := s.statementRepo.UpdateLine(line); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := s.statementRepo.UpdateImportCounts(statementImport); err != nil {
+		return nil, err
+	}
+
+	return statementImport, nil
+}
+
+// removeTransaction returns the list without the given transaction
+func removeTransaction(transactions []*domain.PaymentTransaction, tx *domain.PaymentTransaction) []*domain.PaymentTransaction {
+	result := make([]*domain.PaymentTransaction, 0, len(transactions))
+	for _, t := range transactions {
+		if t != tx {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+// GetReviewQueue returns statement lines and tenant submissions still to be reconciled
+func (s *ReconciliationService) GetReviewQueue() (*ReconciliationQueue, error) {
+	lines, err := s.statementRepo.GetOpenLines()
+	if err != nil {
+		return nil, err
+	}
+
+	pending, err := s.paymentTransactionService.GetPendingVerifications(0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load pending transactions: %w", err)
+	}
+
+	queue := &ReconciliationQueue{
+		Lines:              []*domain.StatementLine{},
+		PendingSubmissions: []*domain.PaymentTransaction{},
+	}
+	queue.Lines = append(queue.Lines, lines...)
+	queue.PendingSubmissions = append(queue.PendingSubmissions, pending...)
+
+	return queue, nil
+}
+
+// MatchLine verifies a pending transaction with the amount of a statement line chosen by the owner
+func (s *ReconciliationService) MatchLine(lineID int, transactionID string, verifiedByUserID int) (*domain.StatementLine, error) {
+	line, err := s.getOpenLine(lineID)
+	if err != nil {
+		return nil, err
+	}
+
+	transactionID = strings.TrimSpace(transactionID)
+	if transactionID == "" {
+		return nil, fmt.Errorf("transaction_id is required")
+	}
+
+	if err := s.paymentTransactionService.VerifyTransaction(transactionID, line.Amount, verifiedByUserID); err != nil {
+		return nil, err
+	}
+
+	line.Status = domain.StatementLineMatched
+	line.MatchedTransactionID = &transactionID
+	line.Note = "Matched by owner"
+	if err := s.statementRepo.UpdateLine(line); err != nil {
+		return nil, err
+	}
+
+	return line, nil
+}
+
+// IgnoreLine dismisses a statement line that is not a tenant payment
+func (s *ReconciliationService) IgnoreLine(lineID int, reason string) (*domain.StatementLine, error) {
+	line, err := s.getOpenLine(lineID)
+	if err != nil {
+		return nil, err
+	}
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, fmt.Errorf("reason is required")
+	}
+
+	line.Status = domain.StatementLineIgnored
+	line.Note = reason
+	if err := s.statementRepo.UpdateLine(line); err != nil {
+		return nil, err
+	}
+
+	return line, nil
+}
+
+// getOpenLine loads a statement line and ensures it still needs reconciling
+func (s *ReconciliationService) getOpenLine(lineID int) (*domain.StatementLine, error) {
+	line, err := s.statementRepo.GetLineByID(lineID)
+	if err != nil {
+		return nil, err
+	}
+	if !line.IsOpen() {
+		return nil, fmt.Errorf("statement line has already been %s", line.Status)
+	}
+	return line, nil
+}
+
+// GetImports returns all statement imports, most recent first
+func (s *ReconciliationService) GetImports() ([]*domain.StatementImport, error) {
+	return s.statementRepo.GetImports()
+}
@@ -0,0 +1,237 @@
+package service
+
+import (
+	"backend-form/m/internal/domain"
+	interfaces "backend-form/m/internal/repository/interfaces"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// RecurringChargeService manages recurring non-rent charge schedules (maintenance, internet, parking)
+// Payments for due schedules are generated by PaymentService alongside rent
+type RecurringChargeService struct {
+	recurringRepo   interfaces.RecurringChargeRepository
+	tenantRepo      interfaces.TenantRepository
+	unitRepo        interfaces.UnitRepository
+	categoryService *ChargeCategoryService
+}
+
+// NewRecurringChargeService creates a new RecurringChargeService
+func NewRecurringChargeService(
+	recurringRepo interfaces.RecurringChargeRepository,
+	tenantRepo interfaces.TenantRepository,
+	unitRepo interfaces.UnitRepository,
+	categoryService *ChargeCategoryService,
+) *RecurringChargeService {
+	return &RecurringChargeService{
+		recurringRepo:   recurringRepo,
+		tenantRepo:      tenantRepo,
+		unitRepo:        unitRepo,
+		categoryService: categoryService,
+	}
+}
+
+// CreateRecurringCharge validates and creates a recurring charge schedule
+// amount 0 uses the category's default amount
+func (s *RecurringChargeService) CreateRecurringCharge(charge *domain.RecurringCharge) error {
+	charge.CategoryCode = domain.NormalizeChargeCategoryCode(charge.CategoryCode)
+	category, err := s.categoryService.GetCategoryByCode(charge.CategoryCode)
+	if err != nil {
+		return fmt.Errorf("unknown payment type '%s'", charge.CategoryCode)
+	}
+	if !category.IsActive {
+		return fmt.Errorf("payment type '%s' is no longer in use", category.DisplayName)
+	}
+	if charge.Amount <= 0 {
+		charge.Amount = category.DefaultAmount
+	}
+
+	charge.IsActive = true
+	charge.Notes = strings.TrimSpace(charge.Notes)
+	if err := charge.Validate(); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	var existing []*domain.RecurringCharge
+	if charge.TenantID != nil {
+		tenant, err := s.tenantRepo.GetTenantByID(*charge.TenantID)
+		if err != nil {
+			return fmt.Errorf("tenant not found: %w", err)
+		}
+		if tenant.IsArchived() {
+			return fmt.Errorf("tenant has moved out")
+		}
+		existing, err = s.recurringRepo.GetRecurringChargesForTenant(tenant.ID, 0)
+		if err != nil {
+			return err
+		}
+	} else {
+		if _, err := s.unitRepo.GetUnitByID(*charge.UnitID); err != nil {
+			return fmt.Errorf("unit not found: %w", err)
+		}
+		existing, err = s.recurringRepo.GetRecurringChargesForTenant(0, *charge.UnitID)
+		if err != nil {
+			return err
+		}
+	}
+
+	// One active schedule per category and scope; amount changes go through ChangeAmount
+	for _, other := range existing {
+		if other.IsActive && other.CategoryCode == charge.CategoryCode {
+			return fmt.Errorf("an active '%s' schedule already exists; change its amount or end it instead", category.DisplayName)
+		}
+	}
+
+	return s.recurringRepo.CreateRecurringCharge(charge)
+}
+
+// GetRecurringChargeByID returns a schedule with its amount changes
+func (s *RecurringChargeService) GetRecurringChargeByID(id int) (*domain.RecurringCharge, error) {
+	charge, err := s.recurringRepo.GetRecurringChargeByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.loadAmountChanges(charge); err != nil {
+		return nil, err
+	}
+	return charge, nil
+}
+
+// loadAmountChanges attaches the amount history to a schedule
+func (s *RecurringChargeService) loadAmountChanges(charge *domain.RecurringCharge) error {
+	changes, err := s.recurringRepo.GetAmountChangesByChargeID(charge.ID)
+	if err != nil {
+		return err
+	}
+	charge.AmountChanges = changes
+	return nil
+}
+
+// UpdateRecurringCharge updates the due day, dates, active flag and notes of a schedule
+// The category, scope and amount are fixed; use ChangeAmount for new amounts
+func (s *RecurringChargeService) UpdateRecurringCharge(charge *domain.RecurringCharge) error {
+	existing, err := s.recurringRepo.GetRecurringChargeByID(charge.ID)
+	if err != nil {
+		return err
+	}
+
+	existing.DueDay = charge.DueDay
+	if !charge.StartDate.IsZero() {
+		existing.StartDate = charge.StartDate
+	}
+	existing.EndDate = charge.EndDate
+	existing.IsActive = charge.IsActive
+	existing.Notes = strings.TrimSpace(charge.Notes)
+	if err := existing.Validate(); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	if err := s.recurringRepo.UpdateRecurringCharge(existing); err != nil {
+		return err
+	}
+	*charge = *existing
+	return nil
+}
+
+// ChangeAmount changes the amount of a schedule from the month of effectiveFrom onwards
+// Payments already generated keep their amount
+func (s *RecurringChargeService) ChangeAmount(chargeID int, amount int, effectiveFrom time.Time) (*domain.RecurringCharge, error) {
+	if amount <= 0 {
+		return nil, fmt.Errorf("amount must be greater than 0")
+	}
+
+	charge, err := s.GetRecurringChargeByID(chargeID)
+	if err != nil {
+		return nil, err
+	}
+
+	effectiveMonth := time.Date(effectiveFrom.Year(), effectiveFrom.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if effectiveMonth.Before(time.Date(charge.StartDate.Year(), charge.StartDate.Month(), 1, 0, 0, 0, 0, time.UTC)) {
+		return nil, fmt.Errorf("amount change cannot take effect before the schedule starts")
+	}
+
+	change := &domain.RecurringChargeAmountChange{
+		RecurringChargeID: charge.ID,
+		Amount:            amount,
+		EffectiveFrom:     effectiveMonth,
+	}
+	if err := s.recurringRepo.CreateAmountChange(change); err != nil {
+		return nil, err
+	}
+	charge.AmountChanges = append(charge.AmountChanges, change)
+
+	return charge, nil
+}
+
+// DeleteRecurringCharge deletes a schedule (payments already generated are kept)
+func (s *RecurringChargeService) DeleteRecurringCharge(id int) error {
+	return s.recurringRepo.DeleteRecurringCharge(id)
+}
+
+// GetAllRecurringCharges returns all schedules with their amount changes
+func (s *RecurringChargeService) GetAllRecurringCharges() ([]*domain.RecurringCharge, error) {
+	charges, err := s.recurringRepo.GetAllRecurringCharges()
+	if err != nil {
+		return nil, err
+	}
+	for _, charge := range charges {
+		if err := s.loadAmountChanges(charge); err != nil {
+			return nil, err
+		}
+	}
+	return charges, nil
+}
+
+// GetRecurringChargesForTenant returns the schedules that apply to a tenant (their own and their unit's)
+func (s *RecurringChargeService) GetRecurringChargesForTenant(tenantID int) ([]*domain.RecurringCharge, error) {
+	tenant, err := s.tenantRepo.GetTenantByID(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("tenant not found: %w", err)
+	}
+
+	charges, err := s.recurringRepo.GetRecurringChargesForTenant(tenant.ID, tenant.UnitID)
+	if err != nil {
+		return nil, err
+	}
+	for _, charge := range charges {
+		if err := s.loadAmountChanges(charge); err != nil {
+			return nil, err
+		}
+	}
+	return charges, nil
+}
+
+// GetDueCharges returns the schedules a tenant should be charged for in a month
+// A tenant schedule wins over the unit schedule of the same category
+func (s *RecurringChargeService) GetDueCharges(tenantID, unitID int, month time.Month, year int) ([]*domain.RecurringCharge, error) {
+	charges, err := s.recurringRepo.GetRecurringChargesForTenant(tenantID, unitID)
+	if err != nil {
+		return nil, err
+	}
+
+	byCategory := make(map[string]*domain.RecurringCharge)
+	var order []string
+	for _, charge := range charges {
+		if !charge.IsDueIn(month, year) {
+			continue
+		}
+		current, seen := byCategory[charge.CategoryCode]
+		if !seen {
+			order = append(order, charge.CategoryCode)
+		}
+		if !seen || (charge.IsTenantSpecific() && !current.IsTenantSpecific()) {
+			byCategory[charge.CategoryCode] = charge
+		}
+	}
+
+	due := make([]*domain.RecurringCharge, 0, len(order))
+	for _, code := range order {
+		charge := byCategory[code]
+		if err := s.loadAmountChanges(charge); err != nil {
+			return nil, err
+		}
+		due = append(due, charge)
+	}
+	return due, nil
+}
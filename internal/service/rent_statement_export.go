@@ -0,0 +1,121 @@
+package service
+
+import (
+	"backend-form/m/internal/domain"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// WriteRentStatementCSV writes a rent statement as CSV, one row per month followed by a total row
+func WriteRentStatementCSV(statement *domain.RentStatement, w io.Writer) error {
+	cw := csv.NewWriter(w)
+	rows := [][]string{
+		{"Financial year", statement.FinancialYear},
+		{"Tenant", statement.TenantName},
+		{"Unit", statement.UnitCode},
+		{"Property address", statement.PropertyAddress},
+		{"Landlord", statement.LandlordName},
+		{"Landlord PAN", statement.LandlordPAN},
+		{"Landlord address", statement.LandlordAddress},
+		{},
+		{"Month", "Due date", "Rent", "Adjusted", "Amount paid", "Paid on", "Transaction IDs"},
+	}
+	for _, month := range statement.Months {
+		rows = append(rows, []string{
+			month.Period,
+			month.DueDate.Format("2006-01-02"),
+			strconv.Itoa(month.RentDue),
+			strconv.Itoa(month.AmountAdjusted),
+			strconv.Itoa(month.AmountPaid),
+			month.GetFormattedPaidOn(),
+			strings.Join(month.TransactionIDs, " "),
+		})
+	}
+	rows = append(rows, []string{"Total", "", strconv.Itoa(statement.TotalRent), strconv.Itoa(statement.TotalAdjusted), strconv.Itoa(statement.TotalPaid), "", ""})
+
+	if err := cw.WriteAll(rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+// RenderRentStatementPDF writes a rent statement as an A4 PDF
+// The built-in PDF fonts have no rupee sign, so amounts are written as "Rs."
+func RenderRentStatementPDF(statement *domain.RentStatement, w io.Writer) error {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	tr := pdf.UnicodeTranslatorFromDescriptor("") // Core fonts use cp1252
+	pdf.SetTitle("Rent Statement FY "+statement.FinancialYear, true)
+	pdf.SetMargins(20, 20, 20)
+	pdf.AddPage()
+
+	// Header
+	pdf.SetFont("Helvetica", "B", 18)
+	pdf.CellFormat(0, 10, "Rent Statement", "", 1, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.CellFormat(0, 6, tr("Financial year "+statement.FinancialYear+" ("+statement.GetFormattedPeriod()+")"), "", 1, "L", false, 0, "")
+	pdf.Ln(4)
+	pdf.SetDrawColor(200, 200, 200)
+	pdf.Line(20, pdf.GetY(), 190, pdf.GetY())
+	pdf.Ln(6)
+
+	// Parties
+	rows := [][2]string{
+		{"Tenant", statement.TenantName},
+		{"Unit", statement.UnitCode},
+		{"Rented property", statement.PropertyAddress},
+		{"Landlord", statement.LandlordName},
+		{"Landlord PAN", statement.LandlordPAN},
+		{"Landlord address", statement.LandlordAddress},
+	}
+	for _, row := range rows {
+		if row[1] == "" {
+			continue
+		}
+		pdf.SetFont("Helvetica", "B", 10)
+		pdf.CellFormat(45, 7, tr(row[0]), "", 0, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "", 10)
+		pdf.MultiCell(0, 7, tr(row[1]), "", "L", false)
+	}
+	pdf.Ln(6)
+
+	// Months
+	widths := []float64{32, 25, 25, 25, 25, 38}
+	pdf.SetFillColor(240, 240, 240)
+	pdf.SetFont("Helvetica", "B", 10)
+	for i, heading := range []string{"Month", "Rent", "Adjusted", "Amount paid", "Paid on", "Transaction ID"} {
+		pdf.CellFormat(widths[i], 8, heading, "1", 0, "L", true, 0, "")
+	}
+	pdf.Ln(-1)
+
+	pdf.SetFont("Helvetica", "", 9)
+	for _, month := range statement.Months {
+		pdf.CellFormat(widths[0], 7, month.Period, "1", 0, "L", false, 0, "")
+		pdf.CellFormat(widths[1], 7, fmt.Sprintf("Rs. %d", month.RentDue), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(widths[2], 7, fmt.Sprintf("Rs. %d", month.AmountAdjusted), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(widths[3], 7, fmt.Sprintf("Rs. %d", month.AmountPaid), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(widths[4], 7, month.GetFormattedPaidOn(), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(widths[5], 7, tr(strings.Join(month.TransactionIDs, ", ")), "1", 1, "L", false, 0, "")
+	}
+	if len(statement.Months) == 0 {
+		pdf.CellFormat(170, 7, "No rent was paid in this financial year.", "1", 1, "L", false, 0, "")
+	}
+
+	pdf.SetFont("Helvetica", "B", 10)
+	pdf.CellFormat(widths[0], 8, "Total", "1", 0, "L", true, 0, "")
+	pdf.CellFormat(widths[1], 8, fmt.Sprintf("Rs. %d", statement.TotalRent), "1", 0, "R", true, 0, "")
+	pdf.CellFormat(widths[2], 8, fmt.Sprintf("Rs. %d", statement.TotalAdjusted), "1", 0, "R", true, 0, "")
+	pdf.CellFormat(widths[3], 8, fmt.Sprintf("Rs. %d", statement.TotalPaid), "1", 0, "R", true, 0, "")
+	pdf.CellFormat(widths[4]+widths[5], 8, "", "1", 1, "L", true, 0, "")
+	pdf.Ln(10)
+
+	pdf.SetFont("Helvetica", "I", 8)
+	pdf.SetTextColor(120, 120, 120)
+	pdf.MultiCell(0, 5, "Generated on "+statement.GeneratedAt.Format("Jan 2, 2006")+". This is a computer-generated statement and does not require a signature.", "", "L", false)
+
+	return pdf.Output(w)
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"backend-form/m/internal/domain"
+	interfaces "backend-form/m/internal/repository/interfaces"
+	"fmt"
+	"time"
+)
+
+// RentStatementService builds financial-year rent statements for tenants' HRA and tax declarations
+type RentStatementService struct {
+	paymentService  *PaymentService
+	planService     *InstallmentPlanService // Rent moved into a plan is paid through its installments
+	tenantRepo      interfaces.TenantRepository
+	unitRepo        interfaces.UnitRepository
+	propertyRepo    interfaces.PropertyRepository
+	landlordName    string
+	landlordPAN     string
+	landlordAddress string
+}
+
+// NewRentStatementService creates a new RentStatementService
+func NewRentStatementService(paymentService *PaymentService, planService *InstallmentPlanService, tenantRepo interfaces.TenantRepository, unitRepo interfaces.UnitRepository, propertyRepo interfaces.PropertyRepository, landlordName, landlordPAN, landlordAddress string) *RentStatementService {
+	return &RentStatementService{
+		paymentService:  paymentService,
+		planService:     planService,
+		tenantRepo:      tenantRepo,
+		unitRepo:        unitRepo,
+		propertyRepo:    propertyRepo,
+		landlordName:    landlordName,
+		landlordPAN:     landlordPAN,
+		landlordAddress: landlordAddress,
+	}
+}
+
+// GetStatement builds a tenant's rent statement for a financial year (e.g. "2025-26")
+func (s *RentStatementService) GetStatement(tenantID int, financialYear string) (*domain.RentStatement, error) {
+	tenant, err := s.tenantRepo.GetTenantByID(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("tenant not found: %w", err)
+	}
+
+	payments, err := s.paymentService.GetPaymentsByTenantID(tenant.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load payments: %w", err)
+	}
+
+	plans, err := s.planService.GetPlansByTenantID(tenant.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load installment plans: %w", err)
+	}
+
+	statement, err := domain.BuildRentStatement(financialYear, payments, plans)
+	if err != nil {
+		return nil, err
+	}
+
+	statement.TenantName = tenant.Name
+	statement.LandlordName = s.landlordName
+	statement.LandlordPAN = s.landlordPAN
+	statement.LandlordAddress = s.landlordAddress
+	if statement.LandlordName == "" {
+		statement.LandlordName = s.paymentService.GetUPIPayeeName()
+	}
+
+	if unit, err := s.unitRepo.GetUnitByID(tenant.UnitID); err == nil {
+		statement.UnitCode = unit.UnitCode
+		if property, err := s.propertyRepo.GetPropertyByID(unit.PropertyID); err == nil {
+			statement.PropertyName = property.Name
+			statement.PropertyAddress = property.Address
+		}
+	}
+	if statement.LandlordAddress == "" {
+		statement.LandlordAddress = statement.PropertyAddress
+	}
+
+	return statement, nil
+}
+
+// GetFinancialYears returns the financial years a tenant can request a statement for, newest first
+func (s *RentStatementService) GetFinancialYears(tenant *domain.Tenant) []string {
+	return domain.FinancialYearsBetween(tenant.MoveInDate, time.Now())
+}
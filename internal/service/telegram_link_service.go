@@ -0,0 +1,187 @@
+package service
+
+import (
+	"backend-form/m/internal/domain"
+	interfaces "backend-form/m/internal/repository/interfaces"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// ErrInvalidTelegramSecret is returned when a webhook call does not carry the configured secret token
+var ErrInvalidTelegramSecret = errors.New("invalid telegram webhook secret")
+
+// TelegramUpdate is the part of a Telegram Bot API update the link flow needs
+type TelegramUpdate struct {
+	UpdateID int64 `json:"update_id"`
+	Message  *struct {
+		Text string `json:"text"`
+		Chat struct {
+			ID int64 `json:"id"`
+		} `json:"chat"`
+		From *struct {
+			Username string `json:"username"`
+		} `json:"from"`
+	} `json:"message"`
+}
+
+// TelegramLinkService lets tenants link their own Telegram chat for notifications
+// The tenant opens a deep link generated on /me; the bot receives "/start <token>" through the webhook and confirms
+type TelegramLinkService struct {
+	linkRepo            interfaces.TelegramLinkRepository
+	tenantRepo          interfaces.TenantRepository
+	notificationService *NotificationService
+	botUsername         string
+	webhookSecret       string
+}
+
+// NewTelegramLinkService creates a new TelegramLinkService
+func NewTelegramLinkService(
+	linkRepo interfaces.TelegramLinkRepository,
+	tenantRepo interfaces.TenantRepository,
+	notificationService *NotificationService,
+	botUsername string,
+	webhookSecret string,
+) *TelegramLinkService {
+	return &TelegramLinkService{
+		linkRepo:            linkRepo,
+		tenantRepo:          tenantRepo,
+		notificationService: notificationService,
+		botUsername:         botUsername,
+		webhookSecret:       webhookSecret,
+	}
+}
+
+// IsEnabled reports whether tenants can link Telegram (bot username and webhook secret configured)
+func (s *TelegramLinkService) IsEnabled() bool {
+	return s.botUsername != "" && s.webhookSecret != ""
+}
+
+// GetLink returns the tenant's linked chat, or nil if there is none
+func (s *TelegramLinkService) GetLink(tenantID int) (*domain.TelegramLink, error) {
+	return s.linkRepo.GetLinkByTenantID(tenantID)
+}
+
+// CreateLinkToken generates a one-time token for the tenant and returns it with its deep link
+func (s *TelegramLinkService) CreateLinkToken(tenantID int) (*domain.TelegramLinkToken, string, error) {
+	if !s.IsEnabled() {
+		return nil, "", fmt.Errorf("telegram linking is not configured")
+	}
+
+	token, err := domain.NewTelegramLinkToken(tenantID, time.Now())
+	if err != nil {
+		return nil, "", err
+	}
+	if err := s.linkRepo.CreateLinkToken(token); err != nil {
+		return nil, "", err
+	}
+
+	return token, token.GetDeepLink(s.botUsername), nil
+}
+
+// Unlink removes the tenant's linked chat so no more notifications are sent to it
+func (s *TelegramLinkService) Unlink(tenantID int) error {
+	return s.linkRepo.DeleteLink(tenantID)
+}
+
+// HandleWebhook processes a bot update delivered by Telegram
+// secretHeader is the X-Telegram-Bot-Api-Secret-Token header set when the webhook was registered
+func (s *TelegramLinkService) HandleWebhook(body []byte, secretHeader string) error {
+	if s.webhookSecret == "" || subtle.ConstantTimeCompare([]byte(secretHeader), []byte(s.webhookSecret)) != 1 {
+		return ErrInvalidTelegramSecret
+	}
+
+	var update TelegramUpdate
+	if err := json.Unmarshal(body, &update); err != nil {
+		return fmt.Errorf("invalid telegram update: %w", err)
+	}
+	if update.Message == nil {
+		return nil // Edits, callbacks etc. are not used
+	}
+
+	chatID := strconv.FormatInt(update.Message.Chat.ID, 10)
+	username := ""
+	if update.Message.From != nil {
+		username = update.Message.From.Username
+	}
+
+	command, argument, ok := domain.ParseTelegramCommand(update.Message.Text)
+	if !ok {
+		s.reply(chatID, "Open the Telegram link on your tenant dashboard to get rent reminders here.")
+		return nil
+	}
+
+	switch command {
+	case "start":
+		if argument == "" {
+			s.reply(chatID, "Open the Telegram link on your tenant dashboard to get rent reminders here.")
+			return nil
+		}
+		return s.linkChat(argument, chatID, username)
+	case "stop":
+		return s.unlinkChat(chatID)
+	default:
+		s.reply(chatID, "Unknown command. Send /stop to stop receiving rent reminders.")
+		return nil
+	}
+}
+
+// linkChat consumes a link token and points the tenant's notifications at the chat
+func (s *TelegramLinkService) linkChat(token, chatID, username string) error {
+	now := time.Now()
+	linkToken, err := s.linkRepo.ConsumeLinkToken(token, now)
+	if err != nil {
+		return err
+	}
+	if linkToken == nil {
+		s.reply(chatID, "❌ This link has expired or was already used. Generate a new one from your tenant dashboard.")
+		return nil
+	}
+
+	link := &domain.TelegramLink{
+		TenantID:         linkToken.TenantID,
+		ChatID:           chatID,
+		TelegramUsername: username,
+		LinkedAt:         now,
+	}
+	if err := s.linkRepo.SaveLink(link); err != nil {
+		return err
+	}
+
+	name := "your tenancy"
+	if tenant, err := s.tenantRepo.GetTenantByID(linkToken.TenantID); err == nil && tenant != nil {
+		name = tenant.Name
+	}
+	s.reply(chatID, fmt.Sprintf("✅ Linked to %s. Rent reminders will be sent here. Send /stop to unlink.", name))
+	return nil
+}
+
+// unlinkChat removes every tenant link pointing at the chat
+func (s *TelegramLinkService) unlinkChat(chatID string) error {
+	links, err := s.linkRepo.GetLinksByChatID(chatID)
+	if err != nil {
+		return err
+	}
+	for _, link := range links {
+		if err := s.linkRepo.DeleteLink(link.TenantID); err != nil {
+			return err
+		}
+	}
+
+	if len(links) == 0 {
+		s.reply(chatID, "This chat is not linked to any tenancy.")
+	} else {
+		s.reply(chatID, "🔕 Unlinked. You will no longer get rent reminders here.")
+	}
+	return nil
+}
+
+// reply answers the chat; failures are only logged since the link itself has already been saved
+func (s *TelegramLinkService) reply(chatID, message string) {
+	if err := s.notificationService.SendTelegramMessage(chatID, message); err != nil {
+		fmt.Printf("Warning: Failed to reply to telegram chat %s: %v\n", chatID, err)
+	}
+}
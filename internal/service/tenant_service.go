@@ -4,28 +4,36 @@ import (
 	"backend-form/m/internal/domain"
 	interfaces "backend-form/m/internal/repository/interfaces"
 	"fmt"
+	"strings"
 	"time"
 )
 
 // TenantService handles tenant-related business logic
 type TenantService struct {
-	tenantRepo     interfaces.TenantRepository
-	unitRepo       interfaces.UnitRepository
-	paymentService *PaymentService
+	tenantRepo       interfaces.TenantRepository
+	unitRepo         interfaces.UnitRepository
+	paymentService   *PaymentService
+	depositService   *DepositService
+	leaseService     *LeaseService
+	prorationService *ProrationService
 }
 
 // NewTenantService creates a new TenantService
-func NewTenantService(tenantRepo interfaces.TenantRepository, unitRepo interfaces.UnitRepository, paymentService *PaymentService) *TenantService {
+func NewTenantService(tenantRepo interfaces.TenantRepository, unitRepo interfaces.UnitRepository, paymentService *PaymentService, depositService *DepositService, leaseService *LeaseService, prorationService *ProrationService) *TenantService {
 	return &TenantService{
-		tenantRepo:     tenantRepo,
-		unitRepo:       unitRepo,
-		paymentService: paymentService,
+		tenantRepo:       tenantRepo,
+		unitRepo:         unitRepo,
+		paymentService:   paymentService,
+		depositService:   depositService,
+		leaseService:     leaseService,
+		prorationService: prorationService,
 	}
 }
 
 // CreateTenant creates a new tenant and updates unit occupancy
 // skipFirstPayment: if true, skips automatic first payment creation (useful for existing tenants)
-func (s *TenantService) CreateTenant(tenant *domain.Tenant, skipFirstPayment bool) error {
+// depositCollected: security deposit received at move-in (0 if not yet collected)
+func (s *TenantService) CreateTenant(tenant *domain.Tenant, skipFirstPayment bool, depositCollected int) error {
 	// Validate tenant data
 	if err := tenant.Validate(); err != nil {
 		return fmt.Errorf("validation failed: %w", err)
@@ -37,10 +45,18 @@ func (s *TenantService) CreateTenant(tenant *domain.Tenant, skipFirstPayment boo
 		return fmt.Errorf("unit not found: %w", err)
 	}
 
+	if unit.IsRetired() {
+		return fmt.Errorf("unit %s is retired", unit.UnitCode)
+	}
+
 	if unit.IsOccupied {
 		return fmt.Errorf("unit %s is already occupied", unit.UnitCode)
 	}
 
+	if depositCollected < 0 {
+		return fmt.Errorf("collected deposit cannot be negative")
+	}
+
 	// Create tenant
 	if err := s.tenantRepo.CreateTenant(tenant); err != nil {
 		return fmt.Errorf("failed to create tenant: %w", err)
@@ -53,6 +69,13 @@ func (s *TenantService) CreateTenant(tenant *domain.Tenant, skipFirstPayment boo
 		return fmt.Errorf("failed to update unit occupancy: %w", err)
 	}
 
+	// Open the security deposit ledger for this tenancy
+	if _, err := s.depositService.CreateDepositForTenant(tenant, unit.SecurityDeposit, depositCollected); err != nil {
+		// Log error but don't fail tenant creation
+		// Collection can be recorded on the ledger later
+		fmt.Printf("Warning: Failed to create security deposit for tenant %d: %v\n", tenant.ID, err)
+	}
+
 	// Create first payment immediately (unless skipped for existing tenants)
 	if !skipFirstPayment {
 		if err := s.createFirstPayment(tenant); err != nil {
@@ -66,6 +89,7 @@ func (s *TenantService) CreateTenant(tenant *domain.Tenant, skipFirstPayment boo
 }
 
 // createFirstPayment creates the first payment for a tenant based on move-in date
+// Rent for a partial move-in month is prorated onto the first payment
 func (s *TenantService) createFirstPayment(tenant *domain.Tenant) error {
 	// Get unit
 	unit, err := s.unitRepo.GetUnitByID(tenant.UnitID)
@@ -83,12 +107,7 @@ func (s *TenantService) createFirstPayment(tenant *domain.Tenant) error {
 	}
 
 	// Use PaymentService to create payment (ensures consistent business logic)
-	_, err = s.paymentService.CreatePaymentForTenant(
-		tenant.ID,
-		tenant.UnitID,
-		firstDueDate,
-		unit.MonthlyRent,
-	)
+	_, err = s.paymentService.CreateFirstPaymentForTenant(tenant, firstDueDate, unit.MonthlyRent)
 	return err
 }
 
@@ -123,6 +142,27 @@ func (s *TenantService) GetAllTenants() ([]*domain.Tenant, error) {
 		return nil, err
 	}
 
+	s.loadTenantUnits(tenants)
+	return tenants, nil
+}
+
+// GetTenantsByPropertyID returns tenants of a property with related data (0 = all properties)
+func (s *TenantService) GetTenantsByPropertyID(propertyID int) ([]*domain.Tenant, error) {
+	if propertyID == 0 {
+		return s.GetAllTenants()
+	}
+
+	tenants, err := s.tenantRepo.GetTenantsByPropertyID(propertyID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.loadTenantUnits(tenants)
+	return tenants, nil
+}
+
+// loadTenantUnits attaches units to tenants using a single bulk query
+func (s *TenantService) loadTenantUnits(tenants []*domain.Tenant) {
 	// Bulk load units to fix N+1 query problem
 	unitIDs := make([]int, 0)
 	unitIDSet := make(map[int]bool)
@@ -155,8 +195,6 @@ func (s *TenantService) GetAllTenants() ([]*domain.Tenant, error) {
 			}
 		}
 	}
-
-	return tenants, nil
 }
 
 // UpdateTenant updates tenant information
@@ -168,35 +206,93 @@ func (s *TenantService) UpdateTenant(tenant *domain.Tenant) error {
 	return s.tenantRepo.UpdateTenant(tenant)
 }
 
-// MoveOutTenant handles tenant move-out process
-func (s *TenantService) MoveOutTenant(tenantID int) error {
+// UpdateTenantEmail sets the email address notifications are sent to ("" removes it)
+func (s *TenantService) UpdateTenantEmail(tenantID int, email string) error {
 	tenant, err := s.tenantRepo.GetTenantByID(tenantID)
 	if err != nil {
-		return fmt.Errorf("tenant not found: %w", err)
+		return err
 	}
 
-	// Delete all payments for this tenant before deleting tenant
-	// This is required because of foreign key constraint: payments.tenant_id → tenants.id
-	// Note: If you want to keep payment history, you'd need to either:
-	// 1. Soft delete tenants (add is_deleted flag) instead of hard delete
-	// 2. Allow NULL tenant_id in payments (schema change)
-	// 3. Move payments to a separate history table before deleting tenant
-	if err := s.paymentService.DeletePaymentsByTenantID(tenantID); err != nil {
-		// Log warning but continue - if deletion fails, tenant deletion will also fail with FK error
-		fmt.Printf("Warning: Failed to delete payments for tenant %d: %v\n", tenantID, err)
+	tenant.Email = strings.TrimSpace(email)
+	return s.UpdateTenant(tenant)
+}
+
+// MoveOutTenant settles the security deposit, archives the tenant and frees the unit
+// Payments, transactions, notifications and family members are kept for history
+// offsetUnpaid: if true, unpaid balances are paid from the deposit before refunding the rest
+// Everything is validated first and then saved in a single transaction, so a rejected move-out changes nothing
+// Returns the deposit settlement statement (nil if the tenant has no deposit ledger)
+func (s *TenantService) MoveOutTenant(tenantID int, moveOutDate time.Time, deductions []domain.DepositDeduction, offsetUnpaid bool) (*domain.DepositSettlement, error) {
+	tenant, err := s.tenantRepo.GetTenantByID(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("tenant not found: %w", err)
 	}
 
-	// Delete tenant (this will cascade delete family members and payment_transactions)
-	if err := s.tenantRepo.DeleteTenant(tenantID); err != nil {
-		return fmt.Errorf("failed to delete tenant: %w", err)
+	if tenant.IsArchived() {
+		return nil, fmt.Errorf("tenant %s has already moved out", tenant.Name)
 	}
 
-	// Update unit occupancy
-	if err := s.unitRepo.UpdateUnitOccupancy(tenant.UnitID, false); err != nil {
-		return fmt.Errorf("failed to update unit occupancy: %w", err)
+	if moveOutDate.Before(tenant.MoveInDate) {
+		return nil, fmt.Errorf("move-out date cannot be before move-in date")
 	}
 
-	return nil
+	moveOut := &domain.MoveOut{
+		TenantID:    tenantID,
+		UnitID:      tenant.UnitID,
+		MoveOutDate: moveOutDate,
+	}
+
+	// Charge only the days of the final month the tenant stayed, before settling against the deposit
+	moveOut.FinalProration, moveOut.FinalPayment, err = s.prorationService.PrepareMoveOutProration(tenantID, moveOutDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prorate final payment: %w", err)
+	}
+
+	deposit, amountStillOwed, err := s.depositService.PrepareSettlement(tenantID, deductions, offsetUnpaid, moveOutDate, moveOut.FinalPayment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to settle security deposit: %w", err)
+	}
+	moveOut.Deposit = deposit
+
+	moveOut.Lease, err = s.leaseService.PrepareEndLease(tenantID, moveOutDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to end lease: %w", err)
+	}
+
+	if err := s.tenantRepo.MoveOutTenant(moveOut); err != nil {
+		return nil, fmt.Errorf("failed to move out tenant: %w", err)
+	}
+
+	if deposit == nil {
+		return nil, nil
+	}
+	return deposit.BuildSettlement(amountStillOwed), nil
+}
+
+// GetArchivedTenants returns past tenants with their units
+func (s *TenantService) GetArchivedTenants() ([]*domain.Tenant, error) {
+	tenants, err := s.tenantRepo.GetArchivedTenants()
+	if err != nil {
+		return nil, err
+	}
+
+	s.loadTenantUnits(tenants)
+	return tenants, nil
+}
+
+// GetTenantHistory returns a tenant (active or archived) with family members and full payment history
+func (s *TenantService) GetTenantHistory(tenantID int) (*domain.Tenant, []*domain.Payment, error) {
+	tenant, err := s.GetTenantByID(tenantID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("tenant not found: %w", err)
+	}
+
+	payments, err := s.paymentService.GetPaymentsByTenantID(tenantID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load payments: %w", err)
+	}
+
+	return tenant, payments, nil
 }
 
 // AddFamilyMember adds a family member to a tenant
@@ -231,6 +327,11 @@ func (s *TenantService) GetTenantSummary() (*TenantSummary, error) {
 		TotalPeople:  0,
 	}
 
+	// Archived tenants are only counted, never mixed into the active totals
+	if archived, err := s.tenantRepo.GetArchivedTenants(); err == nil {
+		summary.ArchivedTenants = len(archived)
+	}
+
 	now := time.Now()
 	firstOfMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
 
@@ -249,4 +350,6 @@ type TenantSummary struct {
 	TotalTenants int `json:"total_tenants"`
 	NewThisMonth int `json:"new_this_month"`
 	TotalPeople  int `json:"total_people"`
+
+	ArchivedTenants int `json:"archived_tenants"`
 }
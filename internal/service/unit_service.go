@@ -4,6 +4,7 @@ import (
 	"backend-form/m/internal/domain"
 	interfaces "backend-form/m/internal/repository/interfaces"
 	"fmt"
+	"strings"
 )
 
 // UnitService handles unit-related business logic
@@ -23,14 +24,22 @@ func (s *UnitService) GetAllUnits() ([]*domain.Unit, error) {
 	return s.unitRepo.GetAllUnits()
 }
 
+// GetUnitsByPropertyID returns units of a property (0 = all properties)
+func (s *UnitService) GetUnitsByPropertyID(propertyID int) ([]*domain.Unit, error) {
+	if propertyID == 0 {
+		return s.unitRepo.GetAllUnits()
+	}
+	return s.unitRepo.GetUnitsByPropertyID(propertyID)
+}
+
 // GetUnitByID returns a unit by ID
 func (s *UnitService) GetUnitByID(id int) (*domain.Unit, error) {
 	return s.unitRepo.GetUnitByID(id)
 }
 
-// GetUnitByCode returns a unit by unit code
-func (s *UnitService) GetUnitByCode(code string) (*domain.Unit, error) {
-	return s.unitRepo.GetUnitByCode(code)
+// GetUnitByCode returns a property's unit by unit code
+func (s *UnitService) GetUnitByCode(propertyID int, code string) (*domain.Unit, error) {
+	return s.unitRepo.GetUnitByCode(propertyID, code)
 }
 
 // GetAvailableUnits returns units that are not occupied
@@ -72,6 +81,78 @@ func (s *UnitService) UpdateUnitOccupancy(unitID int, isOccupied bool) error {
 	return s.unitRepo.UpdateUnitOccupancy(unitID, isOccupied)
 }
 
+// CreateUnit validates and creates a new unit
+// New units always start vacant; occupancy is managed by TenantService
+func (s *UnitService) CreateUnit(unit *domain.Unit) error {
+	unit.UnitCode = strings.TrimSpace(unit.UnitCode)
+	unit.IsOccupied = false
+
+	if err := unit.Validate(); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	// Unit codes must be unique within a property
+	if existing, err := s.unitRepo.GetUnitByCode(unit.PropertyID, unit.UnitCode); err == nil && existing != nil {
+		return fmt.Errorf("unit with code %s already exists", unit.UnitCode)
+	}
+
+	return s.unitRepo.CreateUnit(unit)
+}
+
+// UpdateUnit updates rent, deposit, due day, floor and type of an existing unit
+// Changes apply to payments created after the update; existing payments are not modified
+func (s *UnitService) UpdateUnit(unit *domain.Unit) error {
+	existing, err := s.unitRepo.GetUnitByID(unit.ID)
+	if err != nil {
+		return fmt.Errorf("unit not found: %w", err)
+	}
+
+	if existing.IsRetired() {
+		return fmt.Errorf("unit %s is retired and cannot be edited", existing.UnitCode)
+	}
+
+	unit.UnitCode = strings.TrimSpace(unit.UnitCode)
+	if unit.UnitCode == "" {
+		unit.UnitCode = existing.UnitCode
+	}
+	unit.IsOccupied = existing.IsOccupied // Occupancy is not editable here
+	if unit.PropertyID == 0 {
+		unit.PropertyID = existing.PropertyID
+	}
+
+	if err := unit.Validate(); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	// Renaming or moving must not collide with another unit of the property
+	if unit.UnitCode != existing.UnitCode || unit.PropertyID != existing.PropertyID {
+		if other, err := s.unitRepo.GetUnitByCode(unit.PropertyID, unit.UnitCode); err == nil && other != nil && other.ID != unit.ID {
+			return fmt.Errorf("unit with code %s already exists", unit.UnitCode)
+		}
+	}
+
+	return s.unitRepo.UpdateUnit(unit)
+}
+
+// RetireUnit takes a vacant unit out of service
+// Occupied units must be vacated first so no active tenancy is orphaned
+func (s *UnitService) RetireUnit(unitID int) error {
+	unit, err := s.unitRepo.GetUnitByID(unitID)
+	if err != nil {
+		return fmt.Errorf("unit not found: %w", err)
+	}
+
+	if unit.IsRetired() {
+		return fmt.Errorf("unit %s is already retired", unit.UnitCode)
+	}
+
+	if unit.IsOccupied {
+		return fmt.Errorf("unit %s is occupied; move the tenant out before retiring it", unit.UnitCode)
+	}
+
+	return s.unitRepo.RetireUnit(unitID)
+}
+
 // GetUnitsByFloor returns units grouped by floor
 func (s *UnitService) GetUnitsByFloor() (map[string][]*domain.Unit, error) {
 	units, err := s.unitRepo.GetAllUnits()
@@ -87,9 +168,9 @@ func (s *UnitService) GetUnitsByFloor() (map[string][]*domain.Unit, error) {
 	return floorMap, nil
 }
 
-// GetRentalSummary returns a summary of rental income
-func (s *UnitService) GetRentalSummary() (*RentalSummary, error) {
-	units, err := s.unitRepo.GetAllUnits()
+// GetRentalSummary returns a summary of rental income for a property (0 = all properties)
+func (s *UnitService) GetRentalSummary(propertyID int) (*RentalSummary, error) {
+	units, err := s.GetUnitsByPropertyID(propertyID)
 	if err != nil {
 		return nil, err
 	}
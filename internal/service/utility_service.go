@@ -0,0 +1,241 @@
+package service
+
+import (
+	"backend-form/m/internal/domain"
+	interfaces "backend-form/m/internal/repository/interfaces"
+	"fmt"
+	"time"
+)
+
+// UtilityService handles metered utility billing: tariffs, meter readings and the bills generated from them
+type UtilityService struct {
+	utilityRepo     interfaces.UtilityRepository
+	tenantRepo      interfaces.TenantRepository
+	unitRepo        interfaces.UnitRepository
+	categoryService *ChargeCategoryService
+	paymentService  *PaymentService
+}
+
+// NewUtilityService creates a new UtilityService
+func NewUtilityService(
+	utilityRepo interfaces.UtilityRepository,
+	tenantRepo interfaces.TenantRepository,
+	unitRepo interfaces.UnitRepository,
+	categoryService *ChargeCategoryService,
+	paymentService *PaymentService,
+) *UtilityService {
+	return &UtilityService{
+		utilityRepo:     utilityRepo,
+		tenantRepo:      tenantRepo,
+		unitRepo:        unitRepo,
+		categoryService: categoryService,
+		paymentService:  paymentService,
+	}
+}
+
+// ============================================
+// Tariffs
+// ============================================
+
+// validateTariff validates a tariff and checks its category exists
+func (s *UtilityService) validateTariff(tariff *domain.UtilityTariff) error {
+	tariff.CategoryCode = domain.NormalizeChargeCategoryCode(tariff.CategoryCode)
+	if err := tariff.Validate(); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+	if _, err := s.categoryService.GetCategoryByCode(tariff.CategoryCode); err != nil {
+		return fmt.Errorf("unknown payment type '%s'", tariff.CategoryCode)
+	}
+	return nil
+}
+
+// CreateTariff validates and creates a utility tariff
+func (s *UtilityService) CreateTariff(tariff *domain.UtilityTariff) error {
+	if err := s.validateTariff(tariff); err != nil {
+		return err
+	}
+	return s.utilityRepo.CreateTariff(tariff)
+}
+
+// UpdateTariff validates and updates a utility tariff
+// Bills already generated keep their amounts; new readings use the updated tariff
+func (s *UtilityService) UpdateTariff(tariff *domain.UtilityTariff) error {
+	if err := s.validateTariff(tariff); err != nil {
+		return err
+	}
+	return s.utilityRepo.UpdateTariff(tariff)
+}
+
+// DeleteTariff deletes a utility tariff
+func (s *UtilityService) DeleteTariff(id int) error {
+	return s.utilityRepo.DeleteTariff(id)
+}
+
+// GetAllTariffs returns all utility tariffs
+func (s *UtilityService) GetAllTariffs() ([]*domain.UtilityTariff, error) {
+	return s.utilityRepo.GetAllTariffs()
+}
+
+// ============================================
+// Readings & billing
+// ============================================
+
+// RecordReading records a meter reading and bills the unit's tenants for the consumption
+// previousReading defaults to the meter's last reading; the first reading of a meter without
+// a previous value only sets the baseline. dueDate defaults to the unit's next rent due date.
+// On a shared meter the bill is split between the unit's tenants, by NumberOfPeople if the
+// tariff says so, otherwise equally.
+func (s *UtilityService) RecordReading(unitID int, categoryCode string, previousReading *float64, currentReading float64, readingDate time.Time, dueDate time.Time) (*domain.MeterReading, []*domain.UtilityBill, error) {
+	categoryCode = domain.NormalizeChargeCategoryCode(categoryCode)
+	category, err := s.categoryService.GetCategoryByCode(categoryCode)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unknown payment type '%s'", categoryCode)
+	}
+	if !category.IsActive {
+		return nil, nil, fmt.Errorf("payment type '%s' is no longer in use", category.DisplayName)
+	}
+
+	unit, err := s.unitRepo.GetUnitByID(unitID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unit not found: %w", err)
+	}
+
+	reading := &domain.MeterReading{
+		UnitID:         unitID,
+		CategoryCode:   categoryCode,
+		CurrentReading: currentReading,
+		ReadingDate:    readingDate,
+	}
+
+	baselineOnly := false
+	if previousReading != nil {
+		reading.PreviousReading = *previousReading
+	} else {
+		latest, err := s.utilityRepo.GetLatestReading(unitID, categoryCode)
+		if err != nil {
+			return nil, nil, err
+		}
+		if latest == nil {
+			// First reading of this meter: nothing to bill yet
+			reading.PreviousReading = currentReading
+			baselineOnly = true
+		} else {
+			if readingDate.Before(latest.ReadingDate) {
+				return nil, nil, fmt.Errorf("reading date is before the last reading on %s", latest.ReadingDate.Format("Jan 2, 2006"))
+			}
+			reading.PreviousReading = latest.CurrentReading
+		}
+	}
+
+	if err := reading.Validate(); err != nil {
+		return nil, nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	var tariff *domain.UtilityTariff
+	if !baselineOnly {
+		tariff, err = s.utilityRepo.GetTariffForUnit(unitID, categoryCode)
+		if err != nil {
+			return nil, nil, err
+		}
+		if tariff == nil {
+			return nil, nil, fmt.Errorf("no %s tariff is configured for unit %s", category.DisplayName, unit.UnitCode)
+		}
+	}
+
+	if err := s.utilityRepo.CreateReading(reading); err != nil {
+		return nil, nil, err
+	}
+	if baselineOnly {
+		return reading, []*domain.UtilityBill{}, nil
+	}
+
+	tenants, err := s.tenantRepo.GetTenantsByUnitID(unitID)
+	if err != nil {
+		return reading, nil, fmt.Errorf("failed to load tenants: %w", err)
+	}
+	if len(tenants) == 0 {
+		return reading, []*domain.UtilityBill{}, nil // Vacant unit, reading kept for the next tenant
+	}
+
+	if dueDate.IsZero() {
+		dueDate = time.Date(readingDate.Year(), readingDate.Month(), unit.PaymentDueDay, 0, 0, 0, 0, time.UTC)
+		if readingDate.Day() > unit.PaymentDueDay {
+			dueDate = dueDate.AddDate(0, 1, 0)
+		}
+	}
+
+	consumption := reading.Consumption()
+	usageCharge, slabCharges := tariff.CalculateUsageCharge(consumption)
+	meterTotal := usageCharge + tariff.FixedCharge
+
+	weights := make([]int, len(tenants))
+	totalWeight := 0
+	for i, tenant := range tenants {
+		weights[i] = 1
+		if tariff.SplitByPeople && tenant.NumberOfPeople > 0 {
+			weights[i] = tenant.NumberOfPeople
+		}
+		totalWeight += weights[i]
+	}
+	shares := domain.SplitAmount(meterTotal, weights)
+
+	bills := make([]*domain.UtilityBill, 0, len(tenants))
+	for i, tenant := range tenants {
+		if shares[i] <= 0 {
+			continue
+		}
+
+		notes := fmt.Sprintf("Meter %.2f → %.2f (%.2f units)", reading.PreviousReading, reading.CurrentReading, consumption)
+		if len(tenants) > 1 {
+			notes += fmt.Sprintf(", your share %d/%d", weights[i], totalWeight)
+		}
+		payment, err := s.paymentService.CreateCustomPayment(tenant.ID, unitID, dueDate, shares[i], categoryCode, notes)
+		if err != nil {
+			return reading, bills, fmt.Errorf("failed to bill %s: %w", tenant.Name, err)
+		}
+
+		bill := &domain.UtilityBill{
+			PaymentID:   payment.ID,
+			ReadingID:   reading.ID,
+			TenantID:    tenant.ID,
+			Consumption: consumption,
+			UsageCharge: usageCharge,
+			FixedCharge: tariff.FixedCharge,
+			MeterTotal:  meterTotal,
+			ShareWeight: weights[i],
+			TotalWeight: totalWeight,
+			Amount:      shares[i],
+			SlabCharges: slabCharges,
+			Reading:     reading,
+		}
+		if err := s.utilityRepo.CreateBill(bill); err != nil {
+			return reading, bills, err
+		}
+		bills = append(bills, bill)
+	}
+
+	return reading, bills, nil
+}
+
+// GetReadingsByUnitID returns the meter readings of a unit, most recent first
+func (s *UtilityService) GetReadingsByUnitID(unitID int) ([]*domain.MeterReading, error) {
+	return s.utilityRepo.GetReadingsByUnitID(unitID)
+}
+
+// GetBillsByTenantID returns the utility bills of a tenant, most recent first
+func (s *UtilityService) GetBillsByTenantID(tenantID int) ([]*domain.UtilityBill, error) {
+	return s.utilityRepo.GetBillsByTenantID(tenantID)
+}
+
+// GetBillsByPayment returns a tenant's utility bills keyed by payment ID
+func (s *UtilityService) GetBillsByPayment(tenantID int) map[int]*domain.UtilityBill {
+	result := make(map[int]*domain.UtilityBill)
+	bills, err := s.utilityRepo.GetBillsByTenantID(tenantID)
+	if err != nil {
+		return result
+	}
+	for _, bill := range bills {
+		result[bill.PaymentID] = bill
+	}
+	return result
+}
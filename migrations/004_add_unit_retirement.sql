@@ -0,0 +1,30 @@
+-- Migration: Add Unit Retirement Support
+-- Description: Adds retired_at column so units can be taken out of service without deleting payment history
+-- Date: 2025
+
+BEGIN;
+
+-- ============================================
+-- STEP 1: Add retired_at column to units table
+-- ============================================
+ALTER TABLE units 
+ADD COLUMN IF NOT EXISTS retired_at TIMESTAMP NULL;
+
+-- ============================================
+-- STEP 2: Ensure unit codes stay unique
+-- ============================================
+CREATE UNIQUE INDEX IF NOT EXISTS idx_units_unit_code ON units(unit_code);
+
+-- ============================================
+-- STEP 3: Add index for filtering active units
+-- ============================================
+CREATE INDEX IF NOT EXISTS idx_units_retired_at ON units(retired_at);
+
+COMMIT;
+
+-- ============================================
+-- VERIFICATION QUERIES
+-- ============================================
+-- Run these to verify migration:
+-- SELECT column_name, data_type FROM information_schema.columns WHERE table_name = 'units' AND column_name = 'retired_at';
+-- SELECT COUNT(*) FROM units WHERE retired_at IS NULL;
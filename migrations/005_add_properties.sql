@@ -0,0 +1,53 @@
+-- Migration: Add Multi-Property Support
+-- Description: Adds properties table and groups units under a property (building)
+-- Date: 2025
+
+BEGIN;
+
+-- ============================================
+-- STEP 1: Create properties table
+-- ============================================
+CREATE TABLE IF NOT EXISTS properties (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    address TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- ============================================
+-- STEP 2: Add property_id column to units table
+-- ============================================
+ALTER TABLE units 
+ADD COLUMN IF NOT EXISTS property_id INT NULL REFERENCES properties(id);
+
+-- ============================================
+-- STEP 3: Backfill existing units into a default property
+-- ============================================
+-- All existing units belong to the original single building
+INSERT INTO properties (name)
+SELECT 'Main Building'
+WHERE NOT EXISTS (SELECT 1 FROM properties);
+
+UPDATE units 
+SET property_id = (SELECT MIN(id) FROM properties)
+WHERE property_id IS NULL;
+
+ALTER TABLE units ALTER COLUMN property_id SET NOT NULL;
+
+-- ============================================
+-- STEP 4: Make unit codes unique per property
+-- ============================================
+-- Different buildings may reuse the same codes (e.g. "101"); the index also serves filtering by property
+ALTER TABLE units DROP CONSTRAINT IF EXISTS units_unit_code_key;
+DROP INDEX IF EXISTS idx_units_unit_code;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_units_property_unit_code ON units(property_id, unit_code);
+
+COMMIT;
+
+-- ============================================
+-- VERIFICATION QUERIES
+-- ============================================
+-- Run these to verify migration:
+-- SELECT * FROM properties;
+-- SELECT property_id, COUNT(*) FROM units GROUP BY property_id;
+-- SELECT indexname FROM pg_indexes WHERE tablename = 'units';
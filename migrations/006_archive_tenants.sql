@@ -0,0 +1,40 @@
+-- Migration: Archive Tenants on Move-Out
+-- Description: Adds status and move_out_date to tenants so move-out keeps payment, transaction and notification history
+-- Date: 2025
+
+BEGIN;
+
+-- ============================================
+-- STEP 1: Add status column to tenants table
+-- ============================================
+ALTER TABLE tenants 
+ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'active';
+
+-- ============================================
+-- STEP 2: Add move_out_date column
+-- ============================================
+ALTER TABLE tenants 
+ADD COLUMN IF NOT EXISTS move_out_date TIMESTAMP NULL;
+
+-- ============================================
+-- STEP 3: Restrict status values
+-- ============================================
+ALTER TABLE tenants 
+DROP CONSTRAINT IF EXISTS tenants_status_check;
+
+ALTER TABLE tenants 
+ADD CONSTRAINT tenants_status_check CHECK (status IN ('active', 'archived'));
+
+-- ============================================
+-- STEP 4: Add index for filtering active tenants
+-- ============================================
+CREATE INDEX IF NOT EXISTS idx_tenants_status ON tenants(status);
+
+COMMIT;
+
+-- ============================================
+-- VERIFICATION QUERIES
+-- ============================================
+-- Run these to verify migration:
+-- SELECT column_name, data_type FROM information_schema.columns WHERE table_name = 'tenants' AND column_name IN ('status', 'move_out_date');
+-- SELECT status, COUNT(*) FROM tenants GROUP BY status;
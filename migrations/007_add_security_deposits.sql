@@ -0,0 +1,46 @@
+-- Migration: Add Security Deposit Ledger
+-- Description: Tracks deposit collection, deductions, arrears offsets and refunds per tenancy
+-- Date: 2025
+
+BEGIN;
+
+-- ============================================
+-- STEP 1: Create security_deposits table (one ledger per tenancy)
+-- ============================================
+CREATE TABLE IF NOT EXISTS security_deposits (
+    id SERIAL PRIMARY KEY,
+    tenant_id INTEGER NOT NULL UNIQUE REFERENCES tenants(id) ON DELETE CASCADE,
+    unit_id INTEGER NOT NULL REFERENCES units(id),
+    required_amount INTEGER NOT NULL DEFAULT 0,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'held', 'settled')),
+    settled_at TIMESTAMP NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- ============================================
+-- STEP 2: Create deposit_entries table (ledger movements)
+-- ============================================
+CREATE TABLE IF NOT EXISTS deposit_entries (
+    id SERIAL PRIMARY KEY,
+    deposit_id INTEGER NOT NULL REFERENCES security_deposits(id) ON DELETE CASCADE,
+    entry_type VARCHAR(20) NOT NULL CHECK (entry_type IN ('collection', 'deduction', 'arrears_offset', 'refund')),
+    amount INTEGER NOT NULL CHECK (amount > 0),
+    reason TEXT NOT NULL DEFAULT '',
+    payment_id INTEGER NULL REFERENCES payments(id) ON DELETE SET NULL,
+    entry_date TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- ============================================
+-- STEP 3: Add indexes
+-- ============================================
+CREATE INDEX IF NOT EXISTS idx_deposit_entries_deposit_id ON deposit_entries(deposit_id);
+
+COMMIT;
+
+-- ============================================
+-- VERIFICATION QUERIES
+-- ============================================
+-- Run these to verify migration:
+-- SELECT table_name FROM information_schema.tables WHERE table_name IN ('security_deposits', 'deposit_entries');
+-- SELECT d.tenant_id, d.status, e.entry_type, SUM(e.amount) FROM security_deposits d LEFT JOIN deposit_entries e ON e.deposit_id = d.id GROUP BY d.tenant_id, d.status, e.entry_type;
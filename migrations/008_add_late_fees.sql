@@ -0,0 +1,57 @@
+-- Migration: Add Late Fee Support
+-- Description: Adds late fee policies (per unit or property) and links charged late_fee payments to overdue payments
+-- Date: 2025
+
+BEGIN;
+
+-- ============================================
+-- STEP 1: Create late_fee_policies table
+-- ============================================
+CREATE TABLE IF NOT EXISTS late_fee_policies (
+    id SERIAL PRIMARY KEY,
+    property_id INTEGER NULL REFERENCES properties(id) ON DELETE CASCADE,
+    unit_id INTEGER NULL REFERENCES units(id) ON DELETE CASCADE,
+    fee_type VARCHAR(20) NOT NULL CHECK (fee_type IN ('flat', 'per_day', 'percentage')),
+    amount INTEGER NOT NULL DEFAULT 0,
+    percentage NUMERIC(5, 2) NOT NULL DEFAULT 0,
+    grace_days INTEGER NOT NULL DEFAULT 0,
+    max_fee INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((property_id IS NULL) <> (unit_id IS NULL))
+);
+
+-- One policy per unit and one per property
+CREATE UNIQUE INDEX IF NOT EXISTS idx_late_fee_policies_unit ON late_fee_policies(unit_id) WHERE unit_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_late_fee_policies_property ON late_fee_policies(property_id) WHERE property_id IS NOT NULL;
+
+-- ============================================
+-- STEP 2: Create late_fees table
+-- ============================================
+CREATE TABLE IF NOT EXISTS late_fees (
+    id SERIAL PRIMARY KEY,
+    payment_id INTEGER NOT NULL UNIQUE REFERENCES payments(id) ON DELETE CASCADE,
+    fee_payment_id INTEGER NULL REFERENCES payments(id) ON DELETE SET NULL,
+    policy_id INTEGER NULL REFERENCES late_fee_policies(id) ON DELETE SET NULL,
+    tenant_id INTEGER NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    amount INTEGER NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'applied' CHECK (status IN ('applied', 'waived', 'reversed')),
+    reason TEXT NOT NULL DEFAULT '',
+    actioned_by_user_id INTEGER NULL REFERENCES users(id),
+    actioned_at TIMESTAMP NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- ============================================
+-- STEP 3: Add indexes
+-- ============================================
+CREATE INDEX IF NOT EXISTS idx_late_fees_tenant_id ON late_fees(tenant_id);
+
+COMMIT;
+
+-- ============================================
+-- VERIFICATION QUERIES
+-- ============================================
+-- Run these to verify migration:
+-- SELECT table_name FROM information_schema.tables WHERE table_name IN ('late_fee_policies', 'late_fees');
+-- SELECT status, COUNT(*), SUM(amount) FROM late_fees GROUP BY status;
@@ -0,0 +1,50 @@
+-- Migration: Add Charge Categories
+-- Description: Moves payment labels into an owner-managed charge_categories table (code, display name, default amount, recurring flag)
+-- Date: 2025
+
+BEGIN;
+
+-- ============================================
+-- STEP 1: Create charge_categories table
+-- ============================================
+CREATE TABLE IF NOT EXISTS charge_categories (
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(50) NOT NULL UNIQUE,
+    display_name VARCHAR(100) NOT NULL,
+    default_amount INTEGER NOT NULL DEFAULT 0,
+    is_recurring BOOLEAN NOT NULL DEFAULT FALSE,
+    is_system BOOLEAN NOT NULL DEFAULT FALSE,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- ============================================
+-- STEP 2: Seed the built-in labels
+-- ============================================
+-- rent and late_fee are used by the application itself and cannot be deactivated
+INSERT INTO charge_categories (code, display_name, is_recurring, is_system) VALUES
+    ('rent', 'Rent', TRUE, TRUE),
+    ('water_bill', 'Water Bill', TRUE, FALSE),
+    ('current_bill', 'Current Bill', TRUE, FALSE),
+    ('maintenance', 'Maintenance', TRUE, FALSE),
+    ('late_fee', 'Late Fee', FALSE, TRUE)
+ON CONFLICT (code) DO NOTHING;
+
+-- ============================================
+-- STEP 3: Register any labels already in use
+-- ============================================
+-- Existing payments keep their label; unknown labels become categories so they stay visible
+INSERT INTO charge_categories (code, display_name)
+SELECT DISTINCT label, INITCAP(REPLACE(label, '_', ' '))
+FROM payments
+WHERE label IS NOT NULL AND label <> ''
+ON CONFLICT (code) DO NOTHING;
+
+COMMIT;
+
+-- ============================================
+-- VERIFICATION QUERIES
+-- ============================================
+-- Run these to verify migration:
+-- SELECT code, display_name, default_amount, is_recurring, is_system, is_active FROM charge_categories ORDER BY id;
+-- SELECT p.label, COUNT(*) FROM payments p LEFT JOIN charge_categories c ON c.code = p.label WHERE c.id IS NULL GROUP BY p.label;
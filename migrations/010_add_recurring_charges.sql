@@ -0,0 +1,53 @@
+-- Migration: Add Recurring Charges
+-- Description: Adds recurring non-rent charge schedules (per tenant or unit) that are generated alongside rent, with amount changes over time
+-- Date: 2025
+
+BEGIN;
+
+-- ============================================
+-- STEP 1: Create recurring_charges table
+-- ============================================
+CREATE TABLE IF NOT EXISTS recurring_charges (
+    id SERIAL PRIMARY KEY,
+    tenant_id INTEGER NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    unit_id INTEGER NULL REFERENCES units(id) ON DELETE CASCADE,
+    category_code VARCHAR(50) NOT NULL REFERENCES charge_categories(code) ON UPDATE CASCADE,
+    amount INTEGER NOT NULL CHECK (amount > 0),
+    due_day INTEGER NOT NULL DEFAULT 0 CHECK (due_day BETWEEN 0 AND 28),
+    start_date DATE NOT NULL,
+    end_date DATE NULL,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    notes TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((tenant_id IS NULL) <> (unit_id IS NULL)),
+    CHECK (end_date IS NULL OR end_date >= start_date)
+);
+
+-- ============================================
+-- STEP 2: Create recurring_charge_amounts table
+-- ============================================
+-- Each row changes the schedule's amount from effective_from (first of a month) onwards
+CREATE TABLE IF NOT EXISTS recurring_charge_amounts (
+    id SERIAL PRIMARY KEY,
+    recurring_charge_id INTEGER NOT NULL REFERENCES recurring_charges(id) ON DELETE CASCADE,
+    amount INTEGER NOT NULL CHECK (amount > 0),
+    effective_from DATE NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- ============================================
+-- STEP 3: Add indexes
+-- ============================================
+CREATE INDEX IF NOT EXISTS idx_recurring_charges_tenant_id ON recurring_charges(tenant_id);
+CREATE INDEX IF NOT EXISTS idx_recurring_charges_unit_id ON recurring_charges(unit_id);
+CREATE INDEX IF NOT EXISTS idx_recurring_charge_amounts_charge_id ON recurring_charge_amounts(recurring_charge_id);
+
+COMMIT;
+
+-- ============================================
+-- VERIFICATION QUERIES
+-- ============================================
+-- Run these to verify migration:
+-- SELECT table_name FROM information_schema.tables WHERE table_name IN ('recurring_charges', 'recurring_charge_amounts');
+-- SELECT category_code, COUNT(*) FROM recurring_charges WHERE is_active GROUP BY category_code;
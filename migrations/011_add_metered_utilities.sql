@@ -0,0 +1,71 @@
+-- Migration: Add Metered Utility Billing
+-- Description: Adds utility tariffs (flat or slab rates, fixed charge, shared-meter splitting), meter readings and the bill breakdown behind generated utility payments
+-- Date: 2025
+
+BEGIN;
+
+-- ============================================
+-- STEP 1: Create utility_tariffs table
+-- ============================================
+CREATE TABLE IF NOT EXISTS utility_tariffs (
+    id SERIAL PRIMARY KEY,
+    property_id INTEGER NULL REFERENCES properties(id) ON DELETE CASCADE,
+    unit_id INTEGER NULL REFERENCES units(id) ON DELETE CASCADE,
+    category_code VARCHAR(50) NOT NULL REFERENCES charge_categories(code) ON UPDATE CASCADE,
+    rate_per_unit NUMERIC(10, 2) NOT NULL DEFAULT 0,
+    slabs JSONB NULL,                        -- [{"up_to": 100, "rate": 3.5}, {"up_to": 0, "rate": 5}]
+    fixed_charge INTEGER NOT NULL DEFAULT 0,
+    split_by_people BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((property_id IS NULL) <> (unit_id IS NULL))
+);
+
+-- One tariff per utility for each unit and each property
+CREATE UNIQUE INDEX IF NOT EXISTS idx_utility_tariffs_unit ON utility_tariffs(unit_id, category_code) WHERE unit_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_utility_tariffs_property ON utility_tariffs(property_id, category_code) WHERE property_id IS NOT NULL;
+
+-- ============================================
+-- STEP 2: Create meter_readings table
+-- ============================================
+CREATE TABLE IF NOT EXISTS meter_readings (
+    id SERIAL PRIMARY KEY,
+    unit_id INTEGER NOT NULL REFERENCES units(id) ON DELETE CASCADE,
+    category_code VARCHAR(50) NOT NULL REFERENCES charge_categories(code) ON UPDATE CASCADE,
+    previous_reading NUMERIC(12, 2) NOT NULL,
+    current_reading NUMERIC(12, 2) NOT NULL,
+    reading_date DATE NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (current_reading >= previous_reading)
+);
+
+CREATE INDEX IF NOT EXISTS idx_meter_readings_unit ON meter_readings(unit_id, category_code, reading_date DESC);
+
+-- ============================================
+-- STEP 3: Create utility_bills table
+-- ============================================
+CREATE TABLE IF NOT EXISTS utility_bills (
+    id SERIAL PRIMARY KEY,
+    payment_id INTEGER NOT NULL UNIQUE REFERENCES payments(id) ON DELETE CASCADE,
+    reading_id INTEGER NOT NULL REFERENCES meter_readings(id) ON DELETE CASCADE,
+    tenant_id INTEGER NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    consumption NUMERIC(12, 2) NOT NULL,
+    usage_charge INTEGER NOT NULL,
+    fixed_charge INTEGER NOT NULL DEFAULT 0,
+    meter_total INTEGER NOT NULL,
+    share_weight INTEGER NOT NULL DEFAULT 1,
+    total_weight INTEGER NOT NULL DEFAULT 1,
+    amount INTEGER NOT NULL,
+    slab_charges JSONB NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_utility_bills_tenant_id ON utility_bills(tenant_id);
+
+COMMIT;
+
+-- ============================================
+-- VERIFICATION QUERIES
+-- ============================================
+-- Run these to verify migration:
+-- SELECT table_name FROM information_schema.tables WHERE table_name IN ('utility_tariffs', 'meter_readings', 'utility_bills');
+-- SELECT m.unit_id, m.category_code, m.previous_reading, m.current_reading, SUM(b.amount) FROM meter_readings m LEFT JOIN utility_bills b ON b.reading_id = m.id GROUP BY m.id ORDER BY m.reading_date DESC LIMIT 10;
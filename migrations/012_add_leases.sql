@@ -0,0 +1,43 @@
+-- Migration: Add Lease Agreements
+-- Description: Adds leases with term, rent escalation, notice period, lock-in and renewal history per tenant
+-- Date: 2025
+
+BEGIN;
+
+-- ============================================
+-- STEP 1: Create leases table
+-- ============================================
+CREATE TABLE IF NOT EXISTS leases (
+    id SERIAL PRIMARY KEY,
+    tenant_id INTEGER NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    unit_id INTEGER NOT NULL REFERENCES units(id),
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    monthly_rent INTEGER NOT NULL CHECK (monthly_rent > 0),
+    escalation_percent NUMERIC(5, 2) NOT NULL DEFAULT 0,
+    escalation_interval_months INTEGER NOT NULL DEFAULT 12,
+    notice_period_days INTEGER NOT NULL DEFAULT 0,
+    lock_in_months INTEGER NOT NULL DEFAULT 0,
+    status VARCHAR(20) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'renewed', 'ended')),
+    previous_lease_id INTEGER NULL REFERENCES leases(id) ON DELETE SET NULL,
+    notes TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (end_date > start_date)
+);
+
+-- ============================================
+-- STEP 2: Add indexes
+-- ============================================
+-- Only one lease can be in force per tenant
+CREATE UNIQUE INDEX IF NOT EXISTS idx_leases_active_tenant ON leases(tenant_id) WHERE status = 'active';
+CREATE INDEX IF NOT EXISTS idx_leases_tenant_id ON leases(tenant_id, start_date DESC);
+
+COMMIT;
+
+-- ============================================
+-- VERIFICATION QUERIES
+-- ============================================
+-- Run these to verify migration:
+-- SELECT column_name, data_type FROM information_schema.columns WHERE table_name = 'leases';
+-- SELECT tenant_id, start_date, end_date, monthly_rent, escalation_percent, status FROM leases ORDER BY tenant_id, start_date;
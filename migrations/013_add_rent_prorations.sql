@@ -0,0 +1,41 @@
+-- Migration: Add Rent Proration
+-- Description: Records how partial first and final month rent was calculated (policy, days charged, amounts)
+-- Date: 2025
+
+BEGIN;
+
+-- ============================================
+-- STEP 1: Create rent_prorations table
+-- ============================================
+CREATE TABLE IF NOT EXISTS rent_prorations (
+    id SERIAL PRIMARY KEY,
+    payment_id INTEGER NOT NULL REFERENCES payments(id) ON DELETE CASCADE,
+    tenant_id INTEGER NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    kind VARCHAR(10) NOT NULL CHECK (kind IN ('first', 'final')),
+    policy VARCHAR(20) NOT NULL CHECK (policy IN ('actual_days', 'thirty_day')),
+    monthly_rent INTEGER NOT NULL CHECK (monthly_rent > 0),
+    period_start DATE NOT NULL,
+    period_end DATE NOT NULL,
+    days_charged INTEGER NOT NULL CHECK (days_charged > 0),
+    days_in_month INTEGER NOT NULL CHECK (days_in_month > 0),
+    prorated_amount INTEGER NOT NULL CHECK (prorated_amount >= 0),
+    additional_amount INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (period_end >= period_start)
+);
+
+-- ============================================
+-- STEP 2: Add indexes
+-- ============================================
+-- A payment is prorated at most once
+CREATE UNIQUE INDEX IF NOT EXISTS idx_rent_prorations_payment_id ON rent_prorations(payment_id);
+CREATE INDEX IF NOT EXISTS idx_rent_prorations_tenant_id ON rent_prorations(tenant_id);
+
+COMMIT;
+
+-- ============================================
+-- VERIFICATION QUERIES
+-- ============================================
+-- Run these to verify migration:
+-- SELECT column_name, data_type FROM information_schema.columns WHERE table_name = 'rent_prorations';
+-- SELECT payment_id, kind, policy, period_start, period_end, days_charged, days_in_month, prorated_amount FROM rent_prorations;
@@ -0,0 +1,35 @@
+-- Migration: Add Tenant Credit Wallet
+-- Description: Adds a per-tenant credit ledger; overpayments are credited and drawn down against new payments
+-- Date: 2025
+
+BEGIN;
+
+-- ============================================
+-- STEP 1: Create tenant_credit_entries table
+-- ============================================
+CREATE TABLE IF NOT EXISTS tenant_credit_entries (
+    id SERIAL PRIMARY KEY,
+    tenant_id INTEGER NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    entry_type VARCHAR(20) NOT NULL CHECK (entry_type IN ('overpayment', 'applied', 'refund')),
+    amount INTEGER NOT NULL CHECK (amount > 0),
+    reason TEXT NOT NULL DEFAULT '',
+    transaction_id VARCHAR(255) NULL,
+    payment_id INTEGER NULL REFERENCES payments(id) ON DELETE SET NULL,
+    entry_date DATE NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- ============================================
+-- STEP 2: Add indexes
+-- ============================================
+CREATE INDEX IF NOT EXISTS idx_tenant_credit_entries_tenant_id ON tenant_credit_entries(tenant_id, entry_date);
+
+COMMIT;
+
+-- ============================================
+-- VERIFICATION QUERIES
+-- ============================================
+-- Run these to verify migration:
+-- SELECT column_name, data_type FROM information_schema.columns WHERE table_name = 'tenant_credit_entries';
+-- SELECT tenant_id, SUM(CASE WHEN entry_type = 'overpayment' THEN amount ELSE -amount END) AS balance
+--   FROM tenant_credit_entries GROUP BY tenant_id;
@@ -0,0 +1,36 @@
+-- Migration: Keep Rejected Transactions
+-- Description: Rejecting a submitted transaction now records the reason, rejecting user and time instead of deleting the row,
+--              and a rejected UTR can be submitted again
+-- Date: 2025
+
+BEGIN;
+
+-- ============================================
+-- STEP 1: Add rejection columns to payment_transactions
+-- ============================================
+ALTER TABLE payment_transactions
+    ADD COLUMN IF NOT EXISTS rejected_at TIMESTAMP NULL,                      -- NULL unless owner rejected
+    ADD COLUMN IF NOT EXISTS rejected_by_user_id INT NULL REFERENCES users(id),
+    ADD COLUMN IF NOT EXISTS rejection_reason TEXT NOT NULL DEFAULT '';
+
+-- ============================================
+-- STEP 2: Allow a rejected UTR to be submitted again
+-- ============================================
+-- A UTR stays unique per payment among submissions that were not rejected
+ALTER TABLE payment_transactions DROP CONSTRAINT IF EXISTS unique_txn_per_payment;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_payment_transactions_unique_txn_per_payment
+    ON payment_transactions(payment_id, transaction_id) WHERE rejected_at IS NULL;
+
+-- ============================================
+-- STEP 3: Add indexes
+-- ============================================
+CREATE INDEX IF NOT EXISTS idx_payment_transactions_rejected_at ON payment_transactions(rejected_at) WHERE rejected_at IS NOT NULL;
+
+COMMIT;
+
+-- ============================================
+-- VERIFICATION QUERIES
+-- ============================================
+-- Run these to verify migration:
+-- SELECT column_name, data_type FROM information_schema.columns WHERE table_name = 'payment_transactions';
+-- SELECT transaction_id, rejected_at, rejection_reason FROM payment_transactions WHERE rejected_at IS NOT NULL;
@@ -0,0 +1,50 @@
+-- Migration: Add Bank Statement Reconciliation
+-- Description: Stores imported bank statements and their credit lines, matched against submitted transactions
+-- Date: 2025
+
+BEGIN;
+
+-- ============================================
+-- STEP 1: Create bank_statement_imports table
+-- ============================================
+CREATE TABLE IF NOT EXISTS bank_statement_imports (
+    id SERIAL PRIMARY KEY,
+    file_name VARCHAR(255) NOT NULL,
+    format VARCHAR(20) NOT NULL CHECK (format IN ('csv', 'ofx', 'camt053')),
+    imported_by_user_id INTEGER NOT NULL REFERENCES users(id),
+    line_count INTEGER NOT NULL DEFAULT 0,
+    matched_count INTEGER NOT NULL DEFAULT 0,
+    duplicate_count INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- ============================================
+-- STEP 2: Create bank_statement_lines table
+-- ============================================
+CREATE TABLE IF NOT EXISTS bank_statement_lines (
+    id SERIAL PRIMARY KEY,
+    import_id INTEGER NOT NULL REFERENCES bank_statement_imports(id) ON DELETE CASCADE,
+    txn_date DATE NOT NULL,
+    amount INTEGER NOT NULL CHECK (amount > 0),
+    reference VARCHAR(255) NOT NULL DEFAULT '',
+    description TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL CHECK (status IN ('matched', 'review', 'unmatched', 'ignored')),
+    matched_transaction_id VARCHAR(255) NULL, -- payment_transactions.transaction_id verified from this line
+    note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- ============================================
+-- STEP 3: Add indexes
+-- ============================================
+CREATE INDEX IF NOT EXISTS idx_bank_statement_lines_open ON bank_statement_lines(status) WHERE status IN ('review', 'unmatched');
+CREATE INDEX IF NOT EXISTS idx_bank_statement_lines_dedupe ON bank_statement_lines(txn_date, amount, reference);
+
+COMMIT;
+
+-- ============================================
+-- VERIFICATION QUERIES
+-- ============================================
+-- Run these to verify migration:
+-- SELECT id, file_name, format, line_count, matched_count, duplicate_count FROM bank_statement_imports ORDER BY created_at DESC;
+-- SELECT status, COUNT(*) FROM bank_statement_lines GROUP BY status;
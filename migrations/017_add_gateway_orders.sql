@@ -0,0 +1,41 @@
+-- Migration: Add Payment Gateway Orders
+-- Description: Orders created on a payment gateway for a payment's remaining balance; a signed webhook marks them paid and verifies the transaction
+-- Date: 2025
+
+BEGIN;
+
+-- ============================================
+-- STEP 1: Create gateway_orders table
+-- ============================================
+CREATE TABLE IF NOT EXISTS gateway_orders (
+    id SERIAL PRIMARY KEY,
+    payment_id INT NOT NULL REFERENCES payments(id) ON DELETE CASCADE,
+    tenant_id INT NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    gateway VARCHAR(30) NOT NULL,                        -- mock, razorpay
+    gateway_order_id VARCHAR(100) NOT NULL,              -- Order ID on the gateway
+    amount INT NOT NULL CHECK (amount > 0),              -- Amount ordered in rupees
+    currency VARCHAR(3) NOT NULL DEFAULT 'INR',
+    status VARCHAR(20) NOT NULL DEFAULT 'created' CHECK (status IN ('created', 'paid', 'failed')),
+    gateway_payment_id VARCHAR(100) NULL,                -- Set once paid; also the payment transaction ID
+    amount_paid INT NOT NULL DEFAULT 0,
+    failure_reason TEXT NOT NULL DEFAULT '',
+    paid_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (gateway, gateway_order_id)
+);
+
+-- ============================================
+-- STEP 2: Add indexes
+-- ============================================
+CREATE INDEX IF NOT EXISTS idx_gateway_orders_payment_id ON gateway_orders(payment_id);
+CREATE INDEX IF NOT EXISTS idx_gateway_orders_tenant_id ON gateway_orders(tenant_id);
+
+COMMIT;
+
+-- ============================================
+-- VERIFICATION QUERIES
+-- ============================================
+-- Run these to verify migration:
+-- SELECT column_name, data_type FROM information_schema.columns WHERE table_name = 'gateway_orders';
+-- SELECT gateway, gateway_order_id, amount, status, gateway_payment_id FROM gateway_orders ORDER BY created_at DESC;
@@ -0,0 +1,82 @@
+-- Migration: Add Receipts and Credit Notes
+-- Description: Numbered, immutable receipts issued when a transaction is verified; corrections are issued as credit notes
+-- Date: 2025
+
+BEGIN;
+
+-- ============================================
+-- STEP 1: Create receipt_sequences table
+-- ============================================
+-- One counter per document type and financial year (RCT/2025-26/000001, CN/2025-26/000001)
+CREATE TABLE IF NOT EXISTS receipt_sequences (
+    document_type VARCHAR(20) NOT NULL,
+    financial_year VARCHAR(7) NOT NULL,
+    last_number INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (document_type, financial_year)
+);
+
+-- ============================================
+-- STEP 2: Create receipts table
+-- ============================================
+-- Tenant, unit and payee details are copied at issue time; payment_id and tenant_id are
+-- deliberately not foreign keys so receipts outlive deleted payments and tenants
+CREATE TABLE IF NOT EXISTS receipts (
+    id SERIAL PRIMARY KEY,
+    receipt_number VARCHAR(30) NOT NULL UNIQUE,
+    document_type VARCHAR(20) NOT NULL CHECK (document_type IN ('receipt', 'credit_note')),
+    financial_year VARCHAR(7) NOT NULL,
+    transaction_id VARCHAR(255) NOT NULL,               -- UTR of the verified transaction
+    payment_id INT NOT NULL,
+    tenant_id INT NOT NULL,
+    tenant_name VARCHAR(255) NOT NULL,
+    unit_code VARCHAR(50) NOT NULL,
+    property_name VARCHAR(255) NOT NULL DEFAULT '',
+    property_address TEXT NOT NULL DEFAULT '',
+    payee_name VARCHAR(255) NOT NULL DEFAULT '',
+    payee_upi_id VARCHAR(255) NOT NULL DEFAULT '',
+    label VARCHAR(50) NOT NULL,
+    label_name VARCHAR(100) NOT NULL,
+    period VARCHAR(30) NOT NULL,                        -- e.g. March 2025
+    amount INT NOT NULL CHECK (amount > 0),
+    balance_after INT NOT NULL,
+    verified_at TIMESTAMP NOT NULL,
+    original_receipt_id INT NULL REFERENCES receipts(id), -- Credit notes only
+    original_receipt_number VARCHAR(30) NOT NULL DEFAULT '',
+    reason TEXT NOT NULL DEFAULT '',
+    issued_by_user_id INT NULL REFERENCES users(id),
+    issued_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((document_type = 'credit_note') = (original_receipt_id IS NOT NULL))
+);
+
+-- ============================================
+-- STEP 3: Add indexes
+-- ============================================
+CREATE INDEX IF NOT EXISTS idx_receipts_tenant_id ON receipts(tenant_id);
+CREATE INDEX IF NOT EXISTS idx_receipts_payment_id ON receipts(payment_id);
+CREATE INDEX IF NOT EXISTS idx_receipts_original_receipt_id ON receipts(original_receipt_id) WHERE original_receipt_id IS NOT NULL;
+-- One receipt per transaction allocation
+CREATE UNIQUE INDEX IF NOT EXISTS idx_receipts_allocation ON receipts(transaction_id, payment_id) WHERE document_type = 'receipt';
+
+-- ============================================
+-- STEP 4: Make receipts immutable
+-- ============================================
+CREATE OR REPLACE FUNCTION prevent_receipt_changes() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'receipts are immutable; issue a credit note instead';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS receipts_immutable ON receipts;
+CREATE TRIGGER receipts_immutable
+    BEFORE UPDATE OR DELETE ON receipts
+    FOR EACH ROW EXECUTE FUNCTION prevent_receipt_changes();
+
+COMMIT;
+
+-- ============================================
+-- VERIFICATION QUERIES
+-- ============================================
+-- Run these to verify migration:
+-- SELECT column_name, data_type FROM information_schema.columns WHERE table_name = 'receipts';
+-- SELECT receipt_number, tenant_name, period, amount, balance_after FROM receipts ORDER BY issued_at DESC;
+-- SELECT * FROM receipt_sequences;
@@ -0,0 +1,79 @@
+-- Migration: Add Expense Ledger
+-- Description: Owner expenses (property tax, repairs, salaries, utilities, ...) with categories, bill attachments and property/unit allocation
+-- Date: 2025
+
+BEGIN;
+
+-- ============================================
+-- STEP 1: Create expense_categories table
+-- ============================================
+CREATE TABLE IF NOT EXISTS expense_categories (
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(50) NOT NULL UNIQUE,
+    display_name VARCHAR(100) NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- ============================================
+-- STEP 2: Seed common categories
+-- ============================================
+INSERT INTO expense_categories (code, display_name) VALUES
+    ('property_tax', 'Property Tax'),
+    ('repairs', 'Repairs & Maintenance'),
+    ('salaries', 'Salaries'),
+    ('utilities', 'Utilities'),
+    ('insurance', 'Insurance'),
+    ('other', 'Other')
+ON CONFLICT (code) DO NOTHING;
+
+-- ============================================
+-- STEP 3: Create expenses table
+-- ============================================
+-- An expense with no property is a general expense; a unit expense also records its property
+CREATE TABLE IF NOT EXISTS expenses (
+    id SERIAL PRIMARY KEY,
+    category VARCHAR(50) NOT NULL REFERENCES expense_categories(code) ON UPDATE CASCADE,
+    property_id INT NULL REFERENCES properties(id) ON DELETE SET NULL,
+    unit_id INT NULL REFERENCES units(id) ON DELETE SET NULL,
+    amount INT NOT NULL CHECK (amount > 0),
+    expense_date DATE NOT NULL,
+    vendor VARCHAR(200) NOT NULL DEFAULT '',
+    description TEXT NOT NULL DEFAULT '',
+    payment_method VARCHAR(50) NOT NULL DEFAULT '',
+    reference VARCHAR(100) NOT NULL DEFAULT '',
+    created_by_user_id INT NULL REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- ============================================
+-- STEP 4: Create expense_attachments table
+-- ============================================
+-- Bills and receipts are stored in the database (max 5MB each, enforced by the application)
+CREATE TABLE IF NOT EXISTS expense_attachments (
+    id SERIAL PRIMARY KEY,
+    expense_id INT NOT NULL REFERENCES expenses(id) ON DELETE CASCADE,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size_bytes INT NOT NULL,
+    data BYTEA NOT NULL,
+    uploaded_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- ============================================
+-- STEP 5: Add indexes
+-- ============================================
+CREATE INDEX IF NOT EXISTS idx_expenses_expense_date ON expenses(expense_date);
+CREATE INDEX IF NOT EXISTS idx_expenses_property_id ON expenses(property_id);
+CREATE INDEX IF NOT EXISTS idx_expense_attachments_expense_id ON expense_attachments(expense_id);
+
+COMMIT;
+
+-- ============================================
+-- VERIFICATION QUERIES
+-- ============================================
+-- Run these to verify migration:
+-- SELECT code, display_name, is_active FROM expense_categories ORDER BY id;
+-- SELECT column_name, data_type FROM information_schema.columns WHERE table_name = 'expenses';
+-- SELECT e.expense_date, e.category, e.amount, COUNT(a.id) AS attachments FROM expenses e LEFT JOIN expense_attachments a ON a.expense_id = e.id GROUP BY e.id ORDER BY e.expense_date DESC;
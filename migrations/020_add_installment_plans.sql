@@ -0,0 +1,74 @@
+-- Migration: Add Installment Plans
+-- Description: Lets the owner restructure a tenant's arrears into a schedule of installment payments
+-- Date: 2025
+
+BEGIN;
+
+-- ============================================
+-- STEP 1: Register the installment charge category
+-- ============================================
+INSERT INTO charge_categories (code, display_name, is_recurring, is_system) VALUES
+    ('installment', 'Installment', FALSE, TRUE)
+ON CONFLICT (code) DO NOTHING;
+
+-- ============================================
+-- STEP 2: Create installment_plans table
+-- ============================================
+CREATE TABLE IF NOT EXISTS installment_plans (
+    id SERIAL PRIMARY KEY,
+    tenant_id INTEGER NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    unit_id INTEGER NOT NULL REFERENCES units(id),
+    total_amount INTEGER NOT NULL CHECK (total_amount > 0),
+    status VARCHAR(20) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'completed', 'cancelled')),
+    notes TEXT NOT NULL DEFAULT '',
+    cancel_reason TEXT NOT NULL DEFAULT '',
+    created_by_user_id INTEGER NULL REFERENCES users(id),
+    completed_at TIMESTAMP NULL,
+    cancelled_at TIMESTAMP NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- ============================================
+-- STEP 3: Create installment_plan_arrears table
+-- ============================================
+-- The original payments settled by a plan and the balance moved off each of them
+CREATE TABLE IF NOT EXISTS installment_plan_arrears (
+    id SERIAL PRIMARY KEY,
+    plan_id INTEGER NOT NULL REFERENCES installment_plans(id) ON DELETE CASCADE,
+    payment_id INTEGER NOT NULL REFERENCES payments(id) ON DELETE CASCADE,
+    amount INTEGER NOT NULL CHECK (amount > 0),
+    restored_amount INTEGER NOT NULL DEFAULT 0 CHECK (restored_amount >= 0),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (plan_id, payment_id)
+);
+
+-- ============================================
+-- STEP 4: Create installments table
+-- ============================================
+CREATE TABLE IF NOT EXISTS installments (
+    id SERIAL PRIMARY KEY,
+    plan_id INTEGER NOT NULL REFERENCES installment_plans(id) ON DELETE CASCADE,
+    payment_id INTEGER NULL UNIQUE REFERENCES payments(id) ON DELETE SET NULL,
+    sequence INTEGER NOT NULL CHECK (sequence > 0),
+    amount INTEGER NOT NULL CHECK (amount > 0),
+    due_date DATE NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (plan_id, sequence)
+);
+
+-- ============================================
+-- STEP 5: Add indexes
+-- ============================================
+CREATE INDEX IF NOT EXISTS idx_installment_plans_tenant_id ON installment_plans(tenant_id);
+CREATE INDEX IF NOT EXISTS idx_installment_plan_arrears_payment_id ON installment_plan_arrears(payment_id);
+
+COMMIT;
+
+-- ============================================
+-- VERIFICATION QUERIES
+-- ============================================
+-- Run these to verify migration:
+-- SELECT table_name FROM information_schema.tables WHERE table_name IN ('installment_plans', 'installment_plan_arrears', 'installments');
+-- SELECT code, display_name, is_system FROM charge_categories WHERE code = 'installment';
+-- SELECT status, COUNT(*), SUM(total_amount) FROM installment_plans GROUP BY status;
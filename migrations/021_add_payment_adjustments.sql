@@ -0,0 +1,44 @@
+-- Migration: Add Payment Adjustments
+-- Description: Records discounts, waivers and write-offs against payments, kept apart from money received
+-- Date: 2025
+
+BEGIN;
+
+-- ============================================
+-- STEP 1: Track adjusted amounts on payments
+-- ============================================
+-- remaining_balance = amount - amount_adjusted - amount_paid
+ALTER TABLE payments
+ADD COLUMN IF NOT EXISTS amount_adjusted INTEGER NOT NULL DEFAULT 0;
+
+-- ============================================
+-- STEP 2: Create payment_adjustments table
+-- ============================================
+CREATE TABLE IF NOT EXISTS payment_adjustments (
+    id SERIAL PRIMARY KEY,
+    payment_id INTEGER NOT NULL REFERENCES payments(id) ON DELETE CASCADE,
+    tenant_id INTEGER NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    unit_id INTEGER NOT NULL REFERENCES units(id),
+    adjustment_type VARCHAR(20) NOT NULL CHECK (adjustment_type IN ('discount', 'waiver', 'write_off')),
+    amount INTEGER NOT NULL CHECK (amount > 0),
+    reason TEXT NOT NULL,
+    created_by_user_id INTEGER NULL REFERENCES users(id),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- ============================================
+-- STEP 3: Add indexes
+-- ============================================
+CREATE INDEX IF NOT EXISTS idx_payment_adjustments_payment_id ON payment_adjustments(payment_id);
+CREATE INDEX IF NOT EXISTS idx_payment_adjustments_tenant_id ON payment_adjustments(tenant_id);
+CREATE INDEX IF NOT EXISTS idx_payment_adjustments_created_at ON payment_adjustments(created_at);
+
+COMMIT;
+
+-- ============================================
+-- VERIFICATION QUERIES
+-- ============================================
+-- Run these to verify migration:
+-- SELECT column_name FROM information_schema.columns WHERE table_name = 'payments' AND column_name = 'amount_adjusted';
+-- SELECT adjustment_type, COUNT(*), SUM(amount) FROM payment_adjustments GROUP BY adjustment_type;
+-- SELECT id FROM payments WHERE remaining_balance <> amount - amount_adjusted - amount_paid;
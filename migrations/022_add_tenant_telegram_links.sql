@@ -0,0 +1,46 @@
+-- Migration: Add Tenant Telegram Links
+-- Description: Lets tenants link their own Telegram chat through a one-time deep link so reminders reach them
+-- Date: 2025
+
+BEGIN;
+
+-- ============================================
+-- STEP 1: Create telegram_link_tokens table
+-- ============================================
+-- One-time tokens generated on /me and confirmed by the bot (t.me/<bot>?start=<token>)
+CREATE TABLE IF NOT EXISTS telegram_link_tokens (
+    token VARCHAR(64) PRIMARY KEY,
+    tenant_id INTEGER NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- ============================================
+-- STEP 2: Create tenant_telegram_links table
+-- ============================================
+-- One linked chat per tenant; failures are counted so a dead chat stops being retried
+CREATE TABLE IF NOT EXISTS tenant_telegram_links (
+    tenant_id INTEGER PRIMARY KEY REFERENCES tenants(id) ON DELETE CASCADE,
+    chat_id VARCHAR(32) NOT NULL,
+    telegram_username VARCHAR(64) NULL,
+    linked_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    failure_count INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NULL,
+    last_failure_at TIMESTAMP NULL
+);
+
+-- ============================================
+-- STEP 3: Add indexes
+-- ============================================
+CREATE INDEX IF NOT EXISTS idx_telegram_link_tokens_tenant_id ON telegram_link_tokens(tenant_id);
+CREATE INDEX IF NOT EXISTS idx_tenant_telegram_links_chat_id ON tenant_telegram_links(chat_id);
+
+COMMIT;
+
+-- ============================================
+-- VERIFICATION QUERIES
+-- ============================================
+-- Run these to verify migration:
+-- SELECT COUNT(*) FROM telegram_link_tokens WHERE used_at IS NULL AND expires_at > NOW();
+-- SELECT tenant_id, chat_id, failure_count, last_error FROM tenant_telegram_links ORDER BY linked_at DESC;
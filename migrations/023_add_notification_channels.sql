@@ -0,0 +1,28 @@
+-- Migration: Add Notification Channels
+-- Description: Stores tenant email addresses so notifications can go out by email as well as Telegram, SMS and WhatsApp
+-- Date: 2025
+
+BEGIN;
+
+-- ============================================
+-- STEP 1: Add email to tenants
+-- ============================================
+-- Optional; phone numbers are already stored and used for SMS and WhatsApp
+ALTER TABLE tenants
+ADD COLUMN IF NOT EXISTS email VARCHAR(255) NOT NULL DEFAULT '';
+
+-- ============================================
+-- STEP 2: Document channels recorded on notifications
+-- ============================================
+-- sent_via now holds the channel that delivered the notification: telegram, whatsapp, sms or email
+COMMENT ON COLUMN notifications.sent_via IS 'Channel used: telegram, whatsapp, sms or email';
+COMMENT ON COLUMN notifications.sent_to IS 'Chat ID, phone number or email address the notification was sent to';
+
+COMMIT;
+
+-- ============================================
+-- VERIFICATION QUERIES
+-- ============================================
+-- Run these to verify migration:
+-- SELECT column_name FROM information_schema.columns WHERE table_name = 'tenants' AND column_name = 'email';
+-- SELECT sent_via, COUNT(*), COUNT(error) AS failed FROM notifications GROUP BY sent_via;
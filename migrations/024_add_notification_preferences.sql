@@ -0,0 +1,43 @@
+-- Migration: Add Notification Preferences
+-- Description: Stores how the owner and each tenant want to be notified: channels, notification types, quiet hours, language and reminder lead times
+-- Date: 2025
+
+BEGIN;
+
+-- ============================================
+-- STEP 1: Create notification_preferences table
+-- ============================================
+-- One row for the owner (tenant_id NULL) and at most one per tenant; users without a row get the defaults
+-- (quiet 21:00-09:00, English, owner reminded on the due date and tenants 5 days before)
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    id SERIAL PRIMARY KEY,
+    recipient VARCHAR(20) NOT NULL CHECK (recipient IN ('owner', 'tenant')),
+    tenant_id INTEGER NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    channels TEXT[] NOT NULL DEFAULT '{}',      -- Channels to use, in order; empty means every configured channel
+    muted_types TEXT[] NOT NULL DEFAULT '{}',   -- Notification types the user does not want
+    quiet_hours_start SMALLINT NULL CHECK (quiet_hours_start BETWEEN 0 AND 23),
+    quiet_hours_end SMALLINT NULL CHECK (quiet_hours_end BETWEEN 0 AND 23),
+    language VARCHAR(5) NOT NULL DEFAULT 'en',
+    reminder_days INTEGER[] NOT NULL DEFAULT '{}', -- Days before a due date that reminders are sent
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT chk_notification_preferences_tenant CHECK ((recipient = 'tenant') = (tenant_id IS NOT NULL))
+);
+
+-- ============================================
+-- STEP 2: Create indexes
+-- ============================================
+-- COALESCE makes the owner row unique too (NULLs never conflict in a plain unique index)
+CREATE UNIQUE INDEX IF NOT EXISTS idx_notification_preferences_recipient
+    ON notification_preferences (recipient, COALESCE(tenant_id, 0));
+
+-- Reminders now run hourly and must not repeat within a day
+CREATE INDEX IF NOT EXISTS idx_notifications_type_tenant_created ON notifications(type, recipient, tenant_id, created_at);
+
+COMMIT;
+
+-- ============================================
+-- VERIFICATION QUERIES
+-- ============================================
+-- Run these to verify migration:
+-- SELECT * FROM notification_preferences ORDER BY recipient, tenant_id;
+-- SELECT indexname FROM pg_indexes WHERE tablename = 'notification_preferences';
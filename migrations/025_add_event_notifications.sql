@@ -0,0 +1,30 @@
+-- Migration: Add Event Notifications
+-- Description: Notifications are now also sent when payments are submitted, verified or rejected, fall overdue,
+--              new charges are raised and tenant passwords are reset; ones raised during quiet hours are held
+-- Date: 2025
+
+BEGIN;
+
+-- ============================================
+-- STEP 1: Add held_until to notifications
+-- ============================================
+-- Set when a notification was raised during the recipient's quiet hours; the scheduler sends it once
+-- held_until has passed. sent_at stays NULL until then
+ALTER TABLE notifications
+ADD COLUMN IF NOT EXISTS held_until TIMESTAMP NULL;
+
+-- ============================================
+-- STEP 2: Create indexes
+-- ============================================
+CREATE INDEX IF NOT EXISTS idx_notifications_held_until ON notifications(held_until)
+    WHERE held_until IS NOT NULL AND sent_at IS NULL;
+
+COMMIT;
+
+-- ============================================
+-- VERIFICATION QUERIES
+-- ============================================
+-- Run these to verify migration:
+-- SELECT column_name, data_type FROM information_schema.columns WHERE table_name = 'notifications' AND column_name = 'held_until';
+-- SELECT type, recipient, COUNT(*) FROM notifications GROUP BY type, recipient ORDER BY type;
+-- SELECT id, type, recipient, tenant_id, held_until FROM notifications WHERE held_until IS NOT NULL AND sent_at IS NULL;
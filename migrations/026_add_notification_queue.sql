@@ -0,0 +1,55 @@
+-- Migration: Add Notification Queue
+-- Description: Turns notifications into an outbox: rows are queued first and delivered by a worker that retries
+--              failures with exponential backoff and dead-letters them after a maximum number of attempts
+-- Date: 2025
+
+BEGIN;
+
+-- ============================================
+-- STEP 1: Add queue columns to notifications
+-- ============================================
+-- held_until (quiet hours) becomes the general "do not attempt before" time
+ALTER TABLE notifications RENAME COLUMN held_until TO next_attempt_at;
+
+ALTER TABLE notifications
+ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'pending'
+    CHECK (status IN ('pending', 'sent', 'dead', 'cancelled')),
+ADD COLUMN IF NOT EXISTS attempts INTEGER NOT NULL DEFAULT 0,
+ADD COLUMN IF NOT EXISTS dedupe_key VARCHAR(255) NULL; -- One row per event and recipient; NULL for notifications that may repeat
+
+-- ============================================
+-- STEP 2: Backfill existing notifications
+-- ============================================
+UPDATE notifications SET status = 'sent', attempts = 1 WHERE sent_at IS NOT NULL;
+
+-- Failures from before the queue existed are dead letters; the owner decides whether to retry them
+UPDATE notifications SET status = 'dead', attempts = 1
+WHERE sent_at IS NULL AND next_attempt_at IS NULL;
+
+-- Notifications held for quiet hours stay pending, due at next_attempt_at
+
+-- ============================================
+-- STEP 3: Create indexes
+-- ============================================
+DROP INDEX IF EXISTS idx_notifications_held_until;
+
+-- Worker picks up due notifications
+CREATE INDEX IF NOT EXISTS idx_notifications_queue ON notifications(next_attempt_at)
+    WHERE status = 'pending';
+
+-- Owner's failed notifications view
+CREATE INDEX IF NOT EXISTS idx_notifications_dead ON notifications(created_at)
+    WHERE status = 'dead';
+
+-- Idempotency: restarts and repeated events cannot queue the same notification twice
+CREATE UNIQUE INDEX IF NOT EXISTS idx_notifications_dedupe_key ON notifications(dedupe_key);
+
+COMMIT;
+
+-- ============================================
+-- VERIFICATION QUERIES
+-- ============================================
+-- Run these to verify migration:
+-- SELECT status, COUNT(*) FROM notifications GROUP BY status;
+-- SELECT id, type, recipient, attempts, next_attempt_at, error FROM notifications WHERE status = 'pending' ORDER BY next_attempt_at;
+-- SELECT id, type, recipient, attempts, error FROM notifications WHERE status = 'dead' ORDER BY created_at DESC;